@@ -234,6 +234,38 @@ func (c *GitnessClient) MergePullRequest(repoPath string, pullRequestID int, met
 	return &mergePullRequestResponse, nil
 }
 
+// UpdatePullRequestState closes or reopens the pull request and sets whether it is a draft.
+func (c *GitnessClient) UpdatePullRequestState(repoPath string, pullRequestID int, state string, isDraft bool) (*gitness.FetchPullRequestResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/repos/%s/+/pullreq/%d/state", c.baseURL, repoPath, pullRequestID)
+
+	payload := gitness.UpdatePullRequestStatePayload{
+		State:   state,
+		IsDraft: isDraft,
+	}
+
+	headers := map[string]string{
+		"Accept":        "*/*",
+		"Content-Type":  "application/json",
+		"Authorization": "Bearer " + c.authToken,
+	}
+
+	response, err := c.httpClient.Post(url, payload, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to update pull request state, status code: %d", response.StatusCode)
+	}
+
+	var pullRequest gitness.FetchPullRequestResponse
+	if err := json.NewDecoder(response.Body).Decode(&pullRequest); err != nil {
+		return nil, err
+	}
+	return &pullRequest, nil
+}
+
 func (c *GitnessClient) FetchPullRequest(repoPath string, pullRequestID int) (*gitness.FetchPullRequestResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/repos/%s/+/pullreq/%d", c.baseURL, repoPath, pullRequestID)
 
@@ -321,13 +353,7 @@ func (c *GitnessClient) GetPullRequestCommits(repoPath string, pullRequestNumber
 	return string(body), nil
 }
 
-func (c *GitnessClient) GetBranchCommits(repoPath string, branchName ...string) (*gitness.GetMainBranchCommitResponse, error) {
-	var branch string
-	if len(branchName) > 0 {
-		branch = branchName[0]
-	} else {
-		branch = "main"
-	}
+func (c *GitnessClient) GetBranchCommits(repoPath string, branch string) (*gitness.GetMainBranchCommitResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/repos/%s/+/commits?git_ref=%s&include_stats=false", c.baseURL, repoPath, branch)
 	fmt.Println("URL: ", url)
 	fmt.Println("BaseURL: ", c.baseURL)
@@ -356,3 +382,102 @@ func (c *GitnessClient) GetBranchCommits(repoPath string, branchName ...string)
 
 	return &getMainBranchCommitResponse, nil
 }
+
+// CreatePullRequestComment adds a comment to the pull request, as a reply to the comment parentID unless it is 0.
+func (c *GitnessClient) CreatePullRequestComment(repoPath string, pullRequestID int, text string, parentID int64) (*gitness.PullRequestActivity, error) {
+	return c.createPullRequestComment(repoPath, pullRequestID, gitness.CreatePullRequestCommentPayload{
+		Text:     text,
+		ParentID: parentID,
+	})
+}
+
+// CreatePullRequestCodeComment comments on a line of the new version of a file in the diff between the commits.
+func (c *GitnessClient) CreatePullRequestCodeComment(repoPath string, pullRequestID int, text, path string, line int, sourceSHA, targetSHA string) (*gitness.PullRequestActivity, error) {
+	return c.createPullRequestComment(repoPath, pullRequestID, gitness.CreatePullRequestCommentPayload{
+		Text:            text,
+		Path:            path,
+		SourceCommitSHA: sourceSHA,
+		TargetCommitSHA: targetSHA,
+		LineStart:       line,
+		LineEnd:         line,
+		LineStartNew:    true,
+		LineEndNew:      true,
+	})
+}
+
+func (c *GitnessClient) createPullRequestComment(repoPath string, pullRequestID int, payload gitness.CreatePullRequestCommentPayload) (*gitness.PullRequestActivity, error) {
+	url := fmt.Sprintf("%s/api/v1/repos/%s/+/pullreq/%d/comments", c.baseURL, repoPath, pullRequestID)
+
+	headers := map[string]string{
+		"Accept":        "*/*",
+		"Content-Type":  "application/json",
+		"Authorization": "Bearer " + c.authToken,
+	}
+
+	response, err := c.httpClient.Post(url, payload, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("failed to create pull request comment, status code: %d", response.StatusCode)
+	}
+
+	var activity gitness.PullRequestActivity
+	if err := json.NewDecoder(response.Body).Decode(&activity); err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+func (c *GitnessClient) GetPullRequestActivities(repoPath string, pullRequestID int) ([]gitness.PullRequestActivity, error) {
+	url := fmt.Sprintf("%s/api/v1/repos/%s/+/pullreq/%d/activities", c.baseURL, repoPath, pullRequestID)
+
+	headers := map[string]string{
+		"Accept":        "application/json",
+		"Authorization": "Bearer " + c.authToken,
+	}
+
+	response, err := c.httpClient.Get(url, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch pull request activities, status code: %d", response.StatusCode)
+	}
+
+	var activities []gitness.PullRequestActivity
+	if err := json.NewDecoder(response.Body).Decode(&activities); err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+// UpdatePullRequestCommentStatus sets the status of a comment thread, "active" or "resolved".
+func (c *GitnessClient) UpdatePullRequestCommentStatus(repoPath string, pullRequestID int, commentID int64, status string) error {
+	url := fmt.Sprintf("%s/api/v1/repos/%s/+/pullreq/%d/comments/%d/status", c.baseURL, repoPath, pullRequestID, commentID)
+
+	payload := gitness.UpdatePullRequestCommentStatusPayload{
+		Status: status,
+	}
+
+	headers := map[string]string{
+		"Accept":        "*/*",
+		"Content-Type":  "application/json",
+		"Authorization": "Bearer " + c.authToken,
+	}
+
+	response, err := c.httpClient.Put(url, payload, headers)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to update pull request comment status, status code: %d", response.StatusCode)
+	}
+	return nil
+}
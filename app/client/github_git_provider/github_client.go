@@ -0,0 +1,565 @@
+package github_git_provider
+
+import (
+	"ai-developer/app/client"
+	"ai-developer/app/models/dtos/github"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const apiVersion = "2022-11-28"
+
+// GitHubClient talks to the GitHub REST API v3. The base URL is configurable for GitHub Enterprise.
+type GitHubClient struct {
+	baseURL     string
+	tokenSource TokenSource
+	httpClient  *client.HttpClient
+	logger      *zap.Logger
+}
+
+func NewGitHubClient(
+	baseURL string,
+	tokenSource TokenSource,
+	httpClient *client.HttpClient,
+	logger *zap.Logger,
+) *GitHubClient {
+	return &GitHubClient{
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		tokenSource: tokenSource,
+		httpClient:  httpClient,
+		logger:      logger.Named("GitHubClient"),
+	}
+}
+
+// Token returns the token used by the client, it doubles as the password for git over https.
+func (c *GitHubClient) Token() (string, error) {
+	return c.tokenSource.Token()
+}
+
+func (c *GitHubClient) headers(accept string) (map[string]string, error) {
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"Accept":               accept,
+		"Content-Type":         "application/json",
+		"Authorization":        "Bearer " + token,
+		"X-GitHub-Api-Version": apiVersion,
+	}, nil
+}
+
+// responseError builds the error for an unexpected status, including the message GitHub sends along.
+func (c *GitHubClient) responseError(action string, response *http.Response) error {
+	var errorResponse github.ErrorResponse
+	body, _ := io.ReadAll(response.Body)
+	if err := json.Unmarshal(body, &errorResponse); err == nil && errorResponse.Message != "" {
+		return fmt.Errorf("failed to %s, status code: %d: %s", action, response.StatusCode, errorResponse.Message)
+	}
+	return fmt.Errorf("failed to %s, status code: %d", action, response.StatusCode)
+}
+
+func (c *GitHubClient) getJSON(path string, action string, out interface{}) (*http.Response, error) {
+	headers, err := c.headers("application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.httpClient.Get(c.baseURL+path, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, c.responseError(action, response)
+	}
+	if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+var nextPagePattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// getAllPages fetches the path and every page the Link header points to next. Links leaving the API base
+// URL are not followed, they would receive the token.
+func getAllPages[T any](c *GitHubClient, path string, action string) ([]T, error) {
+	var items []T
+	for path != "" {
+		var page []T
+		response, err := c.getJSON(path, action, &page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+
+		path = ""
+		if matches := nextPagePattern.FindStringSubmatch(response.Header.Get("Link")); matches != nil && strings.HasPrefix(matches[1], c.baseURL+"/") {
+			path = strings.TrimPrefix(matches[1], c.baseURL)
+		}
+	}
+	return items, nil
+}
+
+// repoPath is the API path of the repository, owner and name are escaped as they are used in the URL path.
+func repoPath(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+func (c *GitHubClient) GetAccount(login string) (*github.Account, error) {
+	var account github.Account
+	if _, err := c.getJSON(fmt.Sprintf("/users/%s", url.PathEscape(login)), "fetch account", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// CreateRepository creates the repository under the organisation owner, or under the authenticated user
+// when the owner is empty or a user account.
+func (c *GitHubClient) CreateRepository(owner, name, description string, private bool) (*github.Repository, error) {
+	path := "/user/repos"
+	if owner != "" {
+		account, err := c.GetAccount(owner)
+		if err != nil {
+			return nil, err
+		}
+		if account.Type == "Organization" {
+			path = fmt.Sprintf("/orgs/%s/repos", url.PathEscape(owner))
+		}
+	}
+
+	payload := github.CreateRepositoryPayload{
+		Name:        name,
+		Description: description,
+		Private:     private,
+		AutoInit:    false,
+	}
+	headers, err := c.headers("application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.httpClient.Post(c.baseURL+path, payload, headers)
+	if err != nil {
+		c.logger.Error("Error creating repository", zap.Error(err), zap.String("path", path), zap.Any("payload", payload))
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return nil, c.responseError("create repository", response)
+	}
+
+	var repository github.Repository
+	if err := json.NewDecoder(response.Body).Decode(&repository); err != nil {
+		return nil, err
+	}
+	return &repository, nil
+}
+
+func (c *GitHubClient) GetBranch(owner, repo, branch string) (*github.Branch, error) {
+	var githubBranch github.Branch
+	path := fmt.Sprintf("%s/branches/%s", repoPath(owner, repo), url.PathEscape(branch))
+	if _, err := c.getJSON(path, "fetch branch", &githubBranch); err != nil {
+		return nil, err
+	}
+	return &githubBranch, nil
+}
+
+// CreateBranch points a new branch at the head of the target branch.
+func (c *GitHubClient) CreateBranch(owner, repo, branchName, target string) (*github.Ref, error) {
+	targetBranch, err := c.GetBranch(owner, repo, target)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := github.CreateRefPayload{
+		Ref: "refs/heads/" + branchName,
+		SHA: targetBranch.Commit.SHA,
+	}
+	headers, err := c.headers("application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.httpClient.Post(fmt.Sprintf("%s%s/git/refs", c.baseURL, repoPath(owner, repo)), payload, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return nil, c.responseError("create branch", response)
+	}
+
+	var ref github.Ref
+	if err := json.NewDecoder(response.Body).Decode(&ref); err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+func (c *GitHubClient) CreatePullRequest(owner, repo, head, base, title, body string, isDraft bool) (*github.PullRequest, error) {
+	payload := github.CreatePullRequestPayload{
+		Title: title,
+		Head:  head,
+		Base:  base,
+		Body:  body,
+		Draft: isDraft,
+	}
+	headers, err := c.headers("application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.httpClient.Post(fmt.Sprintf("%s%s/pulls", c.baseURL, repoPath(owner, repo)), payload, headers)
+	if err != nil {
+		c.logger.Error("Error creating pull request", zap.Error(err), zap.Any("payload", payload))
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return nil, c.responseError("create pull request", response)
+	}
+
+	var pullRequest github.PullRequest
+	if err := json.NewDecoder(response.Body).Decode(&pullRequest); err != nil {
+		return nil, err
+	}
+	return &pullRequest, nil
+}
+
+func (c *GitHubClient) FetchPullRequest(owner, repo string, number int) (*github.PullRequest, error) {
+	var pullRequest github.PullRequest
+	if _, err := c.getJSON(fmt.Sprintf("%s/pulls/%d", repoPath(owner, repo), number), "fetch pull request", &pullRequest); err != nil {
+		return nil, err
+	}
+	return &pullRequest, nil
+}
+
+// UpdatePullRequestState closes or reopens the pull request, state is open or closed.
+func (c *GitHubClient) UpdatePullRequestState(owner, repo string, number int, state string) (*github.PullRequest, error) {
+	headers, err := c.headers("application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.httpClient.Patch(fmt.Sprintf("%s%s/pulls/%d", c.baseURL, repoPath(owner, repo), number), github.UpdatePullRequestPayload{State: state}, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, c.responseError("update pull request state", response)
+	}
+
+	var pullRequest github.PullRequest
+	if err := json.NewDecoder(response.Body).Decode(&pullRequest); err != nil {
+		return nil, err
+	}
+	return &pullRequest, nil
+}
+
+// MergePullRequest merges with the given method (merge, squash or rebase). The merge is rejected when
+// sha is set and no longer matches the head of the pull request.
+func (c *GitHubClient) MergePullRequest(owner, repo string, number int, method, sha string) (*github.MergePullRequestResponse, error) {
+	payload := github.MergePullRequestPayload{
+		MergeMethod: method,
+		SHA:         sha,
+	}
+	headers, err := c.headers("application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.httpClient.Put(fmt.Sprintf("%s%s/pulls/%d/merge", c.baseURL, repoPath(owner, repo), number), payload, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, c.responseError("merge pull request", response)
+	}
+
+	var mergeResponse github.MergePullRequestResponse
+	if err := json.NewDecoder(response.Body).Decode(&mergeResponse); err != nil {
+		return nil, err
+	}
+	return &mergeResponse, nil
+}
+
+// GetCompareDiff returns the unified diff between the merge base of base and head, and head.
+func (c *GitHubClient) GetCompareDiff(owner, repo, base, head string) (string, error) {
+	headers, err := c.headers("application/vnd.github.diff")
+	if err != nil {
+		return "", err
+	}
+	response, err := c.httpClient.Get(fmt.Sprintf("%s%s/compare/%s...%s", c.baseURL, repoPath(owner, repo), base, head), headers)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", c.responseError("get diff", response)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func (c *GitHubClient) GetPullRequestCommits(owner, repo string, number int) ([]github.Commit, error) {
+	path := fmt.Sprintf("%s/pulls/%d/commits?per_page=100", repoPath(owner, repo), number)
+	return getAllPages[github.Commit](c, path, "fetch pull request commits")
+}
+
+var lastPagePattern = regexp.MustCompile(`[?&]page=(\d+)[^>]*>;\s*rel="last"`)
+
+// GetBranchCommits returns the latest commit of the branch and the total number of commits on it.
+// GitHub only exposes the total through the pagination links, so one commit is requested per page.
+func (c *GitHubClient) GetBranchCommits(owner, repo, branch string) ([]github.Commit, int, error) {
+	var commits []github.Commit
+	path := fmt.Sprintf("%s/commits?sha=%s&per_page=1", repoPath(owner, repo), url.QueryEscape(branch))
+	response, err := c.getJSON(path, "fetch branch commits", &commits)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(commits)
+	if matches := lastPagePattern.FindStringSubmatch(response.Header.Get("Link")); matches != nil {
+		total, _ = strconv.Atoi(matches[1])
+	}
+	return commits, total, nil
+}
+
+// CreateIssueComment adds a comment to the conversation of the pull request or issue.
+func (c *GitHubClient) CreateIssueComment(owner, repo string, number int, body string) (*github.Comment, error) {
+	headers, err := c.headers("application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	payload := github.CreateCommentPayload{Body: body}
+	response, err := c.httpClient.Post(fmt.Sprintf("%s%s/issues/%d/comments", c.baseURL, repoPath(owner, repo), number), payload, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return nil, c.responseError("create issue comment", response)
+	}
+
+	var comment github.Comment
+	if err := json.NewDecoder(response.Body).Decode(&comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// ListIssues lists the issues of the repository with the label, most recently updated first. Pull requests,
+// which the issues API returns as well, are left out. since limits the result to issues updated after it.
+func (c *GitHubClient) ListIssues(owner, repo, label string, since *time.Time) ([]github.Issue, error) {
+	query := url.Values{}
+	query.Set("state", "all")
+	query.Set("sort", "updated")
+	query.Set("per_page", "100")
+	if label != "" {
+		query.Set("labels", label)
+	}
+	if since != nil {
+		query.Set("since", since.UTC().Format(time.RFC3339))
+	}
+
+	allIssues, err := getAllPages[github.Issue](c, fmt.Sprintf("%s/issues?%s", repoPath(owner, repo), query.Encode()), "list issues")
+	if err != nil {
+		return nil, err
+	}
+	var issues []github.Issue
+	for _, issue := range allIssues {
+		if issue.PullRequest == nil {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// UpdateIssueState closes or reopens the issue, state is open or closed.
+func (c *GitHubClient) UpdateIssueState(owner, repo string, number int, state string) (*github.Issue, error) {
+	headers, err := c.headers("application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.httpClient.Patch(fmt.Sprintf("%s%s/issues/%d", c.baseURL, repoPath(owner, repo), number), github.UpdateIssuePayload{State: state}, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, c.responseError("update issue state", response)
+	}
+
+	var issue github.Issue
+	if err := json.NewDecoder(response.Body).Decode(&issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+func (c *GitHubClient) GetIssueComments(owner, repo string, number int) ([]github.Comment, error) {
+	path := fmt.Sprintf("%s/issues/%d/comments?per_page=100", repoPath(owner, repo), number)
+	return getAllPages[github.Comment](c, path, "fetch pull request comments")
+}
+
+func (c *GitHubClient) GetReviewComments(owner, repo string, number int) ([]github.Comment, error) {
+	path := fmt.Sprintf("%s/pulls/%d/comments?per_page=100", repoPath(owner, repo), number)
+	return getAllPages[github.Comment](c, path, "fetch pull request review comments")
+}
+
+// CreateReviewComment comments on a line of the new version of a file, the line must be part of the diff.
+func (c *GitHubClient) CreateReviewComment(owner, repo string, number int, commitID, path string, line int, body string) (*github.Comment, error) {
+	headers, err := c.headers("application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	payload := github.CreateReviewCommentPayload{Body: body, CommitID: commitID, Path: path, Line: line, Side: "RIGHT"}
+	response, err := c.httpClient.Post(fmt.Sprintf("%s%s/pulls/%d/comments", c.baseURL, repoPath(owner, repo), number), payload, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return nil, c.responseError("create review comment", response)
+	}
+
+	var comment github.Comment
+	if err := json.NewDecoder(response.Body).Decode(&comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// ReplyToReviewComment replies in the thread of a review comment on the diff.
+func (c *GitHubClient) ReplyToReviewComment(owner, repo string, number int, commentID int64, body string) (*github.Comment, error) {
+	headers, err := c.headers("application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	payload := github.CreateCommentPayload{Body: body}
+	response, err := c.httpClient.Post(fmt.Sprintf("%s%s/pulls/%d/comments/%d/replies", c.baseURL, repoPath(owner, repo), number, commentID), payload, headers)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return nil, c.responseError("reply to review comment", response)
+	}
+
+	var comment github.Comment
+	if err := json.NewDecoder(response.Body).Decode(&comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+const reviewThreadsQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100) {
+        nodes { id isResolved comments(first: 1) { nodes { databaseId } } }
+      }
+    }
+  }
+}`
+
+const resolveReviewThreadMutation = `mutation($threadId: ID!) {
+  resolveReviewThread(input: {threadId: $threadId}) { thread { id } }
+}`
+
+const convertPullRequestToDraftMutation = `mutation($pullRequestId: ID!) {
+  convertPullRequestToDraft(input: {pullRequestId: $pullRequestId}) { pullRequest { id } }
+}`
+
+const markPullRequestReadyForReviewMutation = `mutation($pullRequestId: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $pullRequestId}) { pullRequest { id } }
+}`
+
+// SetPullRequestDraft converts the pull request to a draft or marks it ready for review. The REST API
+// cannot change the draft state, so this goes through the GraphQL API.
+func (c *GitHubClient) SetPullRequestDraft(owner, repo string, number int, draft bool) error {
+	pullRequest, err := c.FetchPullRequest(owner, repo, number)
+	if err != nil {
+		return err
+	}
+	if pullRequest.Draft == draft {
+		return nil
+	}
+	mutation, action := markPullRequestReadyForReviewMutation, "mark pull request ready for review"
+	if draft {
+		mutation, action = convertPullRequestToDraftMutation, "convert pull request to draft"
+	}
+	var updated github.GraphQLResponse
+	if err := c.graphQL(mutation, map[string]interface{}{"pullRequestId": pullRequest.NodeID}, action, &updated); err != nil {
+		return err
+	}
+	if len(updated.Errors) > 0 {
+		return fmt.Errorf("failed to %s: %s", action, updated.Errors[0].Message)
+	}
+	return nil
+}
+
+// ResolveReviewThread resolves the review thread started by the review comment. The REST API cannot
+// resolve threads, so this goes through the GraphQL API.
+func (c *GitHubClient) ResolveReviewThread(owner, repo string, number int, commentID int64) error {
+	var threads github.ReviewThreadsResponse
+	err := c.graphQL(reviewThreadsQuery, map[string]interface{}{"owner": owner, "name": repo, "number": number}, "fetch review threads", &threads)
+	if err != nil {
+		return err
+	}
+	if len(threads.Errors) > 0 {
+		return fmt.Errorf("failed to fetch review threads: %s", threads.Errors[0].Message)
+	}
+	for _, thread := range threads.Data.Repository.PullRequest.ReviewThreads.Nodes {
+		if len(thread.Comments.Nodes) == 0 || thread.Comments.Nodes[0].DatabaseID != commentID {
+			continue
+		}
+		if thread.IsResolved {
+			return nil
+		}
+		var resolved github.GraphQLResponse
+		err = c.graphQL(resolveReviewThreadMutation, map[string]interface{}{"threadId": thread.ID}, "resolve review thread", &resolved)
+		if err != nil {
+			return err
+		}
+		if len(resolved.Errors) > 0 {
+			return fmt.Errorf("failed to resolve review thread: %s", resolved.Errors[0].Message)
+		}
+		return nil
+	}
+	return fmt.Errorf("no review thread found for comment %d", commentID)
+}
+
+func (c *GitHubClient) graphQL(query string, variables map[string]interface{}, action string, out interface{}) error {
+	headers, err := c.headers("application/vnd.github+json")
+	if err != nil {
+		return err
+	}
+	response, err := c.httpClient.Post(c.graphQLURL(), github.GraphQLRequest{Query: query, Variables: variables}, headers)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return c.responseError(action, response)
+	}
+	return json.NewDecoder(response.Body).Decode(out)
+}
+
+// graphQLURL is https://api.github.com/graphql, or https://<host>/api/graphql for GitHub Enterprise.
+func (c *GitHubClient) graphQLURL() string {
+	if strings.HasSuffix(c.baseURL, "/api/v3") {
+		return strings.TrimSuffix(c.baseURL, "/v3") + "/graphql"
+	}
+	return c.baseURL + "/graphql"
+}
@@ -0,0 +1,241 @@
+package github_git_provider
+
+import (
+	"ai-developer/app/client"
+	"ai-developer/app/models/dtos/github"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*GitHubClient, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewGitHubClient(server.URL, StaticTokenSource("test-token"), client.NewHttpClient(), zap.NewNop()), server
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, body interface{}) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		t.Fatalf("encoding response: %v", err)
+	}
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	githubClient, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/owner/repo/pulls" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if got := r.Header.Get("X-GitHub-Api-Version"); got != apiVersion {
+			t.Errorf("X-GitHub-Api-Version = %q", got)
+		}
+		var payload github.CreatePullRequestPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decoding payload: %v", err)
+		}
+		if payload.Head != "feature" || payload.Base != "main" || payload.Title != "feat: add login" || !payload.Draft {
+			t.Errorf("unexpected payload %+v", payload)
+		}
+		writeJSON(t, w, http.StatusCreated, github.PullRequest{ID: 1, Number: 7})
+	})
+
+	pullRequest, err := githubClient.CreatePullRequest("owner", "repo", "feature", "main", "feat: add login", "body", true)
+	if err != nil {
+		t.Fatalf("CreatePullRequest: %v", err)
+	}
+	if pullRequest.Number != 7 {
+		t.Errorf("Number = %d, want 7", pullRequest.Number)
+	}
+}
+
+func TestMergePullRequest(t *testing.T) {
+	githubClient, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/repos/owner/repo/pulls/7/merge" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var payload github.MergePullRequestPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decoding payload: %v", err)
+		}
+		if payload.MergeMethod != "squash" || payload.SHA != "abc123" {
+			t.Errorf("unexpected payload %+v", payload)
+		}
+		writeJSON(t, w, http.StatusOK, github.MergePullRequestResponse{SHA: "def456", Merged: true})
+	})
+
+	mergeResponse, err := githubClient.MergePullRequest("owner", "repo", 7, "squash", "abc123")
+	if err != nil {
+		t.Fatalf("MergePullRequest: %v", err)
+	}
+	if !mergeResponse.Merged || mergeResponse.SHA != "def456" {
+		t.Errorf("unexpected merge response %+v", mergeResponse)
+	}
+}
+
+func TestCreateIssueComment(t *testing.T) {
+	githubClient, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/owner/repo/issues/7/comments" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var payload github.CreateCommentPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decoding payload: %v", err)
+		}
+		writeJSON(t, w, http.StatusCreated, github.Comment{ID: 42, Body: payload.Body})
+	})
+
+	comment, err := githubClient.CreateIssueComment("owner", "repo", 7, "Looks good")
+	if err != nil {
+		t.Fatalf("CreateIssueComment: %v", err)
+	}
+	if comment.ID != 42 || comment.Body != "Looks good" {
+		t.Errorf("unexpected comment %+v", comment)
+	}
+}
+
+func TestCreateReviewComment(t *testing.T) {
+	githubClient, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/owner/repo/pulls/7/comments" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var payload github.CreateReviewCommentPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decoding payload: %v", err)
+		}
+		if payload.CommitID != "abc123" || payload.Path != "main.go" || payload.Line != 12 || payload.Side != "RIGHT" {
+			t.Errorf("unexpected payload %+v", payload)
+		}
+		writeJSON(t, w, http.StatusCreated, github.Comment{ID: 43, Body: payload.Body, Path: payload.Path})
+	})
+
+	comment, err := githubClient.CreateReviewComment("owner", "repo", 7, "abc123", "main.go", 12, "Handle the error")
+	if err != nil {
+		t.Fatalf("CreateReviewComment: %v", err)
+	}
+	if comment.ID != 43 || comment.Path != "main.go" {
+		t.Errorf("unexpected comment %+v", comment)
+	}
+}
+
+func TestResponseErrorIncludesGitHubMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{
+			name:    "message",
+			status:  http.StatusMethodNotAllowed,
+			body:    `{"message": "Pull Request is not mergeable"}`,
+			wantErr: "failed to merge pull request, status code: 405: Pull Request is not mergeable",
+		},
+		{
+			name:    "no message",
+			status:  http.StatusBadGateway,
+			body:    "bad gateway",
+			wantErr: "failed to merge pull request, status code: 502",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			githubClient, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+
+			_, err := githubClient.MergePullRequest("owner", "repo", 7, "merge", "")
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetReviewCommentsFollowsNextLinks(t *testing.T) {
+	var server *httptest.Server
+	githubClient, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/pulls/7/comments" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/pulls/7/comments?per_page=100&page=2>; rel="next", <%s/repos/owner/repo/pulls/7/comments?per_page=100&page=2>; rel="last"`, server.URL, server.URL))
+			writeJSON(t, w, http.StatusOK, []github.Comment{{ID: 1}, {ID: 2}})
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/pulls/7/comments?per_page=100&page=1>; rel="first"`, server.URL))
+			writeJSON(t, w, http.StatusOK, []github.Comment{{ID: 3}})
+		default:
+			t.Errorf("unexpected page %s", r.URL.Query().Get("page"))
+		}
+	})
+
+	comments, err := githubClient.GetReviewComments("owner", "repo", 7)
+	if err != nil {
+		t.Fatalf("GetReviewComments: %v", err)
+	}
+	if len(comments) != 3 || comments[2].ID != 3 {
+		t.Errorf("unexpected comments %+v", comments)
+	}
+}
+
+func TestListIssuesDoesNotFollowLinksToOtherHosts(t *testing.T) {
+	requests := 0
+	githubClient, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Link", `<https://attacker.example/repos/owner/repo/issues?page=2>; rel="next"`)
+		writeJSON(t, w, http.StatusOK, []github.Issue{{Number: 1}, {Number: 2, PullRequest: &struct{}{}}})
+	})
+
+	issues, err := githubClient.ListIssues("owner", "repo", "supercoder", nil)
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+	if len(issues) != 1 || issues[0].Number != 1 {
+		t.Errorf("unexpected issues %+v", issues)
+	}
+}
+
+func TestGetBranchCommitsReadsTotalFromLastLink(t *testing.T) {
+	githubClient, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("sha"); got != "release/1.0" {
+			t.Errorf("sha = %q", got)
+		}
+		w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/commits?sha=release%2F1.0&per_page=1&page=2>; rel="next", <https://api.github.com/repos/owner/repo/commits?sha=release%2F1.0&per_page=1&page=15>; rel="last"`)
+		writeJSON(t, w, http.StatusOK, []github.Commit{{SHA: "abc123"}})
+	})
+
+	commits, total, err := githubClient.GetBranchCommits("owner", "repo", "release/1.0")
+	if err != nil {
+		t.Fatalf("GetBranchCommits: %v", err)
+	}
+	if total != 15 || len(commits) != 1 || commits[0].SHA != "abc123" {
+		t.Errorf("commits = %+v, total = %d", commits, total)
+	}
+}
+
+func TestRepositoryPathSegmentsAreEscaped(t *testing.T) {
+	githubClient, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/repos/my%20org/My%20App%3F/pulls/7" {
+			t.Errorf("unexpected path %s", r.URL.EscapedPath())
+		}
+		writeJSON(t, w, http.StatusOK, github.PullRequest{ID: 1, Number: 7})
+	})
+
+	if _, err := githubClient.FetchPullRequest("my org", "My App?", 7); err != nil {
+		t.Fatalf("FetchPullRequest: %v", err)
+	}
+}
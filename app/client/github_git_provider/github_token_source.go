@@ -0,0 +1,115 @@
+package github_git_provider
+
+import (
+	"ai-developer/app/client"
+	"ai-developer/app/models/dtos/github"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// TokenSource returns the token used for GitHub API calls and git over https.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// StaticTokenSource is a personal access or fine-grained token.
+type StaticTokenSource string
+
+func (s StaticTokenSource) Token() (string, error) {
+	if s == "" {
+		return "", errors.New("github token is not configured")
+	}
+	return string(s), nil
+}
+
+// AppTokenSource authenticates as a GitHub App installation, exchanging a short-lived app JWT
+// for an installation token which is cached until shortly before it expires.
+type AppTokenSource struct {
+	apiURL         string
+	appID          string
+	installationID string
+	privateKey     *rsa.PrivateKey
+	httpClient     *client.HttpClient
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func NewAppTokenSource(apiURL, appID, installationID, privateKeyPEM string, httpClient *client.HttpClient) (*AppTokenSource, error) {
+	// Multi-line keys are usually passed through the environment with escaped newlines.
+	privateKeyPEM = strings.ReplaceAll(privateKeyPEM, `\n`, "\n")
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(privateKeyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("invalid github app private key: %w", err)
+	}
+	return &AppTokenSource{
+		apiURL:         strings.TrimSuffix(apiURL, "/"),
+		appID:          appID,
+		installationID: installationID,
+		privateKey:     privateKey,
+		httpClient:     httpClient,
+	}, nil
+}
+
+func (s *AppTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && time.Now().Before(s.expiresAt.Add(-time.Minute)) {
+		return s.token, nil
+	}
+
+	appJWT, err := s.appJWT()
+	if err != nil {
+		return "", err
+	}
+	url := fmt.Sprintf("%s/app/installations/%s/access_tokens", s.apiURL, s.installationID)
+	headers := map[string]string{
+		"Accept":               "application/vnd.github+json",
+		"Authorization":        "Bearer " + appJWT,
+		"X-GitHub-Api-Version": apiVersion,
+	}
+	response, err := s.httpClient.Post(url, struct{}{}, headers)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to create github installation token, status code: %d", response.StatusCode)
+	}
+
+	var installationToken github.InstallationToken
+	if err := json.NewDecoder(response.Body).Decode(&installationToken); err != nil {
+		return "", err
+	}
+	s.token = installationToken.Token
+	s.expiresAt = installationToken.ExpiresAt
+	return s.token, nil
+}
+
+func (s *AppTokenSource) appJWT() (string, error) {
+	now := time.Now()
+	claims := jwt.StandardClaims{
+		// Backdated to allow for clock drift, GitHub rejects tokens valid for more than ten minutes.
+		IssuedAt:  now.Add(-time.Minute).Unix(),
+		ExpiresAt: now.Add(9 * time.Minute).Unix(),
+		Issuer:    s.appID,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(s.privateKey)
+}
+
+// NewTokenSource prefers GitHub App credentials when an app id is configured and falls back to the static token.
+func NewTokenSource(apiURL, token, appID, installationID, privateKeyPEM string, httpClient *client.HttpClient) (TokenSource, error) {
+	if appID != "" {
+		return NewAppTokenSource(apiURL, appID, installationID, privateKeyPEM, httpClient)
+	}
+	return StaticTokenSource(token), nil
+}
@@ -0,0 +1,314 @@
+package gitlab_git_provider
+
+import (
+	"ai-developer/app/client"
+	"ai-developer/app/models/dtos/gitlab"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// GitLabClient talks to the REST API v4 of a self-hosted GitLab instance. Projects and groups are
+// addressed by their full path.
+type GitLabClient struct {
+	baseURL    string
+	token      string
+	httpClient *client.HttpClient
+	logger     *zap.Logger
+}
+
+func NewGitLabClient(
+	baseURL string,
+	token string,
+	httpClient *client.HttpClient,
+	logger *zap.Logger,
+) *GitLabClient {
+	return &GitLabClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/") + "/api/v4",
+		token:      token,
+		httpClient: httpClient,
+		logger:     logger.Named("GitLabClient"),
+	}
+}
+
+func (c *GitLabClient) headers() map[string]string {
+	return map[string]string{
+		"Content-Type":  "application/json",
+		"PRIVATE-TOKEN": c.token,
+	}
+}
+
+// responseError builds the error for an unexpected status, including the message GitLab sends along.
+func (c *GitLabClient) responseError(action string, response *http.Response) error {
+	var errorResponse gitlab.ErrorResponse
+	body, _ := io.ReadAll(response.Body)
+	if err := json.Unmarshal(body, &errorResponse); err == nil {
+		if errorResponse.Message != nil {
+			return fmt.Errorf("failed to %s, status code: %d: %v", action, response.StatusCode, errorResponse.Message)
+		}
+		if errorResponse.Error != "" {
+			return fmt.Errorf("failed to %s, status code: %d: %s", action, response.StatusCode, errorResponse.Error)
+		}
+	}
+	return fmt.Errorf("failed to %s, status code: %d", action, response.StatusCode)
+}
+
+func (c *GitLabClient) getJSON(path string, action string, out interface{}) (*http.Response, error) {
+	response, err := c.httpClient.Get(c.baseURL+path, c.headers())
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return response, c.responseError(action, response)
+	}
+	if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *GitLabClient) sendJSON(send func(string, interface{}, map[string]string) (*http.Response, error), path string, payload interface{}, action string, expectedStatus int, out interface{}) error {
+	response, err := send(c.baseURL+path, payload, c.headers())
+	if err != nil {
+		c.logger.Error("Error calling gitlab", zap.Error(err), zap.String("action", action), zap.String("path", path))
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != expectedStatus {
+		return c.responseError(action, response)
+	}
+	return json.NewDecoder(response.Body).Decode(out)
+}
+
+// GetGroup returns the group with the given full path, or nil when it does not exist.
+func (c *GitLabClient) GetGroup(fullPath string) (*gitlab.Group, error) {
+	var group gitlab.Group
+	response, err := c.getJSON("/groups/"+url.PathEscape(fullPath), "fetch group", &group)
+	if response != nil && response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (c *GitLabClient) CreateGroup(name, path string, parentID int) (*gitlab.Group, error) {
+	payload := gitlab.CreateGroupPayload{
+		Name:       name,
+		Path:       path,
+		ParentID:   parentID,
+		Visibility: "private",
+	}
+	var group gitlab.Group
+	if err := c.sendJSON(c.httpClient.Post, "/groups", payload, "create group", http.StatusCreated, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (c *GitLabClient) CreateProject(namespaceID int, name, path, description, defaultBranch string) (*gitlab.Project, error) {
+	payload := gitlab.CreateProjectPayload{
+		Name:                 name,
+		Path:                 path,
+		NamespaceID:          namespaceID,
+		Description:          description,
+		Visibility:           "private",
+		DefaultBranch:        defaultBranch,
+		InitializeWithReadme: false,
+	}
+	var project gitlab.Project
+	if err := c.sendJSON(c.httpClient.Post, "/projects", payload, "create project", http.StatusCreated, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (c *GitLabClient) CreateBranch(projectPath, branchName, ref string) (*gitlab.Branch, error) {
+	path := fmt.Sprintf("/projects/%s/repository/branches?branch=%s&ref=%s",
+		url.PathEscape(projectPath), url.QueryEscape(branchName), url.QueryEscape(ref))
+	var branch gitlab.Branch
+	if err := c.sendJSON(c.httpClient.Post, path, struct{}{}, "create branch", http.StatusCreated, &branch); err != nil {
+		return nil, err
+	}
+	return &branch, nil
+}
+
+func (c *GitLabClient) CreateMergeRequest(projectPath, sourceBranch, targetBranch, title, description string) (*gitlab.MergeRequest, error) {
+	payload := gitlab.CreateMergeRequestPayload{
+		SourceBranch: sourceBranch,
+		TargetBranch: targetBranch,
+		Title:        title,
+		Description:  description,
+	}
+	var mergeRequest gitlab.MergeRequest
+	path := fmt.Sprintf("/projects/%s/merge_requests", url.PathEscape(projectPath))
+	if err := c.sendJSON(c.httpClient.Post, path, payload, "create merge request", http.StatusCreated, &mergeRequest); err != nil {
+		return nil, err
+	}
+	return &mergeRequest, nil
+}
+
+func (c *GitLabClient) FetchMergeRequest(projectPath string, iid int) (*gitlab.MergeRequest, error) {
+	var mergeRequest gitlab.MergeRequest
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d", url.PathEscape(projectPath), iid)
+	if _, err := c.getJSON(path, "fetch merge request", &mergeRequest); err != nil {
+		return nil, err
+	}
+	return &mergeRequest, nil
+}
+
+// UpdateMergeRequest closes or reopens the merge request or changes its title, GitLab marks merge requests as
+// drafts by a "Draft:" title prefix.
+func (c *GitLabClient) UpdateMergeRequest(projectPath string, iid int, payload gitlab.UpdateMergeRequestPayload) (*gitlab.MergeRequest, error) {
+	var mergeRequest gitlab.MergeRequest
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d", url.PathEscape(projectPath), iid)
+	if err := c.sendJSON(c.httpClient.Put, path, payload, "update merge request", http.StatusOK, &mergeRequest); err != nil {
+		return nil, err
+	}
+	return &mergeRequest, nil
+}
+
+// AcceptMergeRequest merges the merge request. The merge is rejected when sha is set and no longer
+// matches the head of the source branch.
+func (c *GitLabClient) AcceptMergeRequest(projectPath string, iid int, squash bool, sha string) (*gitlab.MergeRequest, error) {
+	payload := gitlab.AcceptMergeRequestPayload{
+		Squash: squash,
+		SHA:    sha,
+	}
+	var mergeRequest gitlab.MergeRequest
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d/merge", url.PathEscape(projectPath), iid)
+	if err := c.sendJSON(c.httpClient.Put, path, payload, "merge merge request", http.StatusOK, &mergeRequest); err != nil {
+		return nil, err
+	}
+	return &mergeRequest, nil
+}
+
+// RebaseMergeRequest starts rebasing the source branch onto the target branch, GitLab rebases in the
+// background and reports progress through RebaseInProgress.
+func (c *GitLabClient) RebaseMergeRequest(projectPath string, iid int) error {
+	var rebase struct {
+		RebaseInProgress bool `json:"rebase_in_progress"`
+	}
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d/rebase", url.PathEscape(projectPath), iid)
+	return c.sendJSON(c.httpClient.Put, path, struct{}{}, "rebase merge request", http.StatusAccepted, &rebase)
+}
+
+func (c *GitLabClient) FetchMergeRequestRebaseStatus(projectPath string, iid int) (*gitlab.MergeRequest, error) {
+	var mergeRequest gitlab.MergeRequest
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d?include_rebase_in_progress=true", url.PathEscape(projectPath), iid)
+	if _, err := c.getJSON(path, "fetch merge request rebase status", &mergeRequest); err != nil {
+		return nil, err
+	}
+	return &mergeRequest, nil
+}
+
+// Compare returns the commits and file diffs between the merge base of from and to, and to.
+func (c *GitLabClient) Compare(projectPath, from, to string) (*gitlab.Compare, error) {
+	var compare gitlab.Compare
+	path := fmt.Sprintf("/projects/%s/repository/compare?from=%s&to=%s&straight=false",
+		url.PathEscape(projectPath), url.QueryEscape(from), url.QueryEscape(to))
+	if _, err := c.getJSON(path, "compare commits", &compare); err != nil {
+		return nil, err
+	}
+	return &compare, nil
+}
+
+func (c *GitLabClient) GetMergeRequestCommits(projectPath string, iid int) ([]gitlab.Commit, error) {
+	var commits []gitlab.Commit
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d/commits?per_page=100", url.PathEscape(projectPath), iid)
+	if _, err := c.getJSON(path, "fetch merge request commits", &commits); err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+// GetBranchCommits returns the latest commit of the branch and the total number of commits on it,
+// taken from the pagination headers.
+func (c *GitLabClient) GetBranchCommits(projectPath, branch string) ([]gitlab.Commit, int, error) {
+	var commits []gitlab.Commit
+	path := fmt.Sprintf("/projects/%s/repository/commits?ref_name=%s&per_page=1", url.PathEscape(projectPath), url.QueryEscape(branch))
+	response, err := c.getJSON(path, "fetch branch commits", &commits)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := len(commits)
+	if header := response.Header.Get("X-Total"); header != "" {
+		total, _ = strconv.Atoi(header)
+	}
+	return commits, total, nil
+}
+
+func (c *GitLabClient) CreateMergeRequestNote(projectPath string, iid int, body string) (*gitlab.Note, error) {
+	var note gitlab.Note
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d/notes", url.PathEscape(projectPath), iid)
+	if err := c.sendJSON(c.httpClient.Post, path, gitlab.CreateNotePayload{Body: body}, "create merge request note", http.StatusCreated, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (c *GitLabClient) GetMergeRequestDiscussions(projectPath string, iid int) ([]gitlab.Discussion, error) {
+	var discussions []gitlab.Discussion
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d/discussions?per_page=100", url.PathEscape(projectPath), iid)
+	if _, err := c.getJSON(path, "fetch merge request discussions", &discussions); err != nil {
+		return nil, err
+	}
+	return discussions, nil
+}
+
+func (c *GitLabClient) CreateMergeRequestDiscussion(projectPath string, iid int, body string, position *gitlab.DiscussionPosition) (*gitlab.Discussion, error) {
+	var discussion gitlab.Discussion
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d/discussions", url.PathEscape(projectPath), iid)
+	payload := gitlab.CreateDiscussionPayload{Body: body, Position: position}
+	if err := c.sendJSON(c.httpClient.Post, path, payload, "create merge request discussion", http.StatusCreated, &discussion); err != nil {
+		return nil, err
+	}
+	return &discussion, nil
+}
+
+func (c *GitLabClient) CreateDiscussionNote(projectPath string, iid int, discussionID, body string) (*gitlab.Note, error) {
+	var note gitlab.Note
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d/discussions/%s/notes", url.PathEscape(projectPath), iid, discussionID)
+	if err := c.sendJSON(c.httpClient.Post, path, gitlab.CreateNotePayload{Body: body}, "create discussion note", http.StatusCreated, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (c *GitLabClient) ResolveDiscussion(projectPath string, iid int, discussionID string) error {
+	var discussion gitlab.Discussion
+	path := fmt.Sprintf("/projects/%s/merge_requests/%d/discussions/%s", url.PathEscape(projectPath), iid, discussionID)
+	return c.sendJSON(c.httpClient.Put, path, gitlab.ResolveDiscussionPayload{Resolved: true}, "resolve discussion", http.StatusOK, &discussion)
+}
+
+// GetLatestPipeline returns the most recent pipeline of the ref, or nil when no pipeline ran for it.
+func (c *GitLabClient) GetLatestPipeline(projectPath, ref string) (*gitlab.Pipeline, error) {
+	var pipelines []gitlab.Pipeline
+	path := fmt.Sprintf("/projects/%s/pipelines?ref=%s&order_by=id&sort=desc&per_page=1", url.PathEscape(projectPath), url.QueryEscape(ref))
+	if _, err := c.getJSON(path, "fetch pipelines", &pipelines); err != nil {
+		return nil, err
+	}
+	if len(pipelines) == 0 {
+		return nil, nil
+	}
+	return &pipelines[0], nil
+}
+
+// CreatePipeline runs the CI/CD configuration of the project for the head of the ref.
+func (c *GitLabClient) CreatePipeline(projectPath, ref string) (*gitlab.Pipeline, error) {
+	var pipeline gitlab.Pipeline
+	path := fmt.Sprintf("/projects/%s/pipeline", url.PathEscape(projectPath))
+	if err := c.sendJSON(c.httpClient.Post, path, gitlab.CreatePipelinePayload{Ref: ref}, "create pipeline", http.StatusCreated, &pipeline); err != nil {
+		return nil, err
+	}
+	return &pipeline, nil
+}
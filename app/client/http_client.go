@@ -17,12 +17,24 @@ func NewHttpClient() *HttpClient {
 }
 
 func (hc *HttpClient) Post(url string, payload interface{}, headers map[string]string) (*http.Response, error) {
+	return hc.sendJSON("POST", url, payload, headers)
+}
+
+func (hc *HttpClient) Put(url string, payload interface{}, headers map[string]string) (*http.Response, error) {
+	return hc.sendJSON("PUT", url, payload, headers)
+}
+
+func (hc *HttpClient) Patch(url string, payload interface{}, headers map[string]string) (*http.Response, error) {
+	return hc.sendJSON("PATCH", url, payload, headers)
+}
+
+func (hc *HttpClient) sendJSON(method string, url string, payload interface{}, headers map[string]string) (*http.Response, error) {
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payloadBytes))
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(payloadBytes))
 	if err != nil {
 		return nil, err
 	}
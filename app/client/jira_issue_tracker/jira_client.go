@@ -0,0 +1,147 @@
+package jira_issue_tracker
+
+import (
+	"ai-developer/app/client"
+	"ai-developer/app/models/dtos/jira"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const searchPageSize = 50
+
+// JiraClient talks to the Jira REST API v2. Jira Cloud authenticates with the account email and an API
+// token, Jira Data Center with a personal access token and no email.
+type JiraClient struct {
+	baseURL    string
+	email      string
+	token      string
+	httpClient *client.HttpClient
+	logger     *zap.Logger
+}
+
+func NewJiraClient(
+	baseURL string,
+	email string,
+	token string,
+	httpClient *client.HttpClient,
+	logger *zap.Logger,
+) *JiraClient {
+	return &JiraClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		email:      email,
+		token:      token,
+		httpClient: httpClient,
+		logger:     logger.Named("JiraClient"),
+	}
+}
+
+func (c *JiraClient) headers() map[string]string {
+	authorization := "Bearer " + c.token
+	if c.email != "" {
+		authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(c.email+":"+c.token))
+	}
+	return map[string]string{
+		"Accept":        "application/json",
+		"Content-Type":  "application/json",
+		"Authorization": authorization,
+	}
+}
+
+// responseError builds the error for an unexpected status, including the messages Jira sends along.
+func (c *JiraClient) responseError(action string, response *http.Response) error {
+	var errorResponse jira.ErrorResponse
+	body, _ := io.ReadAll(response.Body)
+	if err := json.Unmarshal(body, &errorResponse); err == nil {
+		messages := errorResponse.ErrorMessages
+		for field, message := range errorResponse.Errors {
+			messages = append(messages, field+": "+message)
+		}
+		if len(messages) > 0 {
+			return fmt.Errorf("failed to %s, status code: %d: %s", action, response.StatusCode, strings.Join(messages, "; "))
+		}
+	}
+	return fmt.Errorf("failed to %s, status code: %d", action, response.StatusCode)
+}
+
+// SearchIssues returns all issues matching the JQL query.
+func (c *JiraClient) SearchIssues(jql string) ([]jira.Issue, error) {
+	var issues []jira.Issue
+	for startAt := 0; ; startAt += searchPageSize {
+		query := url.Values{}
+		query.Set("jql", jql)
+		query.Set("startAt", strconv.Itoa(startAt))
+		query.Set("maxResults", strconv.Itoa(searchPageSize))
+		query.Set("fields", "summary,description,labels,status")
+
+		response, err := c.httpClient.Get(c.baseURL+"/rest/api/2/search?"+query.Encode(), c.headers())
+		if err != nil {
+			return nil, err
+		}
+		if response.StatusCode != http.StatusOK {
+			err := c.responseError("search issues", response)
+			response.Body.Close()
+			return nil, err
+		}
+		var searchResponse jira.SearchResponse
+		err = json.NewDecoder(response.Body).Decode(&searchResponse)
+		response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, searchResponse.Issues...)
+		if len(searchResponse.Issues) == 0 || startAt+len(searchResponse.Issues) >= searchResponse.Total {
+			return issues, nil
+		}
+	}
+}
+
+// GetTransitions returns the transitions available for the issue in its current status.
+func (c *JiraClient) GetTransitions(issueID string) ([]jira.Transition, error) {
+	response, err := c.httpClient.Get(fmt.Sprintf("%s/rest/api/2/issue/%s/transitions", c.baseURL, url.PathEscape(issueID)), c.headers())
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, c.responseError("fetch issue transitions", response)
+	}
+
+	var transitionsResponse jira.TransitionsResponse
+	if err := json.NewDecoder(response.Body).Decode(&transitionsResponse); err != nil {
+		return nil, err
+	}
+	return transitionsResponse.Transitions, nil
+}
+
+func (c *JiraClient) TransitionIssue(issueID, transitionID string) error {
+	payload := jira.TransitionPayload{Transition: jira.TransitionReference{ID: transitionID}}
+	response, err := c.httpClient.Post(fmt.Sprintf("%s/rest/api/2/issue/%s/transitions", c.baseURL, url.PathEscape(issueID)), payload, c.headers())
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusNoContent {
+		return c.responseError("transition issue", response)
+	}
+	return nil
+}
+
+func (c *JiraClient) AddComment(issueID, body string) error {
+	response, err := c.httpClient.Post(fmt.Sprintf("%s/rest/api/2/issue/%s/comment", c.baseURL, url.PathEscape(issueID)), jira.CommentPayload{Body: body}, c.headers())
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		return c.responseError("add issue comment", response)
+	}
+	return nil
+}
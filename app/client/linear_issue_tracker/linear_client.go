@@ -0,0 +1,160 @@
+package linear_issue_tracker
+
+import (
+	"ai-developer/app/client"
+	"ai-developer/app/models/dtos/linear"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const DefaultAPIURL = "https://api.linear.app/graphql"
+
+const issuesQuery = `query Issues($filter: IssueFilter, $after: String) {
+  issues(filter: $filter, first: 50, after: $after, orderBy: updatedAt) {
+    nodes {
+      id identifier title description url
+      state { id name type }
+      team { id key }
+      labels { nodes { name } }
+    }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+const teamStatesQuery = `query TeamStates($id: String!) {
+  issue(id: $id) { team { states { nodes { id name type } } } }
+}`
+
+const issueUpdateMutation = `mutation IssueUpdate($id: String!, $stateId: String!) {
+  issueUpdate(id: $id, input: { stateId: $stateId }) { success }
+}`
+
+const commentCreateMutation = `mutation CommentCreate($issueId: String!, $body: String!) {
+  commentCreate(input: { issueId: $issueId, body: $body }) { success }
+}`
+
+// LinearClient talks to the Linear GraphQL API with a personal API key.
+type LinearClient struct {
+	apiURL     string
+	apiKey     string
+	httpClient *client.HttpClient
+	logger     *zap.Logger
+}
+
+// NewLinearClient creates a client for the API at apiURL, DefaultAPIURL when it is empty.
+func NewLinearClient(
+	apiURL string,
+	apiKey string,
+	httpClient *client.HttpClient,
+	logger *zap.Logger,
+) *LinearClient {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	return &LinearClient{
+		apiURL:     apiURL,
+		apiKey:     apiKey,
+		httpClient: httpClient,
+		logger:     logger.Named("LinearClient"),
+	}
+}
+
+func (c *LinearClient) graphQL(query string, variables map[string]interface{}, action string, out interface{}) error {
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		"Authorization": c.apiKey,
+	}
+	response, err := c.httpClient.Post(c.apiURL, linear.GraphQLRequest{Query: query, Variables: variables}, headers)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to %s, status code: %d", action, response.StatusCode)
+	}
+	return json.NewDecoder(response.Body).Decode(out)
+}
+
+func graphQLError(action string, errors []linear.GraphQLError) error {
+	if len(errors) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(errors))
+	for _, e := range errors {
+		messages = append(messages, e.Message)
+	}
+	return fmt.Errorf("failed to %s: %s", action, strings.Join(messages, "; "))
+}
+
+// ListIssues lists the issues of the team with the label. since limits the result to issues updated after it.
+func (c *LinearClient) ListIssues(teamKey, label string, since *time.Time) ([]linear.Issue, error) {
+	filter := map[string]interface{}{
+		"team": map[string]interface{}{"key": map[string]interface{}{"eq": teamKey}},
+	}
+	if label != "" {
+		filter["labels"] = map[string]interface{}{"name": map[string]interface{}{"eq": label}}
+	}
+	if since != nil {
+		filter["updatedAt"] = map[string]interface{}{"gt": since.UTC().Format(time.RFC3339)}
+	}
+
+	var issues []linear.Issue
+	var after interface{}
+	for {
+		var issuesResponse linear.IssuesResponse
+		variables := map[string]interface{}{"filter": filter, "after": after}
+		if err := c.graphQL(issuesQuery, variables, "list issues", &issuesResponse); err != nil {
+			return nil, err
+		}
+		if err := graphQLError("list issues", issuesResponse.Errors); err != nil {
+			return nil, err
+		}
+		issues = append(issues, issuesResponse.Data.Issues.Nodes...)
+		pageInfo := issuesResponse.Data.Issues.PageInfo
+		if !pageInfo.HasNextPage {
+			return issues, nil
+		}
+		after = pageInfo.EndCursor
+	}
+}
+
+// GetTeamStates returns the workflow states of the team the issue belongs to.
+func (c *LinearClient) GetTeamStates(issueID string) ([]linear.State, error) {
+	var statesResponse linear.TeamStatesResponse
+	if err := c.graphQL(teamStatesQuery, map[string]interface{}{"id": issueID}, "fetch workflow states", &statesResponse); err != nil {
+		return nil, err
+	}
+	if err := graphQLError("fetch workflow states", statesResponse.Errors); err != nil {
+		return nil, err
+	}
+	return statesResponse.Data.Issue.Team.States.Nodes, nil
+}
+
+func (c *LinearClient) UpdateIssueState(issueID, stateID string) error {
+	variables := map[string]interface{}{"id": issueID, "stateId": stateID}
+	return c.mutate(issueUpdateMutation, variables, "issueUpdate", "update issue state")
+}
+
+func (c *LinearClient) CreateComment(issueID, body string) error {
+	variables := map[string]interface{}{"issueId": issueID, "body": body}
+	return c.mutate(commentCreateMutation, variables, "commentCreate", "create issue comment")
+}
+
+func (c *LinearClient) mutate(mutation string, variables map[string]interface{}, name, action string) error {
+	var mutationResponse linear.MutationResponse
+	if err := c.graphQL(mutation, variables, action, &mutationResponse); err != nil {
+		return err
+	}
+	if err := graphQLError(action, mutationResponse.Errors); err != nil {
+		return err
+	}
+	if !mutationResponse.Data[name].Success {
+		return fmt.Errorf("failed to %s", action)
+	}
+	return nil
+}
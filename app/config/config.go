@@ -25,6 +25,10 @@ func LoadConfig() (*koanf.Koanf, error) {
 		"redis.db":                   0,
 		"github.redirect.url":        "http://localhost:3000/api/github/callback",
 		"github.frontend.url":        "http://localhost:3000",
+		"github.api.url":             "https://api.github.com",
+		"github.host":                "github.com",
+		"git.default.provider":       "GITNESS",
+		"gitlab.user":                "oauth2",
 		"jwt.secret.key":             "asdlajksdjaskdajskdlasd",
 		"jwt.expiry.hours":           "200h",
 		"workspace.service.endpoint": "http://ws:8080",
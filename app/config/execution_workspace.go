@@ -0,0 +1,15 @@
+package config
+
+import (
+	"path/filepath"
+	"strconv"
+)
+
+// ExecutionWorktreesDir is the directory of the project workspace that holds the git worktrees of executions.
+const ExecutionWorktreesDir = ".worktrees"
+
+// ExecutionWorkspacePath is the git worktree an execution of a backend story works in. It lives inside the
+// project workspace so that it is available wherever the workspace is mounted.
+func ExecutionWorkspacePath(projectHashID string, executionID uint) string {
+	return filepath.Join(WorkspaceWorkingDirectory(), projectHashID, ExecutionWorktreesDir, strconv.FormatUint(uint64(executionID), 10))
+}
@@ -0,0 +1,21 @@
+package config
+
+func GithubAPIURL() string { return config.String("github.api.url") }
+
+func GithubHost() string { return config.String("github.host") }
+
+// GithubOwner is the organisation or user that owns repositories of projects which do not set one.
+func GithubOwner() string { return config.String("github.owner") }
+
+func GithubToken() string { return config.String("github.token") }
+
+func GithubAppID() string { return config.String("github.app.id") }
+
+func GithubAppInstallationID() string { return config.String("github.app.installation.id") }
+
+func GithubAppPrivateKey() string { return config.String("github.app.private.key") }
+
+func DefaultGitProvider() string { return config.String("git.default.provider") }
+
+// GithubWebhookSecret verifies the signatures of webhook deliveries from GitHub.
+func GithubWebhookSecret() string { return config.String("github.webhook.secret") }
@@ -0,0 +1,15 @@
+package config
+
+// GitlabURL is the base URL of the self-hosted GitLab instance, e.g. https://gitlab.example.com.
+func GitlabURL() string { return config.String("gitlab.url") }
+
+func GitlabToken() string { return config.String("gitlab.token") }
+
+// GitlabUser is the username sent along with the token for git over https.
+func GitlabUser() string { return config.String("gitlab.user") }
+
+// GitlabParentGroup is the full path of the group under which organisation groups are created, empty for top-level groups.
+func GitlabParentGroup() string { return config.String("gitlab.parent.group") }
+
+// GitlabWebhookSecret is the secret token GitLab sends along with webhook deliveries.
+func GitlabWebhookSecret() string { return config.String("gitlab.webhook.secret") }
@@ -15,3 +15,8 @@ func GitnessToken() string {
 func GitnessHost() string {
 	return config.String("gitness.host")
 }
+
+// GitnessWebhookSecret verifies the signatures of webhook deliveries from Gitness.
+func GitnessWebhookSecret() string {
+	return config.String("gitness.webhook.secret")
+}
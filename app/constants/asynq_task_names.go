@@ -1,7 +1,12 @@
 package constants
 
 const (
-	CreateExecutionJobTaskType   = "create:job"
-	DeleteWorkspaceTaskType      = "delete:workspace"
-	CheckExecutionStatusTaskType = "check:execution_status"
+	CreateExecutionJobTaskType    = "create:job"
+	DeleteWorkspaceTaskType       = "delete:workspace"
+	CheckExecutionStatusTaskType  = "check:execution_status"
+	ReviewPullRequestTaskType     = "review:pull_request"
+	ReconcilePullRequestsTaskType = "reconcile:pull_requests"
+	DeliverWebhookTaskType        = "deliver:webhook"
+	SyncIssueTrackersTaskType     = "sync:issue_trackers"
+	AdvanceRunQueueTaskType       = "advance:run_queue"
 )
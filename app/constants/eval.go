@@ -0,0 +1,10 @@
+package constants
+
+// LLM modes of evaluation runs: stub replays the replies recorded in the benchmark, real calls the LLM.
+const (
+	EvalLLMStub = "stub"
+	EvalLLMReal = "real"
+)
+
+// EvalOrganisationName is the organisation the projects and stories of evaluation runs are created in.
+const EvalOrganisationName = "SuperCoder Evaluation"
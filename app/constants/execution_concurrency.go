@@ -0,0 +1,10 @@
+package constants
+
+import "time"
+
+// MaxConcurrentExecutionsLimit caps the number of stories of one project that may execute at the same time.
+const MaxConcurrentExecutionsLimit = 10
+
+// ExecutionSlotWaitInterval is how long an execution job task waits for a free slot before checking again, when
+// its project already executes as many stories as it may.
+const ExecutionSlotWaitInterval = 30 * time.Second
@@ -0,0 +1,23 @@
+package constants
+
+// Types of executions.
+const (
+	// ExecutionTypeDevelopment executions develop a story in a workspace.
+	ExecutionTypeDevelopment = "DEVELOPMENT"
+	// ExecutionTypeReview executions review a pull request SuperCoder did not write, they have no story.
+	ExecutionTypeReview = "REVIEW"
+)
+
+// Statuses of executions. Executions otherwise share the statuses of their stories, e.g. InProgress and Done.
+const (
+	// ExecutionFailed executions did not finish their story: evaluation executions whose expected tests failed
+	// and executions whose job could not be created.
+	ExecutionFailed = "FAILED"
+)
+
+// Statuses of pull request reviews.
+const (
+	ReviewQueued    = "QUEUED"
+	ReviewCompleted = "COMPLETED"
+	ReviewFailed    = "FAILED"
+)
@@ -0,0 +1,14 @@
+package constants
+
+// Git hosting providers, also stored as the remote type of pull requests.
+const (
+	GitnessProvider = "GITNESS"
+	GitHubProvider  = "GITHUB"
+	GitLabProvider  = "GITLAB"
+)
+
+// DefaultBranch is the default branch of projects which do not configure one.
+const DefaultBranch = "main"
+
+// DefaultBranchNameTemplate names story branches of projects which do not configure a template.
+const DefaultBranchNameTemplate = "branch_{rand}_{story_id}"
@@ -0,0 +1,27 @@
+package constants
+
+// Issue trackers stories can be imported from.
+const (
+	JiraIssueTracker         = "JIRA"
+	LinearIssueTracker       = "LINEAR"
+	GitHubIssuesIssueTracker = "GITHUB_ISSUES"
+)
+
+func ValidIssueTrackers() map[string]bool {
+	return map[string]bool{
+		JiraIssueTracker:         true,
+		LinearIssueTracker:       true,
+		GitHubIssuesIssueTracker: true,
+	}
+}
+
+// DefaultIssueLabel marks the issues imported by trackers which do not configure a label.
+const DefaultIssueLabel = "supercoder"
+
+// States pushed back to the issue trackers as the linked story progresses, each tracker maps them to
+// one of its own states.
+const (
+	IssueStateTodo       = "TODO"
+	IssueStateInProgress = "IN_PROGRESS"
+	IssueStateDone       = "DONE"
+)
@@ -0,0 +1,19 @@
+package constants
+
+// Methods pull requests are merged with.
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// DefaultMergeMethod merges the pull requests of projects which do not configure a merge method.
+const DefaultMergeMethod = MergeMethodSquash
+
+func ValidMergeMethods() map[string]bool {
+	return map[string]bool{
+		MergeMethodMerge:  true,
+		MergeMethodSquash: true,
+		MergeMethodRebase: true,
+	}
+}
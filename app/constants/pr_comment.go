@@ -0,0 +1,23 @@
+package constants
+
+// Sources of pull request comments.
+const (
+	// CommentSourceUser comments were written in SuperCoder and are posted to the git host.
+	CommentSourceUser = "USER"
+	// CommentSourceHost comments were written on the git host and received through its webhook.
+	CommentSourceHost = "HOST"
+	// CommentSourceAgent comments are replies of the agent after addressing comments.
+	CommentSourceAgent = "AGENT"
+)
+
+// CommentMarker is appended to comments SuperCoder posts to git hosts, so that their webhooks are not
+// taken for new review comments.
+const CommentMarker = "<!-- supercoder -->"
+
+// Statuses of pull request comments.
+const (
+	// CommentStatusUnresolved comments are addressed by the next re-execution of the story.
+	CommentStatusUnresolved = "UNRESOLVED"
+	// CommentStatusResolved comments were addressed by a pushed change.
+	CommentStatusResolved = "RESOLVED"
+)
@@ -1,6 +1,9 @@
 package constants
 
 const (
-	Flask  = "flask"
-	NextJs = "nextjs"
+	Flask   = "flask"
+	FastAPI = "fastapi"
+	Express = "express"
+	Go      = "go"
+	NextJs  = "nextjs"
 )
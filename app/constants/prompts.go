@@ -0,0 +1,49 @@
+package constants
+
+// Prompts of the code generators kept in the prompt registry.
+const (
+	PromptFlask          = "python_flask"
+	PromptDjango         = "python_django"
+	PromptFastAPI        = "python_fastapi"
+	PromptExpress        = "node_express"
+	PromptGo             = "go"
+	PromptNextJs         = "nextjs"
+	PromptNextJsEditCode = "nextjs_edit_code"
+)
+
+// PromptsDirectory holds the files of the default versions of the prompts.
+const PromptsDirectory = "/go/prompts"
+
+// PromptDefaults are the files of the default versions of the prompts, relative to PromptsDirectory.
+var PromptDefaults = map[string]string{
+	PromptFlask:          "python/ai_developer_flask.txt",
+	PromptDjango:         "python/ai_developer_django.txt",
+	PromptFastAPI:        "python/ai_developer_fastapi.txt",
+	PromptExpress:        "node/ai_developer_express.txt",
+	PromptGo:             "go/ai_developer_go.txt",
+	PromptNextJs:         "nextjs/ai_frontend_developer.txt",
+	PromptNextJsEditCode: "nextjs/ai_frontend_developer_edit_code.txt",
+}
+
+// PromptModels are the models the prompts are sent to, the cost of executions is estimated at their prices.
+var PromptModels = map[string]string{
+	PromptFlask:          GPT_4O,
+	PromptDjango:         GPT_4O,
+	PromptFastAPI:        GPT_4O,
+	PromptExpress:        GPT_4O,
+	PromptGo:             GPT_4O,
+	PromptNextJs:         "claude-3-5-sonnet-20240620",
+	PromptNextJsEditCode: "claude-3-5-sonnet-20240620",
+}
+
+// BackendFrameworkPrompts are the prompts of the backend code generator by framework.
+var BackendFrameworkPrompts = map[string]string{
+	"flask":   PromptFlask,
+	"django":  PromptDjango,
+	"fastapi": PromptFastAPI,
+	"express": PromptExpress,
+	"go":      PromptGo,
+}
+
+// DefaultPromptVersion is the version of the default prompt file, versions stored in the registry start at 1.
+const DefaultPromptVersion = 0
@@ -0,0 +1,9 @@
+package constants
+
+// Statuses of the stories in the run queue of a project.
+const (
+	RunQueueQueued  = "QUEUED"
+	RunQueueRunning = "RUNNING"
+	RunQueueDone    = "DONE"
+	RunQueueFailed  = "FAILED"
+)
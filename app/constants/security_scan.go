@@ -0,0 +1,5 @@
+package constants
+
+// SemgrepRulesFile holds the Semgrep rules security scans of Python projects run, it is copied into the
+// executor image next to the prompts.
+const SemgrepRulesFile = "/go/security/semgrep/python.yml"
@@ -0,0 +1,43 @@
+package constants
+
+// Events organisations can subscribe their webhooks to.
+const (
+	EventExecutionStarted             = "execution.started"
+	EventExecutionFinished            = "execution.finished"
+	EventExecutionFailed              = "execution.failed"
+	EventExecutionMaxIterationReached = "execution.max_iterations_reached"
+	EventStoryStatusChanged           = "story.status_changed"
+	EventPullRequestOpened            = "pull_request.opened"
+	EventPullRequestMerged            = "pull_request.merged"
+	EventPullRequestClosed            = "pull_request.closed"
+	EventPullRequestReopened          = "pull_request.reopened"
+)
+
+func ValidWebhookEvents() map[string]bool {
+	return map[string]bool{
+		EventExecutionStarted:             true,
+		EventExecutionFinished:            true,
+		EventExecutionFailed:              true,
+		EventExecutionMaxIterationReached: true,
+		EventStoryStatusChanged:           true,
+		EventPullRequestOpened:            true,
+		EventPullRequestMerged:            true,
+		EventPullRequestClosed:            true,
+		EventPullRequestReopened:          true,
+	}
+}
+
+// Statuses of webhook deliveries.
+const (
+	DeliveryPending   = "PENDING"
+	DeliveryRetrying  = "RETRYING"
+	DeliverySucceeded = "SUCCEEDED"
+	DeliveryFailed    = "FAILED"
+)
+
+// WebhookSignatureHeader carries the hex encoded HMAC-SHA256 of "<timestamp>.<body>", keyed with the webhook secret.
+const WebhookSignatureHeader = "X-SuperCoder-Signature-256"
+
+// WebhookTimestampHeader carries the unix time the delivery was signed at, receivers reject old deliveries so that
+// they can not be replayed.
+const WebhookTimestampHeader = "X-SuperCoder-Timestamp"
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"ai-developer/app/models/types"
+	"ai-developer/app/services"
+	"ai-developer/app/types/request"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type InstructionSnippetController struct {
+	instructionSnippetService *services.InstructionSnippetService
+	userService               *services.UserService
+}
+
+func NewInstructionSnippetController(instructionSnippetService *services.InstructionSnippetService, userService *services.UserService) *InstructionSnippetController {
+	return &InstructionSnippetController{
+		instructionSnippetService: instructionSnippetService,
+		userService:               userService,
+	}
+}
+
+func (ctrl *InstructionSnippetController) GetSnippets(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	snippets, err := ctrl.instructionSnippetService.GetSnippets(organisationID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"snippets": snippets})
+}
+
+func (ctrl *InstructionSnippetController) CreateSnippet(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	var createRequest request.CreateInstructionSnippetRequest
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	snippet, err := ctrl.instructionSnippetService.CreateSnippet(organisationID, createRequest)
+	if err != nil {
+		abortWithInstructionSnippetError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"snippet": snippet})
+}
+
+func (ctrl *InstructionSnippetController) UpdateSnippet(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	snippetID, err := strconv.Atoi(c.Param("snippet_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid snippet ID"})
+		return
+	}
+	var updateRequest request.UpdateInstructionSnippetRequest
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	snippet, err := ctrl.instructionSnippetService.UpdateSnippet(organisationID, uint(snippetID), updateRequest)
+	if err != nil {
+		abortWithInstructionSnippetError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"snippet": snippet})
+}
+
+func (ctrl *InstructionSnippetController) DeleteSnippet(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	snippetID, err := strconv.Atoi(c.Param("snippet_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid snippet ID"})
+		return
+	}
+	if err := ctrl.instructionSnippetService.DeleteSnippet(organisationID, uint(snippetID)); err != nil {
+		abortWithInstructionSnippetError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Snippet deleted successfully"})
+}
+
+func (ctrl *InstructionSnippetController) organisationID(c *gin.Context) (uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return 0, false
+	}
+	userIDInt, ok := userID.(int)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is not of type int"})
+		return 0, false
+	}
+	organisationID, err := ctrl.userService.FetchOrganisationIDByUserID(uint(userIDInt))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organisation ID"})
+		return 0, false
+	}
+	return organisationID, true
+}
+
+func abortWithInstructionSnippetError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrInstructionSnippetNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, types.ErrInvalidInstructionSnippet):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"ai-developer/app/models/types"
+	"ai-developer/app/services"
+	"ai-developer/app/services/issue_trackers"
+	"ai-developer/app/types/request"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type IssueTrackerController struct {
+	issueTrackerService *services.IssueTrackerService
+}
+
+func NewIssueTrackerController(issueTrackerService *services.IssueTrackerService) *IssueTrackerController {
+	return &IssueTrackerController{issueTrackerService: issueTrackerService}
+}
+
+func (ctrl *IssueTrackerController) GetIssueTrackers(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	trackers, err := ctrl.issueTrackerService.GetIssueTrackers(uint(projectID))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"issue_trackers": trackers})
+}
+
+func (ctrl *IssueTrackerController) CreateIssueTracker(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	var createRequest request.CreateIssueTrackerRequest
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tracker, err := ctrl.issueTrackerService.CreateIssueTracker(uint(projectID), createRequest)
+	if err != nil {
+		abortWithIssueTrackerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"issue_tracker": tracker})
+}
+
+func (ctrl *IssueTrackerController) UpdateIssueTracker(c *gin.Context) {
+	projectID, trackerID, ok := issueTrackerParams(c)
+	if !ok {
+		return
+	}
+	var updateRequest request.UpdateIssueTrackerRequest
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	tracker, err := ctrl.issueTrackerService.UpdateIssueTracker(projectID, trackerID, updateRequest)
+	if err != nil {
+		abortWithIssueTrackerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"issue_tracker": tracker})
+}
+
+func (ctrl *IssueTrackerController) DeleteIssueTracker(c *gin.Context) {
+	projectID, trackerID, ok := issueTrackerParams(c)
+	if !ok {
+		return
+	}
+	if err := ctrl.issueTrackerService.DeleteIssueTracker(projectID, trackerID); err != nil {
+		abortWithIssueTrackerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Issue tracker deleted successfully"})
+}
+
+func (ctrl *IssueTrackerController) SyncIssueTracker(c *gin.Context) {
+	projectID, trackerID, ok := issueTrackerParams(c)
+	if !ok {
+		return
+	}
+	result, err := ctrl.issueTrackerService.SyncIssueTracker(projectID, trackerID)
+	if err != nil {
+		abortWithIssueTrackerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sync": result})
+}
+
+// HandleWebhook receives the webhook deliveries of a tracker, they are authenticated with its webhook secret.
+func (ctrl *IssueTrackerController) HandleWebhook(c *gin.Context) {
+	trackerID, err := strconv.Atoi(c.Param("tracker_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid issue tracker ID"})
+		return
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	err = ctrl.issueTrackerService.HandleWebhook(uint(trackerID), c.Request.Header, body)
+	if errors.Is(err, issue_trackers.ErrInvalidWebhookSignature) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		abortWithIssueTrackerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+func issueTrackerParams(c *gin.Context) (uint, uint, bool) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return 0, 0, false
+	}
+	trackerID, err := strconv.Atoi(c.Param("tracker_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid issue tracker ID"})
+		return 0, 0, false
+	}
+	return uint(projectID), uint(trackerID), true
+}
+
+func abortWithIssueTrackerError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrIssueTrackerNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, types.ErrInvalidIssueTracker):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"ai-developer/app/models/types"
+	"ai-developer/app/services"
+	"ai-developer/app/types/request"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type OrganisationWebhookController struct {
+	organisationWebhookService *services.OrganisationWebhookService
+	userService                *services.UserService
+}
+
+func NewOrganisationWebhookController(organisationWebhookService *services.OrganisationWebhookService, userService *services.UserService) *OrganisationWebhookController {
+	return &OrganisationWebhookController{
+		organisationWebhookService: organisationWebhookService,
+		userService:                userService,
+	}
+}
+
+func (ctrl *OrganisationWebhookController) GetWebhooks(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	webhooks, err := ctrl.organisationWebhookService.GetWebhooks(organisationID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+func (ctrl *OrganisationWebhookController) CreateWebhook(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	var createRequest request.CreateOrganisationWebhookRequest
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	webhook, err := ctrl.organisationWebhookService.CreateWebhook(organisationID, createRequest)
+	if err != nil {
+		abortWithWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"webhook": webhook})
+}
+
+func (ctrl *OrganisationWebhookController) UpdateWebhook(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	webhookID, err := strconv.Atoi(c.Param("webhook_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+	var updateRequest request.UpdateOrganisationWebhookRequest
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	webhook, err := ctrl.organisationWebhookService.UpdateWebhook(organisationID, uint(webhookID), updateRequest)
+	if err != nil {
+		abortWithWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"webhook": webhook})
+}
+
+func (ctrl *OrganisationWebhookController) DeleteWebhook(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	webhookID, err := strconv.Atoi(c.Param("webhook_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+	if err := ctrl.organisationWebhookService.DeleteWebhook(organisationID, uint(webhookID)); err != nil {
+		abortWithWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+func (ctrl *OrganisationWebhookController) GetDeliveries(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	webhookID, err := strconv.Atoi(c.Param("webhook_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+	deliveries, err := ctrl.organisationWebhookService.GetDeliveries(organisationID, uint(webhookID))
+	if err != nil {
+		abortWithWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+func (ctrl *OrganisationWebhookController) Redeliver(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	webhookID, err := strconv.Atoi(c.Param("webhook_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+	deliveryID, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+		return
+	}
+	delivery, err := ctrl.organisationWebhookService.Redeliver(organisationID, uint(webhookID), uint(deliveryID))
+	if err != nil {
+		abortWithWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"delivery_id": delivery.ID})
+}
+
+func (ctrl *OrganisationWebhookController) organisationID(c *gin.Context) (uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return 0, false
+	}
+	userIDInt, ok := userID.(int)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is not of type int"})
+		return 0, false
+	}
+	organisationID, err := ctrl.userService.FetchOrganisationIDByUserID(uint(userIDInt))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organisation ID"})
+		return 0, false
+	}
+	return organisationID, true
+}
+
+func abortWithWebhookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrWebhookNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, types.ErrInvalidWebhook):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -1,8 +1,13 @@
 package controllers
 
 import (
+	"ai-developer/app/constants"
+	"ai-developer/app/models/types"
 	"ai-developer/app/services"
 	"ai-developer/app/types/request"
+	"ai-developer/app/utils"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"strconv"
@@ -82,12 +87,80 @@ func (controller *ProjectController) CreateProject(context *gin.Context) {
 	return
 }
 
+func (controller *ProjectController) ImportProject(context *gin.Context) {
+	var importProjectRequest request.ImportProjectRequest
+	if err := context.ShouldBindJSON(&importProjectRequest); err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	email, _ := context.Get("email")
+	user, err := controller.userService.GetUserByEmail(email.(string))
+	if err != nil {
+		context.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if user == nil {
+		context.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "User not found"})
+		return
+	}
+	project, err := controller.projectService.ImportProject(int(user.OrganisationID), importProjectRequest)
+	if err != nil {
+		if errors.Is(err, types.ErrForeignRepositoryURL) {
+			context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		context.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	context.JSON(http.StatusOK, gin.H{"project_id": project.ID, "project_url": project.Url, "project_name": project.Name,
+		"project_frontend_url": project.FrontendURL, "project_backend_url": project.BackendURL, "project_framework": project.BackendFramework,
+		"project_frontend_framework": project.FrontendFramework, "project_default_branch": project.DefaultBranch})
+}
+
 func (controller *ProjectController) UpdateProject(context *gin.Context) {
 	var updateProjectRequest request.UpdateProjectRequest
 	if err := context.ShouldBindJSON(&updateProjectRequest); err != nil {
 		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if template := updateProjectRequest.BranchNameTemplate; template != nil && *template != "" {
+		if err := utils.ValidateBranchNameTemplate(*template); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	for _, branch := range []*string{updateProjectRequest.BaseBranch, updateProjectRequest.TargetBranch} {
+		if branch != nil && *branch != "" {
+			if err := utils.ValidateBranchName(*branch); err != nil {
+				context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+	}
+	if template := updateProjectRequest.PullRequestTemplate; template != nil && *template != "" {
+		if err := utils.ValidatePullRequestTemplate(*template); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if method := updateProjectRequest.AutoMergeMethod; method != nil && *method != "" && !constants.ValidMergeMethods()[*method] {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "invalid auto merge method " + *method})
+		return
+	}
+	if maxDiffLines := updateProjectRequest.AutoMergeMaxDiffLines; maxDiffLines != nil && *maxDiffLines < 0 {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "auto merge max diff lines must not be negative"})
+		return
+	}
+	if limit := updateProjectRequest.MaxConcurrentExecutions; limit != nil && (*limit < 1 || *limit > constants.MaxConcurrentExecutionsLimit) {
+		context.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("max concurrent executions must be between 1 and %d", constants.MaxConcurrentExecutionsLimit)})
+		return
+	}
+	if patterns := updateProjectRequest.AutoMergeExcludedPaths; patterns != nil {
+		if err := utils.ValidatePathPatterns(*patterns); err != nil {
+			context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
 	updatedProject, err := controller.projectService.UpdateProject(updateProjectRequest)
 	if err != nil {
 		context.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"ai-developer/app/models/types"
+	"ai-developer/app/services"
+	"ai-developer/app/types/request"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type PromptRegistryController struct {
+	promptRegistryService *services.PromptRegistryService
+	userService           *services.UserService
+}
+
+func NewPromptRegistryController(promptRegistryService *services.PromptRegistryService, userService *services.UserService) *PromptRegistryController {
+	return &PromptRegistryController{
+		promptRegistryService: promptRegistryService,
+		userService:           userService,
+	}
+}
+
+func (ctrl *PromptRegistryController) GetPrompts(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	prompts, err := ctrl.promptRegistryService.GetPrompts(organisationID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"prompts": prompts})
+}
+
+func (ctrl *PromptRegistryController) GetVersions(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	versions, err := ctrl.promptRegistryService.GetVersions(organisationID, c.Param("prompt_key"))
+	if err != nil {
+		abortWithPromptError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"versions": versions})
+}
+
+func (ctrl *PromptRegistryController) CreateVersion(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	var createRequest request.CreatePromptVersionRequest
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	version, err := ctrl.promptRegistryService.CreateVersion(organisationID, c.Param("prompt_key"), createRequest)
+	if err != nil {
+		abortWithPromptError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"version": version})
+}
+
+func (ctrl *PromptRegistryController) UpdateVersion(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	versionNumber, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		return
+	}
+	var updateRequest request.UpdatePromptVersionRequest
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	version, err := ctrl.promptRegistryService.UpdateVersion(organisationID, c.Param("prompt_key"), versionNumber, updateRequest)
+	if err != nil {
+		abortWithPromptError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"version": version})
+}
+
+// GetReport compares the executions assigned to the versions of the prompt.
+func (ctrl *PromptRegistryController) GetReport(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	report, err := ctrl.promptRegistryService.GetReport(organisationID, c.Param("prompt_key"))
+	if err != nil {
+		abortWithPromptError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"variants": report})
+}
+
+func (ctrl *PromptRegistryController) organisationID(c *gin.Context) (uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return 0, false
+	}
+	userIDInt, ok := userID.(int)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is not of type int"})
+		return 0, false
+	}
+	organisationID, err := ctrl.userService.FetchOrganisationIDByUserID(uint(userIDInt))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organisation ID"})
+		return 0, false
+	}
+	return organisationID, true
+}
+
+func abortWithPromptError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrPromptNotFound), errors.Is(err, types.ErrPromptVersionNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, types.ErrInvalidPromptVersion):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -23,7 +23,7 @@ func (ctrl *PullRequestCommentsController) CreateCommentForPrID(c *gin.Context)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	err := ctrl.pullRequestCommentService.CreateComment(createCommentRequest.PullRequestID, createCommentRequest.Comment)
+	err := ctrl.pullRequestCommentService.CreateComment(createCommentRequest.PullRequestID, createCommentRequest.Comment, createCommentRequest.Path, createCommentRequest.Line)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"ai-developer/app/models/types"
 	"ai-developer/app/services"
 	"ai-developer/app/types/request"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
@@ -59,7 +61,7 @@ func (ctrl *PullRequestController) MergePullRequest(c *gin.Context) {
 		return
 	}
 	organisationId := user.OrganisationID
-	mergeSHA, err := ctrl.pullRequestService.MergePullRequestByID(mergePullRequest.PullRequestID, organisationId)
+	mergeSHA, err := ctrl.pullRequestService.MergePullRequestByID(mergePullRequest.PullRequestID, organisationId, mergePullRequest.MergeMethod)
 	if err != nil {
 		fmt.Println("Error while merging Pull Request", err.Error())
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -67,6 +69,89 @@ func (ctrl *PullRequestController) MergePullRequest(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, gin.H{"merge_sha": mergeSHA})
 }
+func (ctrl *PullRequestController) ClosePullRequest(c *gin.Context) {
+	pullRequestID, err := strconv.Atoi(c.Param("pull_request_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pull request ID"})
+		return
+	}
+	pullRequest, err := ctrl.pullRequestService.ClosePullRequestByID(uint(pullRequestID))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": pullRequest.Status, "is_draft": pullRequest.IsDraft})
+}
+
+func (ctrl *PullRequestController) ReopenPullRequest(c *gin.Context) {
+	pullRequestID, err := strconv.Atoi(c.Param("pull_request_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pull request ID"})
+		return
+	}
+	pullRequest, err := ctrl.pullRequestService.ReopenPullRequestByID(uint(pullRequestID))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": pullRequest.Status, "is_draft": pullRequest.IsDraft})
+}
+
+func (ctrl *PullRequestController) SetPullRequestDraft(c *gin.Context) {
+	pullRequestID, err := strconv.Atoi(c.Param("pull_request_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pull request ID"})
+		return
+	}
+	var draftRequest request.SetPullRequestDraftRequest
+	if err := c.ShouldBindJSON(&draftRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	pullRequest, err := ctrl.pullRequestService.SetPullRequestDraftByID(uint(pullRequestID), *draftRequest.Draft)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": pullRequest.Status, "is_draft": pullRequest.IsDraft})
+}
+
+func (ctrl *PullRequestController) GetPullRequestPipeline(c *gin.Context) {
+	pullRequestID, err := strconv.Atoi(c.Param("pull_request_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pull request ID"})
+		return
+	}
+	pipeline, err := ctrl.pullRequestService.GetPullRequestPipeline(uint(pullRequestID))
+	if errors.Is(err, types.ErrPipelinesNotSupported) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"pipeline": pipeline})
+}
+
+func (ctrl *PullRequestController) TriggerPullRequestPipeline(c *gin.Context) {
+	pullRequestID, err := strconv.Atoi(c.Param("pull_request_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pull request ID"})
+		return
+	}
+	pipeline, err := ctrl.pullRequestService.TriggerPullRequestPipeline(uint(pullRequestID))
+	if errors.Is(err, types.ErrPipelinesNotSupported) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"pipeline": pipeline})
+}
+
 func (ctrl *PullRequestController) FetchPullRequestCommits(c *gin.Context) {
 	pullRequestIdStr := c.Param("pull_request_id")
 	pullRequestID, err := strconv.Atoi(pullRequestIdStr)
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"ai-developer/app/services"
+	"ai-developer/app/types/request"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type PullRequestReviewController struct {
+	pullRequestReviewService *services.PullRequestReviewService
+}
+
+func NewPullRequestReviewController(pullRequestReviewService *services.PullRequestReviewService) *PullRequestReviewController {
+	return &PullRequestReviewController{
+		pullRequestReviewService: pullRequestReviewService,
+	}
+}
+
+func (ctrl *PullRequestReviewController) RequestReview(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	var reviewRequest request.ReviewPullRequestRequest
+	if err := c.ShouldBindJSON(&reviewRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	review, err := ctrl.pullRequestReviewService.RequestReview(uint(projectID), reviewRequest.PullRequestNumber)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"review": review})
+}
+
+func (ctrl *PullRequestReviewController) GetReviewsByProjectID(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	reviews, err := ctrl.pullRequestReviewService.GetReviewsByProjectID(uint(projectID))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"reviews": reviews})
+}
+
+func (ctrl *PullRequestReviewController) GetReviewActivityLogs(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	reviewID, err := strconv.Atoi(c.Param("review_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+	logs, err := ctrl.pullRequestReviewService.GetReviewActivityLogs(uint(projectID), uint(reviewID))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"logs": logs})
+}
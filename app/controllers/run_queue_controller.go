@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"ai-developer/app/models/types"
+	"ai-developer/app/services"
+	"ai-developer/app/types/request"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type RunQueueController struct {
+	runQueueService *services.RunQueueService
+}
+
+func NewRunQueueController(runQueueService *services.RunQueueService) *RunQueueController {
+	return &RunQueueController{runQueueService: runQueueService}
+}
+
+func (ctrl *RunQueueController) GetRunQueue(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	active, finished, err := ctrl.runQueueService.GetRunQueue(uint(projectID))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"run_queue": active, "finished": finished})
+}
+
+func (ctrl *RunQueueController) EnqueueStories(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	var enqueueRequest request.EnqueueStoriesRequest
+	if err := c.ShouldBindJSON(&enqueueRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	active, err := ctrl.runQueueService.EnqueueStories(uint(projectID), enqueueRequest.StoryIDs)
+	if err != nil {
+		abortWithRunQueueError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"run_queue": active})
+}
+
+func (ctrl *RunQueueController) RemoveStory(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("project_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+	storyID, err := strconv.Atoi(c.Param("story_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid story ID"})
+		return
+	}
+	if err := ctrl.runQueueService.RemoveStory(uint(projectID), uint(storyID)); err != nil {
+		abortWithRunQueueError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Story removed from the run queue"})
+}
+
+func abortWithRunQueueError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrRunQueueEntryNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, types.ErrInvalidRunQueueRequest):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
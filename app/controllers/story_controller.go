@@ -135,7 +135,8 @@ func (controller *StoryController) UpdateStoryStatus(context *gin.Context) {
 	if errors.Is(err, types.ErrInvalidStatus) ||
 		errors.Is(err, types.ErrStoryDeleted) ||
 		errors.Is(err, types.ErrInvalidStory) ||
-		errors.Is(err, types.ErrInvalidStoryStatusTransition) {
+		errors.Is(err, types.ErrInvalidStoryStatusTransition) ||
+		errors.Is(err, types.ErrStoryDecomposed) {
 		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
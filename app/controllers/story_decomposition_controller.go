@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"ai-developer/app/models/types"
+	"ai-developer/app/services"
+	"ai-developer/app/types/request"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type StoryDecompositionController struct {
+	storyDecompositionService *services.StoryDecompositionService
+}
+
+func NewStoryDecompositionController(storyDecompositionService *services.StoryDecompositionService) *StoryDecompositionController {
+	return &StoryDecompositionController{storyDecompositionService: storyDecompositionService}
+}
+
+func (ctrl *StoryDecompositionController) ProposeSubStories(c *gin.Context) {
+	storyID, err := strconv.Atoi(c.Param("story_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid story ID"})
+		return
+	}
+	var decomposeRequest request.DecomposeStoryRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&decomposeRequest); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	subStories, err := ctrl.storyDecompositionService.ProposeSubStories(uint(storyID), decomposeRequest.Guidance)
+	if err != nil {
+		abortWithDecompositionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sub_stories": subStories})
+}
+
+func (ctrl *StoryDecompositionController) AcceptSubStories(c *gin.Context) {
+	storyID, err := strconv.Atoi(c.Param("story_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid story ID"})
+		return
+	}
+	var acceptRequest request.AcceptSubStoriesRequest
+	if err := c.ShouldBindJSON(&acceptRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	subStories, err := ctrl.storyDecompositionService.AcceptSubStories(uint(storyID), acceptRequest.SubStories)
+	if err != nil {
+		abortWithDecompositionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, subStories)
+}
+
+func (ctrl *StoryDecompositionController) GetSubStories(c *gin.Context) {
+	storyID, err := strconv.Atoi(c.Param("story_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid story ID"})
+		return
+	}
+	subStories, err := ctrl.storyDecompositionService.GetSubStories(uint(storyID))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, subStories)
+}
+
+func abortWithDecompositionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrInvalidStory), errors.Is(err, types.ErrInvalidSubStories):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"ai-developer/app/models/types"
+	"ai-developer/app/services"
+	"ai-developer/app/types/request"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type StoryDependencyController struct {
+	storyDependencyService *services.StoryDependencyService
+}
+
+func NewStoryDependencyController(storyDependencyService *services.StoryDependencyService) *StoryDependencyController {
+	return &StoryDependencyController{storyDependencyService: storyDependencyService}
+}
+
+func (ctrl *StoryDependencyController) GetDependencies(c *gin.Context) {
+	storyID, err := strconv.Atoi(c.Param("story_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid story ID"})
+		return
+	}
+	dependencies, err := ctrl.storyDependencyService.GetDependencies(uint(storyID))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"dependencies": dependencies})
+}
+
+func (ctrl *StoryDependencyController) SetDependencies(c *gin.Context) {
+	storyID, err := strconv.Atoi(c.Param("story_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid story ID"})
+		return
+	}
+	var setRequest request.SetStoryDependenciesRequest
+	if err := c.ShouldBindJSON(&setRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	dependencies, err := ctrl.storyDependencyService.SetDependencies(uint(storyID), setRequest.DependsOn)
+	if errors.Is(err, types.ErrInvalidStoryDependency) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"dependencies": dependencies})
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"ai-developer/app/models/types"
+	"ai-developer/app/services"
+	"ai-developer/app/types/request"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type StoryTemplateController struct {
+	storyTemplateService *services.StoryTemplateService
+	userService          *services.UserService
+}
+
+func NewStoryTemplateController(storyTemplateService *services.StoryTemplateService, userService *services.UserService) *StoryTemplateController {
+	return &StoryTemplateController{
+		storyTemplateService: storyTemplateService,
+		userService:          userService,
+	}
+}
+
+func (ctrl *StoryTemplateController) GetTemplates(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	templates, err := ctrl.storyTemplateService.GetTemplates(organisationID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+func (ctrl *StoryTemplateController) CreateTemplate(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	var createRequest request.CreateStoryTemplateRequest
+	if err := c.ShouldBindJSON(&createRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	template, err := ctrl.storyTemplateService.CreateTemplate(organisationID, createRequest)
+	if err != nil {
+		abortWithStoryTemplateError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"template": template})
+}
+
+func (ctrl *StoryTemplateController) UpdateTemplate(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	templateID, err := strconv.Atoi(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+	var updateRequest request.UpdateStoryTemplateRequest
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	template, err := ctrl.storyTemplateService.UpdateTemplate(organisationID, uint(templateID), updateRequest)
+	if err != nil {
+		abortWithStoryTemplateError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"template": template})
+}
+
+func (ctrl *StoryTemplateController) DeleteTemplate(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	templateID, err := strconv.Atoi(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+	if err := ctrl.storyTemplateService.DeleteTemplate(organisationID, uint(templateID)); err != nil {
+		abortWithStoryTemplateError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Template deleted successfully"})
+}
+
+// RenderTemplate returns the story the template renders to, for the client to review and create.
+func (ctrl *StoryTemplateController) RenderTemplate(c *gin.Context) {
+	organisationID, ok := ctrl.organisationID(c)
+	if !ok {
+		return
+	}
+	templateID, err := strconv.Atoi(c.Param("template_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+	var renderRequest request.RenderStoryTemplateRequest
+	if err := c.ShouldBindJSON(&renderRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	story, err := ctrl.storyTemplateService.RenderTemplate(organisationID, uint(templateID), renderRequest.Values)
+	if err != nil {
+		abortWithStoryTemplateError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"story": story})
+}
+
+func (ctrl *StoryTemplateController) organisationID(c *gin.Context) (uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return 0, false
+	}
+	userIDInt, ok := userID.(int)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID is not of type int"})
+		return 0, false
+	}
+	organisationID, err := ctrl.userService.FetchOrganisationIDByUserID(uint(userIDInt))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organisation ID"})
+		return 0, false
+	}
+	return organisationID, true
+}
+
+func abortWithStoryTemplateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrStoryTemplateNotFound):
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, types.ErrInvalidStoryTemplate):
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/services"
+	"ai-developer/app/services/git_providers"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+)
+
+// webhookRemoteTypes maps the provider segment of the webhook url to the remote type of the git provider.
+var webhookRemoteTypes = map[string]string{
+	"github":  constants.GitHubProvider,
+	"gitlab":  constants.GitLabProvider,
+	"gitness": constants.GitnessProvider,
+}
+
+type WebhookController struct {
+	pullRequestService        *services.PullRequestService
+	pullRequestCommentService *services.PullRequestCommentsService
+	pullRequestReviewService  *services.PullRequestReviewService
+}
+
+func NewWebhookController(pullRequestService *services.PullRequestService,
+	pullRequestCommentService *services.PullRequestCommentsService,
+	pullRequestReviewService *services.PullRequestReviewService) *WebhookController {
+	return &WebhookController{
+		pullRequestService:        pullRequestService,
+		pullRequestCommentService: pullRequestCommentService,
+		pullRequestReviewService:  pullRequestReviewService,
+	}
+}
+
+func (ctrl *WebhookController) HandleGitProviderWebhook(c *gin.Context) {
+	remoteType, ok := webhookRemoteTypes[c.Param("provider")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown git provider"})
+		return
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Every handler sees the delivery, a failing one must not keep the others from handling their part of it.
+	err = errors.Join(
+		ctrl.pullRequestCommentService.HandleWebhook(remoteType, c.Request.Header, body),
+		ctrl.pullRequestService.HandleWebhook(remoteType, c.Request.Header, body),
+		ctrl.pullRequestReviewService.HandleWebhook(remoteType, c.Request.Header, body),
+	)
+	// Deliveries which can not be verified are rejected, the provider would retry server errors forever.
+	if errors.Is(err, git_providers.ErrInvalidWebhookSignature) || errors.Is(err, git_providers.ErrWebhookSecretNotConfigured) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "OK"})
+}
@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests
+ADD COLUMN auto_merge_blocked BOOLEAN NOT NULL DEFAULT FALSE;
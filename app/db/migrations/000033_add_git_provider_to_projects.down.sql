@@ -0,0 +1,3 @@
+ALTER TABLE projects
+DROP COLUMN git_provider,
+DROP COLUMN repository_owner;
@@ -0,0 +1,3 @@
+ALTER TABLE projects
+ADD COLUMN git_provider VARCHAR(50) NOT NULL DEFAULT 'GITNESS',
+ADD COLUMN repository_owner VARCHAR(100);
@@ -0,0 +1,3 @@
+ALTER TABLE projects
+DROP COLUMN default_branch,
+DROP COLUMN import_url;
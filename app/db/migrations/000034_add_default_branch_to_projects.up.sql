@@ -0,0 +1,3 @@
+ALTER TABLE projects
+ADD COLUMN default_branch VARCHAR(100) NOT NULL DEFAULT 'main',
+ADD COLUMN import_url VARCHAR(500);
@@ -0,0 +1,8 @@
+ALTER TABLE projects
+DROP COLUMN base_branch,
+DROP COLUMN target_branch,
+DROP COLUMN branch_name_template;
+
+ALTER TABLE pull_requests
+DROP COLUMN source_branch,
+DROP COLUMN target_branch;
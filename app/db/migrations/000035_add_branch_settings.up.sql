@@ -0,0 +1,8 @@
+ALTER TABLE projects
+ADD COLUMN base_branch VARCHAR(100),
+ADD COLUMN target_branch VARCHAR(100),
+ADD COLUMN branch_name_template VARCHAR(255);
+
+ALTER TABLE pull_requests
+ADD COLUMN source_branch VARCHAR(255),
+ADD COLUMN target_branch VARCHAR(255);
@@ -0,0 +1,2 @@
+ALTER TABLE projects
+ADD COLUMN pull_request_template TEXT;
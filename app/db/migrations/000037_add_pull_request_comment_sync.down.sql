@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_pull_request_comments_external_id;
+
+ALTER TABLE pull_request_comments
+DROP COLUMN external_id,
+DROP COLUMN author,
+DROP COLUMN path,
+DROP COLUMN line,
+DROP COLUMN source;
@@ -0,0 +1,9 @@
+ALTER TABLE pull_request_comments
+ALTER COLUMN comment TYPE TEXT,
+ADD COLUMN external_id VARCHAR(100),
+ADD COLUMN author VARCHAR(255),
+ADD COLUMN path VARCHAR(500),
+ADD COLUMN line INT,
+ADD COLUMN source VARCHAR(50) NOT NULL DEFAULT 'USER';
+
+CREATE INDEX idx_pull_request_comments_external_id ON pull_request_comments (pull_request_id, external_id);
@@ -0,0 +1,5 @@
+ALTER TABLE pull_request_comments
+DROP COLUMN status,
+DROP COLUMN resolution,
+DROP COLUMN execution_id,
+DROP COLUMN resolved_at;
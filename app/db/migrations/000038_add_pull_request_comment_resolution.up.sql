@@ -0,0 +1,8 @@
+ALTER TABLE pull_request_comments
+ADD COLUMN status VARCHAR(50) NOT NULL DEFAULT 'UNRESOLVED',
+ADD COLUMN resolution TEXT,
+ADD COLUMN execution_id INT,
+ADD COLUMN resolved_at TIMESTAMP;
+
+-- Every comment so far triggered its own re-execution.
+UPDATE pull_request_comments SET status = 'RESOLVED', resolved_at = updated_at;
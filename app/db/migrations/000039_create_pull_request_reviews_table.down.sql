@@ -0,0 +1,7 @@
+DROP TABLE pull_request_reviews;
+
+DELETE FROM executions WHERE story_id IS NULL;
+
+ALTER TABLE executions
+DROP COLUMN execution_type,
+ALTER COLUMN story_id SET NOT NULL;
@@ -0,0 +1,19 @@
+ALTER TABLE executions
+ALTER COLUMN story_id DROP NOT NULL,
+ADD COLUMN execution_type VARCHAR(50) NOT NULL DEFAULT 'DEVELOPMENT';
+
+CREATE TABLE pull_request_reviews (
+                                      id SERIAL PRIMARY KEY,
+                                      project_id INT NOT NULL,
+                                      execution_id INT,
+                                      remote_type VARCHAR(50) NOT NULL,
+                                      pull_request_number INT NOT NULL,
+                                      source_sha VARCHAR(100),
+                                      status VARCHAR(50) NOT NULL,
+                                      summary TEXT,
+                                      comments_count INT NOT NULL DEFAULT 0,
+                                      created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                      updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pull_request_reviews_project ON pull_request_reviews(project_id, pull_request_number);
@@ -0,0 +1,6 @@
+ALTER TABLE pull_requests
+ADD COLUMN is_draft BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- merged_at and closed_at were overwritten on every update, they only hold when the pull request was merged or closed.
+UPDATE pull_requests SET merged_at = NULL WHERE status <> 'MERGED';
+UPDATE pull_requests SET closed_at = NULL WHERE status NOT IN ('MERGED', 'CLOSE');
@@ -0,0 +1,8 @@
+ALTER TABLE projects
+DROP COLUMN auto_merge_enabled,
+DROP COLUMN auto_merge_method,
+DROP COLUMN auto_merge_require_server_test,
+DROP COLUMN auto_merge_require_tests,
+DROP COLUMN auto_merge_require_lint,
+DROP COLUMN auto_merge_max_diff_lines,
+DROP COLUMN auto_merge_excluded_paths;
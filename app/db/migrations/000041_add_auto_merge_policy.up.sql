@@ -0,0 +1,8 @@
+ALTER TABLE projects
+ADD COLUMN auto_merge_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN auto_merge_method VARCHAR(20),
+ADD COLUMN auto_merge_require_server_test BOOLEAN NOT NULL DEFAULT TRUE,
+ADD COLUMN auto_merge_require_tests BOOLEAN NOT NULL DEFAULT TRUE,
+ADD COLUMN auto_merge_require_lint BOOLEAN NOT NULL DEFAULT TRUE,
+ADD COLUMN auto_merge_max_diff_lines INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN auto_merge_excluded_paths TEXT;
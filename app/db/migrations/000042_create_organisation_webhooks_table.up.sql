@@ -0,0 +1,29 @@
+CREATE TABLE organisation_webhooks (
+                                       id SERIAL PRIMARY KEY,
+                                       organisation_id INT NOT NULL,
+                                       url VARCHAR(500) NOT NULL,
+                                       secret VARCHAR(255) NOT NULL,
+                                       events TEXT,
+                                       is_active BOOLEAN NOT NULL DEFAULT TRUE,
+                                       created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                       updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_organisation_webhooks_organisation ON organisation_webhooks(organisation_id);
+
+CREATE TABLE webhook_deliveries (
+                                    id SERIAL PRIMARY KEY,
+                                    webhook_id INT NOT NULL,
+                                    event VARCHAR(100) NOT NULL,
+                                    payload TEXT NOT NULL,
+                                    status VARCHAR(50) NOT NULL,
+                                    attempts INT NOT NULL DEFAULT 0,
+                                    response_status INT,
+                                    response_body TEXT,
+                                    error TEXT,
+                                    delivered_at TIMESTAMP WITH TIME ZONE,
+                                    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at);
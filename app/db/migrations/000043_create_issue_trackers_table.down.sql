@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS story_issue_links;
+DROP TABLE IF EXISTS issue_trackers;
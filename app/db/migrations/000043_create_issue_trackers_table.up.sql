@@ -0,0 +1,35 @@
+CREATE TABLE issue_trackers (
+                                id SERIAL PRIMARY KEY,
+                                project_id INT NOT NULL,
+                                provider VARCHAR(50) NOT NULL,
+                                base_url VARCHAR(500),
+                                username VARCHAR(255),
+                                token VARCHAR(500) NOT NULL,
+                                scope VARCHAR(255) NOT NULL,
+                                label VARCHAR(100) NOT NULL,
+                                webhook_secret VARCHAR(255) NOT NULL,
+                                polling_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+                                todo_state VARCHAR(100),
+                                in_progress_state VARCHAR(100),
+                                done_state VARCHAR(100),
+                                last_polled_at TIMESTAMP,
+                                created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_issue_trackers_project ON issue_trackers(project_id);
+
+CREATE TABLE story_issue_links (
+                                   id SERIAL PRIMARY KEY,
+                                   story_id INT NOT NULL UNIQUE,
+                                   issue_tracker_id INT NOT NULL,
+                                   external_id VARCHAR(100) NOT NULL,
+                                   external_key VARCHAR(100) NOT NULL,
+                                   url VARCHAR(500),
+                                   synced_state VARCHAR(50),
+                                   pull_request_url VARCHAR(500),
+                                   created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                   updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_story_issue_links_issue ON story_issue_links(issue_tracker_id, external_id);
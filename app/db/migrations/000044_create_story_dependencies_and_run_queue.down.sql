@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS run_queue_entries;
+DROP TABLE IF EXISTS story_dependencies;
@@ -0,0 +1,24 @@
+CREATE TABLE story_dependencies (
+                                    id SERIAL PRIMARY KEY,
+                                    story_id INT NOT NULL,
+                                    depends_on_story_id INT NOT NULL,
+                                    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_story_dependencies_story ON story_dependencies(story_id, depends_on_story_id);
+CREATE INDEX idx_story_dependencies_depends_on ON story_dependencies(depends_on_story_id);
+
+CREATE TABLE run_queue_entries (
+                                   id SERIAL PRIMARY KEY,
+                                   project_id INT NOT NULL,
+                                   story_id INT NOT NULL,
+                                   position INT NOT NULL,
+                                   status VARCHAR(50) NOT NULL,
+                                   error TEXT,
+                                   started_at TIMESTAMP WITH TIME ZONE,
+                                   finished_at TIMESTAMP WITH TIME ZONE,
+                                   created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                   updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_run_queue_entries_project ON run_queue_entries(project_id, status, position);
@@ -0,0 +1,2 @@
+ALTER TABLE projects
+ADD COLUMN max_concurrent_executions INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_stories_parent_story_id;
+ALTER TABLE stories
+DROP COLUMN parent_story_id;
@@ -0,0 +1,4 @@
+ALTER TABLE stories
+ADD COLUMN parent_story_id INT;
+
+CREATE INDEX idx_stories_parent_story_id ON stories(parent_story_id);
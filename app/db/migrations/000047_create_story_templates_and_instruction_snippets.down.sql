@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS instruction_snippets;
+DROP TABLE IF EXISTS story_templates;
@@ -0,0 +1,25 @@
+CREATE TABLE story_templates (
+                                 id SERIAL PRIMARY KEY,
+                                 organisation_id INT NOT NULL,
+                                 name VARCHAR(100) NOT NULL,
+                                 summary VARCHAR(255) NOT NULL,
+                                 description TEXT,
+                                 test_cases TEXT,
+                                 instructions TEXT,
+                                 created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                 updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_story_templates_organisation ON story_templates(organisation_id);
+
+CREATE TABLE instruction_snippets (
+                                      id SERIAL PRIMARY KEY,
+                                      organisation_id INT NOT NULL,
+                                      name VARCHAR(100) NOT NULL,
+                                      content TEXT NOT NULL,
+                                      is_active BOOLEAN NOT NULL DEFAULT TRUE,
+                                      created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                      updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_instruction_snippets_organisation ON instruction_snippets(organisation_id);
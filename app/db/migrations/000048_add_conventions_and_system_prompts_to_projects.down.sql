@@ -0,0 +1,4 @@
+ALTER TABLE projects
+DROP COLUMN conventions,
+DROP COLUMN backend_system_prompt,
+DROP COLUMN frontend_system_prompt;
@@ -0,0 +1,4 @@
+ALTER TABLE projects
+ADD COLUMN conventions TEXT,
+ADD COLUMN backend_system_prompt TEXT,
+ADD COLUMN frontend_system_prompt TEXT;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS execution_prompt_versions;
+DROP TABLE IF EXISTS prompt_versions;
@@ -0,0 +1,25 @@
+CREATE TABLE prompt_versions (
+                                 id SERIAL PRIMARY KEY,
+                                 organisation_id INT NOT NULL,
+                                 prompt_key VARCHAR(100) NOT NULL,
+                                 version INT NOT NULL,
+                                 content TEXT NOT NULL,
+                                 description VARCHAR(255),
+                                 is_active BOOLEAN NOT NULL DEFAULT FALSE,
+                                 ab_weight INT NOT NULL DEFAULT 0,
+                                 created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                 updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                 UNIQUE (organisation_id, prompt_key, version)
+);
+
+CREATE TABLE execution_prompt_versions (
+                                           id SERIAL PRIMARY KEY,
+                                           execution_id INT NOT NULL,
+                                           organisation_id INT NOT NULL,
+                                           prompt_key VARCHAR(100) NOT NULL,
+                                           version INT NOT NULL,
+                                           created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+                                           UNIQUE (execution_id, prompt_key)
+);
+
+CREATE INDEX idx_execution_prompt_versions_prompt ON execution_prompt_versions(organisation_id, prompt_key, version);
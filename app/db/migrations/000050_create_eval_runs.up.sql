@@ -0,0 +1,30 @@
+CREATE TABLE eval_runs (
+                           id SERIAL PRIMARY KEY,
+                           suite VARCHAR(255) NOT NULL,
+                           label VARCHAR(255),
+                           template VARCHAR(50) NOT NULL,
+                           llm_mode VARCHAR(20) NOT NULL,
+                           stories INT NOT NULL DEFAULT 0,
+                           passed INT NOT NULL DEFAULT 0,
+                           started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                           finished_at TIMESTAMP WITH TIME ZONE,
+                           created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE eval_results (
+                              id SERIAL PRIMARY KEY,
+                              run_id INT NOT NULL,
+                              story_name VARCHAR(255) NOT NULL,
+                              execution_id INT,
+                              passed BOOLEAN NOT NULL DEFAULT FALSE,
+                              execution_status VARCHAR(100),
+                              iterations INT NOT NULL DEFAULT 0,
+                              prompt_tokens INT NOT NULL DEFAULT 0,
+                              completion_tokens INT NOT NULL DEFAULT 0,
+                              duration_ms BIGINT NOT NULL DEFAULT 0,
+                              test_output TEXT,
+                              error TEXT,
+                              created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_eval_results_run ON eval_results(run_id);
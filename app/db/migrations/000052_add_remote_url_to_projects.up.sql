@@ -0,0 +1,2 @@
+ALTER TABLE projects
+ADD COLUMN remote_url VARCHAR(500);
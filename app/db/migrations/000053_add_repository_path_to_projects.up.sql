@@ -0,0 +1,2 @@
+ALTER TABLE projects
+ADD COLUMN repository_path VARCHAR(500);
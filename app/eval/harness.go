@@ -0,0 +1,460 @@
+package eval
+
+import (
+	"ai-developer/app/config"
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"ai-developer/app/repositories"
+	"ai-developer/app/services"
+	"ai-developer/app/types/request"
+	"ai-developer/app/utils"
+	"ai-developer/app/workflow_executors"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const maxTestOutputLength = 4000
+
+const defaultTestTimeout = 5 * time.Minute
+
+// RunOptions are the settings of an evaluation run.
+type RunOptions struct {
+	SuitePath string
+	// LLMMode is constants.EvalLLMStub or constants.EvalLLMReal.
+	LLMMode string
+	Label   string
+	// CompareRunID is an earlier run the report compares the run with.
+	CompareRunID uint
+}
+
+// Harness runs the stories of a benchmark suite through the workflow against local workspaces, checks them with
+// their expected tests and stores the pass rate, iterations, tokens and time per story.
+type Harness struct {
+	db                   *gorm.DB
+	evalRunRepo          *repositories.EvalRunRepository
+	organisationRepo     *repositories.OrganisationRepository
+	projectRepo          *repositories.ProjectRepository
+	storyService         *services.StoryService
+	executionService     *services.ExecutionService
+	executionStepService *services.ExecutionStepService
+	llmAPIKeyService     *services.LLMAPIKeyService
+	workflowExecutor     *workflow_executors.WorkflowExecutor
+}
+
+func NewHarness(
+	db *gorm.DB,
+	evalRunRepo *repositories.EvalRunRepository,
+	organisationRepo *repositories.OrganisationRepository,
+	projectRepo *repositories.ProjectRepository,
+	storyService *services.StoryService,
+	executionService *services.ExecutionService,
+	executionStepService *services.ExecutionStepService,
+	llmAPIKeyService *services.LLMAPIKeyService,
+	workflowExecutor *workflow_executors.WorkflowExecutor,
+) *Harness {
+	return &Harness{
+		db:                   db,
+		evalRunRepo:          evalRunRepo,
+		organisationRepo:     organisationRepo,
+		projectRepo:          projectRepo,
+		storyService:         storyService,
+		executionService:     executionService,
+		executionStepService: executionStepService,
+		llmAPIKeyService:     llmAPIKeyService,
+		workflowExecutor:     workflowExecutor,
+	}
+}
+
+// Run runs the suite through the workflow, which has to be the workflow of the template of the suite, and
+// prints the report of the run.
+func (h *Harness) Run(workflowConfig *workflow_executors.WorkflowConfig, template string, options RunOptions) (*models.EvalRun, error) {
+	if options.SuitePath == "" {
+		return nil, errors.New("no benchmark suite given")
+	}
+	suite, err := LoadSuite(options.SuitePath)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(suite.Template, template) {
+		return nil, fmt.Errorf("suite %s is for the %s template, the executor runs %s", suite.Name, suite.Template, template)
+	}
+	if strings.EqualFold(suite.Template, constants.NextJs) {
+		return nil, fmt.Errorf("evaluating the %s template is not supported", suite.Template)
+	}
+	if options.LLMMode == "" {
+		options.LLMMode = constants.EvalLLMStub
+	}
+	if options.LLMMode != constants.EvalLLMStub && options.LLMMode != constants.EvalLLMReal {
+		return nil, fmt.Errorf("invalid LLM mode %s, use %s or %s", options.LLMMode, constants.EvalLLMStub, constants.EvalLLMReal)
+	}
+
+	var stub *StubLLM
+	if options.LLMMode == constants.EvalLLMStub {
+		for _, story := range suite.Stories {
+			if len(story.StubResponses) == 0 {
+				return nil, fmt.Errorf("story %s has no stub responses", story.Name)
+			}
+		}
+		stub = StartStubLLM()
+		defer stub.Close()
+	}
+
+	organisation, err := h.evalOrganisation(options.LLMMode)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &models.EvalRun{
+		Suite:     suite.Name,
+		Label:     options.Label,
+		Template:  strings.ToLower(suite.Template),
+		LLMMode:   options.LLMMode,
+		Stories:   len(suite.Stories),
+		StartedAt: time.Now(),
+	}
+	if err := h.evalRunRepo.CreateRun(run); err != nil {
+		return nil, err
+	}
+
+	offlineWorkflowConfig := workflow_executors.OfflineWorkflowConfig(workflowConfig)
+	var results []models.EvalResult
+	for _, story := range suite.Stories {
+		fmt.Printf("Evaluating story %s of suite %s\n", story.Name, suite.Name)
+		result := h.runStory(run, organisation, suite, story, offlineWorkflowConfig, stub)
+		if err := h.evalRunRepo.CreateResult(&result); err != nil {
+			return nil, err
+		}
+		if result.Passed {
+			run.Passed++
+		}
+		results = append(results, result)
+	}
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	if err := h.evalRunRepo.UpdateRun(run); err != nil {
+		return nil, err
+	}
+
+	var previousResults []models.EvalResult
+	previousRun, err := h.previousRun(options.CompareRunID)
+	if err != nil {
+		return nil, err
+	}
+	if previousRun != nil {
+		previousResults, err = h.evalRunRepo.GetResults(previousRun.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	printReport(run, results, previousRun, previousResults)
+	return run, nil
+}
+
+// runStory runs the story in a project of its own, starting from the workspace of the story.
+func (h *Harness) runStory(
+	run *models.EvalRun,
+	organisation *models.Organisation,
+	suite *Suite,
+	story Story,
+	workflowConfig *workflow_executors.WorkflowConfig,
+	stub *StubLLM,
+) models.EvalResult {
+	result := models.EvalResult{RunID: run.ID, StoryName: story.Name}
+	fail := func(err error) models.EvalResult {
+		fmt.Printf("Error evaluating story %s: %s\n", story.Name, err.Error())
+		result.Error = err.Error()
+		return result
+	}
+
+	project, err := h.createProject(organisation, suite, story)
+	if err != nil {
+		return fail(err)
+	}
+	execution, err := h.createExecution(project, story)
+	if err != nil {
+		return fail(err)
+	}
+	result.ExecutionID = execution.ID
+
+	projectDir := filepath.Join(config.WorkspaceWorkingDirectory(), project.HashID)
+	worktreeDir := config.ExecutionWorkspacePath(project.HashID, execution.ID)
+	if err := h.addWorktree(projectDir, worktreeDir, execution.BranchName); err != nil {
+		return fail(err)
+	}
+	defer func() {
+		if err := utils.RemoveWorktree(projectDir, worktreeDir); err != nil {
+			fmt.Printf("Error removing worktree: %s\n", err.Error())
+		}
+	}()
+	if stub != nil {
+		stub.Reset(story.StubResponses, map[string]string{"{workspace}": worktreeDir})
+	}
+
+	startedAt := time.Now()
+	err = h.workflowExecutor.Execute(workflowConfig, &workflow_executors.WorkflowExecutionArgs{
+		ExecutionId:  int64(execution.ID),
+		StoryId:      int64(execution.StoryID),
+		Branch:       execution.BranchName,
+		KeepWorktree: true,
+	})
+	result.DurationMs = time.Since(startedAt).Milliseconds()
+	if err != nil {
+		return fail(err)
+	}
+
+	result.Passed, result.TestOutput, err = runTests(worktreeDir, story, suite.TestTimeoutSeconds)
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	iterations, err := h.executionStepService.CountExecutionStepsOfName(execution.ID, steps.CODE_GENERATE_STEP.String())
+	if err != nil {
+		return fail(err)
+	}
+	result.Iterations = int(iterations)
+	result.PromptTokens, result.CompletionTokens, err = h.executionStepService.GetExecutionTokenUsage(execution.ID)
+	if err != nil {
+		return fail(err)
+	}
+
+	result.ExecutionStatus, err = h.finishExecution(execution.ID, result.Passed)
+	if err != nil {
+		return fail(err)
+	}
+	return result
+}
+
+// evalOrganisation returns the organisation evaluation projects are created in, with the LLM keys of the mode.
+func (h *Harness) evalOrganisation(llmMode string) (*models.Organisation, error) {
+	organisation, err := h.organisationRepo.GetOrganisationByName(constants.EvalOrganisationName)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		tx := h.db.Begin()
+		organisation, err = h.organisationRepo.CreateOrganisation(tx, &models.Organisation{
+			Name:        constants.EvalOrganisationName,
+			Description: "Projects of evaluation runs of benchmark stories",
+		})
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return nil, err
+		}
+	}
+
+	openAIAPIKey, claudeAPIKey := "stub", "stub"
+	if llmMode == constants.EvalLLMReal {
+		openAIAPIKey, claudeAPIKey = config.OpenAIAPIKey(), config.ClaudeAPIKey()
+		if openAIAPIKey == "" {
+			return nil, errors.New("evaluating with the real LLM requires the OpenAI API key to be configured")
+		}
+	}
+	if err := h.llmAPIKeyService.CreateOrUpdateLLMAPIKey(organisation.ID, constants.GPT_4O, openAIAPIKey); err != nil {
+		return nil, err
+	}
+	if err := h.llmAPIKeyService.CreateOrUpdateLLMAPIKey(organisation.ID, constants.CLAUDE_3, claudeAPIKey); err != nil {
+		return nil, err
+	}
+	return organisation, nil
+}
+
+// createProject creates the project of the story with a git repository holding the workspace of the story.
+func (h *Harness) createProject(organisation *models.Organisation, suite *Suite, story Story) (*models.Project, error) {
+	project, err := h.projectRepo.CreateProject(&models.Project{
+		OrganisationID:   organisation.ID,
+		Name:             fmt.Sprintf("%s: %s", suite.Name, story.Name),
+		Description:      story.Description,
+		HashID:           "eval-" + uuid.New().String(),
+		BackendFramework: strings.ToLower(suite.Template),
+		GitProvider:      config.DefaultGitProvider(),
+		DefaultBranch:    constants.DefaultBranch,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	projectDir := filepath.Join(config.WorkspaceWorkingDirectory(), project.HashID)
+	if err := copyDir(story.Workspace, projectDir); err != nil {
+		return nil, err
+	}
+	if _, err := utils.InitialiseGit(projectDir); err != nil {
+		return nil, err
+	}
+	if err := utils.ConfigGitUserEmail(projectDir); err != nil {
+		return nil, err
+	}
+	if err := utils.ConfigureGitUserName(projectDir); err != nil {
+		return nil, err
+	}
+	if _, err := utils.GitAddToTrackFiles(projectDir, nil); err != nil {
+		return nil, err
+	}
+	if _, err := utils.GitCommitWithMessage(projectDir, "Initial commit", nil); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// createExecution creates the story and its execution the way executions of stories are started.
+func (h *Harness) createExecution(project *models.Project, story Story) (*models.Execution, error) {
+	storyID, err := h.storyService.CreateStoryForProject(request.CreateStoryRequest{
+		ProjectId:    int(project.ID),
+		Summary:      story.Summary,
+		Description:  story.Description,
+		TestCases:    story.TestCases,
+		Instructions: story.Instructions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	branchName, err := utils.GenerateBranchName(project.BranchNameTemplate, uint(storyID), story.Summary)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := h.db.Begin()
+	if err := h.storyService.UpdateStoryStatusWithTx(tx, storyID, constants.InProgress); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	execution, err := h.executionService.CreateExecutionWithTx(tx, uint(storyID), "", false, branchName)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return execution, nil
+}
+
+// addWorktree gives the execution its worktree on a new branch, which the git provider steps left out of the
+// offline workflow would otherwise do.
+func (h *Harness) addWorktree(projectDir string, worktreeDir string, branchName string) error {
+	if err := utils.ExcludeFromGit(projectDir, "/"+config.ExecutionWorktreesDir+"/"); err != nil {
+		return err
+	}
+	if err := utils.AddWorktree(projectDir, worktreeDir); err != nil {
+		return err
+	}
+	return utils.ResetBranchTo(worktreeDir, branchName, "HEAD")
+}
+
+// finishExecution sets the status of an execution the workflow left in progress after the outcome of the tests,
+// so that evaluation executions count in the reports of the prompt versions they used.
+func (h *Harness) finishExecution(executionID uint, passed bool) (string, error) {
+	execution, err := h.executionService.GetExecutionByID(executionID)
+	if err != nil {
+		return "", err
+	}
+	if execution.Status != constants.InProgress {
+		return execution.Status, nil
+	}
+	status := constants.ExecutionFailed
+	if passed {
+		status = constants.Done
+	}
+	if err := h.executionService.UpdateExecutionStatus(executionID, status); err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+func (h *Harness) previousRun(runID uint) (*models.EvalRun, error) {
+	if runID == 0 {
+		return nil, nil
+	}
+	run, err := h.evalRunRepo.GetRun(runID)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, fmt.Errorf("evaluation run %d not found", runID)
+	}
+	return run, nil
+}
+
+// runTests copies the expected tests of the story into the workspace and runs them. The story passes when the
+// test command exits with 0.
+func runTests(workspaceDir string, story Story, timeoutSeconds int) (bool, string, error) {
+	if story.TestCommand == "" {
+		return false, "", errors.New("no test command")
+	}
+	if story.TestFiles != "" {
+		if err := copyDir(story.TestFiles, workspaceDir); err != nil {
+			return false, "", err
+		}
+	}
+
+	timeout := time.Duration(timeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTestTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", story.TestCommand)
+	cmd.Dir = workspaceDir
+	cmd.Env = os.Environ()
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	runErr := cmd.Run()
+
+	testOutput := output.String()
+	if len(testOutput) > maxTestOutputLength {
+		testOutput = "...\n" + testOutput[len(testOutput)-maxTestOutputLength:]
+	}
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return false, testOutput, runErr
+	}
+	if ctx.Err() != nil {
+		return false, testOutput, fmt.Errorf("tests timed out after %s", timeout)
+	}
+	return runErr == nil, testOutput, nil
+}
+
+// copyDir copies the files of the source directory into the destination directory, leaving out git metadata.
+func copyDir(sourceDir string, destinationDir string) error {
+	return filepath.WalkDir(sourceDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && entry.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		destination := filepath.Join(destinationDir, relativePath)
+		if entry.IsDir() {
+			return os.MkdirAll(destination, os.ModePerm)
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(destination, content, info.Mode().Perm())
+	})
+}
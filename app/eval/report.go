@@ -0,0 +1,67 @@
+package eval
+
+import (
+	"ai-developer/app/models"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// printReport prints the results of the stories of the run, next to those of the previous run when given.
+func printReport(run *models.EvalRun, results []models.EvalResult, previousRun *models.EvalRun, previousResults []models.EvalResult) {
+	previous := make(map[string]models.EvalResult)
+	for _, result := range previousResults {
+		previous[result.StoryName] = result
+	}
+
+	fmt.Printf("\nEvaluation run %d of suite %s (%s, %s LLM)", run.ID, run.Suite, run.Template, run.LLMMode)
+	if run.Label != "" {
+		fmt.Printf(" [%s]", run.Label)
+	}
+	fmt.Println()
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := "STORY\tPASSED\tITERATIONS\tPROMPT TOKENS\tCOMPLETION TOKENS\tTIME"
+	if previousRun != nil {
+		header += fmt.Sprintf("\tRUN %d", previousRun.ID)
+	}
+	fmt.Fprintln(writer, header)
+
+	var iterations, promptTokens, completionTokens int
+	var duration int64
+	for _, result := range results {
+		iterations += result.Iterations
+		promptTokens += result.PromptTokens
+		completionTokens += result.CompletionTokens
+		duration += result.DurationMs
+		line := fmt.Sprintf("%s\t%t\t%d\t%d\t%d\t%s", result.StoryName, result.Passed, result.Iterations,
+			result.PromptTokens, result.CompletionTokens, formatDuration(result.DurationMs))
+		if previousRun != nil {
+			if previousResult, ok := previous[result.StoryName]; ok {
+				line += fmt.Sprintf("\tpassed %t, %d iterations, %d tokens, %s", previousResult.Passed, previousResult.Iterations,
+					previousResult.PromptTokens+previousResult.CompletionTokens, formatDuration(previousResult.DurationMs))
+			} else {
+				line += "\t-"
+			}
+		}
+		fmt.Fprintln(writer, line)
+	}
+	_ = writer.Flush()
+
+	fmt.Printf("Passed %d of %d stories (%s), %d iterations, %d prompt and %d completion tokens, %s\n",
+		run.Passed, run.Stories, passRate(run), iterations, promptTokens, completionTokens, formatDuration(duration))
+	if previousRun != nil {
+		fmt.Printf("Run %d passed %d of %d stories (%s)\n", previousRun.ID, previousRun.Passed, previousRun.Stories, passRate(previousRun))
+	}
+}
+
+func passRate(run *models.EvalRun) string {
+	if run.Stories == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", float64(run.Passed)*100/float64(run.Stories))
+}
+
+func formatDuration(milliseconds int64) string {
+	return (time.Duration(milliseconds) * time.Millisecond).Round(time.Second).String()
+}
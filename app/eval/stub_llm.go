@@ -0,0 +1,126 @@
+package eval
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+)
+
+// StubLLM answers the OpenAI and Claude requests of the workflow with the replies recorded for the story that
+// runs, so that runs are repeatable and cost nothing. Tokens are estimated at four characters each.
+type StubLLM struct {
+	server    *httptest.Server
+	mutex     sync.Mutex
+	responses []string
+	next      int
+	previous  map[string]*string
+}
+
+// stubbedVariables are the environment variables pointing the LLM clients at their API.
+var stubbedVariables = []string{"OPENAI_API_BASE", "CLAUDE_API_BASE"}
+
+// StartStubLLM starts the stub and points the LLM clients at it. Close points them back at their previous API.
+func StartStubLLM() *StubLLM {
+	stub := &StubLLM{previous: map[string]*string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/chat/completions", stub.chatCompletions)
+	mux.HandleFunc("/messages", stub.messages)
+	stub.server = httptest.NewServer(mux)
+	for _, name := range stubbedVariables {
+		if value, ok := os.LookupEnv(name); ok {
+			stub.previous[name] = &value
+		}
+		_ = os.Setenv(name, stub.server.URL)
+	}
+	return stub
+}
+
+// Reset makes the stub reply with the responses, in order, after replacing the variables in them.
+func (s *StubLLM) Reset(responses []string, variables map[string]string) {
+	var replacements []string
+	for name, value := range variables {
+		replacements = append(replacements, name, value)
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.responses = make([]string, len(responses))
+	for i, response := range responses {
+		s.responses[i] = replacer.Replace(response)
+	}
+	s.next = 0
+}
+
+func (s *StubLLM) Close() {
+	s.server.Close()
+	for _, name := range stubbedVariables {
+		if value, ok := s.previous[name]; ok {
+			_ = os.Setenv(name, *value)
+		} else {
+			_ = os.Unsetenv(name)
+		}
+	}
+}
+
+// reply returns the next response and the estimated prompt and completion tokens.
+func (s *StubLLM) reply(r *http.Request) (string, int, int, bool) {
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return "", 0, 0, false
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.responses) == 0 {
+		return "", 0, 0, false
+	}
+	response := s.responses[s.next]
+	if s.next < len(s.responses)-1 {
+		s.next++
+	}
+	return response, len(body) / 4, len(response) / 4, true
+}
+
+func (s *StubLLM) chatCompletions(w http.ResponseWriter, r *http.Request) {
+	response, promptTokens, completionTokens, ok := s.reply(r)
+	if !ok {
+		http.Error(w, "no stub response recorded", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"choices": []map[string]interface{}{
+			{"message": map[string]string{"role": "assistant", "content": response}},
+		},
+		"usage": map[string]int{
+			"prompt_tokens":     promptTokens,
+			"completion_tokens": completionTokens,
+			"total_tokens":      promptTokens + completionTokens,
+		},
+	})
+}
+
+func (s *StubLLM) messages(w http.ResponseWriter, r *http.Request) {
+	response, promptTokens, completionTokens, ok := s.reply(r)
+	if !ok {
+		http.Error(w, "no stub response recorded", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"type":        "message",
+		"role":        "assistant",
+		"content":     []map[string]string{{"type": "text", "text": response}},
+		"stop_reason": "end_turn",
+		"usage": map[string]int{
+			"input_tokens":  promptTokens,
+			"output_tokens": completionTokens,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
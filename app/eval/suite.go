@@ -0,0 +1,91 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Suite is a benchmark of stories run through the workflow of a template. Paths are relative to the suite file.
+type Suite struct {
+	Name string `json:"name"`
+	// Template is the backend framework of the projects the stories run in, e.g. flask.
+	Template string `json:"template"`
+	// Workspace is the directory holding the code the projects start from.
+	Workspace string `json:"workspace"`
+	// TestCommand runs the expected tests of a story in its workspace, the story passes when it exits with 0.
+	TestCommand        string  `json:"test_command"`
+	TestTimeoutSeconds int     `json:"test_timeout_seconds"`
+	Stories            []Story `json:"stories"`
+}
+
+// Story is a benchmark story with the tests its implementation is expected to pass.
+type Story struct {
+	Name         string   `json:"name"`
+	Summary      string   `json:"summary"`
+	Description  string   `json:"description"`
+	TestCases    []string `json:"test_cases"`
+	Instructions string   `json:"instructions"`
+	// Workspace overrides the workspace of the suite.
+	Workspace string `json:"workspace"`
+	// TestFiles is a directory copied into the workspace once the workflow is done, before running the tests.
+	TestFiles string `json:"test_files"`
+	// TestCommand overrides the test command of the suite.
+	TestCommand string `json:"test_command"`
+	// StubResponses are the replies of the stubbed LLM to the requests of the workflow, in order. The last reply
+	// is repeated once they run out. {workspace} is replaced with the directory the story runs in.
+	StubResponses []string `json:"stub_responses"`
+}
+
+// LoadSuite reads the suite file and resolves the paths it holds.
+func LoadSuite(path string) (*Suite, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var suite Suite
+	if err := json.Unmarshal(content, &suite); err != nil {
+		return nil, fmt.Errorf("invalid suite %s: %w", path, err)
+	}
+	if suite.Template == "" {
+		return nil, fmt.Errorf("suite %s has no template", path)
+	}
+	if len(suite.Stories) == 0 {
+		return nil, fmt.Errorf("suite %s has no stories", path)
+	}
+	if suite.Name == "" {
+		suite.Name = filepath.Base(path)
+	}
+
+	dir := filepath.Dir(path)
+	suite.Workspace = resolvePath(dir, suite.Workspace)
+	for i := range suite.Stories {
+		story := &suite.Stories[i]
+		if story.Name == "" {
+			return nil, fmt.Errorf("story %d of suite %s has no name", i+1, path)
+		}
+		if story.Summary == "" {
+			story.Summary = story.Name
+		}
+		story.Workspace = resolvePath(dir, story.Workspace)
+		if story.Workspace == "" {
+			story.Workspace = suite.Workspace
+		}
+		if story.Workspace == "" {
+			return nil, fmt.Errorf("story %s of suite %s has no workspace", story.Name, path)
+		}
+		story.TestFiles = resolvePath(dir, story.TestFiles)
+		if story.TestCommand == "" {
+			story.TestCommand = suite.TestCommand
+		}
+	}
+	return &suite, nil
+}
+
+func resolvePath(dir string, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
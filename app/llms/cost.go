@@ -0,0 +1,17 @@
+package llms
+
+// modelPrices are the USD prices per million prompt and completion tokens.
+var modelPrices = map[string][2]float64{
+	"gpt-4o":                     {2.50, 10.00},
+	"gpt-4o-mini":                {0.15, 0.60},
+	"claude-3-5-sonnet-20240620": {3.00, 15.00},
+}
+
+// EstimateCost returns the USD cost of the tokens for the model, zero for models without a known price.
+func EstimateCost(model string, promptTokens, completionTokens int) float64 {
+	price, ok := modelPrices[model]
+	if !ok {
+		return 0
+	}
+	return (float64(promptTokens)*price[0] + float64(completionTokens)*price[1]) / 1000000
+}
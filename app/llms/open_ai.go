@@ -41,8 +41,17 @@ type OpenAiChatCompletionChoice struct {
 	Message OpenAiChatCompletionMessage `json:"message"`
 }
 
+type OpenAiUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+	// Model is the model the tokens were sent to, so that stored usage can be priced per model.
+	Model string `json:"model,omitempty"`
+}
+
 type OpenAiChatCompletionResponse struct {
 	Choices []OpenAiChatCompletionChoice `json:"choices"`
+	Usage   OpenAiUsage                  `json:"usage"`
 }
 
 func NewOpenAiClient(apiKey string) *OpenAiClient {
@@ -70,6 +79,12 @@ func (c *OpenAiClient) WithApiKey(apiKey string) {
 }
 
 func (c *OpenAiClient) ChatCompletion(messages []OpenAiChatCompletionMessage) (string, error) {
+	content, _, err := c.ChatCompletionWithUsage(messages)
+	return content, err
+}
+
+// ChatCompletionWithUsage is ChatCompletion that also returns the tokens the request used.
+func (c *OpenAiClient) ChatCompletionWithUsage(messages []OpenAiChatCompletionMessage) (string, *OpenAiUsage, error) {
 	url := fmt.Sprintf("%s/chat/completions", c.ApiBaseUrl)
 
 	requestBody := OpenAiOpenAiChatCompletionRequest{
@@ -91,22 +106,23 @@ func (c *OpenAiClient) ChatCompletion(messages []OpenAiChatCompletionMessage) (s
 	response, err := c.HttpClient.Post(url, requestBody, headers)
 	fmt.Println("Response: ", response)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to get response from OpenAI API, status code: %d", response.StatusCode)
+		return "", nil, fmt.Errorf("failed to get response from OpenAI API, status code: %d", response.StatusCode)
 	}
 
 	var chatResponse OpenAiChatCompletionResponse
 	if err := json.NewDecoder(response.Body).Decode(&chatResponse); err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	if len(chatResponse.Choices) == 0 {
-		return "", fmt.Errorf("no response choices found")
+		return "", nil, fmt.Errorf("no response choices found")
 	}
 
-	return chatResponse.Choices[0].Message.Content, nil
+	chatResponse.Usage.Model = c.Model
+	return chatResponse.Choices[0].Message.Content, &chatResponse.Usage, nil
 }
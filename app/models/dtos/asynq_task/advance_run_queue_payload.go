@@ -0,0 +1,6 @@
+package asynq_task
+
+// AdvanceRunQueuePayload names the project whose run queue to advance, all projects with queued stories when zero.
+type AdvanceRunQueuePayload struct {
+	ProjectID uint `json:"project_id"`
+}
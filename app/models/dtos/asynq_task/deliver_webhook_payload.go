@@ -0,0 +1,5 @@
+package asynq_task
+
+type DeliverWebhookPayload struct {
+	DeliveryID uint `json:"delivery_id"`
+}
@@ -0,0 +1,5 @@
+package asynq_task
+
+type ReviewPullRequestPayload struct {
+	ReviewID uint `json:"review_id"`
+}
@@ -0,0 +1,99 @@
+package git_provider
+
+import "time"
+
+// Repository is a project repository on a git hosting provider.
+type Repository struct {
+	ID            string
+	Name          string
+	Path          string
+	DefaultBranch string
+	CloneURL      string
+}
+
+type Branch struct {
+	Name string
+	SHA  string
+}
+
+type PullRequest struct {
+	Number         int
+	Title          string
+	Description    string
+	State          string
+	IsDraft        bool
+	SourceBranch   string
+	TargetBranch   string
+	SourceSHA      string
+	MergeBaseSHA   string
+	MergeTargetSHA string
+	URL            string
+	Merged         bool
+	MergedAt       *time.Time
+	ClosedAt       *time.Time
+}
+
+type MergeResult struct {
+	SHA string
+}
+
+type Commit struct {
+	SHA           string
+	Title         string
+	Message       string
+	AuthorName    string
+	CommitterName string
+	CommittedAt   time.Time
+}
+
+// BranchCommits holds the latest commits of a branch along with the total number of commits on it.
+type BranchCommits struct {
+	Commits      []Commit
+	TotalCommits int
+}
+
+// Comment is a pull request comment. Path and Line are only set for comments anchored to a line of the diff.
+type Comment struct {
+	ID        string
+	Author    string
+	Body      string
+	Path      string
+	Line      int
+	CreatedAt time.Time
+}
+
+// CommentEvent is a pull request comment received through a webhook of the git hosting provider.
+type CommentEvent struct {
+	// RepositoryPath identifies the repository, see GitProvider.RepositoryPath.
+	RepositoryPath    string
+	PullRequestNumber int
+	Comment           Comment
+}
+
+// Pipeline is a CI pipeline the git hosting provider ran for a ref.
+type Pipeline struct {
+	ID     int
+	Ref    string
+	SHA    string
+	Status string
+	URL    string
+}
+
+// Actions of pull request events.
+const (
+	PullRequestOpened   = "opened"
+	PullRequestUpdated  = "updated"
+	PullRequestReopened = "reopened"
+	PullRequestClosed   = "closed"
+	PullRequestMerged   = "merged"
+)
+
+// PullRequestEvent is a change of a pull request received through a webhook of the git hosting provider.
+type PullRequestEvent struct {
+	// RepositoryPath identifies the repository, see GitProvider.RepositoryPath.
+	RepositoryPath    string
+	PullRequestNumber int
+	Action            string
+	SourceSHA         string
+	Author            string
+}
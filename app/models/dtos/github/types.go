@@ -0,0 +1,206 @@
+package github
+
+import "time"
+
+type CreateRepositoryPayload struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Private     bool   `json:"private"`
+	AutoInit    bool   `json:"auto_init"`
+}
+
+type Account struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Type  string `json:"type"` // User, Organization, Bot
+}
+
+type Repository struct {
+	ID            int64   `json:"id"`
+	Name          string  `json:"name"`
+	FullName      string  `json:"full_name"`
+	Description   string  `json:"description"`
+	Private       bool    `json:"private"`
+	DefaultBranch string  `json:"default_branch"`
+	CloneURL      string  `json:"clone_url"`
+	HTMLURL       string  `json:"html_url"`
+	Owner         Account `json:"owner"`
+}
+
+type Branch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+type CreateRefPayload struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+type Ref struct {
+	Ref    string `json:"ref"`
+	Object struct {
+		SHA  string `json:"sha"`
+		Type string `json:"type"`
+	} `json:"object"`
+}
+
+type CreatePullRequestPayload struct {
+	Title string `json:"title"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Body  string `json:"body"`
+	Draft bool   `json:"draft"`
+}
+
+type PullRequestBranch struct {
+	Ref   string `json:"ref"`
+	SHA   string `json:"sha"`
+	Label string `json:"label"`
+}
+
+type PullRequest struct {
+	ID             int64             `json:"id"`
+	NodeID         string            `json:"node_id"`
+	Number         int               `json:"number"`
+	State          string            `json:"state"` // open, closed
+	Title          string            `json:"title"`
+	Body           string            `json:"body"`
+	Draft          bool              `json:"draft"`
+	Merged         bool              `json:"merged"`
+	MergeCommitSHA string            `json:"merge_commit_sha"`
+	HTMLURL        string            `json:"html_url"`
+	Head           PullRequestBranch `json:"head"`
+	Base           PullRequestBranch `json:"base"`
+	User           Account           `json:"user"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	ClosedAt       *time.Time        `json:"closed_at"`
+	MergedAt       *time.Time        `json:"merged_at"`
+}
+
+type UpdatePullRequestPayload struct {
+	State string `json:"state"` // open, closed
+}
+
+type MergePullRequestPayload struct {
+	MergeMethod string `json:"merge_method"` // merge, squash, rebase
+	SHA         string `json:"sha,omitempty"`
+}
+
+type MergePullRequestResponse struct {
+	SHA     string `json:"sha"`
+	Merged  bool   `json:"merged"`
+	Message string `json:"message"`
+}
+
+type CommitIdentity struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
+type Commit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message   string         `json:"message"`
+		Author    CommitIdentity `json:"author"`
+		Committer CommitIdentity `json:"committer"`
+	} `json:"commit"`
+}
+
+type CreateCommentPayload struct {
+	Body string `json:"body"`
+}
+
+// CreateReviewCommentPayload comments on a line of the new version of a file in the pull request diff.
+type CreateReviewCommentPayload struct {
+	Body     string `json:"body"`
+	CommitID string `json:"commit_id"`
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Side     string `json:"side"`
+}
+
+// Comment is either an issue comment on the pull request conversation or a review comment on a line of the diff.
+type Comment struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	User      Account   `json:"user"`
+	Path      string    `json:"path"`
+	Line      *int      `json:"line"`
+	CommitID  string    `json:"commit_id"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type InstallationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type GraphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type GraphQLError struct {
+	Message string `json:"message"`
+}
+
+// ReviewThreadsResponse is the response of the review threads query of a pull request.
+type ReviewThreadsResponse struct {
+	Data struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					Nodes []ReviewThread `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []GraphQLError `json:"errors"`
+}
+
+type ReviewThread struct {
+	ID         string `json:"id"`
+	IsResolved bool   `json:"isResolved"`
+	Comments   struct {
+		Nodes []struct {
+			DatabaseID int64 `json:"databaseId"`
+		} `json:"nodes"`
+	} `json:"comments"`
+}
+
+type GraphQLResponse struct {
+	Errors []GraphQLError `json:"errors"`
+}
+
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+type Label struct {
+	Name string `json:"name"`
+}
+
+// Issue is an issue of a repository, PullRequest is set when the issue is a pull request.
+type Issue struct {
+	ID          int64      `json:"id"`
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	State       string     `json:"state"` // open, closed
+	HTMLURL     string     `json:"html_url"`
+	Labels      []Label    `json:"labels"`
+	PullRequest *struct{}  `json:"pull_request"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+}
+
+type UpdateIssuePayload struct {
+	State string `json:"state"` // open, closed
+}
@@ -0,0 +1,31 @@
+package github
+
+// IssueReference is the issue of issue_comment events, PullRequest is only set for pull request conversations.
+type IssueReference struct {
+	Number      int       `json:"number"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+// CommentWebhookPayload is the payload of the pull_request_review_comment and issue_comment webhook events.
+type CommentWebhookPayload struct {
+	Action      string          `json:"action"`
+	Comment     Comment         `json:"comment"`
+	PullRequest *PullRequest    `json:"pull_request"`
+	Issue       *IssueReference `json:"issue"`
+	Repository  Repository      `json:"repository"`
+}
+
+// PullRequestWebhookPayload is the payload of the pull_request webhook event.
+type PullRequestWebhookPayload struct {
+	Action      string      `json:"action"`
+	Number      int         `json:"number"`
+	PullRequest PullRequest `json:"pull_request"`
+	Repository  Repository  `json:"repository"`
+}
+
+// IssueWebhookPayload is the payload of the issues webhook event.
+type IssueWebhookPayload struct {
+	Action     string     `json:"action"`
+	Issue      Issue      `json:"issue"`
+	Repository Repository `json:"repository"`
+}
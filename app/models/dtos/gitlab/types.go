@@ -0,0 +1,195 @@
+package gitlab
+
+import "time"
+
+type Namespace struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	FullPath string `json:"full_path"`
+}
+
+type CreateGroupPayload struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	ParentID   int    `json:"parent_id,omitempty"`
+	Visibility string `json:"visibility"`
+}
+
+type Group struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	FullPath string `json:"full_path"`
+}
+
+type CreateProjectPayload struct {
+	Name                 string `json:"name"`
+	Path                 string `json:"path"`
+	NamespaceID          int    `json:"namespace_id"`
+	Description          string `json:"description"`
+	Visibility           string `json:"visibility"`
+	DefaultBranch        string `json:"default_branch"`
+	InitializeWithReadme bool   `json:"initialize_with_readme"`
+}
+
+type Project struct {
+	ID                int       `json:"id"`
+	Name              string    `json:"name"`
+	Path              string    `json:"path"`
+	PathWithNamespace string    `json:"path_with_namespace"`
+	DefaultBranch     string    `json:"default_branch"`
+	HTTPURLToRepo     string    `json:"http_url_to_repo"`
+	WebURL            string    `json:"web_url"`
+	Namespace         Namespace `json:"namespace"`
+}
+
+type Branch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+}
+
+type CreateMergeRequestPayload struct {
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+}
+
+type DiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+type MergeRequest struct {
+	ID              int        `json:"id"`
+	IID             int        `json:"iid"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	State           string     `json:"state"` // opened, closed, locked, merged
+	Draft           bool       `json:"draft"`
+	SourceBranch    string     `json:"source_branch"`
+	TargetBranch    string     `json:"target_branch"`
+	SHA             string     `json:"sha"`
+	MergeCommitSHA  string     `json:"merge_commit_sha"`
+	SquashCommitSHA string     `json:"squash_commit_sha"`
+	DiffRefs        DiffRefs   `json:"diff_refs"`
+	WebURL          string     `json:"web_url"`
+	MergedAt        *time.Time `json:"merged_at"`
+	ClosedAt        *time.Time `json:"closed_at"`
+	// RebaseInProgress and MergeError are only set when fetched with include_rebase_in_progress.
+	RebaseInProgress bool   `json:"rebase_in_progress"`
+	MergeError       string `json:"merge_error"`
+}
+
+type UpdateMergeRequestPayload struct {
+	StateEvent string `json:"state_event,omitempty"` // close, reopen
+	Title      string `json:"title,omitempty"`
+}
+
+type AcceptMergeRequestPayload struct {
+	Squash bool   `json:"squash"`
+	SHA    string `json:"sha,omitempty"`
+}
+
+type Commit struct {
+	ID            string    `json:"id"`
+	Title         string    `json:"title"`
+	Message       string    `json:"message"`
+	AuthorName    string    `json:"author_name"`
+	CommitterName string    `json:"committer_name"`
+	CommittedDate time.Time `json:"committed_date"`
+}
+
+type Diff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	AMode       string `json:"a_mode"`
+	BMode       string `json:"b_mode"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+	Diff        string `json:"diff"`
+}
+
+type Compare struct {
+	Commits []Commit `json:"commits"`
+	Diffs   []Diff   `json:"diffs"`
+}
+
+type User struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+type NotePosition struct {
+	BaseSHA  string `json:"base_sha"`
+	StartSHA string `json:"start_sha"`
+	HeadSHA  string `json:"head_sha"`
+	OldPath  string `json:"old_path"`
+	NewPath  string `json:"new_path"`
+	OldLine  *int   `json:"old_line"`
+	NewLine  *int   `json:"new_line"`
+}
+
+type Note struct {
+	ID         int           `json:"id"`
+	Body       string        `json:"body"`
+	Author     User          `json:"author"`
+	System     bool          `json:"system"`
+	Resolvable bool          `json:"resolvable"`
+	Resolved   bool          `json:"resolved"`
+	Position   *NotePosition `json:"position"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type Discussion struct {
+	ID             string `json:"id"`
+	IndividualNote bool   `json:"individual_note"`
+	Notes          []Note `json:"notes"`
+}
+
+type CreateNotePayload struct {
+	Body string `json:"body"`
+}
+
+type CreateDiscussionPayload struct {
+	Body     string              `json:"body"`
+	Position *DiscussionPosition `json:"position,omitempty"`
+}
+
+// DiscussionPosition anchors a discussion to a line of the new version of a file in the merge request diff.
+type DiscussionPosition struct {
+	PositionType string `json:"position_type"`
+	BaseSHA      string `json:"base_sha"`
+	StartSHA     string `json:"start_sha"`
+	HeadSHA      string `json:"head_sha"`
+	OldPath      string `json:"old_path"`
+	NewPath      string `json:"new_path"`
+	NewLine      int    `json:"new_line"`
+}
+
+type ResolveDiscussionPayload struct {
+	Resolved bool `json:"resolved"`
+}
+
+type Pipeline struct {
+	ID     int    `json:"id"`
+	Ref    string `json:"ref"`
+	SHA    string `json:"sha"`
+	Status string `json:"status"`
+	WebURL string `json:"web_url"`
+}
+
+type CreatePipelinePayload struct {
+	Ref string `json:"ref"`
+}
+
+type ErrorResponse struct {
+	Message interface{} `json:"message"`
+	Error   string      `json:"error"`
+}
@@ -0,0 +1,38 @@
+package gitlab
+
+// NoteWebhookPayload is the payload of the Note Hook webhook event.
+type NoteWebhookPayload struct {
+	ObjectKind       string `json:"object_kind"`
+	User             User   `json:"user"`
+	ObjectAttributes struct {
+		ID           int           `json:"id"`
+		Note         string        `json:"note"`
+		NoteableType string        `json:"noteable_type"`
+		System       bool          `json:"system"`
+		Position     *NotePosition `json:"position"`
+		CreatedAt    string        `json:"created_at"`
+	} `json:"object_attributes"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	MergeRequest *struct {
+		IID int `json:"iid"`
+	} `json:"merge_request"`
+}
+
+// MergeRequestWebhookPayload is the payload of the Merge Request Hook webhook event.
+type MergeRequestWebhookPayload struct {
+	ObjectKind       string `json:"object_kind"`
+	User             User   `json:"user"`
+	ObjectAttributes struct {
+		IID        int    `json:"iid"`
+		Action     string `json:"action"` // open, close, reopen, update, merge, approved
+		OldRev     string `json:"oldrev"`
+		LastCommit struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+}
@@ -157,6 +157,11 @@ type CreatePullRequestResponse struct {
 	Stats  struct{}    `json:"stats"`
 }
 
+type UpdatePullRequestStatePayload struct {
+	State   string `json:"state"` // open, closed
+	IsDraft bool   `json:"is_draft"`
+}
+
 type MergePullRequestPayload struct {
 	Method      string `json:"method"`
 	SourceSHA   string `json:"source_sha"`
@@ -182,6 +187,7 @@ type FetchPullRequestResponse struct {
 	TargetRepoID     int    `json:"target_repo_id"`
 	TargetBranch     string `json:"target_branch"`
 	Merged           int64  `json:"merged"`
+	Closed           int64  `json:"closed"`
 	MergeMethod      string `json:"merge_method"`
 	MergeCheckStatus string `json:"merge_check_status"`
 	MergeTargetSHA   string `json:"merge_target_sha"`
@@ -242,3 +248,94 @@ type GetMainBranchCommitResponse struct {
 	} `json:"rename_details"`
 	TotalCommits int `json:"total_commits"`
 }
+
+type CreatePullRequestCommentPayload struct {
+	Text     string `json:"text"`
+	ParentID int64  `json:"parent_id,omitempty"`
+
+	// Code comments are anchored to lines of a file in the diff between the two commits.
+	Path            string `json:"path,omitempty"`
+	SourceCommitSHA string `json:"source_commit_sha,omitempty"`
+	TargetCommitSHA string `json:"target_commit_sha,omitempty"`
+	LineStart       int    `json:"line_start,omitempty"`
+	LineEnd         int    `json:"line_end,omitempty"`
+	LineStartNew    bool   `json:"line_start_new,omitempty"`
+	LineEndNew      bool   `json:"line_end_new,omitempty"`
+}
+
+type UpdatePullRequestCommentStatusPayload struct {
+	Status string `json:"status"`
+}
+
+type PullRequestActivity struct {
+	ID       int64  `json:"id"`
+	Created  int64  `json:"created"`
+	Updated  int64  `json:"updated"`
+	Deleted  *int64 `json:"deleted"`
+	ParentID *int64 `json:"parent_id"`
+	Type     string `json:"type"`
+	Kind     string `json:"kind"`
+	Text     string `json:"text"`
+	Resolved *int64 `json:"resolved"`
+	Author   struct {
+		ID          int    `json:"id"`
+		UID         string `json:"uid"`
+		DisplayName string `json:"display_name"`
+		Email       string `json:"email"`
+	} `json:"author"`
+	CodeComment *struct {
+		Outdated     bool   `json:"outdated"`
+		MergeBaseSHA string `json:"merge_base_sha"`
+		SourceSHA    string `json:"source_sha"`
+		Path         string `json:"path"`
+		LineNew      int    `json:"line_new"`
+		SpanNew      int    `json:"span_new"`
+		LineOld      int    `json:"line_old"`
+		SpanOld      int    `json:"span_old"`
+	} `json:"code_comment"`
+}
+
+// PullRequestCommentWebhookPayload is the payload of the pullreq_comment_created webhook trigger.
+type PullRequestCommentWebhookPayload struct {
+	Trigger string `json:"trigger"`
+	Repo    struct {
+		ID         int64  `json:"id"`
+		Path       string `json:"path"`
+		Identifier string `json:"identifier"`
+	} `json:"repo"`
+	Principal struct {
+		ID          int64  `json:"id"`
+		UID         string `json:"uid"`
+		DisplayName string `json:"display_name"`
+	} `json:"principal"`
+	PullReq struct {
+		Number int `json:"number"`
+	} `json:"pull_req"`
+	Comment struct {
+		ID   int64  `json:"id"`
+		Text string `json:"text"`
+	} `json:"comment"`
+	CodeComment *struct {
+		Path    string `json:"path"`
+		LineNew int    `json:"line_new"`
+	} `json:"code_comment"`
+}
+
+// PullRequestWebhookPayload is the payload of the pullreq_* webhook triggers other than comments.
+type PullRequestWebhookPayload struct {
+	Trigger string `json:"trigger"`
+	Repo    struct {
+		ID         int64  `json:"id"`
+		Path       string `json:"path"`
+		Identifier string `json:"identifier"`
+	} `json:"repo"`
+	Principal struct {
+		ID          int64  `json:"id"`
+		UID         string `json:"uid"`
+		DisplayName string `json:"display_name"`
+	} `json:"principal"`
+	PullReq struct {
+		Number    int    `json:"number"`
+		SourceSHA string `json:"source_sha"`
+	} `json:"pull_req"`
+}
@@ -0,0 +1,14 @@
+package issue_tracker
+
+// Issue is an issue of a tracker, in the shape stories are created from.
+type Issue struct {
+	// ExternalID identifies the issue in API calls to the tracker.
+	ExternalID string
+	// Key is the identifier people use for the issue, e.g. PROJ-12, ENG-12 or #12.
+	Key         string
+	Title       string
+	Description string
+	URL         string
+	Labels      []string
+	Closed      bool
+}
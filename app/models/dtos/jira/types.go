@@ -0,0 +1,65 @@
+package jira
+
+type StatusCategory struct {
+	Key string `json:"key"` // new, indeterminate, done
+}
+
+type Status struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	StatusCategory StatusCategory `json:"statusCategory"`
+}
+
+type IssueFields struct {
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+	Status      Status   `json:"status"`
+}
+
+type Issue struct {
+	ID     string      `json:"id"`
+	Key    string      `json:"key"`
+	Self   string      `json:"self"`
+	Fields IssueFields `json:"fields"`
+}
+
+type SearchResponse struct {
+	StartAt    int     `json:"startAt"`
+	MaxResults int     `json:"maxResults"`
+	Total      int     `json:"total"`
+	Issues     []Issue `json:"issues"`
+}
+
+type Transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	To   Status `json:"to"`
+}
+
+type TransitionsResponse struct {
+	Transitions []Transition `json:"transitions"`
+}
+
+type TransitionReference struct {
+	ID string `json:"id"`
+}
+
+type TransitionPayload struct {
+	Transition TransitionReference `json:"transition"`
+}
+
+type CommentPayload struct {
+	Body string `json:"body"`
+}
+
+// WebhookPayload is the payload of the jira:issue_created and jira:issue_updated webhook events.
+type WebhookPayload struct {
+	WebhookEvent string `json:"webhookEvent"`
+	Issue        Issue  `json:"issue"`
+}
+
+type ErrorResponse struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
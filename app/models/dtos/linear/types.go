@@ -0,0 +1,97 @@
+package linear
+
+type GraphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type GraphQLError struct {
+	Message string `json:"message"`
+}
+
+type GraphQLResponse struct {
+	Errors []GraphQLError `json:"errors"`
+}
+
+type State struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"` // triage, backlog, unstarted, started, completed, canceled
+}
+
+type Label struct {
+	Name string `json:"name"`
+}
+
+type Team struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+type Issue struct {
+	ID          string `json:"id"`
+	Identifier  string `json:"identifier"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	State       State  `json:"state"`
+	Team        Team   `json:"team"`
+	Labels      struct {
+		Nodes []Label `json:"nodes"`
+	} `json:"labels"`
+}
+
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type IssuesResponse struct {
+	Data struct {
+		Issues struct {
+			Nodes    []Issue  `json:"nodes"`
+			PageInfo PageInfo `json:"pageInfo"`
+		} `json:"issues"`
+	} `json:"data"`
+	Errors []GraphQLError `json:"errors"`
+}
+
+type TeamStatesResponse struct {
+	Data struct {
+		Issue struct {
+			Team struct {
+				States struct {
+					Nodes []State `json:"nodes"`
+				} `json:"states"`
+			} `json:"team"`
+		} `json:"issue"`
+	} `json:"data"`
+	Errors []GraphQLError `json:"errors"`
+}
+
+type MutationResponse struct {
+	Data map[string]struct {
+		Success bool `json:"success"`
+	} `json:"data"`
+	Errors []GraphQLError `json:"errors"`
+}
+
+// WebhookIssue is the issue of webhook payloads, which lists labels without the connection wrapper.
+type WebhookIssue struct {
+	ID          string  `json:"id"`
+	Identifier  string  `json:"identifier"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	URL         string  `json:"url"`
+	State       State   `json:"state"`
+	Team        Team    `json:"team"`
+	Labels      []Label `json:"labels"`
+}
+
+// WebhookPayload is the payload of Issue webhook events.
+type WebhookPayload struct {
+	Action string       `json:"action"` // create, update, remove
+	Type   string       `json:"type"`
+	URL    string       `json:"url"`
+	Data   WebhookIssue `json:"data"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// EvalRun is a run of a suite of benchmark stories through the workflow, kept to compare runs, e.g. before and
+// after a prompt or model change.
+type EvalRun struct {
+	ID         uint      `gorm:"primaryKey"`
+	Suite      string    `gorm:"type:varchar(255);not null"`
+	Label      string    `gorm:"type:varchar(255)"`
+	Template   string    `gorm:"type:varchar(50);not null"`
+	LLMMode    string    `gorm:"column:llm_mode;type:varchar(20);not null"`
+	Stories    int       `gorm:"not null;default:0"`
+	Passed     int       `gorm:"not null;default:0"`
+	StartedAt  time.Time `gorm:"not null"`
+	FinishedAt *time.Time
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+// EvalResult is the outcome of a benchmark story in an evaluation run.
+type EvalResult struct {
+	ID               uint   `gorm:"primaryKey"`
+	RunID            uint   `gorm:"not null"`
+	StoryName        string `gorm:"type:varchar(255);not null"`
+	ExecutionID      uint
+	Passed           bool      `gorm:"not null;default:false"`
+	ExecutionStatus  string    `gorm:"type:varchar(100)"`
+	Iterations       int       `gorm:"not null;default:0"`
+	PromptTokens     int       `gorm:"not null;default:0"`
+	CompletionTokens int       `gorm:"not null;default:0"`
+	DurationMs       int64     `gorm:"not null;default:0"`
+	TestOutput       string    `gorm:"type:text"`
+	Error            string    `gorm:"type:text"`
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+}
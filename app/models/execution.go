@@ -6,13 +6,14 @@ import (
 
 type Execution struct {
 	ID          uint      `gorm:"primaryKey"`
-	StoryID     uint      `gorm:"not null"`
+	StoryID     uint      `gorm:"default:null"`
 	Plan        string    `gorm:"type:text"`
 	Status      string    `gorm:"type:varchar(100);not null"`
 	BranchName  string    `gorm:"type:varchar(100);not null"`
 	GitCommitID string    `gorm:"type:varchar(100)"`
 	Instruction string    `gorm:"type:text;not null"`
 	ReExecution bool      `gorm:"default:false"`
+	Type        string    `gorm:"column:execution_type;type:varchar(50);not null;default:DEVELOPMENT"`
 	CreatedAt   time.Time `gorm:"autoCreateTime"`
 	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// InstructionSnippet is appended to the instructions of every story of the organisation while it is active.
+type InstructionSnippet struct {
+	ID             uint      `gorm:"primaryKey"`
+	OrganisationID uint      `gorm:"not null"`
+	Name           string    `gorm:"type:varchar(100);not null"`
+	Content        string    `gorm:"type:text;not null"`
+	IsActive       bool      `gorm:"not null;default:true"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// IssueTracker imports the labelled issues of a Jira project, Linear team or GitHub repository (Scope) as
+// stories of the project, and moves the issues along as the stories progress. The state fields name the
+// tracker state for each story state, the provider default is used when empty.
+type IssueTracker struct {
+	ID              uint       `gorm:"primaryKey"`
+	ProjectID       uint       `gorm:"not null"`
+	Provider        string     `gorm:"type:varchar(50);not null"`
+	BaseURL         string     `gorm:"type:varchar(500)"`
+	Username        string     `gorm:"type:varchar(255)"`
+	Token           string     `gorm:"type:varchar(500);not null"`
+	Scope           string     `gorm:"type:varchar(255);not null"`
+	Label           string     `gorm:"type:varchar(100);not null"`
+	WebhookSecret   string     `gorm:"type:varchar(255);not null"`
+	PollingEnabled  bool       `gorm:"not null;default:true"`
+	TodoState       string     `gorm:"type:varchar(100)"`
+	InProgressState string     `gorm:"type:varchar(100)"`
+	DoneState       string     `gorm:"type:varchar(100)"`
+	LastPolledAt    *time.Time `gorm:"type:timestamp"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime"`
+}
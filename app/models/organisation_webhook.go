@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// OrganisationWebhook receives the events of the organisation it subscribes to, all events when Events is empty.
+type OrganisationWebhook struct {
+	ID             uint      `gorm:"primaryKey"`
+	OrganisationID uint      `gorm:"not null"`
+	URL            string    `gorm:"type:varchar(500);not null"`
+	Secret         string    `gorm:"type:varchar(255);not null"`
+	Events         string    `gorm:"type:text"`
+	IsActive       bool      `gorm:"not null;default:true"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
@@ -5,16 +5,45 @@ import (
 )
 
 type Project struct {
-	ID                uint      `gorm:"primaryKey"`
-	HashID            string    `gorm:"type:varchar(100);not null;unique"`
-	Url               string    `gorm:"type:varchar(100)"`
-	FrontendURL       string    `gorm:"type:varchar(100);"`
-	BackendURL        string    `gorm:"type:varchar(100);"`
-	Name              string    `gorm:"type:varchar(100);"`
-	BackendFramework  string    `gorm:"type:varchar(100);not null"`
-	FrontendFramework string    `gorm:"type:varchar(100);not null"`
-	Description       string    `gorm:"type:text"`
-	OrganisationID    uint      `gorm:"not null"`
-	CreatedAt         time.Time `gorm:"autoCreateTime"`
-	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
+	ID                  uint   `gorm:"primaryKey"`
+	HashID              string `gorm:"type:varchar(100);not null;unique"`
+	Url                 string `gorm:"type:varchar(100)"`
+	FrontendURL         string `gorm:"type:varchar(100);"`
+	BackendURL          string `gorm:"type:varchar(100);"`
+	Name                string `gorm:"type:varchar(100);"`
+	BackendFramework    string `gorm:"type:varchar(100);not null"`
+	FrontendFramework   string `gorm:"type:varchar(100);not null"`
+	Description         string `gorm:"type:text"`
+	OrganisationID      uint   `gorm:"not null"`
+	GitProvider         string `gorm:"type:varchar(50);not null;default:'GITNESS'"`
+	RepositoryOwner     string `gorm:"type:varchar(100)"`
+	DefaultBranch       string `gorm:"type:varchar(100);not null;default:'main'"`
+	ImportURL           string `gorm:"type:varchar(500)"`
+	BaseBranch          string `gorm:"type:varchar(100)"`
+	TargetBranch        string `gorm:"type:varchar(100)"`
+	BranchNameTemplate  string `gorm:"type:varchar(255)"`
+	PullRequestTemplate string `gorm:"type:text"`
+	// Repository the project pushes to when it is not the repository the provider names after the project, e.g.
+	// an imported repository that already was on the provider.
+	RemoteURL string `gorm:"type:varchar(500)"`
+	// Full path of the repository as the git provider reported it when creating it, providers may derive a
+	// path from the project name that differs from it.
+	RepositoryPath string `gorm:"type:varchar(500)"`
+	// Stories of the project which may execute at the same time, each in a git worktree of its own.
+	MaxConcurrentExecutions int `gorm:"not null;default:1"`
+	// Coding conventions fed to every prompt, the .supercoder/conventions.md of the repository when empty.
+	Conventions string `gorm:"type:text"`
+	// System prompts replacing the built-in prompts of the code generators when set.
+	BackendSystemPrompt  string `gorm:"type:text"`
+	FrontendSystemPrompt string `gorm:"type:text"`
+	// Pull requests of stories are merged without review when auto-merge is enabled and the policy holds.
+	AutoMergeEnabled           bool      `gorm:"not null;default:false"`
+	AutoMergeMethod            string    `gorm:"type:varchar(20)"`
+	AutoMergeRequireServerTest bool      `gorm:"not null;default:true"`
+	AutoMergeRequireTests      bool      `gorm:"not null;default:true"`
+	AutoMergeRequireLint       bool      `gorm:"not null;default:true"`
+	AutoMergeMaxDiffLines      int       `gorm:"not null;default:0"`
+	AutoMergeExcludedPaths     string    `gorm:"type:text"`
+	CreatedAt                  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt                  time.Time `gorm:"autoUpdateTime"`
 }
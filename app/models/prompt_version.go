@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// PromptVersion is a version of a prompt of the organisation. Executions use the active version, the default
+// prompt file when no version is active. Versions with an A/B weight take precedence: executions are assigned to
+// them at random in proportion to their weights.
+type PromptVersion struct {
+	ID             uint      `gorm:"primaryKey"`
+	OrganisationID uint      `gorm:"not null"`
+	PromptKey      string    `gorm:"type:varchar(100);not null"`
+	Version        int       `gorm:"not null"`
+	Content        string    `gorm:"type:text;not null"`
+	Description    string    `gorm:"type:varchar(255)"`
+	IsActive       bool      `gorm:"not null;default:false"`
+	ABWeight       int       `gorm:"column:ab_weight;not null;default:0"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+// ExecutionPromptVersion is the version of a prompt an execution is assigned to, kept for the whole execution.
+type ExecutionPromptVersion struct {
+	ID             uint      `gorm:"primaryKey"`
+	ExecutionID    uint      `gorm:"not null"`
+	OrganisationID uint      `gorm:"not null"`
+	PromptKey      string    `gorm:"type:varchar(100);not null"`
+	Version        int       `gorm:"not null"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
@@ -17,9 +17,13 @@ type PullRequest struct {
 	MergeTargetSHA         string    `gorm:"type:varchar(100)"`
 	MergeBaseSHA           string    `gorm:"type:varchar(100)"`
 	RemoteType             string    `gorm:"type:varchar(50);not null"`
+	SourceBranch           string    `gorm:"type:varchar(255)"`
+	TargetBranch           string    `gorm:"type:varchar(255)"`
 	CreatedAt              time.Time `gorm:"autoCreateTime"`
 	UpdatedAt              time.Time `gorm:"autoUpdateTime"`
-	MergedAt               time.Time `gorm:"autoUpdateTime"`
-	ClosedAt               time.Time `gorm:"autoUpdateTime"`
+	MergedAt               *time.Time
+	ClosedAt               *time.Time
+	IsDraft                bool      `gorm:"not null;default:false"`
 	PRType                 string     `gorm:"type:varchar(50);not null"`
+	AutoMergeBlocked       bool      `gorm:"not null;default:false"`
 }
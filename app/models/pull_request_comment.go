@@ -5,10 +5,19 @@ import (
 )
 
 type PullRequestComments struct {
-	ID            uint      `gorm:"primaryKey"`
-	StoryID       uint      `gorm:"not null"`
-	PullRequestID uint      `gorm:"not null"`
-	Comment       string    `gorm:"type:varchar(255);not null"`
-	CreatedAt     time.Time `gorm:"autoCreateTime"`
-	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+	ID            uint       `gorm:"primaryKey"`
+	StoryID       uint       `gorm:"not null"`
+	PullRequestID uint       `gorm:"not null"`
+	Comment       string     `gorm:"type:text;not null"`
+	ExternalID    string     `gorm:"type:varchar(100)"`
+	Author        string     `gorm:"type:varchar(255)"`
+	Path          string     `gorm:"type:varchar(500)"`
+	Line          int        `gorm:"default:null"`
+	Source        string     `gorm:"type:varchar(50);not null;default:USER"`
+	Status        string     `gorm:"type:varchar(50);not null;default:UNRESOLVED"`
+	Resolution    string     `gorm:"type:text"`
+	ExecutionID   uint       `gorm:"default:null"`
+	ResolvedAt    *time.Time `gorm:"default:null"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// PullRequestReview is a review of a pull request SuperCoder did not write, run as an execution of type REVIEW.
+type PullRequestReview struct {
+	ID                uint      `gorm:"primaryKey"`
+	ProjectID         uint      `gorm:"not null"`
+	ExecutionID       uint      `gorm:"default:null"`
+	RemoteType        string    `gorm:"type:varchar(50);not null"`
+	PullRequestNumber int       `gorm:"not null"`
+	SourceSHA         string    `gorm:"type:varchar(100)"`
+	Status            string    `gorm:"type:varchar(50);not null"`
+	Summary           string    `gorm:"type:text"`
+	CommentsCount     int       `gorm:"not null;default:0"`
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
+}
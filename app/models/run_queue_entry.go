@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// RunQueueEntry is a story in the run queue of a project. Queued stories are started in Position order as
+// soon as their prerequisites are done and no other story of the project is executing.
+type RunQueueEntry struct {
+	ID         uint   `gorm:"primaryKey"`
+	ProjectID  uint   `gorm:"not null"`
+	StoryID    uint   `gorm:"not null"`
+	Position   int    `gorm:"not null"`
+	Status     string `gorm:"type:varchar(50);not null"`
+	Error      string `gorm:"type:text"`
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
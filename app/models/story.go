@@ -18,4 +18,6 @@ type Story struct {
 	CreatedAt    time.Time `gorm:"autoCreateTime"`
 	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
 	Type         string    `gorm:"type;not null"`
+	// ParentStoryID is the story this story was split from, see StoryDecompositionService.
+	ParentStoryID *uint `gorm:"default:null"`
 }
@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// StoryDependency makes a story wait for another story of the same project to be done.
+type StoryDependency struct {
+	ID               uint      `gorm:"primaryKey"`
+	StoryID          uint      `gorm:"not null"`
+	DependsOnStoryID uint      `gorm:"not null"`
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+}
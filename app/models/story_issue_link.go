@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// StoryIssueLink links a story to the issue it was imported from. SyncedState is the issue state last
+// pushed to the tracker, PullRequestURL the pull request last announced on the issue.
+type StoryIssueLink struct {
+	ID             uint      `gorm:"primaryKey"`
+	StoryID        uint      `gorm:"not null;uniqueIndex"`
+	IssueTrackerID uint      `gorm:"not null"`
+	ExternalID     string    `gorm:"type:varchar(100);not null"`
+	ExternalKey    string    `gorm:"type:varchar(100);not null"`
+	URL            string    `gorm:"type:varchar(500)"`
+	SyncedState    string    `gorm:"type:varchar(50)"`
+	PullRequestURL string    `gorm:"type:varchar(500)"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// StoryTemplate is a story an organisation creates stories from. Its texts may contain {{placeholder}}s which
+// are filled in when the template is rendered, TestCases holds one test case per line.
+type StoryTemplate struct {
+	ID             uint      `gorm:"primaryKey"`
+	OrganisationID uint      `gorm:"not null"`
+	Name           string    `gorm:"type:varchar(100);not null"`
+	Summary        string    `gorm:"type:varchar(255);not null"`
+	Description    string    `gorm:"type:text"`
+	TestCases      string    `gorm:"type:text"`
+	Instructions   string    `gorm:"type:text"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
@@ -9,3 +9,39 @@ var ErrStoryDeleted = errors.New("story deleted")
 var ErrInvalidStory = errors.New("invalid story")
 
 var ErrInvalidStoryStatusTransition = errors.New("invalid story status transition")
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+var ErrIssueTrackerNotFound = errors.New("issue tracker not found")
+
+var ErrInvalidIssueTracker = errors.New("invalid issue tracker")
+
+var ErrInvalidStoryDependency = errors.New("invalid story dependency")
+
+var ErrRunQueueEntryNotFound = errors.New("story is not in the run queue")
+
+var ErrPipelinesNotSupported = errors.New("git provider does not run pipelines")
+
+var ErrForeignRepositoryURL = errors.New("repository is not hosted by the git provider")
+
+var ErrInvalidRunQueueRequest = errors.New("invalid run queue request")
+
+var ErrInvalidSubStories = errors.New("invalid sub-stories")
+
+var ErrStoryDecomposed = errors.New("story is split into sub-stories")
+
+var ErrStoryTemplateNotFound = errors.New("story template not found")
+
+var ErrInvalidStoryTemplate = errors.New("invalid story template")
+
+var ErrInstructionSnippetNotFound = errors.New("instruction snippet not found")
+
+var ErrInvalidInstructionSnippet = errors.New("invalid instruction snippet")
+
+var ErrPromptNotFound = errors.New("prompt not found")
+
+var ErrPromptVersionNotFound = errors.New("prompt version not found")
+
+var ErrInvalidPromptVersion = errors.New("invalid prompt version")
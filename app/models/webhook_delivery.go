@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// WebhookDelivery is an event sent to an organisation webhook, with the outcome of its latest attempt.
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey"`
+	WebhookID      uint       `gorm:"not null"`
+	Event          string     `gorm:"type:varchar(100);not null"`
+	Payload        string     `gorm:"type:text;not null"`
+	Status         string     `gorm:"type:varchar(50);not null"`
+	Attempts       int        `gorm:"not null;default:0"`
+	ResponseStatus int        `gorm:"default:null"`
+	Error          string     `gorm:"type:text"`
+	DeliveredAt    *time.Time `gorm:"default:null"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime"`
+}
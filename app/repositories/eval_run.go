@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"ai-developer/app/models"
+	"errors"
+	"gorm.io/gorm"
+)
+
+type EvalRunRepository struct {
+	db *gorm.DB
+}
+
+func NewEvalRunRepository(db *gorm.DB) *EvalRunRepository {
+	return &EvalRunRepository{db: db}
+}
+
+func (r *EvalRunRepository) CreateRun(run *models.EvalRun) error {
+	return r.db.Create(run).Error
+}
+
+func (r *EvalRunRepository) UpdateRun(run *models.EvalRun) error {
+	return r.db.Save(run).Error
+}
+
+// GetRun returns the evaluation run, nil if there is no such run.
+func (r *EvalRunRepository) GetRun(runID uint) (*models.EvalRun, error) {
+	var run models.EvalRun
+	err := r.db.First(&run, runID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *EvalRunRepository) CreateResult(result *models.EvalResult) error {
+	return r.db.Create(result).Error
+}
+
+// GetResults returns the results of the stories of the run, in the order they ran.
+func (r *EvalRunRepository) GetResults(runID uint) ([]models.EvalResult, error) {
+	var results []models.EvalResult
+	err := r.db.Where("run_id = ?", runID).Order("id ASC").Find(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"ai-developer/app/constants"
 	"ai-developer/app/models"
 	"fmt"
 	"gorm.io/gorm"
@@ -96,6 +97,21 @@ func (r *ExecutionRepository) CreateExecutionWithTx(tx *gorm.DB, storyID uint, i
 	return execution, nil
 }
 
+// CreateReviewExecution creates the execution of a pull request review, which has no story.
+func (r *ExecutionRepository) CreateReviewExecution(branchName string) (*models.Execution, error) {
+	execution := &models.Execution{
+		BranchName: branchName,
+		Status:     constants.InProgress,
+		Type:       constants.ExecutionTypeReview,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if err := r.db.Create(execution).Error; err != nil {
+		return nil, err
+	}
+	return execution, nil
+}
+
 func (r *ExecutionRepository) GetExecutionsInProgress() ([]*models.Execution, error) {
 	var executions []*models.Execution
 	if err := r.db.Where("status = ?", "IN_PROGRESS").Find(&executions).Error; err != nil {
@@ -121,7 +137,7 @@ func (r *ExecutionRepository) GetExecutionsWithStatusAndCreatedAtRange(status st
 
 func (r *ExecutionRepository) GetExecutionsByBranchName(branchName string) (*models.Execution, error) {
 	var execution models.Execution
-    if err := r.db.Where("branch_name = ?", branchName).Find(&execution).Error; err != nil {
+    if err := r.db.Where("branch_name = ? AND execution_type = ?", branchName, constants.ExecutionTypeDevelopment).Find(&execution).Error; err != nil {
         return nil, err
     }
     return &execution, nil
@@ -68,6 +68,23 @@ func (executionStepRepository *ExecutionStepRepository) FetchExecutionSteps(exec
 	return steps, nil
 }
 
+func (executionStepRepository *ExecutionStepRepository) FetchAllExecutionSteps(executionID uint) ([]models.ExecutionStep, error) {
+	var steps []models.ExecutionStep
+	if err := executionStepRepository.db.Where("execution_id = ?", executionID).Order("created_at asc").Find(&steps).Error; err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+// FetchLatestExecutionStepOfNames returns the most recent execution step whose name is one of names.
+func (executionStepRepository *ExecutionStepRepository) FetchLatestExecutionStepOfNames(executionID uint, names []string) (*models.ExecutionStep, error) {
+	var executionStep models.ExecutionStep
+	if err := executionStepRepository.db.Where("execution_id = ? AND name IN ?", executionID, names).Order("created_at desc").First(&executionStep).Error; err != nil {
+		return nil, err
+	}
+	return &executionStep, nil
+}
+
 func (executionStepRepository *ExecutionStepRepository) CountExecutionStepsOfType(executionID uint, stepType string) (int64, error) {
 	var count int64
 	if err := executionStepRepository.db.Model(&models.ExecutionStep{}).
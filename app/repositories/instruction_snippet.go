@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"ai-developer/app/models"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type InstructionSnippetRepository struct {
+	db *gorm.DB
+}
+
+func NewInstructionSnippetRepository(db *gorm.DB) *InstructionSnippetRepository {
+	return &InstructionSnippetRepository{db: db}
+}
+
+func (r *InstructionSnippetRepository) CreateSnippet(snippet *models.InstructionSnippet) error {
+	snippet.CreatedAt = time.Now()
+	snippet.UpdatedAt = time.Now()
+	return r.db.Create(snippet).Error
+}
+
+// GetOrganisationSnippet returns the snippet of the organisation, nil if the organisation has no such snippet.
+func (r *InstructionSnippetRepository) GetOrganisationSnippet(organisationID, snippetID uint) (*models.InstructionSnippet, error) {
+	var snippet models.InstructionSnippet
+	err := r.db.Where("id = ? AND organisation_id = ?", snippetID, organisationID).First(&snippet).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &snippet, nil
+}
+
+func (r *InstructionSnippetRepository) GetSnippetsByOrganisationID(organisationID uint) ([]models.InstructionSnippet, error) {
+	var snippets []models.InstructionSnippet
+	if err := r.db.Where("organisation_id = ?", organisationID).Order("created_at").Find(&snippets).Error; err != nil {
+		return nil, err
+	}
+	return snippets, nil
+}
+
+// GetActiveSnippetsByOrganisationID returns the active snippets in the order they were created, which is the
+// order they are appended to the instructions in.
+func (r *InstructionSnippetRepository) GetActiveSnippetsByOrganisationID(organisationID uint) ([]models.InstructionSnippet, error) {
+	var snippets []models.InstructionSnippet
+	if err := r.db.Where("organisation_id = ? AND is_active = ?", organisationID, true).Order("created_at, id").Find(&snippets).Error; err != nil {
+		return nil, err
+	}
+	return snippets, nil
+}
+
+func (r *InstructionSnippetRepository) UpdateSnippet(snippet *models.InstructionSnippet) error {
+	snippet.UpdatedAt = time.Now()
+	return r.db.Save(snippet).Error
+}
+
+func (r *InstructionSnippetRepository) DeleteSnippet(snippet *models.InstructionSnippet) error {
+	return r.db.Delete(snippet).Error
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"ai-developer/app/models"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type IssueTrackerRepository struct {
+	db *gorm.DB
+}
+
+func NewIssueTrackerRepository(db *gorm.DB) *IssueTrackerRepository {
+	return &IssueTrackerRepository{db: db}
+}
+
+func (r *IssueTrackerRepository) CreateIssueTracker(tracker *models.IssueTracker) error {
+	tracker.CreatedAt = time.Now()
+	tracker.UpdatedAt = time.Now()
+	return r.db.Create(tracker).Error
+}
+
+// GetIssueTrackerByID returns the tracker, nil if there is no such tracker.
+func (r *IssueTrackerRepository) GetIssueTrackerByID(trackerID uint) (*models.IssueTracker, error) {
+	var tracker models.IssueTracker
+	err := r.db.First(&tracker, trackerID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tracker, nil
+}
+
+// GetProjectIssueTracker returns the tracker of the project, nil if the project has no such tracker.
+func (r *IssueTrackerRepository) GetProjectIssueTracker(projectID, trackerID uint) (*models.IssueTracker, error) {
+	var tracker models.IssueTracker
+	err := r.db.Where("id = ? AND project_id = ?", trackerID, projectID).First(&tracker).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tracker, nil
+}
+
+func (r *IssueTrackerRepository) GetIssueTrackersByProjectID(projectID uint) ([]models.IssueTracker, error) {
+	var trackers []models.IssueTracker
+	if err := r.db.Where("project_id = ?", projectID).Order("created_at").Find(&trackers).Error; err != nil {
+		return nil, err
+	}
+	return trackers, nil
+}
+
+func (r *IssueTrackerRepository) GetAllIssueTrackers() ([]models.IssueTracker, error) {
+	var trackers []models.IssueTracker
+	if err := r.db.Order("id").Find(&trackers).Error; err != nil {
+		return nil, err
+	}
+	return trackers, nil
+}
+
+func (r *IssueTrackerRepository) UpdateIssueTracker(tracker *models.IssueTracker) error {
+	tracker.UpdatedAt = time.Now()
+	return r.db.Save(tracker).Error
+}
+
+// DeleteIssueTracker deletes the tracker together with its issue links, the imported stories are kept.
+func (r *IssueTrackerRepository) DeleteIssueTracker(tracker *models.IssueTracker) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("issue_tracker_id = ?", tracker.ID).Delete(&models.StoryIssueLink{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(tracker).Error
+	})
+}
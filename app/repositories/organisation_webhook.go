@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"ai-developer/app/models"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type OrganisationWebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganisationWebhookRepository(db *gorm.DB) *OrganisationWebhookRepository {
+	return &OrganisationWebhookRepository{db: db}
+}
+
+func (r *OrganisationWebhookRepository) CreateWebhook(webhook *models.OrganisationWebhook) error {
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = time.Now()
+	return r.db.Create(webhook).Error
+}
+
+// GetWebhookByID returns the webhook, nil if there is no such webhook.
+func (r *OrganisationWebhookRepository) GetWebhookByID(webhookID uint) (*models.OrganisationWebhook, error) {
+	var webhook models.OrganisationWebhook
+	err := r.db.First(&webhook, webhookID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetOrganisationWebhook returns the webhook of the organisation, nil if the organisation has no such webhook.
+func (r *OrganisationWebhookRepository) GetOrganisationWebhook(organisationID, webhookID uint) (*models.OrganisationWebhook, error) {
+	var webhook models.OrganisationWebhook
+	err := r.db.Where("id = ? AND organisation_id = ?", webhookID, organisationID).First(&webhook).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *OrganisationWebhookRepository) GetWebhooksByOrganisationID(organisationID uint) ([]models.OrganisationWebhook, error) {
+	var webhooks []models.OrganisationWebhook
+	if err := r.db.Where("organisation_id = ?", organisationID).Order("created_at").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *OrganisationWebhookRepository) GetActiveWebhooksByOrganisationID(organisationID uint) ([]models.OrganisationWebhook, error) {
+	var webhooks []models.OrganisationWebhook
+	if err := r.db.Where("organisation_id = ? AND is_active = ?", organisationID, true).Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *OrganisationWebhookRepository) UpdateWebhook(webhook *models.OrganisationWebhook) error {
+	webhook.UpdatedAt = time.Now()
+	return r.db.Save(webhook).Error
+}
+
+// DeleteWebhook deletes the webhook together with its delivery log.
+func (r *OrganisationWebhookRepository) DeleteWebhook(webhook *models.OrganisationWebhook) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", webhook.ID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(webhook).Error
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"ai-developer/app/models"
 	"ai-developer/app/types/request"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProjectRepository struct {
@@ -27,6 +28,16 @@ func (receiver ProjectRepository) CreateProject(project *models.Project) (*model
 	return project, nil
 }
 
+// GetProjectByIdForUpdateWithTx fetches the project and locks its row until the transaction ends.
+func (receiver ProjectRepository) GetProjectByIdForUpdateWithTx(tx *gorm.DB, projectId int) (*models.Project, error) {
+	var project models.Project
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&project, projectId).Error
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
 func (receiver ProjectRepository) GetProjectById(projectId int) (*models.Project, error) {
 	var project models.Project
 	err := receiver.db.First(&project, projectId).Error
@@ -36,9 +47,73 @@ func (receiver ProjectRepository) GetProjectById(projectId int) (*models.Project
 	return &project, nil
 }
 
+func (receiver ProjectRepository) GetProjectsByName(name string) ([]models.Project, error) {
+	var projects []models.Project
+	err := receiver.db.Where("name = ?", name).Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// GetProjectsByRepositoryPath returns the projects whose stored repository path is repositoryPath, ignoring case.
+func (receiver ProjectRepository) GetProjectsByRepositoryPath(repositoryPath string) ([]models.Project, error) {
+	var projects []models.Project
+	err := receiver.db.Where("LOWER(repository_path) = LOWER(?)", repositoryPath).Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
 func (receiver ProjectRepository) UpdateProject(project *models.Project, updateData request.UpdateProjectRequest) (*models.Project, error) {
 	project.Name = updateData.Name
 	project.Description = updateData.Description
+	if updateData.BaseBranch != nil {
+		project.BaseBranch = *updateData.BaseBranch
+	}
+	if updateData.TargetBranch != nil {
+		project.TargetBranch = *updateData.TargetBranch
+	}
+	if updateData.BranchNameTemplate != nil {
+		project.BranchNameTemplate = *updateData.BranchNameTemplate
+	}
+	if updateData.PullRequestTemplate != nil {
+		project.PullRequestTemplate = *updateData.PullRequestTemplate
+	}
+	if updateData.AutoMergeEnabled != nil {
+		project.AutoMergeEnabled = *updateData.AutoMergeEnabled
+	}
+	if updateData.AutoMergeMethod != nil {
+		project.AutoMergeMethod = *updateData.AutoMergeMethod
+	}
+	if updateData.AutoMergeRequireServerTest != nil {
+		project.AutoMergeRequireServerTest = *updateData.AutoMergeRequireServerTest
+	}
+	if updateData.AutoMergeRequireTests != nil {
+		project.AutoMergeRequireTests = *updateData.AutoMergeRequireTests
+	}
+	if updateData.AutoMergeRequireLint != nil {
+		project.AutoMergeRequireLint = *updateData.AutoMergeRequireLint
+	}
+	if updateData.AutoMergeMaxDiffLines != nil {
+		project.AutoMergeMaxDiffLines = *updateData.AutoMergeMaxDiffLines
+	}
+	if updateData.AutoMergeExcludedPaths != nil {
+		project.AutoMergeExcludedPaths = *updateData.AutoMergeExcludedPaths
+	}
+	if updateData.MaxConcurrentExecutions != nil {
+		project.MaxConcurrentExecutions = *updateData.MaxConcurrentExecutions
+	}
+	if updateData.Conventions != nil {
+		project.Conventions = *updateData.Conventions
+	}
+	if updateData.BackendSystemPrompt != nil {
+		project.BackendSystemPrompt = *updateData.BackendSystemPrompt
+	}
+	if updateData.FrontendSystemPrompt != nil {
+		project.FrontendSystemPrompt = *updateData.FrontendSystemPrompt
+	}
 	err := receiver.db.Save(project).Error
 	if err != nil {
 		return nil, err
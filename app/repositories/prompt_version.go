@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// PromptVersionStats are the executions assigned to a version of a prompt, with the code generation iterations
+// and tokens they used.
+type PromptVersionStats struct {
+	Version          int
+	Executions       int
+	Finished         int
+	Succeeded        int
+	Iterations       int
+	PromptTokens     int
+	CompletionTokens int
+}
+
+type PromptVersionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromptVersionRepository(db *gorm.DB) *PromptVersionRepository {
+	return &PromptVersionRepository{db: db}
+}
+
+// CreateVersion stores the content as the next version of the prompt.
+func (r *PromptVersionRepository) CreateVersion(promptVersion *models.PromptVersion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var latest int
+		err := tx.Model(&models.PromptVersion{}).
+			Where("organisation_id = ? AND prompt_key = ?", promptVersion.OrganisationID, promptVersion.PromptKey).
+			Select("COALESCE(MAX(version), 0)").Scan(&latest).Error
+		if err != nil {
+			return err
+		}
+		promptVersion.Version = latest + 1
+		promptVersion.CreatedAt = time.Now()
+		promptVersion.UpdatedAt = time.Now()
+		return tx.Create(promptVersion).Error
+	})
+}
+
+// GetVersion returns the version of the prompt, nil if there is no such version.
+func (r *PromptVersionRepository) GetVersion(organisationID uint, promptKey string, version int) (*models.PromptVersion, error) {
+	var promptVersion models.PromptVersion
+	err := r.db.Where("organisation_id = ? AND prompt_key = ? AND version = ?", organisationID, promptKey, version).First(&promptVersion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &promptVersion, nil
+}
+
+// GetVersions returns the versions of the prompt, newest first.
+func (r *PromptVersionRepository) GetVersions(organisationID uint, promptKey string) ([]models.PromptVersion, error) {
+	var promptVersions []models.PromptVersion
+	err := r.db.Where("organisation_id = ? AND prompt_key = ?", organisationID, promptKey).Order("version DESC").Find(&promptVersions).Error
+	if err != nil {
+		return nil, err
+	}
+	return promptVersions, nil
+}
+
+// GetActiveVersion returns the active version of the prompt, nil if the default prompt file is used.
+func (r *PromptVersionRepository) GetActiveVersion(organisationID uint, promptKey string) (*models.PromptVersion, error) {
+	var promptVersion models.PromptVersion
+	err := r.db.Where("organisation_id = ? AND prompt_key = ? AND is_active = ?", organisationID, promptKey, true).First(&promptVersion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &promptVersion, nil
+}
+
+// GetWeightedVersions returns the versions of the prompt taking part in an A/B comparison.
+func (r *PromptVersionRepository) GetWeightedVersions(organisationID uint, promptKey string) ([]models.PromptVersion, error) {
+	var promptVersions []models.PromptVersion
+	err := r.db.Where("organisation_id = ? AND prompt_key = ? AND ab_weight > 0", organisationID, promptKey).Order("version").Find(&promptVersions).Error
+	if err != nil {
+		return nil, err
+	}
+	return promptVersions, nil
+}
+
+func (r *PromptVersionRepository) UpdateVersion(promptVersion *models.PromptVersion) error {
+	promptVersion.UpdatedAt = time.Now()
+	return r.db.Save(promptVersion).Error
+}
+
+// SetActiveVersion makes the version the active version of its prompt, nil makes the default prompt file active.
+func (r *PromptVersionRepository) SetActiveVersion(organisationID uint, promptKey string, promptVersion *models.PromptVersion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.PromptVersion{}).
+			Where("organisation_id = ? AND prompt_key = ? AND is_active = ?", organisationID, promptKey, true).
+			Updates(map[string]interface{}{"is_active": false, "updated_at": time.Now()}).Error
+		if err != nil {
+			return err
+		}
+		if promptVersion == nil {
+			return nil
+		}
+		promptVersion.IsActive = true
+		promptVersion.UpdatedAt = time.Now()
+		return tx.Save(promptVersion).Error
+	})
+}
+
+// GetExecutionPromptVersion returns the version of the prompt the execution is assigned to, nil if it is not
+// assigned to one yet.
+func (r *PromptVersionRepository) GetExecutionPromptVersion(executionID uint, promptKey string) (*models.ExecutionPromptVersion, error) {
+	var assignment models.ExecutionPromptVersion
+	err := r.db.Where("execution_id = ? AND prompt_key = ?", executionID, promptKey).First(&assignment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// CreateExecutionPromptVersion assigns the execution to the version, an existing assignment is kept.
+func (r *PromptVersionRepository) CreateExecutionPromptVersion(assignment *models.ExecutionPromptVersion) error {
+	assignment.CreatedAt = time.Now()
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(assignment).Error
+}
+
+// GetVersionStats returns the stats of the executions assigned to the versions of the prompt, by version.
+// Iterations count the code generation steps and tokens are those of all LLM steps of the executions.
+func (r *PromptVersionRepository) GetVersionStats(organisationID uint, promptKey string, codeGenerationStep string) ([]PromptVersionStats, error) {
+	var stats []PromptVersionStats
+	err := r.db.Raw(
+		"SELECT assignments.version AS version, "+
+			"COUNT(*) AS executions, "+
+			"SUM(CASE WHEN executions.status <> ? THEN 1 ELSE 0 END) AS finished, "+
+			"SUM(CASE WHEN executions.status = ? THEN 1 ELSE 0 END) AS succeeded, "+
+			"COALESCE(SUM(steps.iterations), 0) AS iterations, "+
+			"COALESCE(SUM(steps.prompt_tokens), 0) AS prompt_tokens, "+
+			"COALESCE(SUM(steps.completion_tokens), 0) AS completion_tokens "+
+			"FROM execution_prompt_versions assignments "+
+			"JOIN executions ON executions.id = assignments.execution_id "+
+			"LEFT JOIN ("+
+			"SELECT execution_id, "+
+			"SUM(CASE WHEN name = ? THEN 1 ELSE 0 END) AS iterations, "+
+			"SUM(COALESCE((response->'llm_usage'->>'prompt_tokens')::int, 0)) AS prompt_tokens, "+
+			"SUM(COALESCE((response->'llm_usage'->>'completion_tokens')::int, 0)) AS completion_tokens "+
+			"FROM execution_steps "+
+			"WHERE execution_id IN (SELECT execution_id FROM execution_prompt_versions WHERE organisation_id = ? AND prompt_key = ?) "+
+			"GROUP BY execution_id"+
+			") steps ON steps.execution_id = assignments.execution_id "+
+			"WHERE assignments.organisation_id = ? AND assignments.prompt_key = ? "+
+			"GROUP BY assignments.version "+
+			"ORDER BY assignments.version",
+		constants.InProgress, constants.Done, codeGenerationStep, organisationID, promptKey, organisationID, promptKey,
+	).Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -18,7 +18,7 @@ func NewPullRequestRepository(db *gorm.DB) *PullRequestRepository {
 	return &PullRequestRepository{db: db}
 }
 
-func (r *PullRequestRepository) CreatePullRequest(prTitle, prDescription, prID, remoteType string,
+func (r *PullRequestRepository) CreatePullRequest(prTitle, prDescription, prID, remoteType, sourceBranch, targetBranch string,
 	sourceSHA, mergeTargetSHA, mergeBaseSHA string, prNumber int, storyID uint, executionOutputId uint, prType string) (*models.PullRequest, error) {
 	pullRequest := &models.PullRequest{
 		StoryID:                storyID,
@@ -28,6 +28,8 @@ func (r *PullRequestRepository) CreatePullRequest(prTitle, prDescription, prID,
 		PullRequestDescription: prDescription,
 		PullRequestID:          prID,
 		RemoteType:             remoteType,
+		SourceBranch:           sourceBranch,
+		TargetBranch:           targetBranch,
 		SourceSHA:              sourceSHA,
 		MergeTargetSHA:         mergeTargetSHA,
 		MergeBaseSHA:           mergeBaseSHA,
@@ -62,6 +64,33 @@ func (r *PullRequestRepository) UpdatePullRequestStatus(pullRequest *models.Pull
 	return nil
 }
 
+// UpdatePullRequestState saves the status, draft flag and merge and close times of the pull request.
+func (r *PullRequestRepository) UpdatePullRequestState(pullRequest *models.PullRequest, status string, isDraft bool, mergedAt, closedAt *time.Time) error {
+	pullRequest.Status = status
+	pullRequest.IsDraft = isDraft
+	pullRequest.MergedAt = mergedAt
+	pullRequest.ClosedAt = closedAt
+	return r.db.Save(pullRequest).Error
+}
+
+// GetPullRequestsByStatuses returns the pull requests in any of the statuses, oldest first.
+func (r *PullRequestRepository) GetPullRequestsByStatuses(statuses []string) ([]models.PullRequest, error) {
+	var pullRequests []models.PullRequest
+	err := r.db.Where("status IN ?", statuses).Order("created_at").Find(&pullRequests).Error
+	if err != nil {
+		return nil, err
+	}
+	return pullRequests, nil
+}
+
+func (r *PullRequestRepository) UpdatePullRequestAutoMergeBlocked(pullRequest *models.PullRequest, blocked bool) error {
+	pullRequest.AutoMergeBlocked = blocked
+	if err := r.db.Save(pullRequest).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 func (r* PullRequestCommentsRepository) UpdatePullRequestSourceSHA(pullRequest *models.PullRequest, source string) error{
 	pullRequest.SourceSHA = source
     if err := r.db.Save(pullRequest).Error; err!= nil {
@@ -142,6 +171,22 @@ func (r *PullRequestRepository) GetPullRequestWithDetails(pullRequestID uint) (*
 	return &project, nil
 }
 
+// GetPullRequestByProjectAndNumber finds the pull request of a story of the project by its number on the git provider.
+func (r *PullRequestRepository) GetPullRequestByProjectAndNumber(projectID uint, remoteType string, pullRequestNumber int) (*models.PullRequest, error) {
+	var pullRequest models.PullRequest
+	err := r.db.Joins("JOIN stories ON stories.id = pull_requests.story_id").
+		Where("stories.project_id = ? AND pull_requests.remote_type = ? AND pull_requests.pull_request_number = ?", projectID, remoteType, pullRequestNumber).
+		Order("pull_requests.created_at DESC").
+		First(&pullRequest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &pullRequest, nil
+}
+
 func (r *PullRequestRepository) GetOpenPullRequestsByStoryID(storyID int) (*models.PullRequest, error) {
 	var pullRequest *models.PullRequest
 	err := r.db.Where("story_id =? AND status =?", storyID, constants.Open).First(&pullRequest).Error
@@ -1,7 +1,9 @@
 package repositories
 
 import (
+	"ai-developer/app/constants"
 	"ai-developer/app/models"
+	"errors"
 	"gorm.io/gorm"
 	"log"
 	"time"
@@ -24,24 +26,83 @@ func (r *PullRequestCommentsRepository) CountByPullRequestID(pullRequestID uint)
 	return count, nil
 }
 
-func (r *PullRequestCommentsRepository) CreateComment(pullRequestID uint, comment string) error {
-	pullRequestComment := &models.PullRequestComments{
-		PullRequestID: pullRequestID,
-		Comment:       comment,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
-	}
+func (r *PullRequestCommentsRepository) CreateComment(pullRequestComment *models.PullRequestComments) error {
+	pullRequestComment.CreatedAt = time.Now()
+	pullRequestComment.UpdatedAt = time.Now()
 	if err := r.db.Create(pullRequestComment).Error; err != nil {
 		return err
 	}
 	return nil
 }
 
+func (r *PullRequestCommentsRepository) GetCommentByExternalID(pullRequestID uint, externalID string) (*models.PullRequestComments, error) {
+	var comment models.PullRequestComments
+	err := r.db.Where("pull_request_id = ? AND external_id = ?", pullRequestID, externalID).First(&comment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *PullRequestCommentsRepository) UpdateCommentExternalID(comment *models.PullRequestComments, externalID string) error {
+	comment.ExternalID = externalID
+	if err := r.db.Save(comment).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 func (r *PullRequestCommentsRepository) GetAllCommentsByPullRequestID(pullRequestID uint) ([]models.PullRequestComments, error) {
 	var comments []models.PullRequestComments
-	result := r.db.Where("pull_request_id = ?", pullRequestID).Find(&comments)
+	result := r.db.Where("pull_request_id = ?", pullRequestID).Order("created_at ASC, id ASC").Find(&comments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return comments, nil
+}
+
+// GetUnresolvedCommentsByPullRequestID returns the review comments still to be addressed, oldest first.
+func (r *PullRequestCommentsRepository) GetUnresolvedCommentsByPullRequestID(pullRequestID uint) ([]models.PullRequestComments, error) {
+	var comments []models.PullRequestComments
+	result := r.db.Where("pull_request_id = ? AND status = ? AND source <> ?", pullRequestID, constants.CommentStatusUnresolved, constants.CommentSourceAgent).
+		Order("created_at ASC, id ASC").Find(&comments)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return comments, nil
 }
+
+func (r *PullRequestCommentsRepository) GetCommentsByExecutionID(executionID uint) ([]models.PullRequestComments, error) {
+	var comments []models.PullRequestComments
+	result := r.db.Where("execution_id = ?", executionID).Order("created_at ASC, id ASC").Find(&comments)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return comments, nil
+}
+
+// AssignCommentsToExecution records the execution addressing the comments.
+func (r *PullRequestCommentsRepository) AssignCommentsToExecution(commentIDs []uint, executionID uint) error {
+	if len(commentIDs) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.PullRequestComments{}).Where("id IN (?)", commentIDs).
+		Updates(map[string]interface{}{"execution_id": executionID, "updated_at": time.Now()}).Error
+}
+
+func (r *PullRequestCommentsRepository) UpdateCommentResolution(comment *models.PullRequestComments, status, resolution string) error {
+	comment.Status = status
+	comment.Resolution = resolution
+	comment.ResolvedAt = nil
+	if status == constants.CommentStatusResolved {
+		now := time.Now()
+		comment.ResolvedAt = &now
+	}
+	if err := r.db.Save(comment).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"ai-developer/app/models"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type PullRequestReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewPullRequestReviewRepository(db *gorm.DB) *PullRequestReviewRepository {
+	return &PullRequestReviewRepository{db: db}
+}
+
+func (r *PullRequestReviewRepository) CreateReview(review *models.PullRequestReview) error {
+	review.CreatedAt = time.Now()
+	review.UpdatedAt = time.Now()
+	return r.db.Create(review).Error
+}
+
+func (r *PullRequestReviewRepository) GetReviewByID(reviewID uint) (*models.PullRequestReview, error) {
+	var review models.PullRequestReview
+	if err := r.db.First(&review, reviewID).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// GetReviewBySourceSHA returns the review of the given head commit of the pull request, nil if there is none.
+func (r *PullRequestReviewRepository) GetReviewBySourceSHA(projectID uint, pullRequestNumber int, sourceSHA string) (*models.PullRequestReview, error) {
+	var review models.PullRequestReview
+	err := r.db.Where("project_id = ? AND pull_request_number = ? AND source_sha = ?", projectID, pullRequestNumber, sourceSHA).First(&review).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *PullRequestReviewRepository) GetReviewsByProjectID(projectID uint) ([]models.PullRequestReview, error) {
+	var reviews []models.PullRequestReview
+	if err := r.db.Where("project_id = ?", projectID).Order("created_at DESC").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (r *PullRequestReviewRepository) UpdateReview(review *models.PullRequestReview) error {
+	review.UpdatedAt = time.Now()
+	return r.db.Save(review).Error
+}
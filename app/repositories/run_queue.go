@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// runQueueLogLimit is the number of finished entries the run queue of a project lists.
+const runQueueLogLimit = 50
+
+type RunQueueRepository struct {
+	db *gorm.DB
+}
+
+func NewRunQueueRepository(db *gorm.DB) *RunQueueRepository {
+	return &RunQueueRepository{db: db}
+}
+
+func (r *RunQueueRepository) CreateEntry(entry *models.RunQueueEntry) error {
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = time.Now()
+	return r.db.Create(entry).Error
+}
+
+// GetActiveEntries returns the queued, running and failed entries of the project in queue order.
+func (r *RunQueueRepository) GetActiveEntries(projectID uint) ([]models.RunQueueEntry, error) {
+	var entries []models.RunQueueEntry
+	err := r.db.Where("project_id = ? AND status IN ?", projectID, []string{constants.RunQueueQueued, constants.RunQueueRunning, constants.RunQueueFailed}).
+		Order("position").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetFinishedEntries returns the most recently finished entries of the project.
+func (r *RunQueueRepository) GetFinishedEntries(projectID uint) ([]models.RunQueueEntry, error) {
+	var entries []models.RunQueueEntry
+	err := r.db.Where("project_id = ? AND status = ?", projectID, constants.RunQueueDone).
+		Order("finished_at DESC").
+		Limit(runQueueLogLimit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetActiveEntryByStoryID returns the active entry of the story, nil if the story is not in the queue.
+func (r *RunQueueRepository) GetActiveEntryByStoryID(storyID uint) (*models.RunQueueEntry, error) {
+	var entry models.RunQueueEntry
+	err := r.db.Where("story_id = ? AND status IN ?", storyID, []string{constants.RunQueueQueued, constants.RunQueueRunning, constants.RunQueueFailed}).
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetMaxPosition returns the position of the last entry of the project, zero for an empty queue.
+func (r *RunQueueRepository) GetMaxPosition(projectID uint) (int, error) {
+	var position int
+	err := r.db.Model(&models.RunQueueEntry{}).Where("project_id = ?", projectID).
+		Select("COALESCE(MAX(position), 0)").
+		Scan(&position).Error
+	return position, err
+}
+
+// GetProjectIDsWithActiveEntries returns the projects with queued or running stories.
+func (r *RunQueueRepository) GetProjectIDsWithActiveEntries() ([]uint, error) {
+	var projectIDs []uint
+	err := r.db.Model(&models.RunQueueEntry{}).
+		Where("status IN ?", []string{constants.RunQueueQueued, constants.RunQueueRunning}).
+		Distinct().
+		Pluck("project_id", &projectIDs).Error
+	return projectIDs, err
+}
+
+func (r *RunQueueRepository) UpdateEntry(entry *models.RunQueueEntry) error {
+	entry.UpdatedAt = time.Now()
+	return r.db.Save(entry).Error
+}
+
+func (r *RunQueueRepository) DeleteEntry(entry *models.RunQueueEntry) error {
+	return r.db.Delete(entry).Error
+}
@@ -11,8 +11,15 @@ type StoryRepository struct {
 	db *gorm.DB
 }
 
+// notSplitStory leaves out the stories split into sub-stories, which do not execute themselves.
+const notSplitStory = "NOT EXISTS (SELECT 1 FROM stories AS sub_stories WHERE sub_stories.parent_story_id = stories.id AND sub_stories.is_deleted = false)"
+
 func (receiver *StoryRepository) CreateStory(story *models.Story) (*models.Story, error) {
-	err := receiver.db.Create(story).Error
+	return receiver.CreateStoryWithTx(receiver.db, story)
+}
+
+func (receiver *StoryRepository) CreateStoryWithTx(tx *gorm.DB, story *models.Story) (*models.Story, error) {
+	err := tx.Create(story).Error
 	if err != nil {
 		return nil, err
 	}
@@ -28,6 +35,16 @@ func (receiver *StoryRepository) GetStoriesByProjectId(projectId int, storyType
 	return stories, nil
 }
 
+// GetSubStories returns the stories split from the parent story, in the order they were created.
+func (receiver *StoryRepository) GetSubStories(parentStoryId uint) ([]models.Story, error) {
+	var stories []models.Story
+	err := receiver.db.Where("parent_story_id = ? AND is_deleted = ?", parentStoryId, false).Order("id").Find(&stories).Error
+	if err != nil {
+		return nil, err
+	}
+	return stories, nil
+}
+
 func (receiver *StoryRepository) GetStoriesByProjectIdAndSearch(projectId int, searchValue string, storyType string) ([]models.Story, error) {
     var stories []models.Story
     searchPattern := searchValue + "%"
@@ -106,9 +123,26 @@ func (receiver *StoryRepository) UpdateReviewViewedStatus(story *models.Story, v
 	return nil
 }
 
+// CountStoriesByProjectIdAndStatuses counts the stories of the project in any of the statuses. Stories split
+// into sub-stories only carry the status rolled up from their sub-stories and are not counted.
+func (receiver *StoryRepository) CountStoriesByProjectIdAndStatuses(projectId int, statuses []string) (int64, error) {
+	return receiver.CountStoriesByProjectIdAndStatusesWithTx(receiver.db, projectId, statuses)
+}
+
+func (receiver *StoryRepository) CountStoriesByProjectIdAndStatusesWithTx(tx *gorm.DB, projectId int, statuses []string) (int64, error) {
+	var count int64
+	err := tx.Model(&models.Story{}).Where("project_id = ? AND status IN ? AND is_deleted = ?", projectId, statuses, false).
+		Where(notSplitStory).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (receiver *StoryRepository) GetInProgressStoriesByProjectId(projectId int) ([]*models.Story, error) {
 	var stories []*models.Story
-	err := receiver.db.Where("project_id = ? AND is_deleted = ? AND status = ?", projectId, false, constants.InProgress).Find(&stories).Error
+	err := receiver.db.Where("project_id = ? AND is_deleted = ? AND status = ?", projectId, false, constants.InProgress).
+		Where(notSplitStory).Find(&stories).Error
 	if err != nil {
 		return nil, err
 	}
@@ -156,6 +190,10 @@ func (receiver *StoryRepository) UpdateStoryStatusWithTx(tx *gorm.DB, storyID in
 	return nil
 }
 
+func (receiver *StoryRepository) GetDB() *gorm.DB {
+	return receiver.db
+}
+
 func NewStoryRepository(db *gorm.DB) *StoryRepository {
 	return &StoryRepository{
 		db: db,
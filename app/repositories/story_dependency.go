@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"ai-developer/app/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+type StoryDependencyRepository struct {
+	db *gorm.DB
+}
+
+func NewStoryDependencyRepository(db *gorm.DB) *StoryDependencyRepository {
+	return &StoryDependencyRepository{db: db}
+}
+
+func (r *StoryDependencyRepository) GetDependenciesByStoryID(storyID uint) ([]models.StoryDependency, error) {
+	var dependencies []models.StoryDependency
+	if err := r.db.Where("story_id = ?", storyID).Order("id").Find(&dependencies).Error; err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// GetDependenciesByProjectID returns the dependencies between the stories of the project.
+func (r *StoryDependencyRepository) GetDependenciesByProjectID(projectID uint) ([]models.StoryDependency, error) {
+	var dependencies []models.StoryDependency
+	err := r.db.Joins("JOIN stories ON stories.id = story_dependencies.story_id").
+		Where("stories.project_id = ?", projectID).
+		Order("story_dependencies.id").
+		Find(&dependencies).Error
+	if err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// ReplaceDependencies makes the story depend on exactly the given stories.
+func (r *StoryDependencyRepository) ReplaceDependencies(storyID uint, dependsOnStoryIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("story_id = ?", storyID).Delete(&models.StoryDependency{}).Error; err != nil {
+			return err
+		}
+		return r.CreateDependenciesWithTx(tx, storyID, dependsOnStoryIDs)
+	})
+}
+
+// CreateDependenciesWithTx adds the given stories to the prerequisites of the story within a transaction.
+func (r *StoryDependencyRepository) CreateDependenciesWithTx(tx *gorm.DB, storyID uint, dependsOnStoryIDs []uint) error {
+	for _, dependsOnStoryID := range dependsOnStoryIDs {
+		dependency := &models.StoryDependency{StoryID: storyID, DependsOnStoryID: dependsOnStoryID, CreatedAt: time.Now()}
+		if err := tx.Create(dependency).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
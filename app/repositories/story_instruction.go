@@ -10,7 +10,11 @@ type StoryInstructionRepository struct {
 }
 
 func (receiver StoryInstructionRepository) CreateStoryInstructions(s *models.StoryInstruction) error {
-	err := receiver.db.Create(s).Error
+	return receiver.CreateStoryInstructionsWithTx(receiver.db, s)
+}
+
+func (receiver StoryInstructionRepository) CreateStoryInstructionsWithTx(tx *gorm.DB, s *models.StoryInstruction) error {
+	err := tx.Create(s).Error
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"ai-developer/app/models"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type StoryIssueLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewStoryIssueLinkRepository(db *gorm.DB) *StoryIssueLinkRepository {
+	return &StoryIssueLinkRepository{db: db}
+}
+
+func (r *StoryIssueLinkRepository) CreateLink(link *models.StoryIssueLink) error {
+	link.CreatedAt = time.Now()
+	link.UpdatedAt = time.Now()
+	return r.db.Create(link).Error
+}
+
+// GetLinkByStoryID returns the link of the story, nil if the story was not imported from an issue.
+func (r *StoryIssueLinkRepository) GetLinkByStoryID(storyID uint) (*models.StoryIssueLink, error) {
+	var link models.StoryIssueLink
+	err := r.db.Where("story_id = ?", storyID).First(&link).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetLinkByExternalID returns the link of the issue, nil if the issue has not been imported.
+func (r *StoryIssueLinkRepository) GetLinkByExternalID(trackerID uint, externalID string) (*models.StoryIssueLink, error) {
+	var link models.StoryIssueLink
+	err := r.db.Where("issue_tracker_id = ? AND external_id = ?", trackerID, externalID).First(&link).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *StoryIssueLinkRepository) GetLinksByIssueTrackerID(trackerID uint) ([]models.StoryIssueLink, error) {
+	var links []models.StoryIssueLink
+	if err := r.db.Where("issue_tracker_id = ?", trackerID).Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+func (r *StoryIssueLinkRepository) UpdateLink(link *models.StoryIssueLink) error {
+	link.UpdatedAt = time.Now()
+	return r.db.Save(link).Error
+}
+
+// DeleteLinkByStoryID drops the link of a deleted story so the issue can be imported again.
+func (r *StoryIssueLinkRepository) DeleteLinkByStoryID(storyID uint) error {
+	return r.db.Where("story_id = ?", storyID).Delete(&models.StoryIssueLink{}).Error
+}
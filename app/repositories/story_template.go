@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"ai-developer/app/models"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type StoryTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewStoryTemplateRepository(db *gorm.DB) *StoryTemplateRepository {
+	return &StoryTemplateRepository{db: db}
+}
+
+func (r *StoryTemplateRepository) CreateTemplate(template *models.StoryTemplate) error {
+	template.CreatedAt = time.Now()
+	template.UpdatedAt = time.Now()
+	return r.db.Create(template).Error
+}
+
+// GetOrganisationTemplate returns the template of the organisation, nil if the organisation has no such template.
+func (r *StoryTemplateRepository) GetOrganisationTemplate(organisationID, templateID uint) (*models.StoryTemplate, error) {
+	var template models.StoryTemplate
+	err := r.db.Where("id = ? AND organisation_id = ?", templateID, organisationID).First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *StoryTemplateRepository) GetTemplatesByOrganisationID(organisationID uint) ([]models.StoryTemplate, error) {
+	var templates []models.StoryTemplate
+	if err := r.db.Where("organisation_id = ?", organisationID).Order("name").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *StoryTemplateRepository) UpdateTemplate(template *models.StoryTemplate) error {
+	template.UpdatedAt = time.Now()
+	return r.db.Save(template).Error
+}
+
+func (r *StoryTemplateRepository) DeleteTemplate(template *models.StoryTemplate) error {
+	return r.db.Delete(template).Error
+}
@@ -11,7 +11,11 @@ type StoryTestCaseRepository struct {
 }
 
 func (receiver *StoryTestCaseRepository) CreateStoryTestCase(s *models.StoryTestCase) error {
-	err := receiver.db.Create(s).Error
+	return receiver.CreateStoryTestCaseWithTx(receiver.db, s)
+}
+
+func (receiver *StoryTestCaseRepository) CreateStoryTestCaseWithTx(tx *gorm.DB, s *models.StoryTestCase) error {
+	err := tx.Create(s).Error
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"ai-developer/app/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+func (r *WebhookDeliveryRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	delivery.CreatedAt = time.Now()
+	delivery.UpdatedAt = time.Now()
+	return r.db.Create(delivery).Error
+}
+
+func (r *WebhookDeliveryRepository) GetDeliveryByID(deliveryID uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := r.db.First(&delivery, deliveryID).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// GetDeliveriesByWebhookID returns the latest deliveries of the webhook, newest first.
+func (r *WebhookDeliveryRepository) GetDeliveriesByWebhookID(webhookID uint, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	if err := r.db.Where("webhook_id = ?", webhookID).Order("created_at DESC").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) UpdateDelivery(delivery *models.WebhookDelivery) error {
+	delivery.UpdatedAt = time.Now()
+	return r.db.Save(delivery).Error
+}
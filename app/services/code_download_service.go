@@ -1,8 +1,6 @@
 package services
 
 import (
-	"ai-developer/app/config"
-	"ai-developer/app/constants"
 	"ai-developer/app/services/git_providers"
 	"fmt"
 	"github.com/go-git/go-git/v5"
@@ -14,7 +12,7 @@ import (
 
 type CodeDownloadService struct {
 	projectService      *ProjectService
-	gitnessService      *git_providers.GitnessService
+	gitProviderResolver *git_providers.GitProviderResolver
 	organisationService *OrganisationService
 	logger              *zap.Logger
 }
@@ -39,20 +37,20 @@ func (cds CodeDownloadService) GetZipFile(projectId uint) (zipFile *string, err
 		return
 	}
 
-	spaceOrProjectName := cds.gitnessService.GetSpaceOrProjectName(org)
-
-	httpPrefix := "https"
-
-	if config.AppEnv() == constants.Development {
-		httpPrefix = "http"
+	gitProvider, err := cds.gitProviderResolver.ForProject(project)
+	if err != nil {
+		return
+	}
+	username, password, err := gitProvider.Credentials()
+	if err != nil {
+		return
 	}
 
-	origin := fmt.Sprintf("%s://%s/git/%s/%s.git", httpPrefix, config.GitnessHost(), spaceOrProjectName, project.Name)
 	_, err = git.PlainClone(tempDir, false, &git.CloneOptions{
-		URL: origin,
+		URL: git_providers.ProjectRemoteURL(gitProvider, org, project),
 		Auth: &http.BasicAuth{
-			Username: config.GitnessUser(),
-			Password: config.GitnessToken(),
+			Username: username,
+			Password: password,
 		},
 	})
 
@@ -82,12 +80,12 @@ func zipDir(source, target string) error {
 func NewCodeDownloadService(
 	logger *zap.Logger,
 	projectService *ProjectService,
-	gitnessService *git_providers.GitnessService,
+	gitProviderResolver *git_providers.GitProviderResolver,
 	organisationService *OrganisationService,
 ) *CodeDownloadService {
 	return &CodeDownloadService{
 		projectService:      projectService,
-		gitnessService:      gitnessService,
+		gitProviderResolver: gitProviderResolver,
 		organisationService: organisationService,
 		logger:              logger.Named("CodeDownloadService"),
 	}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"ai-developer/app/client/workspace"
+	"ai-developer/app/constants"
 	"ai-developer/app/models"
 	"ai-developer/app/repositories"
 	"github.com/hibiken/asynq"
@@ -18,6 +19,7 @@ type ExecutionService struct {
 	workspaceServiceClient *workspace.WorkspaceServiceClient
 	executionStepService   *ExecutionStepService
 	asynqClient            *asynq.Client
+	webhookService         *OrganisationWebhookService
 	logger                 *zap.Logger
 }
 
@@ -29,6 +31,7 @@ func NewExecutionService(
 	activityLogService *ActivityLogService,
 	executionStepService *ExecutionStepService,
 	asynqClient *asynq.Client,
+	webhookService *OrganisationWebhookService,
 	logger *zap.Logger,
 ) *ExecutionService {
 	return &ExecutionService{
@@ -39,6 +42,7 @@ func NewExecutionService(
 		activityLogService:     activityLogService,
 		executionStepService:   executionStepService,
 		asynqClient:            asynqClient,
+		webhookService:         webhookService,
 		logger:                 logger,
 	}
 }
@@ -59,8 +63,41 @@ func (s *ExecutionService) GetExecutionByID(executionID uint) (*models.Execution
 	return execution, nil
 }
 
+// executionStatusEvents are the webhook events published when an execution reaches a status.
+var executionStatusEvents = map[string]string{
+	constants.Done:                    constants.EventExecutionFinished,
+	constants.MaxLoopIterationReached: constants.EventExecutionMaxIterationReached,
+	constants.InReviewLLMKeyNotFound:  constants.EventExecutionFailed,
+}
+
 func (s *ExecutionService) UpdateExecutionStatus(executionID uint, newStatus string) error {
-	return s.ExecutionRepo.UpdateStatus(executionID, newStatus)
+	if err := s.ExecutionRepo.UpdateStatus(executionID, newStatus); err != nil {
+		return err
+	}
+	if event, ok := executionStatusEvents[newStatus]; ok {
+		execution, err := s.ExecutionRepo.GetExecutionByID(executionID)
+		if err != nil {
+			s.logger.Error("Error fetching execution of event", zap.Uint("execution_id", executionID), zap.Error(err))
+			return nil
+		}
+		s.PublishExecutionEvent(execution, event, nil)
+	}
+	return nil
+}
+
+// PublishExecutionEvent publishes an event about the execution of a story to the webhooks of the organisation.
+func (s *ExecutionService) PublishExecutionEvent(execution *models.Execution, event string, data map[string]interface{}) {
+	if execution.StoryID == 0 {
+		return
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	data["execution_id"] = execution.ID
+	data["status"] = execution.Status
+	data["branch_name"] = execution.BranchName
+	data["re_execution"] = execution.ReExecution
+	s.webhookService.PublishStoryEvent(execution.StoryID, event, data)
 }
 
 func (s *ExecutionService) UpdateCommitID(execution *models.Execution, commitID string) error {
@@ -83,6 +120,10 @@ func (s *ExecutionService) CreateExecutionWithTx(tx *gorm.DB, storyID uint, inst
 	return execution, nil
 }
 
+func (s *ExecutionService) GetExecutionByBranchName(branchName string) (*models.Execution, error) {
+	return s.ExecutionRepo.GetExecutionsByBranchName(branchName)
+}
+
 func (s *ExecutionService) GetExecutionsInProgress() ([]*models.Execution, error) {
 	return s.ExecutionRepo.GetExecutionsInProgress()
 }
@@ -36,6 +36,64 @@ func (s *ExecutionStepService) FetchExecutionSteps(executionID uint, name, stepT
 	return s.executionStepRepository.FetchExecutionSteps(executionID, name, stepType, limit)
 }
 
+func (s *ExecutionStepService) FetchAllExecutionSteps(executionID uint) ([]models.ExecutionStep, error) {
+	return s.executionStepRepository.FetchAllExecutionSteps(executionID)
+}
+
+// TokenUsage counts the tokens an execution sent to and received from one model.
+type TokenUsage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// GetExecutionTokenUsage sums the prompt and completion tokens the LLM steps of the execution recorded
+// under "llm_usage" in their responses.
+func (s *ExecutionStepService) GetExecutionTokenUsage(executionID uint) (int, int, error) {
+	usageByModel, err := s.GetExecutionTokenUsageByModel(executionID, "")
+	if err != nil {
+		return 0, 0, err
+	}
+	promptTokens, completionTokens := 0, 0
+	for _, usage := range usageByModel {
+		promptTokens += usage.PromptTokens
+		completionTokens += usage.CompletionTokens
+	}
+	return promptTokens, completionTokens, nil
+}
+
+// GetExecutionTokenUsageByModel sums the tokens the LLM steps of the execution recorded per model. Usage
+// recorded without its model, by steps which ran before the model was stored, is counted under defaultModel.
+func (s *ExecutionStepService) GetExecutionTokenUsageByModel(executionID uint, defaultModel string) (map[string]TokenUsage, error) {
+	executionSteps, err := s.executionStepRepository.FetchAllExecutionSteps(executionID)
+	if err != nil {
+		return nil, err
+	}
+	usageByModel := map[string]TokenUsage{}
+	for _, executionStep := range executionSteps {
+		usage, ok := executionStep.Response["llm_usage"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		model, _ := usage["model"].(string)
+		if model == "" {
+			model = defaultModel
+		}
+		modelUsage := usageByModel[model]
+		if tokens, ok := usage["prompt_tokens"].(float64); ok {
+			modelUsage.PromptTokens += int(tokens)
+		}
+		if tokens, ok := usage["completion_tokens"].(float64); ok {
+			modelUsage.CompletionTokens += int(tokens)
+		}
+		usageByModel[model] = modelUsage
+	}
+	return usageByModel, nil
+}
+
+func (s *ExecutionStepService) FetchLatestExecutionStepOfNames(executionID uint, names []string) (*models.ExecutionStep, error) {
+	return s.executionStepRepository.FetchLatestExecutionStepOfNames(executionID, names)
+}
+
 func (s *ExecutionStepService) CountExecutionStepsOfType(executionID uint, stepType string) (int64, error) {
 	return s.executionStepRepository.CountExecutionStepsOfType(executionID, stepType)
 }
@@ -1,14 +1,142 @@
 package git_providers
 
 import (
-	"ai-developer/app/models/dtos/gitness"
+	"ai-developer/app/config"
+	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/git_provider"
+	"ai-developer/app/models/types"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 )
 
-type GitService interface {
-	CreateProject(name, description string) (*gitness.Project, error)
-	CreateRepository(projectName, repoName, description string) (*gitness.CreateRepositoryResponse, error)
-	CreateBranch(projectName, repoName, branchName string) (*gitness.CreateBranchResponse, error)
-	CreatePullRequest(projectName, repoName, sourceBranch, targetBranch, title, description string) (*gitness.CreatePullRequestResponse, error)
-	MergePullRequest(projectName, repoName string, pullRequestID int, sourceSHA string) (*gitness.MergePullRequestResponse, error)
-	FetchPullRequest(projectName, repoName string, pullRequestID int) (*gitness.FetchPullRequestResponse, error)
+// GitProvider is a git hosting service holding the repositories of projects. Implementations are
+// selected per project through GitProviderResolver.
+type GitProvider interface {
+	// RemoteType identifies the provider, it is stored on projects and pull requests.
+	RemoteType() string
+	CreateRepository(organisation *models.Organisation, project *models.Project) (*git_provider.Repository, error)
+	CreateBranch(organisation *models.Organisation, project *models.Project, branchName, target string) (*git_provider.Branch, error)
+	CreatePullRequest(organisation *models.Organisation, project *models.Project, sourceBranch, targetBranch, title, description string) (*git_provider.PullRequest, error)
+	FetchPullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int) (*git_provider.PullRequest, error)
+	GetPullRequestDiff(organisation *models.Organisation, project *models.Project, fromSHA, toSHA string) (string, error)
+	GetPullRequestCommits(organisation *models.Organisation, project *models.Project, pullRequestNumber int) ([]git_provider.Commit, error)
+	GetBranchCommits(organisation *models.Organisation, project *models.Project, branch string) (*git_provider.BranchCommits, error)
+	// MergePullRequest merges the pull request at sourceSHA with the merge method, one of merge, squash or rebase.
+	MergePullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int, sourceSHA, method string) (*git_provider.MergeResult, error)
+	ClosePullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int) (*git_provider.PullRequest, error)
+	ReopenPullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int) (*git_provider.PullRequest, error)
+	// SetPullRequestDraft converts an open pull request to a draft, or marks it ready for review.
+	SetPullRequestDraft(organisation *models.Organisation, project *models.Project, pullRequestNumber int, draft bool) (*git_provider.PullRequest, error)
+	CreatePullRequestComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, body string) (*git_provider.Comment, error)
+	GetPullRequestComments(organisation *models.Organisation, project *models.Project, pullRequestNumber int) ([]git_provider.Comment, error)
+	// CreatePullRequestLineComment comments on a line of the version of a file at commitSHA, the head of the pull
+	// request when empty. The line must be part of the diff.
+	CreatePullRequestLineComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, commitSHA, path string, line int, body string) (*git_provider.Comment, error)
+	// ResolvePullRequestComment replies to a comment received from the provider and marks its thread resolved.
+	ResolvePullRequestComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, comment git_provider.Comment, reply string) error
+	// RemoteURL is the https clone URL of the project repository, without credentials.
+	RemoteURL(organisation *models.Organisation, project *models.Project) string
+	// Credentials are the basic auth username and password for git over https.
+	Credentials() (string, string, error)
+	// RepositoryPath is the full path of the project repository as webhook payloads of the provider name it.
+	RepositoryPath(organisation *models.Organisation, project *models.Project) string
+	// ParseRemoteURL returns the full path of the repository a clone URL points to, an error wrapping
+	// types.ErrForeignRepositoryURL when the provider does not host it.
+	ParseRemoteURL(remoteURL string) (string, error)
+	// ParseCommentWebhook verifies a webhook delivery and returns the pull request comment it announces,
+	// nil for deliveries of other events.
+	ParseCommentWebhook(header http.Header, body []byte) (*git_provider.CommentEvent, error)
+	// ParsePullRequestWebhook verifies a webhook delivery and returns the pull request change it announces,
+	// nil for deliveries of other events.
+	ParsePullRequestWebhook(header http.Header, body []byte) (*git_provider.PullRequestEvent, error)
+}
+
+// PipelineProvider is implemented by git providers which run CI pipelines for the repositories they host.
+type PipelineProvider interface {
+	// GetPipeline returns the latest pipeline of the ref, nil when no pipeline ran for it.
+	GetPipeline(organisation *models.Organisation, project *models.Project, ref string) (*git_provider.Pipeline, error)
+	// TriggerPipeline runs a pipeline for the head of the ref.
+	TriggerPipeline(organisation *models.Organisation, project *models.Project, ref string) (*git_provider.Pipeline, error)
+}
+
+// ProjectRemoteURL returns the remote URL stored with the project, the repository the provider names after the
+// project otherwise.
+func ProjectRemoteURL(provider GitProvider, organisation *models.Organisation, project *models.Project) string {
+	if project.RemoteURL != "" {
+		return project.RemoteURL
+	}
+	return provider.RemoteURL(organisation, project)
+}
+
+// AuthenticatedRemoteURL returns the remote URL of the project repository with the provider credentials
+// embedded, for git commands that cannot be handed credentials otherwise.
+func AuthenticatedRemoteURL(provider GitProvider, organisation *models.Organisation, project *models.Project) (string, error) {
+	remoteURL, err := url.Parse(ProjectRemoteURL(provider, organisation, project))
+	if err != nil {
+		return "", err
+	}
+	username, password, err := provider.Credentials()
+	if err != nil {
+		return "", err
+	}
+	remoteURL.User = url.UserPassword(username, password)
+	return remoteURL.String(), nil
+}
+
+type GitProviderResolver struct {
+	providers map[string]GitProvider
+}
+
+func NewGitProviderResolver(gitnessService *GitnessService, gitHubService *GitHubService, gitLabService *GitLabService) *GitProviderResolver {
+	resolver := &GitProviderResolver{providers: map[string]GitProvider{}}
+	for _, provider := range []GitProvider{gitnessService, gitHubService, gitLabService} {
+		resolver.providers[provider.RemoteType()] = provider
+	}
+	return resolver
+}
+
+// ForProject returns the provider hosting the project repository, falling back to the configured default.
+func (r *GitProviderResolver) ForProject(project *models.Project) (GitProvider, error) {
+	if project.GitProvider == "" {
+		return r.ForRemoteType(config.DefaultGitProvider())
+	}
+	return r.ForRemoteType(project.GitProvider)
+}
+
+// ForPullRequest returns the provider the pull request was opened on, which stays authoritative even
+// if the project is moved to another provider afterwards.
+func (r *GitProviderResolver) ForPullRequest(pullRequest *models.PullRequest, project *models.Project) (GitProvider, error) {
+	if pullRequest.RemoteType == "" {
+		return r.ForProject(project)
+	}
+	return r.ForRemoteType(pullRequest.RemoteType)
+}
+
+func (r *GitProviderResolver) ForRemoteType(remoteType string) (GitProvider, error) {
+	provider, ok := r.providers[remoteType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported git provider: %s", remoteType)
+	}
+	return provider, nil
+}
+
+// repositoryPathBelow returns the path of the repository the clone URL points to below the base URL of a
+// provider, without the .git suffix. Clone URLs of other hosts, or without an owner and a name, are rejected.
+func repositoryPathBelow(baseURL string, remoteURL string) (string, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	remote, err := url.Parse(remoteURL)
+	if err != nil || base.Host == "" || !strings.EqualFold(remote.Host, base.Host) {
+		return "", fmt.Errorf("%w: %s", types.ErrForeignRepositoryURL, remoteURL)
+	}
+	path, ok := strings.CutPrefix(remote.Path, strings.TrimSuffix(base.Path, "/")+"/")
+	path = strings.Trim(strings.TrimSuffix(strings.TrimSuffix(path, "/"), ".git"), "/")
+	if !ok || !strings.Contains(path, "/") {
+		return "", fmt.Errorf("%w: %s", types.ErrForeignRepositoryURL, remoteURL)
+	}
+	return path, nil
 }
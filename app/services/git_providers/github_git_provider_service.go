@@ -0,0 +1,354 @@
+package git_providers
+
+import (
+	"ai-developer/app/client/github_git_provider"
+	"ai-developer/app/config"
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/git_provider"
+	"ai-developer/app/models/dtos/github"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type GitHubService struct {
+	client *github_git_provider.GitHubClient
+}
+
+func NewGitHubService(client *github_git_provider.GitHubClient) *GitHubService {
+	return &GitHubService{client: client}
+}
+
+func (s *GitHubService) RemoteType() string {
+	return constants.GitHubProvider
+}
+
+func (s *GitHubService) CreateRepository(organisation *models.Organisation, project *models.Project) (*git_provider.Repository, error) {
+	isPrivate := true
+
+	repo, err := s.client.CreateRepository(s.owner(project), project.Name, project.Description, isPrivate)
+	if err != nil {
+		return nil, err
+	}
+	return &git_provider.Repository{
+		ID:            strconv.FormatInt(repo.ID, 10),
+		Name:          repo.Name,
+		Path:          repo.FullName,
+		DefaultBranch: repo.DefaultBranch,
+		CloneURL:      repo.CloneURL,
+	}, nil
+}
+
+func (s *GitHubService) CreateBranch(organisation *models.Organisation, project *models.Project, branchName, target string) (*git_provider.Branch, error) {
+	ref, err := s.client.CreateBranch(s.owner(project), s.name(project), branchName, target)
+	if err != nil {
+		return nil, err
+	}
+	return &git_provider.Branch{Name: strings.TrimPrefix(ref.Ref, "refs/heads/"), SHA: ref.Object.SHA}, nil
+}
+
+func (s *GitHubService) CreatePullRequest(organisation *models.Organisation, project *models.Project, sourceBranch, targetBranch, title, description string) (*git_provider.PullRequest, error) {
+	isDraft := false
+
+	pr, err := s.client.CreatePullRequest(s.owner(project), s.name(project), sourceBranch, targetBranch, title, description, isDraft)
+	if err != nil {
+		return nil, err
+	}
+	return s.toPullRequest(pr), nil
+}
+
+func (s *GitHubService) FetchPullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int) (*git_provider.PullRequest, error) {
+	pr, err := s.client.FetchPullRequest(s.owner(project), s.name(project), pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+	return s.toPullRequest(pr), nil
+}
+
+func (s *GitHubService) MergePullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int, sourceSHA, method string) (*git_provider.MergeResult, error) {
+	merge, err := s.client.MergePullRequest(s.owner(project), s.name(project), pullRequestNumber, method, sourceSHA)
+	if err != nil {
+		return nil, err
+	}
+	if !merge.Merged {
+		return nil, fmt.Errorf("failed to merge pull request: %s", merge.Message)
+	}
+	return &git_provider.MergeResult{SHA: merge.SHA}, nil
+}
+
+func (s *GitHubService) ClosePullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int) (*git_provider.PullRequest, error) {
+	pr, err := s.client.UpdatePullRequestState(s.owner(project), s.name(project), pullRequestNumber, "closed")
+	if err != nil {
+		return nil, err
+	}
+	return s.toPullRequest(pr), nil
+}
+
+func (s *GitHubService) ReopenPullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int) (*git_provider.PullRequest, error) {
+	pr, err := s.client.UpdatePullRequestState(s.owner(project), s.name(project), pullRequestNumber, "open")
+	if err != nil {
+		return nil, err
+	}
+	return s.toPullRequest(pr), nil
+}
+
+func (s *GitHubService) SetPullRequestDraft(organisation *models.Organisation, project *models.Project, pullRequestNumber int, draft bool) (*git_provider.PullRequest, error) {
+	if err := s.client.SetPullRequestDraft(s.owner(project), s.name(project), pullRequestNumber, draft); err != nil {
+		return nil, err
+	}
+	return s.FetchPullRequest(organisation, project, pullRequestNumber)
+}
+
+func (s *GitHubService) GetPullRequestDiff(organisation *models.Organisation, project *models.Project, fromSHA, toSHA string) (string, error) {
+	return s.client.GetCompareDiff(s.owner(project), s.name(project), fromSHA, toSHA)
+}
+
+func (s *GitHubService) GetPullRequestCommits(organisation *models.Organisation, project *models.Project, pullRequestNumber int) ([]git_provider.Commit, error) {
+	githubCommits, err := s.client.GetPullRequestCommits(s.owner(project), s.name(project), pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+	commits := make([]git_provider.Commit, 0, len(githubCommits))
+	for _, commit := range githubCommits {
+		commits = append(commits, s.toCommit(commit))
+	}
+	return commits, nil
+}
+
+func (s *GitHubService) GetBranchCommits(organisation *models.Organisation, project *models.Project, branch string) (*git_provider.BranchCommits, error) {
+	githubCommits, total, err := s.client.GetBranchCommits(s.owner(project), s.name(project), branch)
+	if err != nil {
+		return nil, err
+	}
+	branchCommits := &git_provider.BranchCommits{TotalCommits: total}
+	for _, commit := range githubCommits {
+		branchCommits.Commits = append(branchCommits.Commits, s.toCommit(commit))
+	}
+	return branchCommits, nil
+}
+
+func (s *GitHubService) CreatePullRequestComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, body string) (*git_provider.Comment, error) {
+	githubComment, err := s.client.CreateIssueComment(s.owner(project), s.name(project), pullRequestNumber, body)
+	if err != nil {
+		return nil, err
+	}
+	comment := s.toComment(*githubComment)
+	return &comment, nil
+}
+
+func (s *GitHubService) CreatePullRequestLineComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, commitSHA, path string, line int, body string) (*git_provider.Comment, error) {
+	if commitSHA == "" {
+		pr, err := s.client.FetchPullRequest(s.owner(project), s.name(project), pullRequestNumber)
+		if err != nil {
+			return nil, err
+		}
+		commitSHA = pr.Head.SHA
+	}
+	githubComment, err := s.client.CreateReviewComment(s.owner(project), s.name(project), pullRequestNumber, commitSHA, path, line, body)
+	if err != nil {
+		return nil, err
+	}
+	comment := s.toComment(*githubComment)
+	return &comment, nil
+}
+
+// ResolvePullRequestComment replies in the thread of a review comment and resolves the thread. Conversation
+// comments have no thread to resolve and are left as they are.
+func (s *GitHubService) ResolvePullRequestComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, comment git_provider.Comment, reply string) error {
+	if comment.Path == "" {
+		return nil
+	}
+	commentID, err := strconv.ParseInt(comment.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment id %s: %w", comment.ID, err)
+	}
+	if _, err := s.client.ReplyToReviewComment(s.owner(project), s.name(project), pullRequestNumber, commentID, reply); err != nil {
+		return err
+	}
+	return s.client.ResolveReviewThread(s.owner(project), s.name(project), pullRequestNumber, commentID)
+}
+
+// GetPullRequestComments merges the conversation comments with the review comments on the diff, oldest first.
+func (s *GitHubService) GetPullRequestComments(organisation *models.Organisation, project *models.Project, pullRequestNumber int) ([]git_provider.Comment, error) {
+	issueComments, err := s.client.GetIssueComments(s.owner(project), s.name(project), pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+	reviewComments, err := s.client.GetReviewComments(s.owner(project), s.name(project), pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]git_provider.Comment, 0, len(issueComments)+len(reviewComments))
+	for _, comment := range append(issueComments, reviewComments...) {
+		comments = append(comments, s.toComment(comment))
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+	return comments, nil
+}
+
+func (s *GitHubService) RemoteURL(organisation *models.Organisation, project *models.Project) string {
+	return fmt.Sprintf("https://%s/%s/%s.git", config.GithubHost(), url.PathEscape(s.owner(project)), url.PathEscape(s.name(project)))
+}
+
+// Credentials use the API token as the password, which works for both personal and installation tokens.
+func (s *GitHubService) Credentials() (string, string, error) {
+	token, err := s.client.Token()
+	if err != nil {
+		return "", "", err
+	}
+	return "x-access-token", token, nil
+}
+
+// owner is the account holding the project repository, read from the stored repository path. Projects without
+// one use the configured default.
+func (s *GitHubService) owner(project *models.Project) string {
+	if owner, _, ok := strings.Cut(project.RepositoryPath, "/"); ok {
+		return owner
+	}
+	if project.RepositoryOwner != "" {
+		return project.RepositoryOwner
+	}
+	return config.GithubOwner()
+}
+
+// name is the repository name GitHub stored, which it may have normalised from the project name.
+func (s *GitHubService) name(project *models.Project) string {
+	if _, name, ok := strings.Cut(project.RepositoryPath, "/"); ok {
+		return name
+	}
+	return project.Name
+}
+
+func (s *GitHubService) toPullRequest(pr *github.PullRequest) *git_provider.PullRequest {
+	return &git_provider.PullRequest{
+		Number:         pr.Number,
+		Title:          pr.Title,
+		Description:    pr.Body,
+		State:          pr.State,
+		IsDraft:        pr.Draft,
+		SourceBranch:   pr.Head.Ref,
+		TargetBranch:   pr.Base.Ref,
+		SourceSHA:      pr.Head.SHA,
+		MergeBaseSHA:   pr.Base.SHA,
+		MergeTargetSHA: pr.MergeCommitSHA,
+		URL:            pr.HTMLURL,
+		Merged:         pr.Merged || pr.MergedAt != nil,
+		MergedAt:       pr.MergedAt,
+		ClosedAt:       pr.ClosedAt,
+	}
+}
+
+func (s *GitHubService) toCommit(commit github.Commit) git_provider.Commit {
+	title, _, _ := strings.Cut(commit.Commit.Message, "\n")
+	return git_provider.Commit{
+		SHA:           commit.SHA,
+		Title:         title,
+		Message:       commit.Commit.Message,
+		AuthorName:    commit.Commit.Author.Name,
+		CommitterName: commit.Commit.Committer.Name,
+		CommittedAt:   commit.Commit.Committer.Date,
+	}
+}
+
+func (s *GitHubService) toComment(comment github.Comment) git_provider.Comment {
+	line := 0
+	if comment.Line != nil {
+		line = *comment.Line
+	}
+	return git_provider.Comment{
+		ID:        strconv.FormatInt(comment.ID, 10),
+		Author:    comment.User.Login,
+		Body:      comment.Body,
+		Path:      comment.Path,
+		Line:      line,
+		CreatedAt: comment.CreatedAt,
+	}
+}
+
+func (s *GitHubService) RepositoryPath(organisation *models.Organisation, project *models.Project) string {
+	return s.owner(project) + "/" + s.name(project)
+}
+
+func (s *GitHubService) ParseRemoteURL(remoteURL string) (string, error) {
+	return repositoryPathBelow("https://"+config.GithubHost(), remoteURL)
+}
+
+// ParseCommentWebhook handles the pull_request_review_comment and issue_comment events, the latter only for
+// comments on pull requests.
+func (s *GitHubService) ParseCommentWebhook(header http.Header, body []byte) (*git_provider.CommentEvent, error) {
+	signature := strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+	if err := verifyHMACSignature(config.GithubWebhookSecret(), body, signature); err != nil {
+		return nil, err
+	}
+	event := header.Get("X-GitHub-Event")
+	if event != "pull_request_review_comment" && event != "issue_comment" {
+		return nil, nil
+	}
+	var payload github.CommentWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	if payload.Action != "created" {
+		return nil, nil
+	}
+
+	var pullRequestNumber int
+	switch {
+	case payload.PullRequest != nil:
+		pullRequestNumber = payload.PullRequest.Number
+	case payload.Issue != nil && payload.Issue.PullRequest != nil:
+		pullRequestNumber = payload.Issue.Number
+	default:
+		return nil, nil
+	}
+	return &git_provider.CommentEvent{
+		RepositoryPath:    payload.Repository.FullName,
+		PullRequestNumber: pullRequestNumber,
+		Comment:           s.toComment(payload.Comment),
+	}, nil
+}
+
+var githubPullRequestActions = map[string]string{
+	"opened":           git_provider.PullRequestOpened,
+	"ready_for_review": git_provider.PullRequestOpened,
+	"synchronize":      git_provider.PullRequestUpdated,
+	"reopened":         git_provider.PullRequestReopened,
+	"closed":           git_provider.PullRequestClosed,
+}
+
+// ParsePullRequestWebhook handles the pull_request event, closed pull requests which were merged are reported as merged.
+func (s *GitHubService) ParsePullRequestWebhook(header http.Header, body []byte) (*git_provider.PullRequestEvent, error) {
+	signature := strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+	if err := verifyHMACSignature(config.GithubWebhookSecret(), body, signature); err != nil {
+		return nil, err
+	}
+	if header.Get("X-GitHub-Event") != "pull_request" {
+		return nil, nil
+	}
+	var payload github.PullRequestWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	action, ok := githubPullRequestActions[payload.Action]
+	if !ok {
+		return nil, nil
+	}
+	if action == git_provider.PullRequestClosed && payload.PullRequest.Merged {
+		action = git_provider.PullRequestMerged
+	}
+	return &git_provider.PullRequestEvent{
+		RepositoryPath:    payload.Repository.FullName,
+		PullRequestNumber: payload.PullRequest.Number,
+		Action:            action,
+		SourceSHA:         payload.PullRequest.Head.SHA,
+		Author:            payload.PullRequest.User.Login,
+	}, nil
+}
@@ -0,0 +1,546 @@
+package git_providers
+
+import (
+	"ai-developer/app/client/gitlab_git_provider"
+	"ai-developer/app/config"
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/git_provider"
+	"ai-developer/app/models/dtos/gitlab"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type GitLabService struct {
+	client *gitlab_git_provider.GitLabClient
+}
+
+func NewGitLabService(client *gitlab_git_provider.GitLabClient) *GitLabService {
+	return &GitLabService{client: client}
+}
+
+func (s *GitLabService) RemoteType() string {
+	return constants.GitLabProvider
+}
+
+// CreateRepository creates the project in the group of the organisation, creating the group first
+// when the organisation has none yet.
+func (s *GitLabService) CreateRepository(organisation *models.Organisation, project *models.Project) (*git_provider.Repository, error) {
+	group, err := s.ensureGroup(organisation)
+	if err != nil {
+		return nil, err
+	}
+	defaultBranch := project.DefaultBranch
+
+	gitlabProject, err := s.client.CreateProject(group.ID, project.Name, s.projectSlug(project), project.Description, defaultBranch)
+	if err != nil {
+		return nil, err
+	}
+	return &git_provider.Repository{
+		ID:            strconv.Itoa(gitlabProject.ID),
+		Name:          gitlabProject.Path,
+		Path:          gitlabProject.PathWithNamespace,
+		DefaultBranch: gitlabProject.DefaultBranch,
+		CloneURL:      gitlabProject.HTTPURLToRepo,
+	}, nil
+}
+
+func (s *GitLabService) ensureGroup(organisation *models.Organisation) (*gitlab.Group, error) {
+	group, err := s.client.GetGroup(s.GroupPath(organisation))
+	if err != nil || group != nil {
+		return group, err
+	}
+
+	parentID := 0
+	if parentPath := config.GitlabParentGroup(); parentPath != "" {
+		parent, err := s.client.GetGroup(parentPath)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, fmt.Errorf("gitlab parent group %s does not exist", parentPath)
+		}
+		parentID = parent.ID
+	}
+	fmt.Printf("Creating GitLab group for organisation %s\n", organisation.Name)
+	return s.client.CreateGroup(organisation.Name, s.groupSlug(organisation), parentID)
+}
+
+func (s *GitLabService) CreateBranch(organisation *models.Organisation, project *models.Project, branchName, target string) (*git_provider.Branch, error) {
+	branch, err := s.client.CreateBranch(s.projectPath(organisation, project), branchName, target)
+	if err != nil {
+		return nil, err
+	}
+	return &git_provider.Branch{Name: branch.Name, SHA: branch.Commit.ID}, nil
+}
+
+func (s *GitLabService) CreatePullRequest(organisation *models.Organisation, project *models.Project, sourceBranch, targetBranch, title, description string) (*git_provider.PullRequest, error) {
+	mergeRequest, err := s.client.CreateMergeRequest(s.projectPath(organisation, project), sourceBranch, targetBranch, title, description)
+	if err != nil {
+		return nil, err
+	}
+	return s.toPullRequest(mergeRequest), nil
+}
+
+func (s *GitLabService) FetchPullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int) (*git_provider.PullRequest, error) {
+	mergeRequest, err := s.client.FetchMergeRequest(s.projectPath(organisation, project), pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+	return s.toPullRequest(mergeRequest), nil
+}
+
+// MergePullRequest accepts the merge request. GitLab merges with the merge commit or fast-forward method set
+// on the project, the rebase method rebases the source branch first and squash squashes its commits.
+func (s *GitLabService) MergePullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int, sourceSHA, method string) (*git_provider.MergeResult, error) {
+	projectPath := s.projectPath(organisation, project)
+	if method == constants.MergeMethodRebase {
+		rebasedSHA, err := s.rebase(projectPath, pullRequestNumber)
+		if err != nil {
+			return nil, err
+		}
+		sourceSHA = rebasedSHA
+	}
+	squash := method == constants.MergeMethodSquash
+
+	mergeRequest, err := s.client.AcceptMergeRequest(projectPath, pullRequestNumber, squash, sourceSHA)
+	if err != nil {
+		return nil, err
+	}
+	if mergeRequest.State != "merged" {
+		return nil, fmt.Errorf("failed to merge merge request, state: %s", mergeRequest.State)
+	}
+	sha := mergeRequest.SquashCommitSHA
+	if sha == "" {
+		sha = mergeRequest.MergeCommitSHA
+	}
+	return &git_provider.MergeResult{SHA: sha}, nil
+}
+
+const (
+	rebasePollInterval = 2 * time.Second
+	rebasePollAttempts = 30
+)
+
+// rebase rebases the source branch of the merge request and waits for GitLab to finish, returning the rebased head.
+func (s *GitLabService) rebase(projectPath string, pullRequestNumber int) (string, error) {
+	if err := s.client.RebaseMergeRequest(projectPath, pullRequestNumber); err != nil {
+		return "", err
+	}
+	for attempt := 0; attempt < rebasePollAttempts; attempt++ {
+		time.Sleep(rebasePollInterval)
+		mergeRequest, err := s.client.FetchMergeRequestRebaseStatus(projectPath, pullRequestNumber)
+		if err != nil {
+			return "", err
+		}
+		if mergeRequest.RebaseInProgress {
+			continue
+		}
+		if mergeRequest.MergeError != "" {
+			return "", fmt.Errorf("failed to rebase merge request: %s", mergeRequest.MergeError)
+		}
+		return mergeRequest.SHA, nil
+	}
+	return "", fmt.Errorf("rebase of merge request %d did not finish in time", pullRequestNumber)
+}
+
+func (s *GitLabService) ClosePullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int) (*git_provider.PullRequest, error) {
+	mergeRequest, err := s.client.UpdateMergeRequest(s.projectPath(organisation, project), pullRequestNumber, gitlab.UpdateMergeRequestPayload{StateEvent: "close"})
+	if err != nil {
+		return nil, err
+	}
+	return s.toPullRequest(mergeRequest), nil
+}
+
+func (s *GitLabService) ReopenPullRequest(organisation *models.Organisation, project *models.Project, pullRequestNumber int) (*git_provider.PullRequest, error) {
+	mergeRequest, err := s.client.UpdateMergeRequest(s.projectPath(organisation, project), pullRequestNumber, gitlab.UpdateMergeRequestPayload{StateEvent: "reopen"})
+	if err != nil {
+		return nil, err
+	}
+	return s.toPullRequest(mergeRequest), nil
+}
+
+// SetPullRequestDraft adds or removes the "Draft:" title prefix GitLab marks drafts with.
+func (s *GitLabService) SetPullRequestDraft(organisation *models.Organisation, project *models.Project, pullRequestNumber int, draft bool) (*git_provider.PullRequest, error) {
+	mergeRequest, err := s.client.FetchMergeRequest(s.projectPath(organisation, project), pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+	if mergeRequest.Draft == draft {
+		return s.toPullRequest(mergeRequest), nil
+	}
+	title := draftTitlePattern.ReplaceAllString(mergeRequest.Title, "")
+	if draft {
+		title = "Draft: " + title
+	}
+	mergeRequest, err = s.client.UpdateMergeRequest(s.projectPath(organisation, project), pullRequestNumber, gitlab.UpdateMergeRequestPayload{Title: title})
+	if err != nil {
+		return nil, err
+	}
+	return s.toPullRequest(mergeRequest), nil
+}
+
+// GetPullRequestDiff returns the unified diff from the merge base of fromSHA and toSHA. GitLab only
+// returns the hunks per file, so the file headers are rebuilt in git's format.
+func (s *GitLabService) GetPullRequestDiff(organisation *models.Organisation, project *models.Project, fromSHA, toSHA string) (string, error) {
+	compare, err := s.client.Compare(s.projectPath(organisation, project), fromSHA, toSHA)
+	if err != nil {
+		return "", err
+	}
+
+	var diff strings.Builder
+	for _, fileDiff := range compare.Diffs {
+		fmt.Fprintf(&diff, "diff --git a/%s b/%s\n", fileDiff.OldPath, fileDiff.NewPath)
+		oldPath, newPath := "a/"+fileDiff.OldPath, "b/"+fileDiff.NewPath
+		switch {
+		case fileDiff.NewFile:
+			fmt.Fprintf(&diff, "new file mode %s\n", fileDiff.BMode)
+			oldPath = "/dev/null"
+		case fileDiff.DeletedFile:
+			fmt.Fprintf(&diff, "deleted file mode %s\n", fileDiff.AMode)
+			newPath = "/dev/null"
+		case fileDiff.RenamedFile:
+			fmt.Fprintf(&diff, "rename from %s\nrename to %s\n", fileDiff.OldPath, fileDiff.NewPath)
+		}
+		if fileDiff.Diff == "" {
+			continue
+		}
+		fmt.Fprintf(&diff, "--- %s\n+++ %s\n", oldPath, newPath)
+		diff.WriteString(fileDiff.Diff)
+		if !strings.HasSuffix(fileDiff.Diff, "\n") {
+			diff.WriteString("\n")
+		}
+	}
+	return diff.String(), nil
+}
+
+func (s *GitLabService) GetPullRequestCommits(organisation *models.Organisation, project *models.Project, pullRequestNumber int) ([]git_provider.Commit, error) {
+	gitlabCommits, err := s.client.GetMergeRequestCommits(s.projectPath(organisation, project), pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+	commits := make([]git_provider.Commit, 0, len(gitlabCommits))
+	for _, commit := range gitlabCommits {
+		commits = append(commits, s.toCommit(commit))
+	}
+	return commits, nil
+}
+
+func (s *GitLabService) GetBranchCommits(organisation *models.Organisation, project *models.Project, branch string) (*git_provider.BranchCommits, error) {
+	gitlabCommits, total, err := s.client.GetBranchCommits(s.projectPath(organisation, project), branch)
+	if err != nil {
+		return nil, err
+	}
+	branchCommits := &git_provider.BranchCommits{TotalCommits: total}
+	for _, commit := range gitlabCommits {
+		branchCommits.Commits = append(branchCommits.Commits, s.toCommit(commit))
+	}
+	return branchCommits, nil
+}
+
+func (s *GitLabService) CreatePullRequestComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, body string) (*git_provider.Comment, error) {
+	note, err := s.client.CreateMergeRequestNote(s.projectPath(organisation, project), pullRequestNumber, body)
+	if err != nil {
+		return nil, err
+	}
+	comment := s.toComment(*note)
+	return &comment, nil
+}
+
+// CreatePullRequestLineComment starts a discussion on the line. GitLab positions refer to a version of the merge
+// request diff rather than a commit, so the comment is placed on the latest version when commitSHA is no longer
+// the head of the merge request.
+func (s *GitLabService) CreatePullRequestLineComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, commitSHA, path string, line int, body string) (*git_provider.Comment, error) {
+	projectPath := s.projectPath(organisation, project)
+	mergeRequest, err := s.client.FetchMergeRequest(projectPath, pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+	discussion, err := s.client.CreateMergeRequestDiscussion(projectPath, pullRequestNumber, body, &gitlab.DiscussionPosition{
+		PositionType: "text",
+		BaseSHA:      mergeRequest.DiffRefs.BaseSHA,
+		StartSHA:     mergeRequest.DiffRefs.StartSHA,
+		HeadSHA:      mergeRequest.DiffRefs.HeadSHA,
+		OldPath:      path,
+		NewPath:      path,
+		NewLine:      line,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(discussion.Notes) == 0 {
+		return nil, fmt.Errorf("merge request discussion has no notes")
+	}
+	comment := s.toComment(discussion.Notes[0])
+	return &comment, nil
+}
+
+// ResolvePullRequestComment replies in the discussion of the note and resolves it when it is resolvable.
+func (s *GitLabService) ResolvePullRequestComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, comment git_provider.Comment, reply string) error {
+	projectPath := s.projectPath(organisation, project)
+	discussions, err := s.client.GetMergeRequestDiscussions(projectPath, pullRequestNumber)
+	if err != nil {
+		return err
+	}
+	for _, discussion := range discussions {
+		for _, note := range discussion.Notes {
+			if strconv.Itoa(note.ID) != comment.ID {
+				continue
+			}
+			if _, err := s.client.CreateDiscussionNote(projectPath, pullRequestNumber, discussion.ID, reply); err != nil {
+				return err
+			}
+			if !note.Resolvable || note.Resolved {
+				return nil
+			}
+			return s.client.ResolveDiscussion(projectPath, pullRequestNumber, discussion.ID)
+		}
+	}
+	return fmt.Errorf("no discussion found for note %s", comment.ID)
+}
+
+// GetPullRequestComments returns the notes of all merge request discussions, leaving out system notes, oldest first.
+func (s *GitLabService) GetPullRequestComments(organisation *models.Organisation, project *models.Project, pullRequestNumber int) ([]git_provider.Comment, error) {
+	discussions, err := s.client.GetMergeRequestDiscussions(s.projectPath(organisation, project), pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []git_provider.Comment
+	for _, discussion := range discussions {
+		for _, note := range discussion.Notes {
+			if note.System {
+				continue
+			}
+			comments = append(comments, s.toComment(note))
+		}
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+	return comments, nil
+}
+
+func (s *GitLabService) RemoteURL(organisation *models.Organisation, project *models.Project) string {
+	return fmt.Sprintf("%s/%s.git", strings.TrimSuffix(config.GitlabURL(), "/"), s.projectPath(organisation, project))
+}
+
+func (s *GitLabService) Credentials() (string, string, error) {
+	if config.GitlabToken() == "" {
+		return "", "", fmt.Errorf("gitlab token is not configured")
+	}
+	return config.GitlabUser(), config.GitlabToken(), nil
+}
+
+var groupSlugPattern = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// draftTitlePattern matches the title prefixes GitLab marks draft merge requests with.
+var draftTitlePattern = regexp.MustCompile(`^\s*(?i:\[draft\]|\(draft\)|draft:|draft\s-|\[wip\]|wip:)\s*`)
+
+// groupSlug is the path of the organisation group. The id keeps it unique when names only differ in
+// characters GitLab does not allow in paths.
+func (s *GitLabService) groupSlug(organisation *models.Organisation) string {
+	name := strings.Trim(groupSlugPattern.ReplaceAllString(organisation.Name, "-"), "-._")
+	return name + "_" + strconv.Itoa(int(organisation.ID))
+}
+
+// GroupPath is the full path of the organisation group, nested under the configured parent group.
+func (s *GitLabService) GroupPath(organisation *models.Organisation) string {
+	if parentPath := strings.Trim(config.GitlabParentGroup(), "/"); parentPath != "" {
+		return parentPath + "/" + s.groupSlug(organisation)
+	}
+	return s.groupSlug(organisation)
+}
+
+func (s *GitLabService) RepositoryPath(organisation *models.Organisation, project *models.Project) string {
+	return s.projectPath(organisation, project)
+}
+
+func (s *GitLabService) ParseRemoteURL(remoteURL string) (string, error) {
+	return repositoryPathBelow(config.GitlabURL(), remoteURL)
+}
+
+// ParseCommentWebhook handles the Note Hook event for notes on merge requests, leaving out system notes.
+func (s *GitLabService) ParseCommentWebhook(header http.Header, body []byte) (*git_provider.CommentEvent, error) {
+	secret := config.GitlabWebhookSecret()
+	if secret == "" {
+		return nil, ErrWebhookSecretNotConfigured
+	}
+	if subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), []byte(secret)) != 1 {
+		return nil, ErrInvalidWebhookSignature
+	}
+	if header.Get("X-Gitlab-Event") != "Note Hook" {
+		return nil, nil
+	}
+	var payload gitlab.NoteWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	attributes := payload.ObjectAttributes
+	if attributes.NoteableType != "MergeRequest" || attributes.System || payload.MergeRequest == nil {
+		return nil, nil
+	}
+
+	note := gitlab.Note{
+		ID:        attributes.ID,
+		Body:      attributes.Note,
+		Author:    payload.User,
+		Position:  attributes.Position,
+		CreatedAt: time.Now(),
+	}
+	// Webhooks use GitLab's own "2006-01-02 15:04:05 UTC" format rather than RFC 3339.
+	for _, layout := range []string{"2006-01-02 15:04:05 MST", time.RFC3339} {
+		if createdAt, err := time.Parse(layout, attributes.CreatedAt); err == nil {
+			note.CreatedAt = createdAt
+			break
+		}
+	}
+	return &git_provider.CommentEvent{
+		RepositoryPath:    payload.Project.PathWithNamespace,
+		PullRequestNumber: payload.MergeRequest.IID,
+		Comment:           s.toComment(note),
+	}, nil
+}
+
+var gitlabMergeRequestActions = map[string]string{
+	"open":   git_provider.PullRequestOpened,
+	"update": git_provider.PullRequestUpdated,
+	"reopen": git_provider.PullRequestReopened,
+	"close":  git_provider.PullRequestClosed,
+	"merge":  git_provider.PullRequestMerged,
+}
+
+// ParsePullRequestWebhook handles the Merge Request Hook event. Updates which do not push commits, e.g. title
+// or label changes, are left out.
+func (s *GitLabService) ParsePullRequestWebhook(header http.Header, body []byte) (*git_provider.PullRequestEvent, error) {
+	secret := config.GitlabWebhookSecret()
+	if secret == "" {
+		return nil, ErrWebhookSecretNotConfigured
+	}
+	if subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), []byte(secret)) != 1 {
+		return nil, ErrInvalidWebhookSignature
+	}
+	if header.Get("X-Gitlab-Event") != "Merge Request Hook" {
+		return nil, nil
+	}
+	var payload gitlab.MergeRequestWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	attributes := payload.ObjectAttributes
+	action, ok := gitlabMergeRequestActions[attributes.Action]
+	if !ok || (action == git_provider.PullRequestUpdated && attributes.OldRev == "") {
+		return nil, nil
+	}
+	return &git_provider.PullRequestEvent{
+		RepositoryPath:    payload.Project.PathWithNamespace,
+		PullRequestNumber: attributes.IID,
+		Action:            action,
+		SourceSHA:         attributes.LastCommit.ID,
+		Author:            payload.User.Username,
+	}, nil
+}
+
+// projectPath is the full path of the project on GitLab: the path GitLab reported when creating the project,
+// the path of the remote URL of a project using an existing repository, or else the path the project is
+// created at in the organisation group.
+func (s *GitLabService) projectPath(organisation *models.Organisation, project *models.Project) string {
+	if project.RepositoryPath != "" {
+		return project.RepositoryPath
+	}
+	if path, ok := strings.CutPrefix(project.RemoteURL, strings.TrimSuffix(config.GitlabURL(), "/")+"/"); ok {
+		return strings.TrimSuffix(path, ".git")
+	}
+	return s.GroupPath(organisation) + "/" + s.projectSlug(project)
+}
+
+// projectSlug is the path of the project in the organisation group, the name with the characters GitLab
+// does not allow in paths replaced.
+func (s *GitLabService) projectSlug(project *models.Project) string {
+	return strings.Trim(groupSlugPattern.ReplaceAllString(project.Name, "-"), "-._")
+}
+
+// GetPipeline returns the latest pipeline of the ref, nil when no pipeline ran for it.
+func (s *GitLabService) GetPipeline(organisation *models.Organisation, project *models.Project, ref string) (*git_provider.Pipeline, error) {
+	pipeline, err := s.client.GetLatestPipeline(s.projectPath(organisation, project), ref)
+	if err != nil || pipeline == nil {
+		return nil, err
+	}
+	return s.toPipeline(pipeline), nil
+}
+
+// TriggerPipeline runs the CI/CD configuration of the project for the head of the ref.
+func (s *GitLabService) TriggerPipeline(organisation *models.Organisation, project *models.Project, ref string) (*git_provider.Pipeline, error) {
+	pipeline, err := s.client.CreatePipeline(s.projectPath(organisation, project), ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.toPipeline(pipeline), nil
+}
+
+func (s *GitLabService) toPipeline(pipeline *gitlab.Pipeline) *git_provider.Pipeline {
+	return &git_provider.Pipeline{
+		ID:     pipeline.ID,
+		Ref:    pipeline.Ref,
+		SHA:    pipeline.SHA,
+		Status: pipeline.Status,
+		URL:    pipeline.WebURL,
+	}
+}
+
+func (s *GitLabService) toPullRequest(mergeRequest *gitlab.MergeRequest) *git_provider.PullRequest {
+	mergeTargetSHA := mergeRequest.SquashCommitSHA
+	if mergeTargetSHA == "" {
+		mergeTargetSHA = mergeRequest.MergeCommitSHA
+	}
+	return &git_provider.PullRequest{
+		Number:         mergeRequest.IID,
+		Title:          mergeRequest.Title,
+		Description:    mergeRequest.Description,
+		State:          mergeRequest.State,
+		IsDraft:        mergeRequest.Draft,
+		SourceBranch:   mergeRequest.SourceBranch,
+		TargetBranch:   mergeRequest.TargetBranch,
+		SourceSHA:      mergeRequest.SHA,
+		MergeBaseSHA:   mergeRequest.DiffRefs.BaseSHA,
+		MergeTargetSHA: mergeTargetSHA,
+		URL:            mergeRequest.WebURL,
+		Merged:         mergeRequest.State == "merged",
+		MergedAt:       mergeRequest.MergedAt,
+		ClosedAt:       mergeRequest.ClosedAt,
+	}
+}
+
+func (s *GitLabService) toCommit(commit gitlab.Commit) git_provider.Commit {
+	return git_provider.Commit{
+		SHA:           commit.ID,
+		Title:         commit.Title,
+		Message:       commit.Message,
+		AuthorName:    commit.AuthorName,
+		CommitterName: commit.CommitterName,
+		CommittedAt:   commit.CommittedDate,
+	}
+}
+
+func (s *GitLabService) toComment(note gitlab.Note) git_provider.Comment {
+	comment := git_provider.Comment{
+		ID:        strconv.Itoa(note.ID),
+		Author:    note.Author.Username,
+		Body:      note.Body,
+		CreatedAt: note.CreatedAt,
+	}
+	if note.Position != nil {
+		comment.Path = note.Position.NewPath
+		if note.Position.NewLine != nil {
+			comment.Line = *note.Position.NewLine
+		}
+	}
+	return comment
+}
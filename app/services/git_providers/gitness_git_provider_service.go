@@ -2,10 +2,16 @@ package git_providers
 
 import (
 	"ai-developer/app/client/git_provider"
+	"ai-developer/app/config"
+	"ai-developer/app/constants"
 	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/git_provider"
 	"ai-developer/app/models/dtos/gitness"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
+	"time"
 )
 
 type GitnessService struct {
@@ -16,6 +22,10 @@ func NewGitnessService(client *gitness_git_provider.GitnessClient) *GitnessServi
 	return &GitnessService{client: client}
 }
 
+func (s *GitnessService) RemoteType() string {
+	return constants.GitnessProvider
+}
+
 func (s *GitnessService) CreateProject(name, description string) (*gitness.Project, error) {
 	fmt.Printf("Creating Gitness project %s\n", name)
 	project, err := s.client.CreateProject(name, description)
@@ -25,94 +35,337 @@ func (s *GitnessService) CreateProject(name, description string) (*gitness.Proje
 	return project, nil
 }
 
-func (s *GitnessService) CreateRepository(projectName, repoName, description string) (*gitness.CreateRepositoryResponse, error) {
-	defaultBranch := "main"
+func (s *GitnessService) CreateRepository(organisation *models.Organisation, project *models.Project) (*git_provider.Repository, error) {
+	defaultBranch := project.DefaultBranch
 	license := "none"
 	isPublic := true
 	readme := false
 
-	repo, err := s.client.CreateRepository(projectName, repoName, description, defaultBranch, license, isPublic, readme)
+	repo, err := s.client.CreateRepository(s.GetSpaceOrProjectName(organisation), project.Name, project.Description, defaultBranch, license, isPublic, readme)
 	if err != nil {
 		return nil, err
 	}
-	return repo, nil
+	return &git_provider.Repository{
+		ID:            strconv.Itoa(repo.ID),
+		Name:          repo.Identifier,
+		Path:          repo.Path,
+		DefaultBranch: repo.DefaultBranch,
+		CloneURL:      repo.GitURL,
+	}, nil
 }
 
-func (s *GitnessService) CreateBranch(projectName, repoName, branchName string) (*gitness.CreateBranchResponse, error) {
-	repoPath := fmt.Sprintf("%s/%s", projectName, repoName)
-	target := "refs/heads/main"
+func (s *GitnessService) CreateBranch(organisation *models.Organisation, project *models.Project, branchName, target string) (*git_provider.Branch, error) {
 	bypassRules := false
 
-	branch, err := s.client.CreateBranch(repoPath, branchName, target, bypassRules)
+	branch, err := s.client.CreateBranch(s.repoPath(organisation, project), branchName, "refs/heads/"+target, bypassRules)
 	if err != nil {
 		return nil, err
 	}
-	return branch, nil
+	return &git_provider.Branch{Name: branch.Name, SHA: branch.SHA}, nil
 }
 
-func (s *GitnessService) CreatePullRequest(projectName, repoName, sourceBranch, targetBranch, title, description string) (*gitness.CreatePullRequestResponse, error) {
-	fmt.Printf("Creating pull request for %s/%s\n", projectName, repoName)
-	repoPath := fmt.Sprintf("%s/%s", projectName, repoName)
+func (s *GitnessService) CreatePullRequest(organisation *models.Organisation, project *models.Project, sourceBranch, targetBranch, title, description string) (*git_provider.PullRequest, error) {
+	fmt.Printf("Creating pull request for %s\n", s.repoPath(organisation, project))
 	isDraft := false
 
-	pr, err := s.client.CreatePullRequest(repoPath, sourceBranch, targetBranch, title, description, isDraft)
-	fmt.Println("Created pull request: ", pr)
+	pr, err := s.client.CreatePullRequest(s.repoPath(organisation, project), sourceBranch, targetBranch, title, description, isDraft)
 	if err != nil {
 		fmt.Printf("Error creating pull request from gitservice: %v\n", err)
 		return nil, err
 	}
-	return pr, nil
+	fmt.Println("Created pull request: ", pr)
+	return &git_provider.PullRequest{
+		Number:       pr.Number,
+		Title:        pr.Title,
+		Description:  pr.Description,
+		State:        pr.State,
+		IsDraft:      pr.IsDraft,
+		SourceBranch: pr.SourceBranch,
+		TargetBranch: pr.TargetBranch,
+		SourceSHA:    pr.SourceSHA,
+		MergeBaseSHA: pr.MergeBaseSHA,
+	}, nil
 }
 
-func (s *GitnessService) MergePullRequest(projectName, repoName string, pullRequestID int, sourceSHA string) (*gitness.MergePullRequestResponse, error) {
-	fmt.Println("Project Name: ", projectName)
-	fmt.Println("Repo Name: ", repoName)
-	fmt.Println("Pull Request ID: ", pullRequestID)
-	fmt.Println("Source SHA: ", sourceSHA)
-
-	repoPath := fmt.Sprintf("%s/%s", projectName, repoName)
-	method := "squash"
+func (s *GitnessService) MergePullRequest(organisation *models.Organisation, project *models.Project, pullRequestID int, sourceSHA, method string) (*git_provider.MergeResult, error) {
 	bypassRules := false
 	dryRun := false
 
-	merge, err := s.client.MergePullRequest(repoPath, pullRequestID, method, sourceSHA, bypassRules, dryRun)
+	merge, err := s.client.MergePullRequest(s.repoPath(organisation, project), pullRequestID, method, sourceSHA, bypassRules, dryRun)
+	if err != nil {
+		return nil, err
+	}
 	fmt.Println("Merged pull request: ", merge)
+	return &git_provider.MergeResult{SHA: merge.SHA}, nil
+}
+
+func (s *GitnessService) FetchPullRequest(organisation *models.Organisation, project *models.Project, pullRequestID int) (*git_provider.PullRequest, error) {
+	pr, err := s.client.FetchPullRequest(s.repoPath(organisation, project), pullRequestID)
 	if err != nil {
 		return nil, err
 	}
-	return merge, nil
+	return s.toPullRequest(pr), nil
 }
 
-func (s *GitnessService) FetchPullRequest(projectName, repoName string, pullRequestID int) (*gitness.FetchPullRequestResponse, error) {
-	repoPath := fmt.Sprintf("%s/%s", projectName, repoName)
+func (s *GitnessService) ClosePullRequest(organisation *models.Organisation, project *models.Project, pullRequestID int) (*git_provider.PullRequest, error) {
+	pr, err := s.client.UpdatePullRequestState(s.repoPath(organisation, project), pullRequestID, "closed", false)
+	if err != nil {
+		return nil, err
+	}
+	return s.toPullRequest(pr), nil
+}
 
-	pr, err := s.client.FetchPullRequest(repoPath, pullRequestID)
+func (s *GitnessService) ReopenPullRequest(organisation *models.Organisation, project *models.Project, pullRequestID int) (*git_provider.PullRequest, error) {
+	pr, err := s.client.UpdatePullRequestState(s.repoPath(organisation, project), pullRequestID, "open", false)
 	if err != nil {
 		return nil, err
 	}
-	return pr, nil
+	return s.toPullRequest(pr), nil
 }
 
-func (s *GitnessService) FetchPullRequestCommits(projectName, repoName string, pullRequestNumber int) (string, error) {
-	repoPath := fmt.Sprintf("%s/%s", projectName, repoName)
-	commitsResponse, err := s.client.GetPullRequestCommits(repoPath, pullRequestNumber)
+func (s *GitnessService) SetPullRequestDraft(organisation *models.Organisation, project *models.Project, pullRequestID int, draft bool) (*git_provider.PullRequest, error) {
+	pr, err := s.client.UpdatePullRequestState(s.repoPath(organisation, project), pullRequestID, "open", draft)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return commitsResponse, nil
+	return s.toPullRequest(pr), nil
 }
 
-func (s *GitnessService) GetPullRequestDiff(projectName, repoName, fromSHA, toSHA string) (string, error) {
-	repoPath := fmt.Sprintf("%s/%s", projectName, repoName)
+func (s *GitnessService) toPullRequest(pr *gitness.FetchPullRequestResponse) *git_provider.PullRequest {
+	return &git_provider.PullRequest{
+		Number:         pr.Number,
+		Title:          pr.Title,
+		Description:    pr.Description,
+		State:          pr.State,
+		IsDraft:        pr.IsDraft,
+		SourceBranch:   pr.SourceBranch,
+		TargetBranch:   pr.TargetBranch,
+		SourceSHA:      pr.SourceSHA,
+		MergeBaseSHA:   pr.MergeBaseSHA,
+		MergeTargetSHA: pr.MergeTargetSHA,
+		Merged:         pr.Merged != 0,
+		MergedAt:       unixMilliTime(pr.Merged),
+		ClosedAt:       unixMilliTime(pr.Closed),
+	}
+}
 
-	diff, err := s.client.GetPullRequestDiff(repoPath, fromSHA, toSHA)
+func (s *GitnessService) GetPullRequestCommits(organisation *models.Organisation, project *models.Project, pullRequestNumber int) ([]git_provider.Commit, error) {
+	commitsResponse, err := s.client.GetPullRequestCommits(s.repoPath(organisation, project), pullRequestNumber)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	var gitnessCommits []gitness.Commit
+	if err := json.Unmarshal([]byte(commitsResponse), &gitnessCommits); err != nil {
+		return nil, err
+	}
+
+	commits := make([]git_provider.Commit, 0, len(gitnessCommits))
+	for _, commit := range gitnessCommits {
+		committedAt, err := time.Parse(time.RFC3339, commit.Committer.When)
+		if err != nil {
+			fmt.Println("Error parsing commit time:", err)
+			continue
+		}
+		commits = append(commits, git_provider.Commit{
+			SHA:           commit.SHA,
+			Title:         commit.Title,
+			Message:       commit.Message,
+			AuthorName:    commit.Author.Identity.Name,
+			CommitterName: commit.Committer.Identity.Name,
+			CommittedAt:   committedAt,
+		})
+	}
+	return commits, nil
+}
 
+func (s *GitnessService) GetPullRequestDiff(organisation *models.Organisation, project *models.Project, fromSHA, toSHA string) (string, error) {
+	diff, err := s.client.GetPullRequestDiff(s.repoPath(organisation, project), fromSHA, toSHA)
+	if err != nil {
+		return "", err
+	}
 	return diff, nil
 }
 
+func (s *GitnessService) GetBranchCommits(organisation *models.Organisation, project *models.Project, branch string) (*git_provider.BranchCommits, error) {
+	commitResponse, err := s.client.GetBranchCommits(s.repoPath(organisation, project), branch)
+	if err != nil {
+		return nil, err
+	}
+
+	branchCommits := &git_provider.BranchCommits{TotalCommits: commitResponse.TotalCommits}
+	for _, commit := range commitResponse.Commits {
+		branchCommits.Commits = append(branchCommits.Commits, git_provider.Commit{
+			SHA:           commit.SHA,
+			Title:         commit.Title,
+			Message:       commit.Message,
+			AuthorName:    commit.Author.Identity.Name,
+			CommitterName: commit.Committer.Identity.Name,
+			CommittedAt:   commit.Committer.When,
+		})
+	}
+	return branchCommits, nil
+}
+
+func (s *GitnessService) CreatePullRequestComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, body string) (*git_provider.Comment, error) {
+	activity, err := s.client.CreatePullRequestComment(s.repoPath(organisation, project), pullRequestNumber, body, 0)
+	if err != nil {
+		return nil, err
+	}
+	comment := s.activityToComment(*activity)
+	return &comment, nil
+}
+
+func (s *GitnessService) CreatePullRequestLineComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, commitSHA, path string, line int, body string) (*git_provider.Comment, error) {
+	repoPath := s.repoPath(organisation, project)
+	pr, err := s.client.FetchPullRequest(repoPath, pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+	if commitSHA == "" {
+		commitSHA = pr.SourceSHA
+	}
+	activity, err := s.client.CreatePullRequestCodeComment(repoPath, pullRequestNumber, body, path, line, commitSHA, pr.MergeBaseSHA)
+	if err != nil {
+		return nil, err
+	}
+	comment := s.activityToComment(*activity)
+	return &comment, nil
+}
+
+// ResolvePullRequestComment replies to the comment and resolves its thread.
+func (s *GitnessService) ResolvePullRequestComment(organisation *models.Organisation, project *models.Project, pullRequestNumber int, comment git_provider.Comment, reply string) error {
+	commentID, err := strconv.ParseInt(comment.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment id %s: %w", comment.ID, err)
+	}
+	repoPath := s.repoPath(organisation, project)
+	if _, err := s.client.CreatePullRequestComment(repoPath, pullRequestNumber, reply, commentID); err != nil {
+		return err
+	}
+	return s.client.UpdatePullRequestCommentStatus(repoPath, pullRequestNumber, commentID, "resolved")
+}
+
+// GetPullRequestComments returns the comments of the pull request activity, leaving out system events and deleted comments.
+func (s *GitnessService) GetPullRequestComments(organisation *models.Organisation, project *models.Project, pullRequestNumber int) ([]git_provider.Comment, error) {
+	activities, err := s.client.GetPullRequestActivities(s.repoPath(organisation, project), pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []git_provider.Comment
+	for _, activity := range activities {
+		if activity.Deleted != nil || (activity.Type != "comment" && activity.Type != "code-comment") {
+			continue
+		}
+		comments = append(comments, s.activityToComment(activity))
+	}
+	return comments, nil
+}
+
+func (s *GitnessService) activityToComment(activity gitness.PullRequestActivity) git_provider.Comment {
+	comment := git_provider.Comment{
+		ID:        strconv.FormatInt(activity.ID, 10),
+		Author:    activity.Author.DisplayName,
+		Body:      activity.Text,
+		CreatedAt: time.UnixMilli(activity.Created),
+	}
+	if activity.CodeComment != nil {
+		comment.Path = activity.CodeComment.Path
+		comment.Line = activity.CodeComment.LineNew
+	}
+	return comment
+}
+
+func (s *GitnessService) RemoteURL(organisation *models.Organisation, project *models.Project) string {
+	httpPrefix := "https"
+
+	if config.AppEnv() == constants.Development {
+		httpPrefix = "http"
+	}
+	return fmt.Sprintf("%s://%s/git/%s.git", httpPrefix, config.GitnessHost(), s.repoPath(organisation, project))
+}
+
+func (s *GitnessService) Credentials() (string, string, error) {
+	return config.GitnessUser(), config.GitnessToken(), nil
+}
+
+func (s *GitnessService) RepositoryPath(organisation *models.Organisation, project *models.Project) string {
+	return s.repoPath(organisation, project)
+}
+
+// ParseRemoteURL reads the path below the /git prefix Gitness serves repositories at.
+func (s *GitnessService) ParseRemoteURL(remoteURL string) (string, error) {
+	return repositoryPathBelow("https://"+config.GitnessHost()+"/git", remoteURL)
+}
+
+// ParseCommentWebhook handles the pullreq_comment_created trigger.
+func (s *GitnessService) ParseCommentWebhook(header http.Header, body []byte) (*git_provider.CommentEvent, error) {
+	if err := verifyHMACSignature(config.GitnessWebhookSecret(), body, header.Get("X-Gitness-Signature")); err != nil {
+		return nil, err
+	}
+	var payload gitness.PullRequestCommentWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	if payload.Trigger != "pullreq_comment_created" {
+		return nil, nil
+	}
+
+	comment := git_provider.Comment{
+		ID:        strconv.FormatInt(payload.Comment.ID, 10),
+		Author:    payload.Principal.UID,
+		Body:      payload.Comment.Text,
+		CreatedAt: time.Now(),
+	}
+	if payload.CodeComment != nil {
+		comment.Path = payload.CodeComment.Path
+		comment.Line = payload.CodeComment.LineNew
+	}
+	return &git_provider.CommentEvent{
+		RepositoryPath:    payload.Repo.Path,
+		PullRequestNumber: payload.PullReq.Number,
+		Comment:           comment,
+	}, nil
+}
+
+var gitnessPullRequestTriggers = map[string]string{
+	"pullreq_created":        git_provider.PullRequestOpened,
+	"pullreq_branch_updated": git_provider.PullRequestUpdated,
+	"pullreq_reopened":       git_provider.PullRequestReopened,
+	"pullreq_closed":         git_provider.PullRequestClosed,
+	"pullreq_merged":         git_provider.PullRequestMerged,
+}
+
+// ParsePullRequestWebhook handles the pullreq_* triggers other than comments.
+func (s *GitnessService) ParsePullRequestWebhook(header http.Header, body []byte) (*git_provider.PullRequestEvent, error) {
+	if err := verifyHMACSignature(config.GitnessWebhookSecret(), body, header.Get("X-Gitness-Signature")); err != nil {
+		return nil, err
+	}
+	var payload gitness.PullRequestWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	action, ok := gitnessPullRequestTriggers[payload.Trigger]
+	if !ok {
+		return nil, nil
+	}
+	return &git_provider.PullRequestEvent{
+		RepositoryPath:    payload.Repo.Path,
+		PullRequestNumber: payload.PullReq.Number,
+		Action:            action,
+		SourceSHA:         payload.PullReq.SourceSHA,
+		Author:            payload.Principal.UID,
+	}, nil
+}
+
+// repoPath is the stored path of the project repository, projects without one are in the organisation space.
+func (s *GitnessService) repoPath(organisation *models.Organisation, project *models.Project) string {
+	if project.RepositoryPath != "" {
+		return project.RepositoryPath
+	}
+	return fmt.Sprintf("%s/%s", s.GetSpaceOrProjectName(organisation), project.Name)
+}
+
 func (s *GitnessService) GetSpaceOrProjectName(organisation *models.Organisation) string {
 	return organisation.Name + "_" + strconv.Itoa(int(organisation.ID))
 
@@ -123,14 +376,11 @@ func (s *GitnessService) GetSpaceOrProjectDescription(organisation *models.Organ
 
 }
 
-func (s *GitnessService) GetAllCommitsOfProjectBranch(organisation *models.Organisation, projectName string) (*gitness.GetMainBranchCommitResponse, error) {
-	spaceOrProjectName := s.GetSpaceOrProjectName(organisation)
-	repoPath := fmt.Sprintf("%s/%s", spaceOrProjectName, projectName)
-
-	commitResponse, err := s.client.GetBranchCommits(repoPath)
-
-	if err != nil {
-		return nil, err
+// unixMilliTime converts the millisecond timestamps of Gitness, nil when unset.
+func unixMilliTime(milliseconds int64) *time.Time {
+	if milliseconds == 0 {
+		return nil
 	}
-	return commitResponse, nil
+	t := time.UnixMilli(milliseconds)
+	return &t
 }
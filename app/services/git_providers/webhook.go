@@ -0,0 +1,29 @@
+package git_providers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// ErrWebhookSecretNotConfigured is returned for deliveries of providers without a webhook secret, they can not be
+// verified.
+var ErrWebhookSecretNotConfigured = errors.New("webhook secret is not configured")
+
+// verifyHMACSignature checks the hex encoded HMAC-SHA256 signature of the webhook body. Deliveries are
+// rejected while no secret is configured.
+func verifyHMACSignature(secret string, body []byte, signature string) error {
+	if secret == "" {
+		return ErrWebhookSecretNotConfigured
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidWebhookSignature
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"ai-developer/app/models"
+	"ai-developer/app/repositories"
+	"ai-developer/app/services/git_providers"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// findProjectByRepositoryPath returns the project whose repository on the git host of remoteType is repositoryPath,
+// nil when no project matches.
+func findProjectByRepositoryPath(
+	projectRepo *repositories.ProjectRepository,
+	organisationRepo *repositories.OrganisationRepository,
+	gitProviderResolver *git_providers.GitProviderResolver,
+	remoteType, repositoryPath string,
+) (*models.Project, error) {
+	// Projects store the path the provider reported when creating their repository, projects created before
+	// that are matched against the path of the repository named after them.
+	projects, err := projectRepo.GetProjectsByRepositoryPath(repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+	named, err := projectRepo.GetProjectsByName(path.Base(repositoryPath))
+	if err != nil {
+		return nil, err
+	}
+	projects = append(projects, named...)
+	for i := range projects {
+		project := &projects[i]
+		gitProvider, err := gitProviderResolver.ForProject(project)
+		if err != nil || gitProvider.RemoteType() != remoteType {
+			continue
+		}
+		organisation, err := organisationRepo.GetOrganisationByID(project.OrganisationID)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(gitProvider.RepositoryPath(organisation, project), repositoryPath) {
+			return project, nil
+		}
+	}
+	fmt.Println("No project found for repository : ", repositoryPath)
+	return nil, nil
+}
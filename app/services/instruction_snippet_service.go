@@ -0,0 +1,141 @@
+package services
+
+import (
+	"ai-developer/app/models"
+	"ai-developer/app/models/types"
+	"ai-developer/app/repositories"
+	"ai-developer/app/types/request"
+	"ai-developer/app/types/response"
+	"fmt"
+	"strings"
+)
+
+const maxSnippetNameLength = 100
+
+// InstructionSnippetService manages the instruction snippets of organisations, e.g. coding standards or
+// libraries not to use. The active snippets are appended to the instructions of every story of the organisation.
+type InstructionSnippetService struct {
+	snippetRepo *repositories.InstructionSnippetRepository
+	projectRepo *repositories.ProjectRepository
+}
+
+func NewInstructionSnippetService(snippetRepo *repositories.InstructionSnippetRepository, projectRepo *repositories.ProjectRepository) *InstructionSnippetService {
+	return &InstructionSnippetService{
+		snippetRepo: snippetRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+func (s *InstructionSnippetService) GetSnippets(organisationID uint) ([]response.InstructionSnippet, error) {
+	snippets, err := s.snippetRepo.GetSnippetsByOrganisationID(organisationID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]response.InstructionSnippet, 0, len(snippets))
+	for _, snippet := range snippets {
+		result = append(result, toInstructionSnippetResponse(&snippet))
+	}
+	return result, nil
+}
+
+func (s *InstructionSnippetService) CreateSnippet(organisationID uint, createRequest request.CreateInstructionSnippetRequest) (*response.InstructionSnippet, error) {
+	snippet := &models.InstructionSnippet{
+		OrganisationID: organisationID,
+		Name:           strings.TrimSpace(createRequest.Name),
+		Content:        strings.TrimSpace(createRequest.Content),
+		IsActive:       true,
+	}
+	if createRequest.IsActive != nil {
+		snippet.IsActive = *createRequest.IsActive
+	}
+	if err := validateInstructionSnippet(snippet); err != nil {
+		return nil, err
+	}
+	if err := s.snippetRepo.CreateSnippet(snippet); err != nil {
+		return nil, err
+	}
+	result := toInstructionSnippetResponse(snippet)
+	return &result, nil
+}
+
+func (s *InstructionSnippetService) UpdateSnippet(organisationID, snippetID uint, updateRequest request.UpdateInstructionSnippetRequest) (*response.InstructionSnippet, error) {
+	snippet, err := s.getSnippet(organisationID, snippetID)
+	if err != nil {
+		return nil, err
+	}
+	if updateRequest.Name != nil {
+		snippet.Name = strings.TrimSpace(*updateRequest.Name)
+	}
+	if updateRequest.Content != nil {
+		snippet.Content = strings.TrimSpace(*updateRequest.Content)
+	}
+	if updateRequest.IsActive != nil {
+		snippet.IsActive = *updateRequest.IsActive
+	}
+	if err := validateInstructionSnippet(snippet); err != nil {
+		return nil, err
+	}
+	if err := s.snippetRepo.UpdateSnippet(snippet); err != nil {
+		return nil, err
+	}
+	result := toInstructionSnippetResponse(snippet)
+	return &result, nil
+}
+
+func (s *InstructionSnippetService) DeleteSnippet(organisationID, snippetID uint) error {
+	snippet, err := s.getSnippet(organisationID, snippetID)
+	if err != nil {
+		return err
+	}
+	return s.snippetRepo.DeleteSnippet(snippet)
+}
+
+// GetActiveSnippetContents returns the contents of the active snippets of the organisation owning the project,
+// in the order they are appended to the instructions of its stories.
+func (s *InstructionSnippetService) GetActiveSnippetContents(projectID uint) ([]string, error) {
+	project, err := s.projectRepo.GetProjectById(int(projectID))
+	if err != nil {
+		return nil, err
+	}
+	snippets, err := s.snippetRepo.GetActiveSnippetsByOrganisationID(project.OrganisationID)
+	if err != nil {
+		return nil, err
+	}
+	contents := make([]string, 0, len(snippets))
+	for _, snippet := range snippets {
+		contents = append(contents, snippet.Content)
+	}
+	return contents, nil
+}
+
+func (s *InstructionSnippetService) getSnippet(organisationID, snippetID uint) (*models.InstructionSnippet, error) {
+	snippet, err := s.snippetRepo.GetOrganisationSnippet(organisationID, snippetID)
+	if err != nil {
+		return nil, err
+	}
+	if snippet == nil {
+		return nil, types.ErrInstructionSnippetNotFound
+	}
+	return snippet, nil
+}
+
+func validateInstructionSnippet(snippet *models.InstructionSnippet) error {
+	if snippet.Name == "" || len(snippet.Name) > maxSnippetNameLength {
+		return fmt.Errorf("%w: name must have 1 to %d characters", types.ErrInvalidInstructionSnippet, maxSnippetNameLength)
+	}
+	if snippet.Content == "" {
+		return fmt.Errorf("%w: content must not be empty", types.ErrInvalidInstructionSnippet)
+	}
+	return nil
+}
+
+func toInstructionSnippetResponse(snippet *models.InstructionSnippet) response.InstructionSnippet {
+	return response.InstructionSnippet{
+		ID:        snippet.ID,
+		Name:      snippet.Name,
+		Content:   snippet.Content,
+		IsActive:  snippet.IsActive,
+		CreatedAt: snippet.CreatedAt,
+		UpdatedAt: snippet.UpdatedAt,
+	}
+}
@@ -0,0 +1,472 @@
+package services
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/issue_tracker"
+	"ai-developer/app/models/types"
+	"ai-developer/app/repositories"
+	"ai-developer/app/services/git_providers"
+	"ai-developer/app/services/issue_trackers"
+	"ai-developer/app/types/request"
+	"ai-developer/app/types/response"
+	"ai-developer/app/utils"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	issueTrackerSecretLength = 32
+	// maxStoryTitleLength is the size of the title column of stories.
+	maxStoryTitleLength = 100
+)
+
+// IssueTrackerService creates stories from the labelled issues of the trackers configured on a project, from
+// webhook deliveries or by polling, and moves the issues along as the stories progress. Imported stories stay
+// in sync with their issue while they are TODO, afterwards the story is left alone.
+type IssueTrackerService struct {
+	issueTrackerRepo     *repositories.IssueTrackerRepository
+	storyIssueLinkRepo   *repositories.StoryIssueLinkRepository
+	storyRepo            *repositories.StoryRepository
+	pullRequestRepo      *repositories.PullRequestRepository
+	projectRepo          *repositories.ProjectRepository
+	organisationRepo     *repositories.OrganisationRepository
+	storyService         *StoryService
+	issueTrackerResolver *issue_trackers.IssueTrackerResolver
+	gitProviderResolver  *git_providers.GitProviderResolver
+	logger               *zap.Logger
+}
+
+func NewIssueTrackerService(
+	issueTrackerRepo *repositories.IssueTrackerRepository,
+	storyIssueLinkRepo *repositories.StoryIssueLinkRepository,
+	storyRepo *repositories.StoryRepository,
+	pullRequestRepo *repositories.PullRequestRepository,
+	projectRepo *repositories.ProjectRepository,
+	organisationRepo *repositories.OrganisationRepository,
+	storyService *StoryService,
+	issueTrackerResolver *issue_trackers.IssueTrackerResolver,
+	gitProviderResolver *git_providers.GitProviderResolver,
+	logger *zap.Logger,
+) *IssueTrackerService {
+	return &IssueTrackerService{
+		issueTrackerRepo:     issueTrackerRepo,
+		storyIssueLinkRepo:   storyIssueLinkRepo,
+		storyRepo:            storyRepo,
+		pullRequestRepo:      pullRequestRepo,
+		projectRepo:          projectRepo,
+		organisationRepo:     organisationRepo,
+		storyService:         storyService,
+		issueTrackerResolver: issueTrackerResolver,
+		gitProviderResolver:  gitProviderResolver,
+		logger:               logger.Named("IssueTrackerService"),
+	}
+}
+
+func (s *IssueTrackerService) GetIssueTrackers(projectID uint) ([]response.IssueTracker, error) {
+	trackers, err := s.issueTrackerRepo.GetIssueTrackersByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]response.IssueTracker, 0, len(trackers))
+	for _, tracker := range trackers {
+		result = append(result, toIssueTrackerResponse(&tracker))
+	}
+	return result, nil
+}
+
+// CreateIssueTracker configures a tracker for the project, the response carries the secret webhook
+// deliveries of the tracker have to be signed with.
+func (s *IssueTrackerService) CreateIssueTracker(projectID uint, createRequest request.CreateIssueTrackerRequest) (*response.IssueTracker, error) {
+	var err error
+	secret := createRequest.WebhookSecret
+	if secret == "" {
+		secret, err = utils.RandString(issueTrackerSecretLength)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tracker := &models.IssueTracker{
+		ProjectID:       projectID,
+		Provider:        strings.ToUpper(createRequest.Provider),
+		BaseURL:         strings.TrimSpace(createRequest.BaseURL),
+		Username:        createRequest.Username,
+		Token:           createRequest.Token,
+		Scope:           strings.TrimSpace(createRequest.Scope),
+		Label:           strings.TrimSpace(createRequest.Label),
+		WebhookSecret:   secret,
+		PollingEnabled:  createRequest.PollingEnabled == nil || *createRequest.PollingEnabled,
+		TodoState:       createRequest.TodoState,
+		InProgressState: createRequest.InProgressState,
+		DoneState:       createRequest.DoneState,
+	}
+	if tracker.Label == "" {
+		tracker.Label = constants.DefaultIssueLabel
+	}
+	if err := validateIssueTracker(tracker); err != nil {
+		return nil, err
+	}
+	if err := s.issueTrackerRepo.CreateIssueTracker(tracker); err != nil {
+		return nil, err
+	}
+	result := toIssueTrackerResponse(tracker)
+	result.WebhookSecret = secret
+	return &result, nil
+}
+
+func (s *IssueTrackerService) UpdateIssueTracker(projectID, trackerID uint, updateRequest request.UpdateIssueTrackerRequest) (*response.IssueTracker, error) {
+	tracker, err := s.getIssueTracker(projectID, trackerID)
+	if err != nil {
+		return nil, err
+	}
+	if updateRequest.BaseURL != nil {
+		tracker.BaseURL = strings.TrimSpace(*updateRequest.BaseURL)
+	}
+	if updateRequest.Username != nil {
+		tracker.Username = *updateRequest.Username
+	}
+	if updateRequest.Token != nil {
+		tracker.Token = *updateRequest.Token
+	}
+	if updateRequest.Scope != nil {
+		tracker.Scope = strings.TrimSpace(*updateRequest.Scope)
+	}
+	if updateRequest.Label != nil {
+		tracker.Label = strings.TrimSpace(*updateRequest.Label)
+	}
+	if updateRequest.WebhookSecret != nil {
+		if *updateRequest.WebhookSecret == "" {
+			return nil, fmt.Errorf("%w: webhook secret must not be empty", types.ErrInvalidIssueTracker)
+		}
+		tracker.WebhookSecret = *updateRequest.WebhookSecret
+	}
+	if updateRequest.PollingEnabled != nil {
+		tracker.PollingEnabled = *updateRequest.PollingEnabled
+	}
+	if updateRequest.TodoState != nil {
+		tracker.TodoState = *updateRequest.TodoState
+	}
+	if updateRequest.InProgressState != nil {
+		tracker.InProgressState = *updateRequest.InProgressState
+	}
+	if updateRequest.DoneState != nil {
+		tracker.DoneState = *updateRequest.DoneState
+	}
+	if err := validateIssueTracker(tracker); err != nil {
+		return nil, err
+	}
+	if err := s.issueTrackerRepo.UpdateIssueTracker(tracker); err != nil {
+		return nil, err
+	}
+	result := toIssueTrackerResponse(tracker)
+	return &result, nil
+}
+
+// DeleteIssueTracker removes the tracker, the stories imported from it are kept.
+func (s *IssueTrackerService) DeleteIssueTracker(projectID, trackerID uint) error {
+	tracker, err := s.getIssueTracker(projectID, trackerID)
+	if err != nil {
+		return err
+	}
+	return s.issueTrackerRepo.DeleteIssueTracker(tracker)
+}
+
+// SyncIssueTracker imports the issues updated since the last sync and pushes the story states of the tracker,
+// whether or not polling is enabled.
+func (s *IssueTrackerService) SyncIssueTracker(projectID, trackerID uint) (*response.IssueTrackerSync, error) {
+	tracker, err := s.getIssueTracker(projectID, trackerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.syncIssueTracker(tracker, true)
+}
+
+// SyncIssueTrackers polls the trackers with polling enabled and pushes the story states of all trackers. A
+// failing tracker does not keep the others from syncing.
+func (s *IssueTrackerService) SyncIssueTrackers() error {
+	trackers, err := s.issueTrackerRepo.GetAllIssueTrackers()
+	if err != nil {
+		return err
+	}
+	for i := range trackers {
+		if _, err := s.syncIssueTracker(&trackers[i], trackers[i].PollingEnabled); err != nil {
+			s.logger.Error("Error syncing issue tracker", zap.Uint("tracker_id", trackers[i].ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// HandleWebhook imports the issue a webhook delivery of the tracker announces.
+func (s *IssueTrackerService) HandleWebhook(trackerID uint, header http.Header, body []byte) error {
+	tracker, err := s.issueTrackerRepo.GetIssueTrackerByID(trackerID)
+	if err != nil {
+		return err
+	}
+	if tracker == nil {
+		return types.ErrIssueTrackerNotFound
+	}
+	issueTracker, err := s.issueTrackerResolver.ForProvider(tracker.Provider)
+	if err != nil {
+		return err
+	}
+	issue, err := issueTracker.ParseWebhook(tracker, header, body)
+	if err != nil || issue == nil {
+		return err
+	}
+	_, _, err = s.importIssue(tracker, *issue)
+	return err
+}
+
+func (s *IssueTrackerService) syncIssueTracker(tracker *models.IssueTracker, poll bool) (*response.IssueTrackerSync, error) {
+	issueTracker, err := s.issueTrackerResolver.ForProvider(tracker.Provider)
+	if err != nil {
+		return nil, err
+	}
+	result := &response.IssueTrackerSync{}
+	if poll {
+		polledAt := time.Now()
+		issues, err := issueTracker.ListIssues(tracker, tracker.LastPolledAt)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			imported, updated, err := s.importIssue(tracker, issue)
+			if err != nil {
+				s.logger.Error("Error importing issue", zap.Uint("tracker_id", tracker.ID), zap.String("issue", issue.Key), zap.Error(err))
+				result.Failed++
+				continue
+			}
+			if imported {
+				result.Imported++
+			} else if updated {
+				result.Updated++
+			}
+		}
+		// The poll window only moves on once every issue in it was imported, so the next poll retries the
+		// failed ones.
+		if result.Failed == 0 {
+			tracker.LastPolledAt = &polledAt
+			if err := s.issueTrackerRepo.UpdateIssueTracker(tracker); err != nil {
+				return nil, err
+			}
+		}
+	}
+	result.Pushed, err = s.pushStoryStates(tracker, issueTracker)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// importIssue creates a story for an issue seen for the first time, or updates the story of a known issue
+// while the story is still TODO. Closed issues are not imported.
+func (s *IssueTrackerService) importIssue(tracker *models.IssueTracker, issue issue_tracker.Issue) (bool, bool, error) {
+	link, err := s.storyIssueLinkRepo.GetLinkByExternalID(tracker.ID, issue.ExternalID)
+	if err != nil {
+		return false, false, err
+	}
+	parsed := utils.ParseIssueDescription(issue.Description)
+	title := issueStoryTitle(issue)
+
+	if link == nil {
+		if issue.Closed {
+			return false, false, nil
+		}
+		storyID, err := s.storyService.CreateStoryForProject(request.CreateStoryRequest{
+			ProjectId:    int(tracker.ProjectID),
+			Summary:      title,
+			Description:  parsed.Description,
+			TestCases:    parsed.TestCases,
+			Instructions: parsed.Instructions,
+		})
+		if err != nil {
+			return false, false, err
+		}
+		err = s.storyIssueLinkRepo.CreateLink(&models.StoryIssueLink{
+			StoryID:        uint(storyID),
+			IssueTrackerID: tracker.ID,
+			ExternalID:     issue.ExternalID,
+			ExternalKey:    issue.Key,
+			URL:            issue.URL,
+			SyncedState:    constants.IssueStateTodo,
+		})
+		if err != nil {
+			return false, false, err
+		}
+		s.logger.Info("Imported issue", zap.Uint("tracker_id", tracker.ID), zap.String("issue", issue.Key), zap.Int("story_id", storyID))
+		return true, false, nil
+	}
+
+	story, err := s.storyRepo.GetStoryById(int(link.StoryID))
+	if err != nil {
+		return false, false, err
+	}
+	if story.IsDeleted || story.Status != constants.Todo {
+		return false, false, nil
+	}
+	err = s.storyService.UpdateStoryForProject(request.UpdateStoryRequest{
+		StoryID:      int(story.ID),
+		Summary:      title,
+		Description:  parsed.Description,
+		TestCases:    parsed.TestCases,
+		Instructions: parsed.Instructions,
+	})
+	if err != nil {
+		return false, false, err
+	}
+	link.ExternalKey = issue.Key
+	link.URL = issue.URL
+	return false, true, s.storyIssueLinkRepo.UpdateLink(link)
+}
+
+// pushStoryStates moves the issues of the tracker whose story changed state since the last push, and
+// comments the pull request of a finished story on its issue. It returns the number of issues moved.
+func (s *IssueTrackerService) pushStoryStates(tracker *models.IssueTracker, issueTracker issue_trackers.IssueTracker) (int, error) {
+	links, err := s.storyIssueLinkRepo.GetLinksByIssueTrackerID(tracker.ID)
+	if err != nil {
+		return 0, err
+	}
+	pushed := 0
+	for i := range links {
+		link := &links[i]
+		story, err := s.storyRepo.GetStoryById(int(link.StoryID))
+		if err != nil || story.IsDeleted {
+			continue
+		}
+		state := issueStateOfStory(story.Status)
+		if state == link.SyncedState {
+			continue
+		}
+		if err := issueTracker.SetIssueState(tracker, link.ExternalID, state); err != nil {
+			s.logger.Error("Error updating issue state", zap.String("issue", link.ExternalKey), zap.String("state", state), zap.Error(err))
+			continue
+		}
+		link.SyncedState = state
+		pushed++
+
+		if state == constants.IssueStateDone {
+			pullRequestURL := s.pullRequestURL(story)
+			if pullRequestURL != "" && pullRequestURL != link.PullRequestURL {
+				body := fmt.Sprintf("SuperCoder finished %q, pull request: %s", story.Title, pullRequestURL)
+				if err := issueTracker.CreateIssueComment(tracker, link.ExternalID, body); err != nil {
+					s.logger.Error("Error commenting pull request on issue", zap.String("issue", link.ExternalKey), zap.Error(err))
+				} else {
+					link.PullRequestURL = pullRequestURL
+				}
+			}
+		}
+		if err := s.storyIssueLinkRepo.UpdateLink(link); err != nil {
+			return pushed, err
+		}
+	}
+	return pushed, nil
+}
+
+// pullRequestURL returns the web URL of the latest pull request of the story, empty when there is none or the
+// git provider cannot be reached.
+func (s *IssueTrackerService) pullRequestURL(story *models.Story) string {
+	pullRequests, err := s.pullRequestRepo.GetAllPullRequestsByStoryIDs([]uint{story.ID}, "ALL")
+	if err != nil || len(pullRequests) == 0 {
+		return ""
+	}
+	pullRequest := pullRequests[0]
+	project, err := s.projectRepo.GetProjectById(int(story.ProjectID))
+	if err != nil {
+		return ""
+	}
+	organisation, err := s.organisationRepo.GetOrganisationByID(project.OrganisationID)
+	if err != nil {
+		return ""
+	}
+	gitProvider, err := s.gitProviderResolver.ForPullRequest(pullRequest, project)
+	if err != nil {
+		return ""
+	}
+	remotePullRequest, err := gitProvider.FetchPullRequest(organisation, project, pullRequest.PullRequestNumber)
+	if err != nil {
+		s.logger.Error("Error fetching pull request", zap.Uint("pull_request_id", pullRequest.ID), zap.Error(err))
+		return ""
+	}
+	return remotePullRequest.URL
+}
+
+func (s *IssueTrackerService) getIssueTracker(projectID, trackerID uint) (*models.IssueTracker, error) {
+	tracker, err := s.issueTrackerRepo.GetProjectIssueTracker(projectID, trackerID)
+	if err != nil {
+		return nil, err
+	}
+	if tracker == nil {
+		return nil, types.ErrIssueTrackerNotFound
+	}
+	return tracker, nil
+}
+
+// issueStateOfStory maps story statuses to issue states, stories waiting for or in execution and stories in
+// review count as in progress.
+func issueStateOfStory(status string) string {
+	switch status {
+	case constants.Todo:
+		return constants.IssueStateTodo
+	case constants.Done:
+		return constants.IssueStateDone
+	default:
+		return constants.IssueStateInProgress
+	}
+}
+
+// issueStoryTitle prefixes the issue key to the title and shortens it to fit the story title.
+func issueStoryTitle(issue issue_tracker.Issue) string {
+	title := strings.TrimSpace(issue.Key + " " + issue.Title)
+	if runes := []rune(title); len(runes) > maxStoryTitleLength {
+		title = string(runes[:maxStoryTitleLength-3]) + "..."
+	}
+	return title
+}
+
+func validateIssueTracker(tracker *models.IssueTracker) error {
+	if !constants.ValidIssueTrackers()[tracker.Provider] {
+		return fmt.Errorf("%w: unsupported provider %s", types.ErrInvalidIssueTracker, tracker.Provider)
+	}
+	if tracker.Token == "" {
+		return fmt.Errorf("%w: token must not be empty", types.ErrInvalidIssueTracker)
+	}
+	if tracker.Scope == "" {
+		return fmt.Errorf("%w: scope must not be empty", types.ErrInvalidIssueTracker)
+	}
+	if tracker.Label == "" {
+		return fmt.Errorf("%w: label must not be empty", types.ErrInvalidIssueTracker)
+	}
+	if tracker.Provider == constants.JiraIssueTracker && tracker.BaseURL == "" {
+		return fmt.Errorf("%w: jira trackers need the base url of the site", types.ErrInvalidIssueTracker)
+	}
+	if tracker.Provider == constants.GitHubIssuesIssueTracker {
+		if owner, repo, ok := strings.Cut(tracker.Scope, "/"); !ok || owner == "" || repo == "" {
+			return fmt.Errorf("%w: scope must be the repository as owner/repo", types.ErrInvalidIssueTracker)
+		}
+	}
+	return nil
+}
+
+func toIssueTrackerResponse(tracker *models.IssueTracker) response.IssueTracker {
+	return response.IssueTracker{
+		ID:              tracker.ID,
+		ProjectID:       tracker.ProjectID,
+		Provider:        tracker.Provider,
+		BaseURL:         tracker.BaseURL,
+		Username:        tracker.Username,
+		Scope:           tracker.Scope,
+		Label:           tracker.Label,
+		PollingEnabled:  tracker.PollingEnabled,
+		TodoState:       tracker.TodoState,
+		InProgressState: tracker.InProgressState,
+		DoneState:       tracker.DoneState,
+		LastPolledAt:    tracker.LastPolledAt,
+		WebhookURL:      fmt.Sprintf("/api/issue-trackers/%d/webhook", tracker.ID),
+		CreatedAt:       tracker.CreatedAt,
+		UpdatedAt:       tracker.UpdatedAt,
+	}
+}
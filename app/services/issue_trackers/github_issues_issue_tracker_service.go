@@ -0,0 +1,142 @@
+package issue_trackers
+
+import (
+	"ai-developer/app/client"
+	"ai-developer/app/client/github_git_provider"
+	"ai-developer/app/config"
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/github"
+	"ai-developer/app/models/dtos/issue_tracker"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// GitHubIssuesService imports the issues of a GitHub repository, the scope of the tracker is owner/repo.
+// GitHub issues are only open or closed, issues are reopened for TODO and IN_PROGRESS and closed for DONE
+// unless the tracker maps the states to open or closed itself.
+type GitHubIssuesService struct {
+	httpClient *client.HttpClient
+	logger     *zap.Logger
+}
+
+func NewGitHubIssuesService(httpClient *client.HttpClient, logger *zap.Logger) *GitHubIssuesService {
+	return &GitHubIssuesService{httpClient: httpClient, logger: logger}
+}
+
+func (s *GitHubIssuesService) Provider() string {
+	return constants.GitHubIssuesIssueTracker
+}
+
+func (s *GitHubIssuesService) client(tracker *models.IssueTracker) *github_git_provider.GitHubClient {
+	baseURL := tracker.BaseURL
+	if baseURL == "" {
+		baseURL = config.GithubAPIURL()
+	}
+	return github_git_provider.NewGitHubClient(baseURL, github_git_provider.StaticTokenSource(tracker.Token), s.httpClient, s.logger)
+}
+
+// repository splits the scope of the tracker into owner and repository name.
+func (s *GitHubIssuesService) repository(tracker *models.IssueTracker) (string, string, error) {
+	owner, repo, ok := strings.Cut(tracker.Scope, "/")
+	if !ok || owner == "" || repo == "" {
+		return "", "", fmt.Errorf("invalid github repository %q, expected owner/repo", tracker.Scope)
+	}
+	return owner, repo, nil
+}
+
+func (s *GitHubIssuesService) ListIssues(tracker *models.IssueTracker, since *time.Time) ([]issue_tracker.Issue, error) {
+	owner, repo, err := s.repository(tracker)
+	if err != nil {
+		return nil, err
+	}
+	gitHubIssues, err := s.client(tracker).ListIssues(owner, repo, tracker.Label, since)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]issue_tracker.Issue, 0, len(gitHubIssues))
+	for _, gitHubIssue := range gitHubIssues {
+		issues = append(issues, s.toIssue(gitHubIssue))
+	}
+	return issues, nil
+}
+
+// ParseWebhook handles the opened, edited, labeled and reopened actions of the issues event.
+func (s *GitHubIssuesService) ParseWebhook(tracker *models.IssueTracker, header http.Header, body []byte) (*issue_tracker.Issue, error) {
+	signature := strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+	if err := verifyHMACSignature(tracker.WebhookSecret, body, signature); err != nil {
+		return nil, err
+	}
+	if header.Get("X-GitHub-Event") != "issues" {
+		return nil, nil
+	}
+	var payload github.IssueWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	switch payload.Action {
+	case "opened", "edited", "labeled", "reopened":
+	default:
+		return nil, nil
+	}
+	issue := s.toIssue(payload.Issue)
+	if !strings.EqualFold(payload.Repository.FullName, tracker.Scope) || !hasLabel(issue.Labels, tracker.Label) {
+		return nil, nil
+	}
+	return &issue, nil
+}
+
+func (s *GitHubIssuesService) SetIssueState(tracker *models.IssueTracker, issueID, state string) error {
+	owner, repo, err := s.repository(tracker)
+	if err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(issueID)
+	if err != nil {
+		return err
+	}
+	gitHubState := strings.ToLower(configuredState(tracker, state))
+	if gitHubState != "open" && gitHubState != "closed" {
+		gitHubState = "open"
+		if state == constants.IssueStateDone {
+			gitHubState = "closed"
+		}
+	}
+	_, err = s.client(tracker).UpdateIssueState(owner, repo, number, gitHubState)
+	return err
+}
+
+func (s *GitHubIssuesService) CreateIssueComment(tracker *models.IssueTracker, issueID, body string) error {
+	owner, repo, err := s.repository(tracker)
+	if err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(issueID)
+	if err != nil {
+		return err
+	}
+	_, err = s.client(tracker).CreateIssueComment(owner, repo, number, body)
+	return err
+}
+
+func (s *GitHubIssuesService) toIssue(gitHubIssue github.Issue) issue_tracker.Issue {
+	labels := make([]string, 0, len(gitHubIssue.Labels))
+	for _, label := range gitHubIssue.Labels {
+		labels = append(labels, label.Name)
+	}
+	return issue_tracker.Issue{
+		ExternalID:  strconv.Itoa(gitHubIssue.Number),
+		Key:         fmt.Sprintf("#%d", gitHubIssue.Number),
+		Title:       gitHubIssue.Title,
+		Description: gitHubIssue.Body,
+		URL:         gitHubIssue.HTMLURL,
+		Labels:      labels,
+		Closed:      gitHubIssue.State == "closed",
+	}
+}
@@ -0,0 +1,89 @@
+package issue_trackers
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/issue_tracker"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// IssueTracker is an issue tracking service stories are imported from. Implementations are selected per
+// tracker configuration through IssueTrackerResolver.
+type IssueTracker interface {
+	// Provider identifies the tracker, it is stored on the tracker configurations.
+	Provider() string
+	// ListIssues returns the issues in the scope of the tracker carrying its label, only the issues updated
+	// after since when it is set.
+	ListIssues(tracker *models.IssueTracker, since *time.Time) ([]issue_tracker.Issue, error)
+	// ParseWebhook verifies a webhook delivery and returns the issue it announces, nil for deliveries of
+	// other events and for issues outside the scope or without the label of the tracker.
+	ParseWebhook(tracker *models.IssueTracker, header http.Header, body []byte) (*issue_tracker.Issue, error)
+	// SetIssueState moves the issue to the tracker state of state, one of the IssueState constants.
+	SetIssueState(tracker *models.IssueTracker, issueID, state string) error
+	CreateIssueComment(tracker *models.IssueTracker, issueID, body string) error
+}
+
+type IssueTrackerResolver struct {
+	trackers map[string]IssueTracker
+}
+
+func NewIssueTrackerResolver(jiraService *JiraService, linearService *LinearService, gitHubIssuesService *GitHubIssuesService) *IssueTrackerResolver {
+	resolver := &IssueTrackerResolver{trackers: map[string]IssueTracker{}}
+	for _, tracker := range []IssueTracker{jiraService, linearService, gitHubIssuesService} {
+		resolver.trackers[tracker.Provider()] = tracker
+	}
+	return resolver
+}
+
+func (r *IssueTrackerResolver) ForProvider(provider string) (IssueTracker, error) {
+	tracker, ok := r.trackers[provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported issue tracker: %s", provider)
+	}
+	return tracker, nil
+}
+
+// configuredState returns the tracker state configured for state, empty when the provider default applies.
+func configuredState(tracker *models.IssueTracker, state string) string {
+	switch state {
+	case constants.IssueStateTodo:
+		return tracker.TodoState
+	case constants.IssueStateInProgress:
+		return tracker.InProgressState
+	case constants.IssueStateDone:
+		return tracker.DoneState
+	}
+	return ""
+}
+
+func hasLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyHMACSignature checks the hex encoded HMAC-SHA256 signature of the webhook body.
+func verifyHMACSignature(secret string, body []byte, signature string) error {
+	if secret == "" {
+		return errors.New("webhook secret is not configured")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return ErrInvalidWebhookSignature
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package issue_trackers
+
+import (
+	"ai-developer/app/client"
+	"ai-developer/app/client/jira_issue_tracker"
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/issue_tracker"
+	"ai-developer/app/models/dtos/jira"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// jiraStatusCategories are the status categories issues are moved to when the tracker names no status.
+var jiraStatusCategories = map[string]string{
+	constants.IssueStateTodo:       "new",
+	constants.IssueStateInProgress: "indeterminate",
+	constants.IssueStateDone:       "done",
+}
+
+// JiraService imports the issues of a Jira project, the scope of the tracker is the project key.
+type JiraService struct {
+	httpClient *client.HttpClient
+	logger     *zap.Logger
+}
+
+func NewJiraService(httpClient *client.HttpClient, logger *zap.Logger) *JiraService {
+	return &JiraService{httpClient: httpClient, logger: logger}
+}
+
+func (s *JiraService) Provider() string {
+	return constants.JiraIssueTracker
+}
+
+func (s *JiraService) client(tracker *models.IssueTracker) *jira_issue_tracker.JiraClient {
+	return jira_issue_tracker.NewJiraClient(tracker.BaseURL, tracker.Username, tracker.Token, s.httpClient, s.logger)
+}
+
+func (s *JiraService) ListIssues(tracker *models.IssueTracker, since *time.Time) ([]issue_tracker.Issue, error) {
+	jql := fmt.Sprintf(`project = "%s" AND labels = "%s"`, jqlEscape(tracker.Scope), jqlEscape(tracker.Label))
+	if since != nil {
+		// JQL compares minutes in the time zone of the user, a minute of overlap keeps updates from slipping through.
+		jql += fmt.Sprintf(` AND updated >= "%s"`, since.Add(-time.Minute).Format("2006-01-02 15:04"))
+	}
+	jql += " ORDER BY updated ASC"
+
+	jiraIssues, err := s.client(tracker).SearchIssues(jql)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]issue_tracker.Issue, 0, len(jiraIssues))
+	for _, jiraIssue := range jiraIssues {
+		issues = append(issues, s.toIssue(tracker, jiraIssue))
+	}
+	return issues, nil
+}
+
+// ParseWebhook handles the jira:issue_created and jira:issue_updated events, signed in the X-Hub-Signature
+// header as Jira Cloud signs the deliveries of webhooks with a secret.
+func (s *JiraService) ParseWebhook(tracker *models.IssueTracker, header http.Header, body []byte) (*issue_tracker.Issue, error) {
+	signature := strings.TrimPrefix(header.Get("X-Hub-Signature"), "sha256=")
+	if err := verifyHMACSignature(tracker.WebhookSecret, body, signature); err != nil {
+		return nil, err
+	}
+	var payload jira.WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	if payload.WebhookEvent != "jira:issue_created" && payload.WebhookEvent != "jira:issue_updated" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(payload.Issue.Key, tracker.Scope+"-") || !hasLabel(payload.Issue.Fields.Labels, tracker.Label) {
+		return nil, nil
+	}
+	issue := s.toIssue(tracker, payload.Issue)
+	return &issue, nil
+}
+
+// SetIssueState applies the transition leading to the configured status, or to the first status of the
+// matching status category when none is configured.
+func (s *JiraService) SetIssueState(tracker *models.IssueTracker, issueID, state string) error {
+	jiraClient := s.client(tracker)
+	transitions, err := jiraClient.GetTransitions(issueID)
+	if err != nil {
+		return err
+	}
+	status := configuredState(tracker, state)
+	for _, transition := range transitions {
+		matches := transition.To.StatusCategory.Key == jiraStatusCategories[state]
+		if status != "" {
+			matches = strings.EqualFold(transition.To.Name, status) || strings.EqualFold(transition.Name, status)
+		}
+		if matches {
+			return jiraClient.TransitionIssue(issueID, transition.ID)
+		}
+	}
+	if status == "" {
+		status = jiraStatusCategories[state]
+	}
+	return fmt.Errorf("no transition of issue %s leads to %s", issueID, status)
+}
+
+func (s *JiraService) CreateIssueComment(tracker *models.IssueTracker, issueID, body string) error {
+	return s.client(tracker).AddComment(issueID, body)
+}
+
+func (s *JiraService) toIssue(tracker *models.IssueTracker, jiraIssue jira.Issue) issue_tracker.Issue {
+	return issue_tracker.Issue{
+		ExternalID:  jiraIssue.ID,
+		Key:         jiraIssue.Key,
+		Title:       jiraIssue.Fields.Summary,
+		Description: jiraIssue.Fields.Description,
+		URL:         strings.TrimSuffix(tracker.BaseURL, "/") + "/browse/" + jiraIssue.Key,
+		Labels:      jiraIssue.Fields.Labels,
+		Closed:      jiraIssue.Fields.Status.StatusCategory.Key == "done",
+	}
+}
+
+func jqlEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
@@ -0,0 +1,129 @@
+package issue_trackers
+
+import (
+	"ai-developer/app/client"
+	"ai-developer/app/client/linear_issue_tracker"
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/issue_tracker"
+	"ai-developer/app/models/dtos/linear"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// linearStateTypes are the workflow state types issues are moved to when the tracker names no state.
+var linearStateTypes = map[string]string{
+	constants.IssueStateTodo:       "unstarted",
+	constants.IssueStateInProgress: "started",
+	constants.IssueStateDone:       "completed",
+}
+
+// LinearService imports the issues of a Linear team, the scope of the tracker is the team key. The base URL
+// of the tracker overrides the GraphQL endpoint.
+type LinearService struct {
+	httpClient *client.HttpClient
+	logger     *zap.Logger
+}
+
+func NewLinearService(httpClient *client.HttpClient, logger *zap.Logger) *LinearService {
+	return &LinearService{httpClient: httpClient, logger: logger}
+}
+
+func (s *LinearService) Provider() string {
+	return constants.LinearIssueTracker
+}
+
+func (s *LinearService) client(tracker *models.IssueTracker) *linear_issue_tracker.LinearClient {
+	return linear_issue_tracker.NewLinearClient(tracker.BaseURL, tracker.Token, s.httpClient, s.logger)
+}
+
+func (s *LinearService) ListIssues(tracker *models.IssueTracker, since *time.Time) ([]issue_tracker.Issue, error) {
+	linearIssues, err := s.client(tracker).ListIssues(tracker.Scope, tracker.Label, since)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]issue_tracker.Issue, 0, len(linearIssues))
+	for _, linearIssue := range linearIssues {
+		labels := make([]string, 0, len(linearIssue.Labels.Nodes))
+		for _, label := range linearIssue.Labels.Nodes {
+			labels = append(labels, label.Name)
+		}
+		issues = append(issues, issue_tracker.Issue{
+			ExternalID:  linearIssue.ID,
+			Key:         linearIssue.Identifier,
+			Title:       linearIssue.Title,
+			Description: linearIssue.Description,
+			URL:         linearIssue.URL,
+			Labels:      labels,
+			Closed:      isClosedLinearState(linearIssue.State),
+		})
+	}
+	return issues, nil
+}
+
+// ParseWebhook handles the create and update actions of Issue events, signed in the Linear-Signature header.
+func (s *LinearService) ParseWebhook(tracker *models.IssueTracker, header http.Header, body []byte) (*issue_tracker.Issue, error) {
+	if err := verifyHMACSignature(tracker.WebhookSecret, body, header.Get("Linear-Signature")); err != nil {
+		return nil, err
+	}
+	var payload linear.WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	if payload.Type != "Issue" || (payload.Action != "create" && payload.Action != "update") {
+		return nil, nil
+	}
+	labels := make([]string, 0, len(payload.Data.Labels))
+	for _, label := range payload.Data.Labels {
+		labels = append(labels, label.Name)
+	}
+	if !strings.EqualFold(payload.Data.Team.Key, tracker.Scope) || !hasLabel(labels, tracker.Label) {
+		return nil, nil
+	}
+	return &issue_tracker.Issue{
+		ExternalID:  payload.Data.ID,
+		Key:         payload.Data.Identifier,
+		Title:       payload.Data.Title,
+		Description: payload.Data.Description,
+		URL:         payload.Data.URL,
+		Labels:      labels,
+		Closed:      isClosedLinearState(payload.Data.State),
+	}, nil
+}
+
+// SetIssueState moves the issue to the configured workflow state of its team, or to the first state of the
+// matching type when none is configured.
+func (s *LinearService) SetIssueState(tracker *models.IssueTracker, issueID, state string) error {
+	linearClient := s.client(tracker)
+	states, err := linearClient.GetTeamStates(issueID)
+	if err != nil {
+		return err
+	}
+	name := configuredState(tracker, state)
+	for _, workflowState := range states {
+		matches := workflowState.Type == linearStateTypes[state]
+		if name != "" {
+			matches = strings.EqualFold(workflowState.Name, name)
+		}
+		if matches {
+			return linearClient.UpdateIssueState(issueID, workflowState.ID)
+		}
+	}
+	if name == "" {
+		name = linearStateTypes[state]
+	}
+	return fmt.Errorf("no workflow state %s for issue %s", name, issueID)
+}
+
+func (s *LinearService) CreateIssueComment(tracker *models.IssueTracker, issueID, body string) error {
+	return s.client(tracker).CreateComment(issueID, body)
+}
+
+func isClosedLinearState(state linear.State) bool {
+	return state.Type == "completed" || state.Type == "canceled"
+}
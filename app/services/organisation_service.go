@@ -1,6 +1,7 @@
 package services
 
 import (
+	"ai-developer/app/config"
 	"ai-developer/app/models"
 	"ai-developer/app/repositories"
 	"ai-developer/app/services/git_providers"
@@ -41,11 +42,14 @@ func (s *OrganisationService) CreateOrganisation(organisation *models.Organisati
 		tx.Rollback()
 		return nil, err
 	}
-	projectSpace, err := s.gitnessService.CreateProject(s.gitnessService.GetSpaceOrProjectName(org), s.gitnessService.GetSpaceOrProjectDescription(org))
-	fmt.Println("Project/Space created: ", projectSpace)
-	if err != nil {
-		tx.Rollback()
-		return nil, err
+	// Spaces only exist on Gitness, deployments hosting every repository elsewhere do not configure it.
+	if config.GitnessURL() != "" {
+		projectSpace, err := s.gitnessService.CreateProject(s.gitnessService.GetSpaceOrProjectName(org), s.gitnessService.GetSpaceOrProjectDescription(org))
+		fmt.Println("Project/Space created: ", projectSpace)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
 	}
 
 	if err := tx.Commit().Error; err != nil {
@@ -0,0 +1,503 @@
+package services
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/asynq_task"
+	"ai-developer/app/models/types"
+	"ai-developer/app/repositories"
+	"ai-developer/app/types/request"
+	"ai-developer/app/types/response"
+	"ai-developer/app/utils"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+const (
+	// webhookMaxRetry is how often a failed delivery is retried, asynq backs off exponentially between attempts.
+	webhookMaxRetry = 8
+	// deliveryLogLimit is the number of deliveries the delivery log returns.
+	deliveryLogLimit    = 100
+	webhookSecretLength = 32
+)
+
+var errBlockedWebhookAddress = errors.New("webhook url resolves to a loopback, private or link-local address")
+
+// webhookHTTPClient connects to public addresses only. The address is checked after the host is resolved, for every
+// connection including redirects, so that webhooks can not reach internal services.
+var webhookHTTPClient = &http.Client{
+	Timeout: 15 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 10 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if ip := net.ParseIP(host); ip == nil || isBlockedWebhookIP(ip) {
+					return errBlockedWebhookAddress
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+	},
+}
+
+// OrganisationWebhookService sends the events of an organisation to the webhooks it configured. Every event is
+// stored as a delivery and sent by the worker, signed with the secret of the webhook and retried until it succeeds.
+type OrganisationWebhookService struct {
+	webhookRepo  *repositories.OrganisationWebhookRepository
+	deliveryRepo *repositories.WebhookDeliveryRepository
+	storyRepo    *repositories.StoryRepository
+	projectRepo  *repositories.ProjectRepository
+	asynqClient  *asynq.Client
+	logger       *zap.Logger
+}
+
+func NewOrganisationWebhookService(
+	webhookRepo *repositories.OrganisationWebhookRepository,
+	deliveryRepo *repositories.WebhookDeliveryRepository,
+	storyRepo *repositories.StoryRepository,
+	projectRepo *repositories.ProjectRepository,
+	asynqClient *asynq.Client,
+	logger *zap.Logger,
+) *OrganisationWebhookService {
+	return &OrganisationWebhookService{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		storyRepo:    storyRepo,
+		projectRepo:  projectRepo,
+		asynqClient:  asynqClient,
+		logger:       logger.Named("OrganisationWebhookService"),
+	}
+}
+
+func (s *OrganisationWebhookService) GetWebhooks(organisationID uint) ([]response.OrganisationWebhook, error) {
+	webhooks, err := s.webhookRepo.GetWebhooksByOrganisationID(organisationID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]response.OrganisationWebhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		result = append(result, toWebhookResponse(&webhook))
+	}
+	return result, nil
+}
+
+// CreateWebhook registers a webhook, the response carries the secret the deliveries are signed with.
+func (s *OrganisationWebhookService) CreateWebhook(organisationID uint, createRequest request.CreateOrganisationWebhookRequest) (*response.OrganisationWebhook, error) {
+	if err := validateWebhookURL(createRequest.URL); err != nil {
+		return nil, err
+	}
+	events, err := joinWebhookEvents(createRequest.Events)
+	if err != nil {
+		return nil, err
+	}
+	secret := createRequest.Secret
+	if secret == "" {
+		secret, err = utils.RandString(webhookSecretLength)
+		if err != nil {
+			return nil, err
+		}
+	}
+	webhook := &models.OrganisationWebhook{
+		OrganisationID: organisationID,
+		URL:            createRequest.URL,
+		Secret:         secret,
+		Events:         events,
+		IsActive:       true,
+	}
+	if err := s.webhookRepo.CreateWebhook(webhook); err != nil {
+		return nil, err
+	}
+	result := toWebhookResponse(webhook)
+	result.Secret = secret
+	return &result, nil
+}
+
+func (s *OrganisationWebhookService) UpdateWebhook(organisationID, webhookID uint, updateRequest request.UpdateOrganisationWebhookRequest) (*response.OrganisationWebhook, error) {
+	webhook, err := s.getWebhook(organisationID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if updateRequest.URL != nil {
+		if err := validateWebhookURL(*updateRequest.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = *updateRequest.URL
+	}
+	if updateRequest.Events != nil {
+		webhook.Events, err = joinWebhookEvents(*updateRequest.Events)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if updateRequest.Secret != nil {
+		if *updateRequest.Secret == "" {
+			return nil, fmt.Errorf("%w: secret must not be empty", types.ErrInvalidWebhook)
+		}
+		webhook.Secret = *updateRequest.Secret
+	}
+	if updateRequest.IsActive != nil {
+		webhook.IsActive = *updateRequest.IsActive
+	}
+	if err := s.webhookRepo.UpdateWebhook(webhook); err != nil {
+		return nil, err
+	}
+	result := toWebhookResponse(webhook)
+	return &result, nil
+}
+
+func (s *OrganisationWebhookService) DeleteWebhook(organisationID, webhookID uint) error {
+	webhook, err := s.getWebhook(organisationID, webhookID)
+	if err != nil {
+		return err
+	}
+	return s.webhookRepo.DeleteWebhook(webhook)
+}
+
+// GetDeliveries returns the delivery log of the webhook, newest first.
+func (s *OrganisationWebhookService) GetDeliveries(organisationID, webhookID uint) ([]response.WebhookDelivery, error) {
+	webhook, err := s.getWebhook(organisationID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.deliveryRepo.GetDeliveriesByWebhookID(webhook.ID, deliveryLogLimit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]response.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, response.WebhookDelivery{
+			ID:             delivery.ID,
+			Event:          delivery.Event,
+			Payload:        delivery.Payload,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			ResponseStatus: delivery.ResponseStatus,
+			Error:          delivery.Error,
+			DeliveredAt:    delivery.DeliveredAt,
+			CreatedAt:      delivery.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// Redeliver sends the payload of an earlier delivery again as a new delivery.
+func (s *OrganisationWebhookService) Redeliver(organisationID, webhookID, deliveryID uint) (*models.WebhookDelivery, error) {
+	webhook, err := s.getWebhook(organisationID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := s.deliveryRepo.GetDeliveryByID(deliveryID)
+	if err != nil || delivery.WebhookID != webhook.ID {
+		return nil, types.ErrWebhookNotFound
+	}
+	return s.queueDelivery(webhook, delivery.Event, delivery.Payload)
+}
+
+// PublishStoryEvent publishes an event about the story to the webhooks of the organisation owning it. Publishing
+// is best effort, errors are logged.
+func (s *OrganisationWebhookService) PublishStoryEvent(storyID uint, event string, data map[string]interface{}) {
+	story, err := s.storyRepo.GetStoryById(int(storyID))
+	if err != nil {
+		s.logger.Error("Error fetching story of event", zap.String("event", event), zap.Uint("story_id", storyID), zap.Error(err))
+		return
+	}
+	project, err := s.projectRepo.GetProjectById(int(story.ProjectID))
+	if err != nil {
+		s.logger.Error("Error fetching project of event", zap.String("event", event), zap.Uint("story_id", storyID), zap.Error(err))
+		return
+	}
+	data["story_id"] = story.ID
+	data["story_title"] = story.Title
+	data["project_id"] = project.ID
+	data["project_name"] = project.Name
+	s.Publish(project.OrganisationID, event, data)
+}
+
+// PublishStoryStatusChanged publishes the status change of the story.
+func (s *OrganisationWebhookService) PublishStoryStatusChanged(storyID uint, previousStatus, status string) {
+	if previousStatus == status {
+		return
+	}
+	s.PublishStoryEvent(storyID, constants.EventStoryStatusChanged, map[string]interface{}{
+		"previous_status": previousStatus,
+		"status":          status,
+	})
+}
+
+// Publish queues a delivery of the event for every active webhook of the organisation subscribed to it.
+func (s *OrganisationWebhookService) Publish(organisationID uint, event string, data map[string]interface{}) {
+	webhooks, err := s.webhookRepo.GetActiveWebhooksByOrganisationID(organisationID)
+	if err != nil {
+		s.logger.Error("Error fetching webhooks", zap.Uint("organisation_id", organisationID), zap.Error(err))
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"event":           event,
+		"organisation_id": organisationID,
+		"created_at":      time.Now().UTC(),
+		"data":            data,
+	})
+	if err != nil {
+		s.logger.Error("Error encoding event", zap.String("event", event), zap.Error(err))
+		return
+	}
+	for _, webhook := range webhooks {
+		if !subscribesTo(&webhook, event) {
+			continue
+		}
+		if _, err := s.queueDelivery(&webhook, event, string(payload)); err != nil {
+			s.logger.Error("Error queueing webhook delivery", zap.Uint("webhook_id", webhook.ID), zap.String("event", event), zap.Error(err))
+		}
+	}
+}
+
+// Deliver sends the delivery to its webhook. An error is returned while the webhook does not accept it, so that
+// the task is retried; final tells that it is the last attempt.
+func (s *OrganisationWebhookService) Deliver(deliveryID uint, final bool) error {
+	delivery, err := s.deliveryRepo.GetDeliveryByID(deliveryID)
+	if err != nil {
+		return err
+	}
+	if delivery.Status == constants.DeliverySucceeded {
+		return nil
+	}
+	webhook, err := s.webhookRepo.GetWebhookByID(delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+	if webhook == nil || !webhook.IsActive {
+		delivery.Status = constants.DeliveryFailed
+		delivery.Error = "webhook was deleted or deactivated"
+		return s.deliveryRepo.UpdateDelivery(delivery)
+	}
+
+	delivery.Attempts++
+	statusCode, sendErr := sendWebhook(webhook, delivery)
+	delivery.ResponseStatus = statusCode
+	delivery.Error = ""
+	if sendErr == nil && (statusCode < 200 || statusCode >= 300) {
+		sendErr = fmt.Errorf("webhook responded with status %d", statusCode)
+	}
+	if sendErr == nil {
+		now := time.Now()
+		delivery.Status = constants.DeliverySucceeded
+		delivery.DeliveredAt = &now
+	} else {
+		delivery.Error = sendErr.Error()
+		delivery.Status = constants.DeliveryRetrying
+		if final {
+			delivery.Status = constants.DeliveryFailed
+		}
+	}
+	if err := s.deliveryRepo.UpdateDelivery(delivery); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+func (s *OrganisationWebhookService) getWebhook(organisationID, webhookID uint) (*models.OrganisationWebhook, error) {
+	webhook, err := s.webhookRepo.GetOrganisationWebhook(organisationID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, types.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func (s *OrganisationWebhookService) queueDelivery(webhook *models.OrganisationWebhook, event, payload string) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{
+		WebhookID: webhook.ID,
+		Event:     event,
+		Payload:   payload,
+		Status:    constants.DeliveryPending,
+	}
+	if err := s.deliveryRepo.CreateDelivery(delivery); err != nil {
+		return nil, err
+	}
+	payloadBytes, err := json.Marshal(asynq_task.DeliverWebhookPayload{DeliveryID: delivery.ID})
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.asynqClient.Enqueue(asynq.NewTask(constants.DeliverWebhookTaskType, payloadBytes),
+		asynq.MaxRetry(webhookMaxRetry),
+		asynq.Timeout(time.Minute),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// sendWebhook posts the payload of the delivery and returns the status code of the response. The payload is signed
+// with the HMAC-SHA256 of the timestamp and the body keyed with the secret of the webhook. The response body is not
+// read, so that a webhook can not be used to read internal services back through the delivery log.
+func sendWebhook(webhook *models.OrganisationWebhook, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(webhook.Secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "SuperCoder-Webhooks")
+	req.Header.Set("X-SuperCoder-Event", delivery.Event)
+	req.Header.Set("X-SuperCoder-Delivery", strconv.Itoa(int(delivery.ID)))
+	req.Header.Set(constants.WebhookTimestampHeader, timestamp)
+	req.Header.Set(constants.WebhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := webhookHTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// blockedWebhookNetworks are special purpose ranges not covered by the net.IP predicates which can still reach
+// internal services, e.g. cloud metadata endpoints behind a carrier-grade NAT.
+var blockedWebhookNetworks = mustParseCIDRs(
+	"0.0.0.0/8",       // "this" network
+	"100.64.0.0/10",   // carrier-grade NAT
+	"192.0.0.0/24",    // IETF protocol assignments
+	"192.0.2.0/24",    // documentation
+	"198.18.0.0/15",   // benchmarking
+	"198.51.100.0/24", // documentation
+	"203.0.113.0/24",  // documentation
+	"240.0.0.0/4",     // reserved, including the limited broadcast address
+	"64:ff9b:1::/48",  // local-use NAT64
+	"100::/64",        // discard-only
+	"2001:db8::/32",   // documentation
+)
+
+var (
+	nat64Network     = mustParseCIDRs("64:ff9b::/96")[0]
+	sixToFourNetwork = mustParseCIDRs("2002::/16")[0]
+	teredoNetwork    = mustParseCIDRs("2001::/32")[0]
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+func isBlockedWebhookIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, network := range blockedWebhookNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	// IPv6 transition addresses carry an IPv4 address which is where the packets end up.
+	if embedded := embeddedIPv4(ip); embedded != nil {
+		return isBlockedWebhookIP(embedded)
+	}
+	return false
+}
+
+// embeddedIPv4 returns the IPv4 address of NAT64, 6to4, Teredo and IPv4-compatible IPv6 addresses, nil otherwise.
+func embeddedIPv4(ip net.IP) net.IP {
+	if ip.To4() != nil {
+		return nil
+	}
+	ip = ip.To16()
+	if ip == nil {
+		return nil
+	}
+	switch {
+	case nat64Network.Contains(ip):
+		return net.IPv4(ip[12], ip[13], ip[14], ip[15])
+	case sixToFourNetwork.Contains(ip):
+		return net.IPv4(ip[2], ip[3], ip[4], ip[5])
+	case teredoNetwork.Contains(ip):
+		return net.IPv4(ip[12]^0xff, ip[13]^0xff, ip[14]^0xff, ip[15]^0xff)
+	case net.IP(ip[:12]).Equal(make(net.IP, 12)):
+		return net.IPv4(ip[12], ip[13], ip[14], ip[15])
+	}
+	return nil
+}
+
+func subscribesTo(webhook *models.OrganisationWebhook, event string) bool {
+	if webhook.Events == "" {
+		return true
+	}
+	for _, subscribed := range strings.Split(webhook.Events, ",") {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+func joinWebhookEvents(events []string) (string, error) {
+	for _, event := range events {
+		if !constants.ValidWebhookEvents()[event] {
+			return "", fmt.Errorf("%w: unknown event %s", types.ErrInvalidWebhook, event)
+		}
+	}
+	return strings.Join(events, ","), nil
+}
+
+func validateWebhookURL(webhookURL string) error {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https url", types.ErrInvalidWebhook)
+	}
+	host := parsed.Hostname()
+	if ip := net.ParseIP(host); (ip != nil && isBlockedWebhookIP(ip)) || strings.EqualFold(host, "localhost") {
+		return fmt.Errorf("%w: url must not point to a loopback, private or link-local address", types.ErrInvalidWebhook)
+	}
+	return nil
+}
+
+func toWebhookResponse(webhook *models.OrganisationWebhook) response.OrganisationWebhook {
+	events := []string{}
+	if webhook.Events != "" {
+		events = strings.Split(webhook.Events, ",")
+	}
+	return response.OrganisationWebhook{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    events,
+		IsActive:  webhook.IsActive,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIsBlockedWebhookIP(t *testing.T) {
+	tests := []struct {
+		ip      string
+		blocked bool
+	}{
+		{"8.8.8.8", false},
+		{"1.1.1.1", false},
+		{"2606:4700:4700::1111", false},
+		{"127.0.0.1", true},
+		{"10.0.0.1", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"0.0.0.0", true},
+		{"0.1.2.3", true},
+		{"100.64.0.1", true},
+		{"100.100.100.200", true},
+		{"100.127.255.255", true},
+		{"100.128.0.1", false},
+		{"198.18.0.1", true},
+		{"198.19.255.255", true},
+		{"198.20.0.1", false},
+		{"192.0.0.170", true},
+		{"240.0.0.1", true},
+		{"255.255.255.255", true},
+		{"224.0.0.1", true},
+		{"::", true},
+		{"::1", true},
+		{"fc00::1", true},
+		{"fe80::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"::ffff:169.254.169.254", true},
+		{"::ffff:8.8.8.8", false},
+		{"::10.0.0.1", true},
+		{"64:ff9b::a9fe:a9fe", true},
+		{"64:ff9b::7f00:1", true},
+		{"64:ff9b::6440:1", true},
+		{"64:ff9b::808:808", false},
+		{"64:ff9b:1::808:808", true},
+		{"2002:a9fe:a9fe::", true},
+		{"2002:c0a8:101::1", true},
+		{"2002:6440:1::", true},
+		{"2002:808:808::", false},
+		{"2001:0:4136:e378:8000:63bf:80ff:fffe", true},
+		{"2001:0:4136:e378:8000:63bf:f7f7:f7f7", false},
+		{"2001:db8::1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			ip := net.ParseIP(tt.ip)
+			if ip == nil {
+				t.Fatalf("invalid test address %s", tt.ip)
+			}
+			if got := isBlockedWebhookIP(ip); got != tt.blocked {
+				t.Errorf("isBlockedWebhookIP(%s) = %v, want %v", tt.ip, got, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestValidateWebhookURLRejectsInternalAddresses(t *testing.T) {
+	for _, webhookURL := range []string{
+		"http://100.100.100.200/latest/meta-data",
+		"http://198.18.0.1/hook",
+		"http://[64:ff9b::a9fe:a9fe]/latest/meta-data",
+		"http://[2002:a00:1::]/hook",
+	} {
+		if err := validateWebhookURL(webhookURL); err == nil {
+			t.Errorf("validateWebhookURL(%s) accepted an internal address", webhookURL)
+		}
+	}
+	if err := validateWebhookURL("https://hooks.example.com/supercoder"); err != nil {
+		t.Errorf("validateWebhookURL rejected a public url: %v", err)
+	}
+}
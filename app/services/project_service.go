@@ -6,19 +6,24 @@ import (
 	"ai-developer/app/constants"
 	"ai-developer/app/models"
 	"ai-developer/app/models/dtos/asynq_task"
+	"ai-developer/app/models/types"
 	"ai-developer/app/repositories"
 	"ai-developer/app/services/git_providers"
 	"ai-developer/app/types/request"
 	"ai-developer/app/types/response"
 	"ai-developer/app/utils"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type ProjectService struct {
@@ -27,7 +32,7 @@ type ProjectService struct {
 	organisationRepository *repositories.OrganisationRepository
 	storyRepository        *repositories.StoryRepository
 	pullRequestRepository  *repositories.PullRequestRepository
-	gitnessService         *git_providers.GitnessService
+	gitProviderResolver    *git_providers.GitProviderResolver
 	hashIdGenerator        *utils.HashIDGenerator
 	workspaceServiceClient *workspace.WorkspaceServiceClient
 	asynqClient            *asynq.Client
@@ -82,7 +87,8 @@ func (s *ProjectService) GetProjectDetailsById(projectId int) (*models.Project,
 	return project, nil
 }
 
-func (s *ProjectService) CreateProject(organisationID int, requestData request.CreateProjectRequest) (*models.Project, error) {
+// newProject builds a project with a fresh workspace, the workspace URLs depend on the environment.
+func (s *ProjectService) newProject(organisationID int, name, description, gitProvider, repositoryOwner, defaultBranch string) *models.Project {
 	hashID := s.hashIdGenerator.Generate() + "-" + uuid.New().String()
 	url := "http://localhost:8081/?folder=/workspaces/" + hashID
 	backend_url := "http://localhost:5000"
@@ -94,32 +100,54 @@ func (s *ProjectService) CreateProject(organisationID int, requestData request.C
 		backend_url = fmt.Sprintf("https://be-%s.%s", hashID, host)
 		frontend_url = fmt.Sprintf("https://fe-%s.%s", hashID, host)
 	}
-	project := &models.Project{
-		OrganisationID:    uint(organisationID),
-		Name:              requestData.Name,
-		BackendFramework:  requestData.Framework,
-		FrontendFramework: requestData.FrontendFramework,
-		Description:       requestData.Description,
-		HashID:            hashID,
-		Url:               url,
-		BackendURL:        backend_url,
-		FrontendURL:       frontend_url,
+	if gitProvider == "" {
+		gitProvider = config.DefaultGitProvider()
+	}
+	if defaultBranch == "" {
+		defaultBranch = constants.DefaultBranch
+	}
+	return &models.Project{
+		OrganisationID:  uint(organisationID),
+		Name:            name,
+		Description:     description,
+		HashID:          hashID,
+		Url:             url,
+		BackendURL:      backend_url,
+		FrontendURL:     frontend_url,
+		GitProvider:     gitProvider,
+		RepositoryOwner: repositoryOwner,
+		DefaultBranch:   defaultBranch,
+	}
+}
+
+func (s *ProjectService) CreateProject(organisationID int, requestData request.CreateProjectRequest) (*models.Project, error) {
+	project := s.newProject(organisationID, requestData.Name, requestData.Description, requestData.GitProvider,
+		requestData.RepositoryOwner, requestData.DefaultBranch)
+	project.BackendFramework = requestData.Framework
+	project.FrontendFramework = requestData.FrontendFramework
+	hashID := project.HashID
+
+	provider, err := s.gitProviderResolver.ForProject(project)
+	if err != nil {
+		return nil, err
 	}
 
 	organisation, err := s.organisationRepository.GetOrganisationByID(uint(int(project.OrganisationID)))
-	spaceOrProjectName := s.gitnessService.GetSpaceOrProjectName(organisation)
-	repository, err := s.gitnessService.CreateRepository(spaceOrProjectName, project.Name, project.Description)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := provider.CreateRepository(organisation, project)
 	if err != nil {
 		s.logger.Error("Error creating repository", zap.Error(err))
 		return nil, err
 	}
-	httpPrefix := "https"
-
-	if config.AppEnv() == constants.Development {
-		httpPrefix = "http"
+	project.RepositoryPath = repository.Path
+	remoteGitURL := provider.RemoteURL(organisation, project)
+	gitUsername, gitPassword, err := provider.Credentials()
+	if err != nil {
+		s.logger.Error("Error getting git credentials", zap.Error(err))
+		return nil, err
 	}
-
-	remoteGitURL := fmt.Sprintf("%s://%s:%s@%s/git/%s/%s.git", httpPrefix, config.GitnessUser(), config.GitnessToken(), config.GitnessHost(), spaceOrProjectName, project.Name)
 	backendService := requestData.Framework
 	frontendService := requestData.FrontendFramework
 	//Making Call to Workspace Service to create workspace on project level
@@ -129,8 +157,9 @@ func (s *ProjectService) CreateProject(organisationID int, requestData request.C
 			BackendTemplate:  &backendService,
 			FrontendTemplate: &frontendService,
 			RemoteURL:        remoteGitURL,
-			GitnessUserName:  config.GitnessUser(),
-			GitnessToken:     config.GitnessToken(),
+			GitnessUserName:  gitUsername,
+			GitnessToken:     gitPassword,
+			DefaultBranch:    project.DefaultBranch,
 		},
 	)
 
@@ -139,23 +168,154 @@ func (s *ProjectService) CreateProject(organisationID int, requestData request.C
 		return nil, err
 	}
 
-	//Enqueue job to delete workspace with updated delay
+	err = s.enqueueWorkspaceDeletion(project.HashID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Project created successfully with repository", zap.Any("project", project), zap.Any("repository", repository))
+	return s.projectRepo.CreateProject(project)
+}
+
+// ImportProject creates a project from an existing repository. The workspace service clones the repository
+// and pushes the imported branch to a new repository on the git provider, unless the repository already is
+// the project repository. Frameworks not given in the request are detected from the imported code.
+func (s *ProjectService) ImportProject(organisationID int, requestData request.ImportProjectRequest) (*models.Project, error) {
+	importURL, err := url.Parse(requestData.CloneURL)
+	if err != nil || importURL.Host == "" {
+		return nil, fmt.Errorf("%w: invalid clone url %s", types.ErrForeignRepositoryURL, requestData.CloneURL)
+	}
+	// Credentials are only used for the clone and never stored with the project.
+	importURL.User = nil
+
+	defaultBranch := requestData.DefaultBranch
+	if defaultBranch == "" {
+		// The repository created for the project has to default to the imported branch, so it is detected first.
+		defaultBranch, err = utils.RemoteDefaultBranch(authenticatedCloneURL(requestData))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	project := s.newProject(organisationID, requestData.Name, requestData.Description, requestData.GitProvider,
+		requestData.RepositoryOwner, defaultBranch)
+	project.ImportURL = importURL.String()
+	provider, err := s.gitProviderResolver.ForProject(project)
+	if err != nil {
+		return nil, err
+	}
+	organisation, err := s.organisationRepository.GetOrganisationByID(uint(int(project.OrganisationID)))
+	if err != nil {
+		return nil, err
+	}
+
+	remoteGitURL := ""
+	if requestData.UseExistingRepository {
+		// The repository is pushed to with the provider credentials, so it has to be hosted by the provider.
+		repositoryPath, err := provider.ParseRemoteURL(project.ImportURL)
+		if err != nil {
+			return nil, err
+		}
+		project.RemoteURL = project.ImportURL
+		project.RepositoryPath = repositoryPath
+		project.RepositoryOwner, _, _ = strings.Cut(repositoryPath, "/")
+	} else {
+		repository, err := provider.CreateRepository(organisation, project)
+		if err != nil {
+			s.logger.Error("Error creating repository", zap.Error(err))
+			return nil, err
+		}
+		s.logger.Info("Repository created for imported project", zap.Any("repository", repository))
+		project.RepositoryPath = repository.Path
+		remoteGitURL = provider.RemoteURL(organisation, project)
+	}
+	gitUsername, gitPassword, err := provider.Credentials()
+	if err != nil {
+		s.logger.Error("Error getting git credentials", zap.Error(err))
+		return nil, err
+	}
+
+	workspaceResponse, err := s.workspaceServiceClient.CreateWorkspace(
+		&request.CreateWorkspaceRequest{
+			WorkspaceId:     project.HashID,
+			RemoteURL:       remoteGitURL,
+			GitnessUserName: gitUsername,
+			GitnessToken:    gitPassword,
+			DefaultBranch:   project.DefaultBranch,
+			Import: &request.ImportSource{
+				CloneURL: requestData.CloneURL,
+				UserName: requestData.Username,
+				Token:    requestData.Token,
+			},
+		},
+	)
+	if err != nil {
+		s.logger.Error("Error importing workspace", zap.Error(err))
+		return nil, err
+	}
+	if workspaceResponse == nil || workspaceResponse.WorkspaceDetails == nil {
+		return nil, errors.New("invalid response from workspace service for import")
+	}
+
+	workspaceDetails := workspaceResponse.WorkspaceDetails
+	if workspaceDetails.DefaultBranch != nil && *workspaceDetails.DefaultBranch != "" {
+		project.DefaultBranch = *workspaceDetails.DefaultBranch
+	}
+	project.BackendFramework = requestData.Framework
+	if project.BackendFramework == "" && workspaceDetails.BackendTemplate != nil {
+		project.BackendFramework = *workspaceDetails.BackendTemplate
+	}
+	project.FrontendFramework = requestData.FrontendFramework
+	if project.FrontendFramework == "" && workspaceDetails.FrontendTemplate != nil {
+		project.FrontendFramework = *workspaceDetails.FrontendTemplate
+	}
+	if project.BackendFramework == "" && project.FrontendFramework == "" {
+		s.logger.Warn("No framework detected for imported project", zap.String("clone_url", project.ImportURL))
+	}
+
+	err = s.enqueueWorkspaceDeletion(project.HashID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Project imported successfully", zap.Any("project", project))
+	return s.projectRepo.CreateProject(project)
+}
+
+// enqueueWorkspaceDeletion schedules the fallback deletion of the workspace once its connection expires.
+func (s *ProjectService) enqueueWorkspaceDeletion(workspaceID string) error {
 	payloadBytes, err := json.Marshal(asynq_task.CreateDeleteWorkspaceTaskPayload{
-		WorkspaceID: project.HashID,
+		WorkspaceID: workspaceID,
 	})
 	if err != nil {
 		s.logger.Error("Failed to marshal payload", zap.Error(err))
-		return nil, err
+		return err
 	}
 	_, err = s.asynqClient.Enqueue(
 		asynq.NewTask(constants.DeleteWorkspaceTaskType, payloadBytes),
 		asynq.ProcessIn(constants.ProjectConnectionTTL+10*time.Minute),
 		asynq.MaxRetry(3),
-		asynq.TaskID("delete:fallback:"+project.HashID),
+		asynq.TaskID("delete:fallback:"+workspaceID),
 	)
+	if err != nil {
+		s.logger.Error("Failed to enqueue workspace deletion", zap.Error(err))
+		return err
+	}
+	return nil
+}
 
-	s.logger.Info("Project created successfully with repository", zap.Any("project", project), zap.Any("repository", repository))
-	return s.projectRepo.CreateProject(project)
+// authenticatedCloneURL returns the clone URL of the import with the credentials of the request embedded.
+func authenticatedCloneURL(requestData request.ImportProjectRequest) string {
+	cloneURL, err := url.Parse(requestData.CloneURL)
+	if err != nil || requestData.Token == "" {
+		return requestData.CloneURL
+	}
+	username := requestData.Username
+	if username == "" {
+		username = "git"
+	}
+	cloneURL.User = url.UserPassword(username, requestData.Token)
+	return cloneURL.String()
 }
 func (s *ProjectService) CreateProjectWorkspace(projectID int, backendTemplate string) error {
 	project, err := s.projectRepo.GetProjectById(projectID)
@@ -172,23 +332,39 @@ func (s *ProjectService) CreateProjectWorkspace(projectID int, backendTemplate s
 	}
 
 	organisation, err := s.organisationRepository.GetOrganisationByID(uint(int(project.OrganisationID)))
-	spaceOrProjectName := s.gitnessService.GetSpaceOrProjectName(organisation)
-	httpPrefix := "https"
-
-	if config.AppEnv() == constants.Development {
-		httpPrefix = "http"
+	if err != nil {
+		return err
+	}
+	provider, err := s.gitProviderResolver.ForProject(project)
+	if err != nil {
+		return err
+	}
+	remoteGitURL := git_providers.ProjectRemoteURL(provider, organisation, project)
+	gitUsername, gitPassword, err := provider.Credentials()
+	if err != nil {
+		s.logger.Error("Error getting git credentials", zap.Error(err))
+		return err
+	}
+	createWorkspaceRequest := &request.CreateWorkspaceRequest{
+		WorkspaceId:     project.HashID,
+		BackendTemplate: &backendTemplate,
+		//FrontendTemplate: &backendService,
+		RemoteURL:       remoteGitURL,
+		GitnessUserName: gitUsername,
+		GitnessToken:    gitPassword,
+		DefaultBranch:   project.DefaultBranch,
+	}
+	if project.ImportURL != "" {
+		// Imported projects are restored from their repository, seeding them from a template would push unrelated history.
+		createWorkspaceRequest.RemoteURL = ""
+		createWorkspaceRequest.Import = &request.ImportSource{
+			CloneURL: remoteGitURL,
+			UserName: gitUsername,
+			Token:    gitPassword,
+		}
 	}
-	remoteGitURL := fmt.Sprintf("%s://%s:%s@%s/git/%s/%s.git", httpPrefix, config.GitnessUser(), config.GitnessToken(), config.GitnessHost(), spaceOrProjectName, project.Name)
 	s.logger.Info("Active count is less than 1, creating workspace....")
-	_, err = s.workspaceServiceClient.CreateWorkspace(
-		&request.CreateWorkspaceRequest{
-			WorkspaceId:     project.HashID,
-			BackendTemplate: &backendTemplate,
-			//FrontendTemplate: &backendService,
-			RemoteURL:       remoteGitURL,
-			GitnessUserName: config.GitnessUser(),
-			GitnessToken:    config.GitnessToken(),
-		})
+	_, err = s.workspaceServiceClient.CreateWorkspace(createWorkspaceRequest)
 	if err != nil {
 		s.logger.Error("Failed to create workspace", zap.Error(err))
 		return err
@@ -288,16 +464,20 @@ func (s *ProjectService) GetActiveProjectCount(workspaceID string) (int, error)
 	return activeCount, nil
 }
 
-func (s *ProjectService) GetMainBranchCommits(organisation *models.Organisation, projectName string) (int, string, error) {
-	commits, err := s.gitnessService.GetAllCommitsOfProjectBranch(organisation, projectName)
+func (s *ProjectService) GetMainBranchCommits(organisation *models.Organisation, project *models.Project) (int, string, error) {
+	provider, err := s.gitProviderResolver.ForProject(project)
+	if err != nil {
+		return 0, "", err
+	}
+	commits, err := provider.GetBranchCommits(organisation, project, utils.ProjectBaseBranch(project))
 	if err != nil {
 		return 0, "", err
 	}
 	var lastCommitDate string
 	if len(commits.Commits) > 0 {
-		committerWhen := commits.Commits[0].Committer.When
+		committerWhen := commits.Commits[0].CommittedAt
 		fmt.Println("Committer 'When':", committerWhen)
-		lastCommitDate = utils.TimeAgo(commits.Commits[0].Committer.When, time.Now().UTC())
+		lastCommitDate = utils.TimeAgo(commits.Commits[0].CommittedAt, time.Now().UTC())
 	} else {
 		fmt.Println("No commits found.")
 	}
@@ -308,8 +488,12 @@ func (s *ProjectService) GetProjectById(projectId uint) (*models.Project, error)
 	return s.projectRepo.GetProjectById(int(projectId))
 }
 
+func (s *ProjectService) GetProjectByIdForUpdateWithTx(tx *gorm.DB, projectId uint) (*models.Project, error) {
+	return s.projectRepo.GetProjectByIdForUpdateWithTx(tx, int(projectId))
+}
+
 func NewProjectService(projectRepo *repositories.ProjectRepository,
-	gitnessService *git_providers.GitnessService,
+	gitProviderResolver *git_providers.GitProviderResolver,
 	organisationRepository *repositories.OrganisationRepository,
 	storyRepository *repositories.StoryRepository,
 	pullRequestRepository *repositories.PullRequestRepository,
@@ -320,7 +504,7 @@ func NewProjectService(projectRepo *repositories.ProjectRepository,
 ) *ProjectService {
 	return &ProjectService{
 		projectRepo:            projectRepo,
-		gitnessService:         gitnessService,
+		gitProviderResolver:    gitProviderResolver,
 		organisationRepository: organisationRepository,
 		storyRepository:        storyRepository,
 		pullRequestRepository:  pullRequestRepository,
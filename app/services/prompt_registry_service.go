@@ -0,0 +1,309 @@
+package services
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/llms"
+	"ai-developer/app/models"
+	"ai-developer/app/models/types"
+	"ai-developer/app/repositories"
+	"ai-developer/app/types/request"
+	"ai-developer/app/types/response"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// PromptRef identifies the version of a prompt an execution step was sent, it is kept in the request of the step.
+type PromptRef struct {
+	Key     string `json:"key"`
+	Version int    `json:"version"`
+	// ProjectOverride tells that the system prompt of the project replaced the prompt.
+	ProjectOverride bool `json:"project_override,omitempty"`
+}
+
+// PromptRegistryService keeps the versions of the prompts of the code generators per organisation, with the
+// prompt files as version 0. An execution is assigned a version of each prompt it uses the first time it uses
+// it: the active version, or a version picked at random by A/B weight while versions have weights.
+type PromptRegistryService struct {
+	promptVersionRepo *repositories.PromptVersionRepository
+	logger            *zap.Logger
+}
+
+func NewPromptRegistryService(promptVersionRepo *repositories.PromptVersionRepository, logger *zap.Logger) *PromptRegistryService {
+	return &PromptRegistryService{
+		promptVersionRepo: promptVersionRepo,
+		logger:            logger.Named("PromptRegistryService"),
+	}
+}
+
+// ResolvePrompt returns the content of the version of the prompt the execution is assigned to, assigning the
+// execution to a version when it is not assigned to one yet.
+func (s *PromptRegistryService) ResolvePrompt(organisationID, executionID uint, promptKey string) (string, PromptRef, error) {
+	ref := PromptRef{Key: promptKey}
+	if _, ok := constants.PromptDefaults[promptKey]; !ok {
+		return "", ref, types.ErrPromptNotFound
+	}
+	assignment, err := s.promptVersionRepo.GetExecutionPromptVersion(executionID, promptKey)
+	if err != nil {
+		return "", ref, err
+	}
+	if assignment == nil {
+		version, err := s.pickVersion(organisationID, promptKey)
+		if err != nil {
+			return "", ref, err
+		}
+		assignment = &models.ExecutionPromptVersion{
+			ExecutionID:    executionID,
+			OrganisationID: organisationID,
+			PromptKey:      promptKey,
+			Version:        version,
+		}
+		if err := s.promptVersionRepo.CreateExecutionPromptVersion(assignment); err != nil {
+			return "", ref, err
+		}
+		// Another step of the execution may have assigned it first, that assignment holds.
+		assignment, err = s.promptVersionRepo.GetExecutionPromptVersion(executionID, promptKey)
+		if err != nil || assignment == nil {
+			return "", ref, fmt.Errorf("failed to assign execution %d to a version of prompt %s: %v", executionID, promptKey, err)
+		}
+		s.logger.Info("Assigned execution to prompt version",
+			zap.Uint("execution_id", executionID), zap.String("prompt_key", promptKey), zap.Int("version", assignment.Version))
+	}
+	ref.Version = assignment.Version
+	content, err := s.versionContent(organisationID, promptKey, assignment.Version)
+	if err != nil {
+		return "", ref, err
+	}
+	return content, ref, nil
+}
+
+// GetPrompts returns the prompts of the registry with the versions the organisation uses.
+func (s *PromptRegistryService) GetPrompts(organisationID uint) ([]response.Prompt, error) {
+	keys := make([]string, 0, len(constants.PromptDefaults))
+	for key := range constants.PromptDefaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	prompts := make([]response.Prompt, 0, len(keys))
+	for _, key := range keys {
+		versions, err := s.promptVersionRepo.GetVersions(organisationID, key)
+		if err != nil {
+			return nil, err
+		}
+		prompt := response.Prompt{Key: key, ActiveVersion: constants.DefaultPromptVersion, Versions: len(versions)}
+		for _, version := range versions {
+			if version.IsActive {
+				prompt.ActiveVersion = version.Version
+			}
+			if version.ABWeight > 0 {
+				prompt.ABTest = true
+			}
+		}
+		prompts = append(prompts, prompt)
+	}
+	return prompts, nil
+}
+
+// GetVersions returns the versions of the prompt, newest first and ending with the default prompt file.
+func (s *PromptRegistryService) GetVersions(organisationID uint, promptKey string) ([]response.PromptVersion, error) {
+	if _, ok := constants.PromptDefaults[promptKey]; !ok {
+		return nil, types.ErrPromptNotFound
+	}
+	versions, err := s.promptVersionRepo.GetVersions(organisationID, promptKey)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]response.PromptVersion, 0, len(versions)+1)
+	active := false
+	for _, version := range versions {
+		result = append(result, toPromptVersionResponse(&version))
+		active = active || version.IsActive
+	}
+	defaultContent, err := readDefaultPrompt(promptKey)
+	if err != nil {
+		return nil, err
+	}
+	result = append(result, response.PromptVersion{
+		PromptKey:   promptKey,
+		Version:     constants.DefaultPromptVersion,
+		Content:     defaultContent,
+		Description: "Default prompt file",
+		IsActive:    !active,
+	})
+	return result, nil
+}
+
+// CreateVersion stores a new version of the prompt, a copy of the version executions use when it has no content.
+func (s *PromptRegistryService) CreateVersion(organisationID uint, promptKey string, createRequest request.CreatePromptVersionRequest) (*response.PromptVersion, error) {
+	if _, ok := constants.PromptDefaults[promptKey]; !ok {
+		return nil, types.ErrPromptNotFound
+	}
+	content := createRequest.Content
+	if strings.TrimSpace(content) == "" {
+		active, err := s.promptVersionRepo.GetActiveVersion(organisationID, promptKey)
+		if err != nil {
+			return nil, err
+		}
+		if active != nil {
+			content = active.Content
+		} else if content, err = readDefaultPrompt(promptKey); err != nil {
+			return nil, err
+		}
+	}
+	promptVersion := &models.PromptVersion{
+		OrganisationID: organisationID,
+		PromptKey:      promptKey,
+		Content:        content,
+		Description:    createRequest.Description,
+	}
+	if err := s.promptVersionRepo.CreateVersion(promptVersion); err != nil {
+		return nil, err
+	}
+	if createRequest.Activate {
+		if err := s.promptVersionRepo.SetActiveVersion(organisationID, promptKey, promptVersion); err != nil {
+			return nil, err
+		}
+	}
+	result := toPromptVersionResponse(promptVersion)
+	return &result, nil
+}
+
+// UpdateVersion changes the description, activation or A/B weight of the version.
+func (s *PromptRegistryService) UpdateVersion(organisationID uint, promptKey string, version int, updateRequest request.UpdatePromptVersionRequest) (*response.PromptVersion, error) {
+	if _, ok := constants.PromptDefaults[promptKey]; !ok {
+		return nil, types.ErrPromptNotFound
+	}
+	promptVersion, err := s.promptVersionRepo.GetVersion(organisationID, promptKey, version)
+	if err != nil {
+		return nil, err
+	}
+	if promptVersion == nil {
+		return nil, types.ErrPromptVersionNotFound
+	}
+	if updateRequest.ABWeight != nil {
+		if *updateRequest.ABWeight < 0 || *updateRequest.ABWeight > 100 {
+			return nil, fmt.Errorf("%w: A/B weight must be between 0 and 100", types.ErrInvalidPromptVersion)
+		}
+		promptVersion.ABWeight = *updateRequest.ABWeight
+	}
+	if updateRequest.Description != nil {
+		promptVersion.Description = *updateRequest.Description
+	}
+	if err := s.promptVersionRepo.UpdateVersion(promptVersion); err != nil {
+		return nil, err
+	}
+	if updateRequest.IsActive != nil && *updateRequest.IsActive != promptVersion.IsActive {
+		if *updateRequest.IsActive {
+			err = s.promptVersionRepo.SetActiveVersion(organisationID, promptKey, promptVersion)
+		} else {
+			err = s.promptVersionRepo.SetActiveVersion(organisationID, promptKey, nil)
+			promptVersion.IsActive = false
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := toPromptVersionResponse(promptVersion)
+	return &result, nil
+}
+
+// GetReport compares the success rate, iterations and cost of the executions assigned to each version of the prompt.
+func (s *PromptRegistryService) GetReport(organisationID uint, promptKey string) ([]response.PromptVariantReport, error) {
+	if _, ok := constants.PromptDefaults[promptKey]; !ok {
+		return nil, types.ErrPromptNotFound
+	}
+	stats, err := s.promptVersionRepo.GetVersionStats(organisationID, promptKey, steps.CODE_GENERATE_STEP.String())
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]response.PromptVariantReport, 0, len(stats))
+	for _, stat := range stats {
+		report := response.PromptVariantReport{
+			Version:    stat.Version,
+			Executions: stat.Executions,
+			Finished:   stat.Finished,
+			Succeeded:  stat.Succeeded,
+			TotalCost:  llms.EstimateCost(constants.PromptModels[promptKey], stat.PromptTokens, stat.CompletionTokens),
+		}
+		if stat.Finished > 0 {
+			report.SuccessRate = float64(stat.Succeeded) / float64(stat.Finished)
+		}
+		if stat.Executions > 0 {
+			report.AverageIterations = float64(stat.Iterations) / float64(stat.Executions)
+			report.AverageCost = report.TotalCost / float64(stat.Executions)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// pickVersion picks the version of the prompt for a new execution: one of the versions with an A/B weight at
+// random in proportion to the weights, else the active version.
+func (s *PromptRegistryService) pickVersion(organisationID uint, promptKey string) (int, error) {
+	weighted, err := s.promptVersionRepo.GetWeightedVersions(organisationID, promptKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(weighted) > 0 {
+		total := 0
+		for _, version := range weighted {
+			total += version.ABWeight
+		}
+		pick := rand.Intn(total)
+		for _, version := range weighted {
+			if pick < version.ABWeight {
+				return version.Version, nil
+			}
+			pick -= version.ABWeight
+		}
+	}
+	active, err := s.promptVersionRepo.GetActiveVersion(organisationID, promptKey)
+	if err != nil {
+		return 0, err
+	}
+	if active == nil {
+		return constants.DefaultPromptVersion, nil
+	}
+	return active.Version, nil
+}
+
+func (s *PromptRegistryService) versionContent(organisationID uint, promptKey string, version int) (string, error) {
+	if version == constants.DefaultPromptVersion {
+		return readDefaultPrompt(promptKey)
+	}
+	promptVersion, err := s.promptVersionRepo.GetVersion(organisationID, promptKey, version)
+	if err != nil {
+		return "", err
+	}
+	if promptVersion == nil {
+		return "", types.ErrPromptVersionNotFound
+	}
+	return promptVersion.Content, nil
+}
+
+func readDefaultPrompt(promptKey string) (string, error) {
+	path := filepath.Join(constants.PromptsDirectory, constants.PromptDefaults[promptKey])
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read prompt %s from %s: %w", promptKey, path, err)
+	}
+	return string(content), nil
+}
+
+func toPromptVersionResponse(promptVersion *models.PromptVersion) response.PromptVersion {
+	return response.PromptVersion{
+		PromptKey:   promptVersion.PromptKey,
+		Version:     promptVersion.Version,
+		Content:     promptVersion.Content,
+		Description: promptVersion.Description,
+		IsActive:    promptVersion.IsActive,
+		ABWeight:    promptVersion.ABWeight,
+		CreatedAt:   promptVersion.CreatedAt,
+	}
+}
@@ -4,46 +4,282 @@ import (
 	"ai-developer/app/constants"
 	"ai-developer/app/models"
 	"ai-developer/app/models/dtos/asynq_task"
+	"ai-developer/app/models/dtos/git_provider"
 	"ai-developer/app/repositories"
+	"ai-developer/app/services/git_providers"
+	"ai-developer/app/utils"
 	"encoding/json"
 	"fmt"
 	"github.com/hibiken/asynq"
+	"net/http"
+	"strings"
 	"time"
 )
 
 type PullRequestCommentsService struct {
 	pullRequestCommentsRepo *repositories.PullRequestCommentsRepository
 	pullRequestRepo         *repositories.PullRequestRepository
+	projectRepo             *repositories.ProjectRepository
+	organisationRepo        *repositories.OrganisationRepository
 	storyService            *StoryService
+	gitProviderResolver     *git_providers.GitProviderResolver
 	asynqClient             *asynq.Client
 }
 
 func NewPullRequestCommentsService(
 	pullRequestCommentsRepo *repositories.PullRequestCommentsRepository,
 	pullRequestRepo *repositories.PullRequestRepository,
+	projectRepo *repositories.ProjectRepository,
+	organisationRepo *repositories.OrganisationRepository,
 	storyService *StoryService,
+	gitProviderResolver *git_providers.GitProviderResolver,
 	asynqClient *asynq.Client,
 ) *PullRequestCommentsService {
 	return &PullRequestCommentsService{
 		pullRequestCommentsRepo: pullRequestCommentsRepo,
 		pullRequestRepo:         pullRequestRepo,
+		projectRepo:             projectRepo,
+		organisationRepo:        organisationRepo,
 		storyService:            storyService,
+		gitProviderResolver:     gitProviderResolver,
 		asynqClient:             asynqClient,
 	}
 }
-func (s *PullRequestCommentsService) CreateComment(pullRequestID uint, comment string) error {
-	err := s.pullRequestCommentsRepo.CreateComment(pullRequestID, comment)
+
+// CreateComment stores a comment made in SuperCoder, mirrors it to the pull request on the git host and
+// re-executes the story to address it. A path and line anchor the comment to a line of the changed code.
+func (s *PullRequestCommentsService) CreateComment(pullRequestID uint, comment string, path string, line int) error {
+	pullRequest, err := s.pullRequestRepo.GetPullRequestByID(pullRequestID)
+	if err != nil {
+		return err
+	}
+	pullRequestComment := &models.PullRequestComments{
+		StoryID:       pullRequest.StoryID,
+		PullRequestID: pullRequestID,
+		Comment:       comment,
+		Path:          path,
+		Line:          line,
+		Source:        constants.CommentSourceUser,
+	}
+	err = s.pullRequestCommentsRepo.CreateComment(pullRequestComment)
 	if err != nil {
 		return err
 	}
+
+	// Mirroring is best effort, the comment is addressed either way.
+	hostComment, err := s.postCommentToHost(pullRequest, comment, path, line)
+	if err != nil {
+		fmt.Println("Error posting comment to git host : ", err)
+	} else if err = s.pullRequestCommentsRepo.UpdateCommentExternalID(pullRequestComment, hostComment.ID); err != nil {
+		fmt.Println("Error updating comment external id : ", err)
+	}
+
 	fmt.Println("Enquing Comment to execute!")
-	pullRequest, _ := s.pullRequestRepo.GetPullRequestByID(pullRequestID)
+	return s.enqueueReExecution(pullRequest)
+}
+
+// HandleWebhook stores a comment made on the git host and re-executes the story to address it. Deliveries for
+// other events, unknown pull requests and comments SuperCoder posted itself are ignored.
+func (s *PullRequestCommentsService) HandleWebhook(remoteType string, header http.Header, body []byte) error {
+	gitProvider, err := s.gitProviderResolver.ForRemoteType(remoteType)
+	if err != nil {
+		return err
+	}
+	event, err := gitProvider.ParseCommentWebhook(header, body)
+	if err != nil {
+		return err
+	}
+	if event == nil || strings.Contains(event.Comment.Body, constants.CommentMarker) {
+		return nil
+	}
+
+	pullRequest, err := s.findPullRequest(remoteType, event.RepositoryPath, event.PullRequestNumber)
+	if err != nil || pullRequest == nil {
+		return err
+	}
+	existing, err := s.pullRequestCommentsRepo.GetCommentByExternalID(pullRequest.ID, event.Comment.ID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		fmt.Println("Comment already received : ", event.Comment.ID)
+		return nil
+	}
+
+	err = s.pullRequestCommentsRepo.CreateComment(&models.PullRequestComments{
+		StoryID:       pullRequest.StoryID,
+		PullRequestID: pullRequest.ID,
+		Comment:       event.Comment.Body,
+		ExternalID:    event.Comment.ID,
+		Author:        event.Comment.Author,
+		Path:          event.Comment.Path,
+		Line:          event.Comment.Line,
+		Source:        constants.CommentSourceHost,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("Enquing host comment to execute!")
+	return s.enqueueReExecution(pullRequest)
+}
+
+// CreateAgentReply posts the reply of the agent on the pull request and stores it without triggering a
+// re-execution.
+func (s *PullRequestCommentsService) CreateAgentReply(pullRequest *models.PullRequest, reply string) error {
+	hostComment, err := s.postToHost(pullRequest, reply+"\n\n"+constants.CommentMarker)
+	if err != nil {
+		return err
+	}
+	return s.pullRequestCommentsRepo.CreateComment(&models.PullRequestComments{
+		StoryID:       pullRequest.StoryID,
+		PullRequestID: pullRequest.ID,
+		Comment:       reply,
+		ExternalID:    hostComment.ID,
+		Author:        hostComment.Author,
+		Source:        constants.CommentSourceAgent,
+		Status:        constants.CommentStatusResolved,
+	})
+}
+
+// CommentResolution is the outcome of addressing a review comment.
+type CommentResolution struct {
+	Status string
+	Note   string
+}
+
+// GetUnresolvedComments returns the review comments the next re-execution addresses.
+func (s *PullRequestCommentsService) GetUnresolvedComments(pullRequestID uint) ([]models.PullRequestComments, error) {
+	return s.pullRequestCommentsRepo.GetUnresolvedCommentsByPullRequestID(pullRequestID)
+}
+
+func (s *PullRequestCommentsService) AssignCommentsToExecution(comments []models.PullRequestComments, executionID uint) error {
+	commentIDs := make([]uint, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.ID)
+	}
+	return s.pullRequestCommentsRepo.AssignCommentsToExecution(commentIDs, executionID)
+}
+
+// GetExecutionComments returns the unresolved comments the execution set out to address.
+func (s *PullRequestCommentsService) GetExecutionComments(executionID uint) ([]models.PullRequestComments, error) {
+	comments, err := s.pullRequestCommentsRepo.GetCommentsByExecutionID(executionID)
+	if err != nil {
+		return nil, err
+	}
+	var unresolved []models.PullRequestComments
+	for _, comment := range comments {
+		if comment.Status == constants.CommentStatusUnresolved {
+			unresolved = append(unresolved, comment)
+		}
+	}
+	return unresolved, nil
+}
+
+// ResolveComments records the resolution of each comment once the fix is pushed, resolves the threads of the
+// resolved host comments on the git host and replies with the status of every comment, grouped by file.
+// Comments without a resolution are taken as resolved.
+func (s *PullRequestCommentsService) ResolveComments(pullRequest *models.PullRequest, comments []models.PullRequestComments, resolutions map[uint]CommentResolution, summary string) error {
+	project, err := s.pullRequestRepo.GetPullRequestWithDetails(pullRequest.ID)
+	if err != nil {
+		return err
+	}
+	organisation, err := s.organisationRepo.GetOrganisationByID(project.OrganisationID)
+	if err != nil {
+		return err
+	}
+	gitProvider, err := s.gitProviderResolver.ForPullRequest(pullRequest, project)
+	if err != nil {
+		return err
+	}
+
+	for i := range comments {
+		comment := &comments[i]
+		resolution, ok := resolutions[comment.ID]
+		if !ok || resolution.Status == "" {
+			resolution.Status = constants.CommentStatusResolved
+		}
+		err = s.pullRequestCommentsRepo.UpdateCommentResolution(comment, resolution.Status, resolution.Note)
+		if err != nil {
+			return err
+		}
+		if comment.Source != constants.CommentSourceHost || comment.ExternalID == "" || resolution.Status != constants.CommentStatusResolved {
+			continue
+		}
+		reply := "Resolved."
+		if resolution.Note != "" {
+			reply = "Resolved: " + resolution.Note
+		}
+		hostComment := git_provider.Comment{ID: comment.ExternalID, Path: comment.Path, Line: comment.Line}
+		err = gitProvider.ResolvePullRequestComment(organisation, project, pullRequest.PullRequestNumber, hostComment, reply+"\n\n"+constants.CommentMarker)
+		if err != nil {
+			// The status reply below still tells the reviewer.
+			fmt.Println("Error resolving comment on git host : ", err)
+		}
+	}
+	return s.CreateAgentReply(pullRequest, summary+"\n\n"+formatResolutions(comments))
+}
+
+func (s *PullRequestCommentsService) GetAllCommentsByPullRequestID(pullRequestID uint) ([]models.PullRequestComments, error) {
+	return s.pullRequestCommentsRepo.GetAllCommentsByPullRequestID(pullRequestID)
+}
+
+func (s *PullRequestCommentsService) postToHost(pullRequest *models.PullRequest, body string) (*git_provider.Comment, error) {
+	gitProvider, organisation, project, err := s.resolveHost(pullRequest)
+	if err != nil {
+		return nil, err
+	}
+	return gitProvider.CreatePullRequestComment(organisation, project, pullRequest.PullRequestNumber, body)
+}
+
+// postCommentToHost posts a comment anchored to a line as a review comment on the line at the source commit of
+// the pull request. Hosts only accept review comments on lines of the diff, other comments are posted to the
+// conversation with their location.
+func (s *PullRequestCommentsService) postCommentToHost(pullRequest *models.PullRequest, comment, path string, line int) (*git_provider.Comment, error) {
+	gitProvider, organisation, project, err := s.resolveHost(pullRequest)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" && line > 0 {
+		hostComment, err := gitProvider.CreatePullRequestLineComment(organisation, project, pullRequest.PullRequestNumber, pullRequest.SourceSHA, path, line, comment+"\n\n"+constants.CommentMarker)
+		if err == nil {
+			return hostComment, nil
+		}
+		fmt.Println("Error creating line comment, posting it to the conversation : ", err)
+	}
+	return gitProvider.CreatePullRequestComment(organisation, project, pullRequest.PullRequestNumber, formatHostComment(comment, path, line))
+}
+
+func (s *PullRequestCommentsService) resolveHost(pullRequest *models.PullRequest) (git_providers.GitProvider, *models.Organisation, *models.Project, error) {
+	project, err := s.pullRequestRepo.GetPullRequestWithDetails(pullRequest.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	organisation, err := s.organisationRepo.GetOrganisationByID(project.OrganisationID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	gitProvider, err := s.gitProviderResolver.ForPullRequest(pullRequest, project)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return gitProvider, organisation, project, nil
+}
 
+// findPullRequest matches the repository path of the webhook against the repository paths of the projects.
+func (s *PullRequestCommentsService) findPullRequest(remoteType, repositoryPath string, pullRequestNumber int) (*models.PullRequest, error) {
+	project, err := findProjectByRepositoryPath(s.projectRepo, s.organisationRepo, s.gitProviderResolver, remoteType, repositoryPath)
+	if err != nil || project == nil {
+		return nil, err
+	}
+	return s.pullRequestRepo.GetPullRequestByProjectAndNumber(project.ID, remoteType, pullRequestNumber)
+}
+
+func (s *PullRequestCommentsService) enqueueReExecution(pullRequest *models.PullRequest) error {
 	//Enqueue to Asynq
 	payload := asynq_task.CreateJobPayload{
 		StoryID:       pullRequest.StoryID,
 		ReExecute:     true,
-		PullRequestId: pullRequestID,
+		PullRequestId: pullRequest.ID,
 	}
 	// Serialize the payload to JSON
 	payloadBytes, err := json.Marshal(payload)
@@ -66,6 +302,51 @@ func (s *PullRequestCommentsService) CreateComment(pullRequestID uint, comment s
 	return nil
 }
 
-func (s *PullRequestCommentsService) GetAllCommentsByPullRequestID(pullRequestID uint) ([]models.PullRequestComments, error) {
-	return s.pullRequestCommentsRepo.GetAllCommentsByPullRequestID(pullRequestID)
+// formatHostComment prefixes anchored comments posted to the conversation with their location, and marks them
+// so that the webhook echo is ignored.
+func formatHostComment(comment, path string, line int) string {
+	if path != "" && line > 0 {
+		comment = fmt.Sprintf("`%s:%d`\n\n%s", path, line, comment)
+	} else if path != "" {
+		comment = fmt.Sprintf("`%s`\n\n%s", path, comment)
+	}
+	return comment + "\n\n" + constants.CommentMarker
+}
+
+func formatResolutions(comments []models.PullRequestComments) string {
+	var sb strings.Builder
+	for _, group := range utils.GroupCommentsByFile(comments) {
+		if group.Path == "" {
+			sb.WriteString("**General**\n")
+		} else {
+			sb.WriteString(fmt.Sprintf("**%s**\n", group.Path))
+		}
+		for _, comment := range group.Comments {
+			check := " "
+			if comment.Status == constants.CommentStatusResolved {
+				check = "x"
+			}
+			location := ""
+			if comment.Line > 0 {
+				location = fmt.Sprintf("line %d: ", comment.Line)
+			}
+			sb.WriteString(fmt.Sprintf("- [%s] %s%s", check, location, excerpt(comment.Comment)))
+			if comment.Resolution != "" {
+				sb.WriteString(" — " + comment.Resolution)
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// excerpt shortens a comment to its first line for quoting in replies.
+func excerpt(comment string) string {
+	const maxExcerptLength = 80
+	line, _, _ := strings.Cut(strings.TrimSpace(comment), "\n")
+	if runes := []rune(line); len(runes) > maxExcerptLength {
+		line = string(runes[:maxExcerptLength]) + "…"
+	}
+	return "\"" + line + "\""
 }
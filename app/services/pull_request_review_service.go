@@ -0,0 +1,431 @@
+package services
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/llms"
+	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/asynq_task"
+	"ai-developer/app/models/dtos/git_provider"
+	"ai-developer/app/repositories"
+	"ai-developer/app/services/git_providers"
+	"ai-developer/app/utils"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hibiken/asynq"
+	"gorm.io/gorm"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxReviewDiffLength caps the diff handed to the LLM when reviewing a pull request.
+const maxReviewDiffLength = 60000
+
+type reviewComment struct {
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Category string `json:"category"`
+	Severity string `json:"severity"`
+	Body     string `json:"body"`
+}
+
+type reviewResult struct {
+	Summary  string          `json:"summary"`
+	Comments []reviewComment `json:"comments"`
+}
+
+// PullRequestReviewService reviews pull requests SuperCoder did not write. Every review runs as an
+// execution of type REVIEW, so that its progress shows in the activity log.
+type PullRequestReviewService struct {
+	reviewRepo           *repositories.PullRequestReviewRepository
+	projectRepo          *repositories.ProjectRepository
+	organisationRepo     *repositories.OrganisationRepository
+	pullRequestRepo      *repositories.PullRequestRepository
+	executionRepo        *repositories.ExecutionRepository
+	executionStepService *ExecutionStepService
+	activityLogService   *ActivityLogService
+	llmAPIKeyService     *LLMAPIKeyService
+	gitProviderResolver  *git_providers.GitProviderResolver
+	asynqClient          *asynq.Client
+}
+
+func NewPullRequestReviewService(
+	reviewRepo *repositories.PullRequestReviewRepository,
+	projectRepo *repositories.ProjectRepository,
+	organisationRepo *repositories.OrganisationRepository,
+	pullRequestRepo *repositories.PullRequestRepository,
+	executionRepo *repositories.ExecutionRepository,
+	executionStepService *ExecutionStepService,
+	activityLogService *ActivityLogService,
+	llmAPIKeyService *LLMAPIKeyService,
+	gitProviderResolver *git_providers.GitProviderResolver,
+	asynqClient *asynq.Client,
+) *PullRequestReviewService {
+	return &PullRequestReviewService{
+		reviewRepo:           reviewRepo,
+		projectRepo:          projectRepo,
+		organisationRepo:     organisationRepo,
+		pullRequestRepo:      pullRequestRepo,
+		executionRepo:        executionRepo,
+		executionStepService: executionStepService,
+		activityLogService:   activityLogService,
+		llmAPIKeyService:     llmAPIKeyService,
+		gitProviderResolver:  gitProviderResolver,
+		asynqClient:          asynqClient,
+	}
+}
+
+// RequestReview queues a review of the pull request with the given number on the project repository.
+func (s *PullRequestReviewService) RequestReview(projectID uint, pullRequestNumber int) (*models.PullRequestReview, error) {
+	project, err := s.projectRepo.GetProjectById(int(projectID))
+	if err != nil {
+		return nil, err
+	}
+	gitProvider, err := s.gitProviderResolver.ForProject(project)
+	if err != nil {
+		return nil, err
+	}
+	organisation, err := s.organisationRepo.GetOrganisationByID(project.OrganisationID)
+	if err != nil {
+		return nil, err
+	}
+	pullRequest, err := gitProvider.FetchPullRequest(organisation, project, pullRequestNumber)
+	if err != nil {
+		return nil, err
+	}
+	return s.queueReview(project, gitProvider.RemoteType(), pullRequestNumber, pullRequest.SourceSHA)
+}
+
+// HandleWebhook queues a review when a pull request is opened or pushed to on the git host. Pull requests
+// SuperCoder opened itself are left alone, they are reviewed through their comments.
+func (s *PullRequestReviewService) HandleWebhook(remoteType string, header http.Header, body []byte) error {
+	gitProvider, err := s.gitProviderResolver.ForRemoteType(remoteType)
+	if err != nil {
+		return err
+	}
+	event, err := gitProvider.ParsePullRequestWebhook(header, body)
+	if err != nil || event == nil {
+		return err
+	}
+	switch event.Action {
+	case git_provider.PullRequestOpened, git_provider.PullRequestUpdated, git_provider.PullRequestReopened:
+	default:
+		return nil
+	}
+
+	project, err := findProjectByRepositoryPath(s.projectRepo, s.organisationRepo, s.gitProviderResolver, remoteType, event.RepositoryPath)
+	if err != nil || project == nil {
+		return err
+	}
+	pullRequest, err := s.pullRequestRepo.GetPullRequestByProjectAndNumber(project.ID, remoteType, event.PullRequestNumber)
+	if err != nil {
+		return err
+	}
+	if pullRequest != nil {
+		return nil
+	}
+	existing, err := s.reviewRepo.GetReviewBySourceSHA(project.ID, event.PullRequestNumber, event.SourceSHA)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		fmt.Println("Pull request already reviewed at : ", event.SourceSHA)
+		return nil
+	}
+	_, err = s.queueReview(project, remoteType, event.PullRequestNumber, event.SourceSHA)
+	return err
+}
+
+func (s *PullRequestReviewService) GetReviewsByProjectID(projectID uint) ([]models.PullRequestReview, error) {
+	return s.reviewRepo.GetReviewsByProjectID(projectID)
+}
+
+// GetReviewActivityLogs returns the activity log of the execution that ran the review of the project.
+func (s *PullRequestReviewService) GetReviewActivityLogs(projectID, reviewID uint) ([]models.ActivityLog, error) {
+	review, err := s.reviewRepo.GetReviewByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.ProjectID != projectID {
+		return nil, errors.New("review not found")
+	}
+	if review.ExecutionID == 0 {
+		return []models.ActivityLog{}, nil
+	}
+	return s.activityLogService.GetActivityLogsByExecutionID(review.ExecutionID)
+}
+
+// RunReview reviews the pull request with the LLM and posts the findings as line comments and a summary. A review
+// which fails stays queued to be retried, final tells that it is the last attempt. Retries reuse the findings of
+// the earlier attempt and skip the comments it already posted.
+func (s *PullRequestReviewService) RunReview(reviewID uint, final bool) error {
+	review, err := s.reviewRepo.GetReviewByID(reviewID)
+	if err != nil {
+		return err
+	}
+	if review.Status != constants.ReviewQueued {
+		fmt.Println("Review already ran : ", review.ID)
+		return nil
+	}
+	project, err := s.projectRepo.GetProjectById(int(review.ProjectID))
+	if err != nil {
+		return err
+	}
+	organisation, err := s.organisationRepo.GetOrganisationByID(project.OrganisationID)
+	if err != nil {
+		return err
+	}
+	gitProvider, err := s.gitProviderResolver.ForRemoteType(review.RemoteType)
+	if err != nil {
+		return err
+	}
+	pullRequest, err := gitProvider.FetchPullRequest(organisation, project, review.PullRequestNumber)
+	if err != nil {
+		return err
+	}
+
+	var execution *models.Execution
+	if review.ExecutionID != 0 {
+		execution, err = s.executionRepo.GetExecutionByID(review.ExecutionID)
+	} else {
+		execution, err = s.executionRepo.CreateReviewExecution(pullRequest.SourceBranch)
+	}
+	if err != nil {
+		return err
+	}
+	previousResult, err := s.previousReviewResult(execution.ID)
+	if err != nil {
+		return err
+	}
+	review.ExecutionID = execution.ID
+	review.SourceSHA = pullRequest.SourceSHA
+	if err = s.reviewRepo.UpdateReview(review); err != nil {
+		return err
+	}
+	executionStep, err := s.executionStepService.CreateExecutionStep(execution.ID, steps.PULL_REQUEST_REVIEW_STEP.String(), steps.LLM.String(), map[string]interface{}{
+		"pull_request_number": review.PullRequestNumber,
+		"source_sha":          pullRequest.SourceSHA,
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := s.review(gitProvider, organisation, project, pullRequest, execution, executionStep, previousResult)
+	if err != nil {
+		if stepErr := s.executionStepService.UpdateExecutionStepStatus(executionStep, "FAILED"); stepErr != nil {
+			fmt.Println("Error updating execution step : ", stepErr)
+		}
+		if !final {
+			if logErr := s.activityLogService.CreateActivityLog(execution.ID, executionStep.ID, "WARNING", fmt.Sprintf("Review of pull request #%d failed, retrying: %s", review.PullRequestNumber, err.Error())); logErr != nil {
+				fmt.Println("Error creating activity log : ", logErr)
+			}
+			return err
+		}
+		review.Status = constants.ReviewFailed
+		if updateErr := s.reviewRepo.UpdateReview(review); updateErr != nil {
+			fmt.Println("Error updating review : ", updateErr)
+		}
+		if logErr := s.activityLogService.CreateActivityLog(execution.ID, executionStep.ID, "ERROR", fmt.Sprintf("Review of pull request #%d failed: %s", review.PullRequestNumber, err.Error())); logErr != nil {
+			fmt.Println("Error creating activity log : ", logErr)
+		}
+		if statusErr := s.executionRepo.UpdateStatus(execution.ID, "FAILED"); statusErr != nil {
+			fmt.Println("Error updating execution status : ", statusErr)
+		}
+		return err
+	}
+
+	err = s.executionStepService.UpdateExecutionStepStatus(executionStep, "SUCCESS")
+	if err != nil {
+		return err
+	}
+	review.Status = constants.ReviewCompleted
+	review.Summary = result.Summary
+	review.CommentsCount = len(result.Comments)
+	if err = s.reviewRepo.UpdateReview(review); err != nil {
+		return err
+	}
+	err = s.activityLogService.CreateActivityLog(execution.ID, executionStep.ID, "INFO", fmt.Sprintf("Reviewed pull request #%d with %d comments.", review.PullRequestNumber, len(result.Comments)))
+	if err != nil {
+		return err
+	}
+	return s.executionRepo.UpdateStatus(execution.ID, constants.Done)
+}
+
+func (s *PullRequestReviewService) queueReview(project *models.Project, remoteType string, pullRequestNumber int, sourceSHA string) (*models.PullRequestReview, error) {
+	review := &models.PullRequestReview{
+		ProjectID:         project.ID,
+		RemoteType:        remoteType,
+		PullRequestNumber: pullRequestNumber,
+		SourceSHA:         sourceSHA,
+		Status:            constants.ReviewQueued,
+	}
+	if err := s.reviewRepo.CreateReview(review); err != nil {
+		return nil, err
+	}
+	payloadBytes, err := json.Marshal(asynq_task.ReviewPullRequestPayload{ReviewID: review.ID})
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.asynqClient.Enqueue(asynq.NewTask(constants.ReviewPullRequestTaskType, payloadBytes),
+		asynq.MaxRetry(3),
+		asynq.Timeout(10*time.Minute),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// previousReviewResult returns the findings an earlier attempt of the review stored, nil if there are none.
+func (s *PullRequestReviewService) previousReviewResult(executionID uint) (*reviewResult, error) {
+	executionStep, err := s.executionStepService.FetchLatestExecutionStepOfNames(executionID, []string{steps.PULL_REQUEST_REVIEW_STEP.String()})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	stored, ok := executionStep.Response["review"]
+	if !ok {
+		return nil, nil
+	}
+	storedJSON, err := json.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+	var result reviewResult
+	if err := json.Unmarshal(storedJSON, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// review posts the findings of the review, asking the LLM for them unless an earlier attempt stored them. The
+// findings are stored before they are posted, comments found on the pull request already are not posted again.
+func (s *PullRequestReviewService) review(
+	gitProvider git_providers.GitProvider,
+	organisation *models.Organisation,
+	project *models.Project,
+	pullRequest *git_provider.PullRequest,
+	execution *models.Execution,
+	executionStep *models.ExecutionStep,
+	result *reviewResult,
+) (*reviewResult, error) {
+	err := s.activityLogService.CreateActivityLog(execution.ID, executionStep.ID, "INFO", fmt.Sprintf("Reviewing pull request #%d '%s'...", pullRequest.Number, pullRequest.Title))
+	if err != nil {
+		return nil, err
+	}
+	diff, err := gitProvider.GetPullRequestDiff(organisation, project, pullRequest.MergeBaseSHA, pullRequest.SourceSHA)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(diff) == "" {
+		return &reviewResult{Summary: "The pull request has no changes to review."}, nil
+	}
+
+	var usage *llms.OpenAiUsage
+	if result == nil {
+		result, usage, err = s.reviewDiff(project, pullRequest, diff)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = s.executionStepService.UpdateExecutionStepResponse(executionStep, map[string]interface{}{
+		"review":    result,
+		"llm_usage": usage,
+	}, "IN_PROGRESS")
+	if err != nil {
+		return nil, err
+	}
+
+	existingComments, err := gitProvider.GetPullRequestComments(organisation, project, pullRequest.Number)
+	if err != nil {
+		return nil, err
+	}
+	posted := make(map[string]bool)
+	for _, comment := range existingComments {
+		posted[strings.TrimSpace(comment.Body)] = true
+	}
+
+	// Hosts only accept line comments on lines of the diff, the others are listed in the summary.
+	commentable := utils.DiffNewLines(diff)
+	var unanchored []reviewComment
+	for _, comment := range result.Comments {
+		body := formatReviewComment(comment) + "\n\n" + constants.CommentMarker
+		if posted[strings.TrimSpace(body)] {
+			continue
+		}
+		if comment.Path != "" && commentable[comment.Path][comment.Line] {
+			_, err = gitProvider.CreatePullRequestLineComment(organisation, project, pullRequest.Number, pullRequest.SourceSHA, comment.Path, comment.Line, body)
+			if err == nil {
+				continue
+			}
+			fmt.Println("Error creating line comment : ", err)
+		}
+		unanchored = append(unanchored, comment)
+	}
+
+	summary := "## SuperCoder review\n\n" + result.Summary
+	if len(unanchored) > 0 {
+		summary += "\n\n### Further comments\n"
+		for _, comment := range unanchored {
+			location := ""
+			if comment.Path != "" {
+				location = fmt.Sprintf("`%s:%d` ", comment.Path, comment.Line)
+			}
+			summary += "\n- " + location + strings.ReplaceAll(formatReviewComment(comment), "\n", " ")
+		}
+	}
+	summary += "\n\n" + constants.CommentMarker
+	if posted[strings.TrimSpace(summary)] {
+		return result, nil
+	}
+	_, err = gitProvider.CreatePullRequestComment(organisation, project, pullRequest.Number, summary)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *PullRequestReviewService) reviewDiff(project *models.Project, pullRequest *git_provider.PullRequest, diff string) (*reviewResult, *llms.OpenAiUsage, error) {
+	llmAPIKey, err := s.llmAPIKeyService.GetLLMAPIKeyByModelName(constants.GPT_4O, project.OrganisationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if llmAPIKey == nil || llmAPIKey.LLMAPIKey == "" {
+		return nil, nil, fmt.Errorf("LLM API Key for model %s not found in database", constants.GPT_4O)
+	}
+	if len(diff) > maxReviewDiffLength {
+		diff = diff[:maxReviewDiffLength] + "\n... diff truncated ..."
+	}
+	response, usage, err := llms.NewOpenAiClient(llmAPIKey.LLMAPIKey).ChatCompletionWithUsage([]llms.OpenAiChatCompletionMessage{
+		{
+			Role: "system",
+			Content: "You are a senior engineer reviewing a pull request. Look for bugs, security issues, style problems and missing test coverage. " +
+				"Only comment on real problems in the changed code, do not praise and do not restate the change. " +
+				"Reply with a JSON object with the keys `summary`, a short markdown assessment of the pull request, and `comments`, an array of objects " +
+				"with the keys `path`, `line` (the line number in the new version of the file, on a line the diff adds or shows), " +
+				"`category` (one of bug, security, style, tests), `severity` (one of high, medium, low) and `body`.",
+		},
+		{
+			Role: "user",
+			Content: fmt.Sprintf("Project: %s\n%s\nBackend framework: %s\nFrontend framework: %s\n\nPull request: %s\n%s\n\nDiff:\n%s",
+				project.Name, project.Description, project.BackendFramework, project.FrontendFramework,
+				pullRequest.Title, pullRequest.Description, diff),
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var result reviewResult
+	if err := json.Unmarshal([]byte(strings.TrimSpace(utils.StripCodeFence(response))), &result); err != nil {
+		return nil, usage, fmt.Errorf("failed to parse review: %w", err)
+	}
+	return &result, usage, nil
+}
+
+func formatReviewComment(comment reviewComment) string {
+	return fmt.Sprintf("**%s** (%s): %s", comment.Category, comment.Severity, strings.TrimSpace(comment.Body))
+}
@@ -1,44 +1,48 @@
 package services
 
 import (
-	"ai-developer/app/config"
 	"ai-developer/app/constants"
 	"ai-developer/app/models"
-	"ai-developer/app/models/dtos/gitness"
+	"ai-developer/app/models/dtos/git_provider"
+	"ai-developer/app/models/types"
 	"ai-developer/app/repositories"
 	"ai-developer/app/services/git_providers"
 	"ai-developer/app/types/response"
 	"ai-developer/app/utils"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type PullRequestService struct {
 	pullRequestRepo         *repositories.PullRequestRepository
 	pullRequestCommentsRepo *repositories.PullRequestCommentsRepository
-	gitService              *git_providers.GitnessService
+	gitProviderResolver     *git_providers.GitProviderResolver
 	organisationRepo        *repositories.OrganisationRepository
 	storyRepo               *repositories.StoryRepository
 	projectRepo             *repositories.ProjectRepository
 	executionRepo           *repositories.ExecutionRepository
 	executionOutputRepo     *repositories.ExecutionOutputRepository
+	webhookService          *OrganisationWebhookService
 }
 
 func NewPullRequestService(pullRequestRepo *repositories.PullRequestRepository, pullRequestCommentsRepo *repositories.PullRequestCommentsRepository,
-	gitService *git_providers.GitnessService, organisationRepo *repositories.OrganisationRepository, storyRepo *repositories.StoryRepository,
-	projectRepo *repositories.ProjectRepository, executionRepo *repositories.ExecutionRepository, executionOutputRepo *repositories.ExecutionOutputRepository) *PullRequestService {
+	gitProviderResolver *git_providers.GitProviderResolver, organisationRepo *repositories.OrganisationRepository, storyRepo *repositories.StoryRepository,
+	projectRepo *repositories.ProjectRepository, executionRepo *repositories.ExecutionRepository, executionOutputRepo *repositories.ExecutionOutputRepository,
+	webhookService *OrganisationWebhookService) *PullRequestService {
 	return &PullRequestService{
 		pullRequestRepo:         pullRequestRepo,
 		pullRequestCommentsRepo: pullRequestCommentsRepo,
-		gitService:              gitService,
+		gitProviderResolver:     gitProviderResolver,
 		organisationRepo:        organisationRepo,
 		storyRepo:               storyRepo,
 		projectRepo:             projectRepo,
 		executionRepo:           executionRepo,
 		executionOutputRepo:     executionOutputRepo,
+		webhookService:          webhookService,
 	}
 }
 
@@ -69,6 +73,7 @@ func (s *PullRequestService) GetAllPullRequests(projectID int, status string) ([
 			PullRequestNumber:      pullRequest.PullRequestNumber,
 			PullRequestName:        pullRequest.PullRequestTitle,
 			Status:                 pullRequest.Status,
+			IsDraft:                pullRequest.IsDraft,
 			CreatedOn:              pullRequest.CreatedAt.Format("Jan 2"),
 			MergedOn:               s.GetMergeDate(pullRequest.MergedAt, pullRequest.Status),
 			ClosedOn:               s.GetClosedDate(pullRequest.ClosedAt, pullRequest.Status),
@@ -79,7 +84,8 @@ func (s *PullRequestService) GetAllPullRequests(projectID int, status string) ([
 	return allPullRequests, nil
 }
 
-func (s *PullRequestService) MergePullRequestByID(pullRequestID int, organisationID uint) (*gitness.MergePullRequestResponse, error) {
+// MergePullRequestByID merges the pull request with the merge method, the merge method of the project when empty.
+func (s *PullRequestService) MergePullRequestByID(pullRequestID int, organisationID uint, method string) (*git_provider.MergeResult, error) {
 	fmt.Println("Organisation ID: ", organisationID)
 	fmt.Println("Pull Request ID: ", pullRequestID)
 	organisation, err := s.organisationRepo.GetOrganisationByID(organisationID)
@@ -104,22 +110,301 @@ func (s *PullRequestService) MergePullRequestByID(pullRequestID int, organisatio
 		fmt.Println("Error fetching Project by ID")
 		return nil, err
 	}
-	spaceOrProjectName := s.gitService.GetSpaceOrProjectName(organisation)
-	prResponse, err := s.gitService.FetchPullRequest(spaceOrProjectName, project.Name, pullRequest.PullRequestNumber)
+	gitProvider, err := s.gitProviderResolver.ForPullRequest(pullRequest, project)
+	if err != nil {
+		return nil, err
+	}
+	prResponse, err := gitProvider.FetchPullRequest(organisation, project, pullRequest.PullRequestNumber)
 	if err != nil {
 		fmt.Println("Error fetching Pull Request by ID")
 		return nil, err
 	}
-	mergeSHA, err := s.gitService.MergePullRequest(spaceOrProjectName, project.Name, pullRequest.PullRequestNumber, prResponse.SourceSHA)
+	if method == "" {
+		method = utils.ProjectMergeMethod(project)
+	}
+	if !constants.ValidMergeMethods()[method] {
+		return nil, fmt.Errorf("invalid merge method %s", method)
+	}
+	mergeSHA, err := gitProvider.MergePullRequest(organisation, project, pullRequest.PullRequestNumber, prResponse.SourceSHA, method)
 	if err != nil {
 		fmt.Println("Error merging pull request")
 		return nil, err
 	}
 	fmt.Println("PR Merged Successfully")
-	err = s.pullRequestRepo.UpdatePullRequestStatus(pullRequest, constants.Merged)
+	mergedAt := time.Now()
+	err = s.updateState(pullRequest, constants.Merged, false, &mergedAt, &mergedAt)
+	if err != nil {
+		fmt.Println("Error updating pull request state : ", err)
+	}
 	return mergeSHA, nil
 }
 
+// ClosePullRequestByID closes the pull request on the git host without merging it.
+func (s *PullRequestService) ClosePullRequestByID(pullRequestID uint) (*models.PullRequest, error) {
+	return s.changeState(pullRequestID, func(gitProvider git_providers.GitProvider, organisation *models.Organisation, project *models.Project, pullRequest *models.PullRequest) (*git_provider.PullRequest, error) {
+		if pullRequest.Status != constants.Open {
+			return nil, fmt.Errorf("pull request is %s", strings.ToLower(pullRequest.Status))
+		}
+		return gitProvider.ClosePullRequest(organisation, project, pullRequest.PullRequestNumber)
+	})
+}
+
+// ReopenPullRequestByID reopens a closed pull request on the git host.
+func (s *PullRequestService) ReopenPullRequestByID(pullRequestID uint) (*models.PullRequest, error) {
+	return s.changeState(pullRequestID, func(gitProvider git_providers.GitProvider, organisation *models.Organisation, project *models.Project, pullRequest *models.PullRequest) (*git_provider.PullRequest, error) {
+		if pullRequest.Status != constants.Close {
+			return nil, fmt.Errorf("pull request is %s", strings.ToLower(pullRequest.Status))
+		}
+		return gitProvider.ReopenPullRequest(organisation, project, pullRequest.PullRequestNumber)
+	})
+}
+
+// SetPullRequestDraftByID converts an open pull request to a draft, or marks it ready for review.
+func (s *PullRequestService) SetPullRequestDraftByID(pullRequestID uint, draft bool) (*models.PullRequest, error) {
+	return s.changeState(pullRequestID, func(gitProvider git_providers.GitProvider, organisation *models.Organisation, project *models.Project, pullRequest *models.PullRequest) (*git_provider.PullRequest, error) {
+		if pullRequest.Status != constants.Open {
+			return nil, fmt.Errorf("pull request is %s", strings.ToLower(pullRequest.Status))
+		}
+		return gitProvider.SetPullRequestDraft(organisation, project, pullRequest.PullRequestNumber, draft)
+	})
+}
+
+// GetPullRequestPipeline returns the latest pipeline of the source branch of the pull request, nil when no
+// pipeline ran for it.
+func (s *PullRequestService) GetPullRequestPipeline(pullRequestID uint) (*response.PullRequestPipeline, error) {
+	return s.pullRequestPipeline(pullRequestID, func(pipelineProvider git_providers.PipelineProvider, organisation *models.Organisation, project *models.Project, pullRequest *models.PullRequest) (*git_provider.Pipeline, error) {
+		return pipelineProvider.GetPipeline(organisation, project, pullRequest.SourceBranch)
+	})
+}
+
+// TriggerPullRequestPipeline runs a pipeline for the head of the source branch of the pull request.
+func (s *PullRequestService) TriggerPullRequestPipeline(pullRequestID uint) (*response.PullRequestPipeline, error) {
+	return s.pullRequestPipeline(pullRequestID, func(pipelineProvider git_providers.PipelineProvider, organisation *models.Organisation, project *models.Project, pullRequest *models.PullRequest) (*git_provider.Pipeline, error) {
+		if pullRequest.Status != constants.Open {
+			return nil, fmt.Errorf("pull request is %s", strings.ToLower(pullRequest.Status))
+		}
+		return pipelineProvider.TriggerPipeline(organisation, project, pullRequest.SourceBranch)
+	})
+}
+
+func (s *PullRequestService) pullRequestPipeline(pullRequestID uint, call func(git_providers.PipelineProvider, *models.Organisation, *models.Project, *models.PullRequest) (*git_provider.Pipeline, error)) (*response.PullRequestPipeline, error) {
+	pullRequest, err := s.pullRequestRepo.GetPullRequestByID(pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	gitProvider, organisation, project, err := s.resolvePullRequest(pullRequest)
+	if err != nil {
+		return nil, err
+	}
+	pipelineProvider, ok := gitProvider.(git_providers.PipelineProvider)
+	if !ok {
+		return nil, types.ErrPipelinesNotSupported
+	}
+	pipeline, err := call(pipelineProvider, organisation, project, pullRequest)
+	if err != nil || pipeline == nil {
+		return nil, err
+	}
+	return &response.PullRequestPipeline{
+		ID:     pipeline.ID,
+		Ref:    pipeline.Ref,
+		SHA:    pipeline.SHA,
+		Status: pipeline.Status,
+		URL:    pipeline.URL,
+	}, nil
+}
+
+// ReconcilePullRequests syncs the state of the open and closed pull requests with their git host, so that
+// pull requests merged, closed or reopened on the host are reflected.
+func (s *PullRequestService) ReconcilePullRequests() error {
+	pullRequests, err := s.pullRequestRepo.GetPullRequestsByStatuses([]string{constants.Open, constants.Close})
+	if err != nil {
+		return err
+	}
+	for i := range pullRequests {
+		if err := s.syncPullRequest(&pullRequests[i]); err != nil {
+			fmt.Printf("Error syncing pull request %d : %s\n", pullRequests[i].ID, err.Error())
+		}
+	}
+	return nil
+}
+
+// HandleWebhook syncs the pull request a pull request webhook delivery announces a change of.
+func (s *PullRequestService) HandleWebhook(remoteType string, header http.Header, body []byte) error {
+	gitProvider, err := s.gitProviderResolver.ForRemoteType(remoteType)
+	if err != nil {
+		return err
+	}
+	event, err := gitProvider.ParsePullRequestWebhook(header, body)
+	if err != nil || event == nil {
+		return err
+	}
+	switch event.Action {
+	case git_provider.PullRequestClosed, git_provider.PullRequestMerged, git_provider.PullRequestReopened:
+	default:
+		return nil
+	}
+	project, err := findProjectByRepositoryPath(s.projectRepo, s.organisationRepo, s.gitProviderResolver, remoteType, event.RepositoryPath)
+	if err != nil || project == nil {
+		return err
+	}
+	pullRequest, err := s.pullRequestRepo.GetPullRequestByProjectAndNumber(project.ID, remoteType, event.PullRequestNumber)
+	if err != nil || pullRequest == nil {
+		return err
+	}
+	return s.syncPullRequest(pullRequest)
+}
+
+type pullRequestStateChange func(gitProvider git_providers.GitProvider, organisation *models.Organisation, project *models.Project, pullRequest *models.PullRequest) (*git_provider.PullRequest, error)
+
+func (s *PullRequestService) changeState(pullRequestID uint, change pullRequestStateChange) (*models.PullRequest, error) {
+	pullRequest, err := s.pullRequestRepo.GetPullRequestByID(pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	gitProvider, organisation, project, err := s.resolvePullRequest(pullRequest)
+	if err != nil {
+		return nil, err
+	}
+	hostPullRequest, err := change(gitProvider, organisation, project, pullRequest)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyHostState(pullRequest, hostPullRequest); err != nil {
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
+func (s *PullRequestService) syncPullRequest(pullRequest *models.PullRequest) error {
+	gitProvider, organisation, project, err := s.resolvePullRequest(pullRequest)
+	if err != nil {
+		return err
+	}
+	hostPullRequest, err := gitProvider.FetchPullRequest(organisation, project, pullRequest.PullRequestNumber)
+	if err != nil {
+		return err
+	}
+	return s.applyHostState(pullRequest, hostPullRequest)
+}
+
+func (s *PullRequestService) resolvePullRequest(pullRequest *models.PullRequest) (git_providers.GitProvider, *models.Organisation, *models.Project, error) {
+	story, err := s.storyRepo.GetStoryById(int(pullRequest.StoryID))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	project, err := s.projectRepo.GetProjectById(int(story.ProjectID))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	organisation, err := s.organisationRepo.GetOrganisationByID(project.OrganisationID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	gitProvider, err := s.gitProviderResolver.ForPullRequest(pullRequest, project)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return gitProvider, organisation, project, nil
+}
+
+// applyHostState stores the state of the pull request on the git host. Hosts which do not report when a
+// pull request was merged or closed get the time the change was noticed.
+func (s *PullRequestService) applyHostState(pullRequest *models.PullRequest, hostPullRequest *git_provider.PullRequest) error {
+	status := constants.Open
+	var mergedAt, closedAt *time.Time
+	switch {
+	case hostPullRequest.Merged:
+		status = constants.Merged
+		mergedAt = firstTime(hostPullRequest.MergedAt, pullRequest.MergedAt)
+		closedAt = firstTime(hostPullRequest.ClosedAt, mergedAt)
+	case hostPullRequest.State == "closed" || hostPullRequest.State == "locked":
+		status = constants.Close
+		closedAt = firstTime(hostPullRequest.ClosedAt, pullRequest.ClosedAt)
+	}
+	if status == pullRequest.Status && hostPullRequest.IsDraft == pullRequest.IsDraft &&
+		sameTime(mergedAt, pullRequest.MergedAt) && sameTime(closedAt, pullRequest.ClosedAt) {
+		return nil
+	}
+	return s.updateState(pullRequest, status, hostPullRequest.IsDraft, mergedAt, closedAt)
+}
+
+// updateState stores the state of the pull request and moves its story along. Stories are done once their
+// pull request is raised, so merged and reopened pull requests leave the story done while closed ones send
+// it back to the backlog.
+func (s *PullRequestService) updateState(pullRequest *models.PullRequest, status string, isDraft bool, mergedAt, closedAt *time.Time) error {
+	previousStatus := pullRequest.Status
+	if err := s.pullRequestRepo.UpdatePullRequestState(pullRequest, status, isDraft, mergedAt, closedAt); err != nil {
+		return err
+	}
+	if status == previousStatus {
+		return nil
+	}
+	fmt.Printf("Pull request %d moved from %s to %s\n", pullRequest.ID, previousStatus, status)
+	event := constants.EventPullRequestReopened
+	switch status {
+	case constants.Merged:
+		event = constants.EventPullRequestMerged
+	case constants.Close:
+		event = constants.EventPullRequestClosed
+	}
+	s.publishPullRequestEvent(pullRequest, event)
+
+	story, err := s.storyRepo.GetStoryById(int(pullRequest.StoryID))
+	if err != nil {
+		return err
+	}
+	// A running execution updates the story itself once it is done.
+	if story.IsDeleted || story.Status == constants.InProgress || story.Status == constants.ExecutionEnqueued {
+		return nil
+	}
+	storyStatus := constants.Done
+	if status == constants.Close {
+		storyStatus = constants.Todo
+	}
+	if story.Status == storyStatus {
+		return nil
+	}
+	previousStoryStatus := story.Status
+	if err := s.storyRepo.UpdateStoryStatus(story, storyStatus); err != nil {
+		return err
+	}
+	s.webhookService.PublishStoryStatusChanged(story.ID, previousStoryStatus, storyStatus)
+	if err := rollUpParentStatus(s.storyRepo, s.webhookService, story.ID); err != nil {
+		fmt.Println("Error rolling up the status of the parent story: ", err)
+	}
+	return nil
+}
+
+// publishPullRequestEvent publishes an event about the pull request of a story to the webhooks of the organisation.
+func (s *PullRequestService) publishPullRequestEvent(pullRequest *models.PullRequest, event string) {
+	s.webhookService.PublishStoryEvent(pullRequest.StoryID, event, map[string]interface{}{
+		"pull_request_id":     pullRequest.ID,
+		"pull_request_number": pullRequest.PullRequestNumber,
+		"title":               pullRequest.PullRequestTitle,
+		"status":              pullRequest.Status,
+		"source_branch":       pullRequest.SourceBranch,
+		"target_branch":       pullRequest.TargetBranch,
+		"remote_type":         pullRequest.RemoteType,
+	})
+}
+
+func firstTime(times ...*time.Time) *time.Time {
+	for _, t := range times {
+		if t != nil {
+			return t
+		}
+	}
+	now := time.Now()
+	return &now
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
 func (s *PullRequestService) GetPullRequestsCommits(pullRequestID int, organisationID int) ([]*response.GetAllCommitsResponse, error) {
 	organisation, err := s.organisationRepo.GetOrganisationByID(uint(organisationID))
 	if err!=nil{
@@ -145,16 +430,15 @@ func (s *PullRequestService) GetPullRequestsCommits(pullRequestID int, organisat
 		fmt.Println("Error fetching Project by ID")
 		return nil, err
 	}
-	spaceOrProjectName := s.gitService.GetSpaceOrProjectName(organisation)
-	commitsResponse, err := s.gitService.FetchPullRequestCommits(spaceOrProjectName, project.Name, pullRequest.PullRequestNumber)
-	if err!=nil{
+	gitProvider, err := s.gitProviderResolver.ForPullRequest(pullRequest, project)
+	if err != nil {
 		return nil, err
 	}
-	commits, err := s.FetchCommitsResponse(commitsResponse)
-	if err != nil {
+	commitsResponse, err := gitProvider.GetPullRequestCommits(organisation, project, pullRequest.PullRequestNumber)
+	if err!=nil{
 		return nil, err
 	}
-	return commits, err
+	return s.FetchCommitsResponse(commitsResponse), nil
 }
 
 func (s *PullRequestService) GetPullRequestDiffByPullRequestID(pullRequestID uint) (string, error) {
@@ -179,11 +463,14 @@ func (s *PullRequestService) GetPullRequestDiffByPullRequestID(pullRequestID uin
         fmt.Println("Error getting organisation by ID: ", err)
         return "", err
     }
-	spaceOrProjectName := s.gitService.GetSpaceOrProjectName(organisation)
+	gitProvider, err := s.gitProviderResolver.ForPullRequest(pullRequest, project)
+	if err != nil {
+		return "", err
+	}
 	fmt.Printf("Project: %v\n", project)
-	diff, err := s.gitService.GetPullRequestDiff(
-		spaceOrProjectName,
-		project.Name,
+	diff, err := gitProvider.GetPullRequestDiff(
+		organisation,
+		project,
 		pullRequest.MergeBaseSHA,
 		pullRequest.SourceSHA,
 	)
@@ -194,51 +481,32 @@ func (s *PullRequestService) GetPullRequestDiffByPullRequestID(pullRequestID uin
 	return diff, nil
 }
 
-func (s *PullRequestService) FetchCommitsResponse(commitResponse string) ([]*response.GetAllCommitsResponse, error) {
-	var commits []map[string]interface{}
-	err := json.Unmarshal([]byte(commitResponse), &commits)
-	if err != nil {
-		fmt.Println("Error parsing JSON:", err)
-		return nil, err
-	}
+func (s *PullRequestService) FetchCommitsResponse(commits []git_provider.Commit) []*response.GetAllCommitsResponse {
 	var allCommitsResponse []*response.GetAllCommitsResponse
-
-	// Parsing the commit data
+	currentTime := time.Now().UTC()
 	for _, commit := range commits {
-		committer := commit["committer"].(map[string]interface{})
-		identity := committer["identity"].(map[string]interface{})
-		SHA := commit["sha"]
-		when := committer["when"].(string)
-
-		commitTime, err := time.Parse(time.RFC3339, when)
-		if err != nil {
-			fmt.Println("Error parsing commit time:", err)
-			continue
-		}
-		currentTime := time.Now().UTC()
 		allCommitsResponse = append(
 			allCommitsResponse,
 			&response.GetAllCommitsResponse{
-				Title:    commit["title"].(string),
-				Commiter: identity["name"].(string),
-				SHA:      SHA.(string),
-				Time:     utils.TimeAgo(commitTime, currentTime),
-				Date:     commitTime.Format("02 January, 2006"),
+				Title:    commit.Title,
+				Commiter: commit.CommitterName,
+				SHA:      commit.SHA,
+				Time:     utils.TimeAgo(commit.CommittedAt, currentTime),
+				Date:     commit.CommittedAt.Format("02 January, 2006"),
 			})
-
 	}
-	return allCommitsResponse, nil
+	return allCommitsResponse
 }
 
-func (s *PullRequestService) GetMergeDate(mergeDate time.Time, status string) string {
-	if status == constants.Merged {
+func (s *PullRequestService) GetMergeDate(mergeDate *time.Time, status string) string {
+	if status == constants.Merged && mergeDate != nil {
 		return mergeDate.Format("Jan 2")
 	}
 	return ""
 }
 
-func (s *PullRequestService) GetClosedDate(mergeDate time.Time, status string) string {
-	if status == constants.Close {
+func (s *PullRequestService) GetClosedDate(mergeDate *time.Time, status string) string {
+	if status == constants.Close && mergeDate != nil {
 		return mergeDate.Format("Jan 2")
 	}
 	return ""
@@ -253,8 +521,13 @@ func (s *PullRequestService) GetTotalComments(pullRequestID uint) (int64, error)
 	return commentsCount, nil
 }
 
-func (s *PullRequestService) CreatePullRequest(prTitle, prDescription, prID, remoteType string, sourceSHA, mergeTargetSHA, mergeBaseSHA string, prNumber int, storyID uint, executionOutputId uint, prType string) (*models.PullRequest, error) {
-	return s.pullRequestRepo.CreatePullRequest(prTitle, prDescription, prID, remoteType, sourceSHA, mergeTargetSHA, mergeBaseSHA, prNumber, storyID, executionOutputId, prType)
+func (s *PullRequestService) CreatePullRequest(prTitle, prDescription, prID, remoteType, sourceBranch, targetBranch string, sourceSHA, mergeTargetSHA, mergeBaseSHA string, prNumber int, storyID uint, executionOutputId uint, prType string) (*models.PullRequest, error) {
+	pullRequest, err := s.pullRequestRepo.CreatePullRequest(prTitle, prDescription, prID, remoteType, sourceBranch, targetBranch, sourceSHA, mergeTargetSHA, mergeBaseSHA, prNumber, storyID, executionOutputId, prType)
+	if err != nil {
+		return nil, err
+	}
+	s.publishPullRequestEvent(pullRequest, constants.EventPullRequestOpened)
+	return pullRequest, nil
 }
 
 func (s *PullRequestService) GetPullRequestByID(pullRequestId uint) (*models.PullRequest, error) {
@@ -264,6 +537,10 @@ func (s *PullRequestService) GetPullRequestByID(pullRequestId uint) (*models.Pul
 func (s *PullRequestService) UpdatePullRequestSourceSHA(pullRequest *models.PullRequest, sourceSHA string) error {
 	return s.pullRequestRepo.UpdatePullRequestSourceSHA(pullRequest, sourceSHA)
 }
+
+func (s *PullRequestService) UpdatePullRequestAutoMergeBlocked(pullRequest *models.PullRequest, blocked bool) error {
+	return s.pullRequestRepo.UpdatePullRequestAutoMergeBlocked(pullRequest, blocked)
+}
 func (s *PullRequestService) CreateManualPullRequest(projectID int, title string, description string) (int, error){
 	project, err := s.projectRepo.GetProjectById(projectID)
 	if err!= nil {
@@ -294,8 +571,8 @@ func (s *PullRequestService) CreateManualPullRequest(projectID int, title string
         return -1, err
 	}
 	fmt.Printf("-------Current branch: %s----- ", currentBranch)
-	if currentBranch=="main"{
-		return -1, errors.New("current branch is main can not raise a pr")
+	if currentBranch==utils.ProjectBaseBranch(project) || currentBranch==utils.ProjectTargetBranch(project){
+		return -1, fmt.Errorf("current branch is %s can not raise a pr", currentBranch)
 	}
 	execution, err := s.executionRepo.GetExecutionsByBranchName(currentBranch)
 	if err!= nil {
@@ -329,18 +606,21 @@ func (s *PullRequestService) CreateManualPullRequest(projectID int, title string
 		fmt.Println("failed to fetch organisation", err)
 		return -1, err
 	}
-	spaceOrProjectName := s.gitService.GetSpaceOrProjectName(organisation)
+	gitProvider, err := s.gitProviderResolver.ForProject(project)
+	if err != nil {
+		return -1, err
+	}
 	openPullRequest, err := s.pullRequestRepo.GetOpenPullRequestsByStoryID(int(storyID))
 	if err!= nil {
         fmt.Println("failed to fetch open pull requests by story id", err)
         return -1, err
     }
 
-	httpPrefix := "https"
-	if config.AppEnv() == constants.Development {
-		httpPrefix = "http"
+	origin, err := git_providers.AuthenticatedRemoteURL(gitProvider, organisation, project)
+	if err != nil {
+		fmt.Printf("Error building remote url: %s\n", err.Error())
+		return -1, err
 	}
-	origin := fmt.Sprintf("%s://%s:%s@%s/git/%s/%s.git", httpPrefix, config.GitnessUser(), config.GitnessToken(), config.GitnessHost(), spaceOrProjectName, project.Name)
 	err = utils.GitPush(workingDir, origin, currentBranch)
 	if err!=nil{
 		fmt.Printf("Error pushing changes: %s\n", err.Error())
@@ -348,19 +628,23 @@ func (s *PullRequestService) CreateManualPullRequest(projectID int, title string
 	}
 
 	if openPullRequest == nil {
-		err := utils.PullOriginMain(workingDir, origin)
+		err := utils.PullOriginBaseBranch(workingDir, origin, utils.ProjectTargetBranch(project))
 		if err!= nil {
             fmt.Printf("Error pulling origin main: %s\n", err.Error())
             return -1, err
         }
 		fmt.Println("____no open pull requests, creating a new one____")
-		pr, err := s.gitService.CreatePullRequest(spaceOrProjectName, project.Name, currentBranch, "main", "Pull Request: "+title, description)
+		pr, err := gitProvider.CreatePullRequest(organisation, project, currentBranch, utils.ProjectTargetBranch(project), "Pull Request: "+title, description)
 		if err != nil {
 			fmt.Printf("Error creating pull request: %s\n", err.Error())
 			return -1, err
 		}
 		prType := constants.Manual
-		pullRequest, err := s.CreatePullRequest(pr.Title, pr.Description, strconv.Itoa(pr.Number), "GITNESS", pr.SourceSHA, "sample", pr.MergeBaseSHA, pr.Number, storyID, 0, prType)
+		mergeTargetSHA := pr.MergeTargetSHA
+		if mergeTargetSHA == "" {
+			mergeTargetSHA = pr.MergeBaseSHA
+		}
+		pullRequest, err := s.CreatePullRequest(pr.Title, pr.Description, strconv.Itoa(pr.Number), gitProvider.RemoteType(), pr.SourceBranch, pr.TargetBranch, pr.SourceSHA, mergeTargetSHA, pr.MergeBaseSHA, pr.Number, storyID, 0, prType)
 		if err!= nil {
 			fmt.Printf("Error creating pull request in database: %s\n", err.Error())
 			return -1, err
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"ai-developer/app/models/dtos/asynq_task"
+	"ai-developer/app/models/types"
+	"ai-developer/app/repositories"
+	"ai-developer/app/types/response"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+// RunQueueService executes the queued stories of a project. A queued story is started as soon as its
+// prerequisites are done and the project executes fewer stories than its concurrency limit; among the ready
+// stories the one queued first goes first. The queue is advanced by the worker whenever an execution ends, a story is
+// queued, and periodically.
+type RunQueueService struct {
+	runQueueRepo           *repositories.RunQueueRepository
+	storyRepo              *repositories.StoryRepository
+	projectRepo            *repositories.ProjectRepository
+	storyService           *StoryService
+	storyDependencyService *StoryDependencyService
+	asynqClient            *asynq.Client
+	logger                 *zap.Logger
+}
+
+func NewRunQueueService(
+	runQueueRepo *repositories.RunQueueRepository,
+	storyRepo *repositories.StoryRepository,
+	projectRepo *repositories.ProjectRepository,
+	storyService *StoryService,
+	storyDependencyService *StoryDependencyService,
+	asynqClient *asynq.Client,
+	logger *zap.Logger,
+) *RunQueueService {
+	return &RunQueueService{
+		runQueueRepo:           runQueueRepo,
+		storyRepo:              storyRepo,
+		projectRepo:            projectRepo,
+		storyService:           storyService,
+		storyDependencyService: storyDependencyService,
+		asynqClient:            asynqClient,
+		logger:                 logger.Named("RunQueueService"),
+	}
+}
+
+// GetRunQueue returns the active entries of the project in queue order, and the recently finished ones.
+func (s *RunQueueService) GetRunQueue(projectID uint) ([]response.RunQueueEntry, []response.RunQueueEntry, error) {
+	active, err := s.runQueueRepo.GetActiveEntries(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	finished, err := s.runQueueRepo.GetFinishedEntries(projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	activeEntries, err := s.toRunQueueEntries(active)
+	if err != nil {
+		return nil, nil, err
+	}
+	finishedEntries, err := s.toRunQueueEntries(finished)
+	if err != nil {
+		return nil, nil, err
+	}
+	return activeEntries, finishedEntries, nil
+}
+
+// EnqueueStories appends the stories to the run queue of the project, preceded by the prerequisites they are
+// waiting for which are not queued yet. Done stories and stories already in the queue are skipped, failed
+// entries are queued again. Stories split into sub-stories are queued as their sub-stories.
+func (s *RunQueueService) EnqueueStories(projectID uint, storyIDs []uint) ([]response.RunQueueEntry, error) {
+	var ordered []*models.Story
+	visited := map[uint]bool{}
+	var visit func(storyID uint) error
+	visit = func(storyID uint) error {
+		if visited[storyID] {
+			return nil
+		}
+		visited[storyID] = true
+		story, err := s.storyRepo.GetStoryById(int(storyID))
+		if err != nil || story.IsDeleted || story.ProjectID != projectID {
+			return fmt.Errorf("%w: story %d is not a story of the project", types.ErrInvalidRunQueueRequest, storyID)
+		}
+		if story.Status == constants.Done {
+			return nil
+		}
+		subStories, err := s.storyRepo.GetSubStories(story.ID)
+		if err != nil {
+			return err
+		}
+		if len(subStories) > 0 {
+			// A story split into sub-stories executes through them.
+			for _, subStory := range subStories {
+				if err := visit(subStory.ID); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		prerequisites, err := s.storyDependencyService.UnsatisfiedPrerequisites(story.ID)
+		if err != nil {
+			return err
+		}
+		for _, prerequisite := range prerequisites {
+			if err := visit(prerequisite.ID); err != nil {
+				return err
+			}
+		}
+		ordered = append(ordered, story)
+		return nil
+	}
+	for _, storyID := range storyIDs {
+		if err := visit(storyID); err != nil {
+			return nil, err
+		}
+	}
+
+	position, err := s.runQueueRepo.GetMaxPosition(projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, story := range ordered {
+		entry, err := s.runQueueRepo.GetActiveEntryByStoryID(story.ID)
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil {
+			if entry.Status == constants.RunQueueFailed {
+				entry.Status = constants.RunQueueQueued
+				entry.Error = ""
+				if err := s.runQueueRepo.UpdateEntry(entry); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		position++
+		entry = &models.RunQueueEntry{
+			ProjectID: projectID,
+			StoryID:   story.ID,
+			Position:  position,
+			Status:    constants.RunQueueQueued,
+		}
+		if err := s.runQueueRepo.CreateEntry(entry); err != nil {
+			return nil, err
+		}
+	}
+
+	s.ScheduleAdvance(projectID)
+	active, _, err := s.GetRunQueue(projectID)
+	return active, err
+}
+
+// RemoveStory takes a queued or failed story out of the run queue, running stories cannot be removed.
+func (s *RunQueueService) RemoveStory(projectID, storyID uint) error {
+	entry, err := s.runQueueRepo.GetActiveEntryByStoryID(storyID)
+	if err != nil {
+		return err
+	}
+	if entry == nil || entry.ProjectID != projectID {
+		return types.ErrRunQueueEntryNotFound
+	}
+	if entry.Status == constants.RunQueueRunning {
+		return fmt.Errorf("%w: story %d is running", types.ErrInvalidRunQueueRequest, storyID)
+	}
+	return s.runQueueRepo.DeleteEntry(entry)
+}
+
+// ScheduleAdvance asks the worker to advance the run queue of the project. Scheduling is best effort, the
+// periodic advance picks up what is missed.
+func (s *RunQueueService) ScheduleAdvance(projectID uint) {
+	payloadBytes, err := json.Marshal(asynq_task.AdvanceRunQueuePayload{ProjectID: projectID})
+	if err != nil {
+		s.logger.Error("Error marshalling run queue payload", zap.Error(err))
+		return
+	}
+	task := asynq.NewTask(constants.AdvanceRunQueueTaskType, payloadBytes)
+	_, err = s.asynqClient.Enqueue(task, asynq.Unique(30*time.Second), asynq.MaxRetry(3))
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+		s.logger.Error("Error scheduling run queue advance", zap.Uint("project_id", projectID), zap.Error(err))
+	}
+}
+
+// AdvanceRunQueues advances the run queues of all projects with queued or running stories.
+func (s *RunQueueService) AdvanceRunQueues() error {
+	projectIDs, err := s.runQueueRepo.GetProjectIDsWithActiveEntries()
+	if err != nil {
+		return err
+	}
+	for _, projectID := range projectIDs {
+		if err := s.AdvanceRunQueue(projectID); err != nil {
+			s.logger.Error("Error advancing run queue", zap.Uint("project_id", projectID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// AdvanceRunQueue records the outcome of finished stories and starts ready stories while the project executes
+// fewer stories than its concurrency limit. Stories started outside the queue count against the limit too.
+func (s *RunQueueService) AdvanceRunQueue(projectID uint) error {
+	entries, err := s.runQueueRepo.GetActiveEntries(projectID)
+	if err != nil {
+		return err
+	}
+	var queued []*models.RunQueueEntry
+	for i := range entries {
+		entry := &entries[i]
+		story, err := s.storyRepo.GetStoryById(int(entry.StoryID))
+		if err != nil {
+			return err
+		}
+		if story.IsDeleted {
+			if err := s.runQueueRepo.DeleteEntry(entry); err != nil {
+				return err
+			}
+			continue
+		}
+		switch {
+		case story.Status == constants.Done:
+			if err := s.finishEntry(entry, constants.RunQueueDone, ""); err != nil {
+				return err
+			}
+		case entry.Status == constants.RunQueueRunning && isExecutingStatus(story.Status):
+			// Still executing.
+		case entry.Status == constants.RunQueueRunning:
+			if err := s.finishEntry(entry, constants.RunQueueFailed, fmt.Sprintf("story ended in status %s", story.Status)); err != nil {
+				return err
+			}
+		case entry.Status == constants.RunQueueQueued:
+			queued = append(queued, entry)
+		}
+	}
+	if len(queued) == 0 {
+		return nil
+	}
+	project, err := s.projectRepo.GetProjectById(int(projectID))
+	if err != nil {
+		return err
+	}
+	executing, err := s.storyRepo.CountStoriesByProjectIdAndStatuses(int(projectID), []string{constants.InProgress, constants.ExecutionEnqueued})
+	if err != nil {
+		return err
+	}
+	available := int64(project.MaxConcurrentExecutions) - executing
+
+	for _, entry := range queued {
+		if available <= 0 {
+			return nil
+		}
+		unsatisfied, err := s.storyDependencyService.UnsatisfiedPrerequisites(entry.StoryID)
+		if err != nil {
+			return err
+		}
+		if len(unsatisfied) > 0 {
+			continue
+		}
+		s.logger.Info("Starting queued story", zap.Uint("project_id", projectID), zap.Uint("story_id", entry.StoryID))
+		if err := s.storyService.UpdateStoryStatus(int(entry.StoryID), constants.InProgress); err != nil {
+			if err := s.finishEntry(entry, constants.RunQueueFailed, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		now := time.Now()
+		entry.Status = constants.RunQueueRunning
+		entry.StartedAt = &now
+		if err := s.runQueueRepo.UpdateEntry(entry); err != nil {
+			return err
+		}
+		available--
+	}
+	return nil
+}
+
+func (s *RunQueueService) finishEntry(entry *models.RunQueueEntry, status, message string) error {
+	now := time.Now()
+	entry.Status = status
+	entry.Error = message
+	entry.FinishedAt = &now
+	return s.runQueueRepo.UpdateEntry(entry)
+}
+
+func (s *RunQueueService) toRunQueueEntries(entries []models.RunQueueEntry) ([]response.RunQueueEntry, error) {
+	result := make([]response.RunQueueEntry, 0, len(entries))
+	for _, entry := range entries {
+		story, err := s.storyRepo.GetStoryById(int(entry.StoryID))
+		if err != nil {
+			return nil, err
+		}
+		waitingFor := []uint{}
+		if entry.Status == constants.RunQueueQueued {
+			unsatisfied, err := s.storyDependencyService.UnsatisfiedPrerequisites(entry.StoryID)
+			if err != nil {
+				return nil, err
+			}
+			for _, prerequisite := range unsatisfied {
+				waitingFor = append(waitingFor, prerequisite.ID)
+			}
+		}
+		result = append(result, response.RunQueueEntry{
+			ID:          entry.ID,
+			StoryID:     entry.StoryID,
+			StoryTitle:  story.Title,
+			StoryStatus: story.Status,
+			Position:    entry.Position,
+			Status:      entry.Status,
+			Error:       entry.Error,
+			WaitingFor:  waitingFor,
+			StartedAt:   entry.StartedAt,
+			FinishedAt:  entry.FinishedAt,
+			CreatedAt:   entry.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// isExecutingStatus reports whether a story with the status is waiting for or in execution.
+func isExecutingStatus(status string) bool {
+	return status == constants.InProgress || status == constants.ExecutionEnqueued
+}
@@ -0,0 +1,327 @@
+package services
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/llms"
+	"ai-developer/app/models"
+	"ai-developer/app/models/types"
+	"ai-developer/app/repositories"
+	"ai-developer/app/types/request"
+	"ai-developer/app/types/response"
+	"ai-developer/app/utils"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// maxSubStories caps the number of sub-stories a story is split into.
+const maxSubStories = 8
+
+type decompositionResult struct {
+	SubStories []request.SubStory `json:"sub_stories"`
+}
+
+// StoryDecompositionService splits stories too large to execute in one go into sub-stories. The LLM proposes
+// the sub-stories, which are returned for review and only created once they are accepted. A story split into
+// sub-stories no longer executes itself, its status is rolled up from the statuses of its sub-stories.
+type StoryDecompositionService struct {
+	storyRepo              *repositories.StoryRepository
+	projectRepo            *repositories.ProjectRepository
+	storyService           *StoryService
+	storyDependencyService *StoryDependencyService
+	llmAPIKeyService       *LLMAPIKeyService
+	webhookService         *OrganisationWebhookService
+	logger                 *zap.Logger
+}
+
+func NewStoryDecompositionService(
+	storyRepo *repositories.StoryRepository,
+	projectRepo *repositories.ProjectRepository,
+	storyService *StoryService,
+	storyDependencyService *StoryDependencyService,
+	llmAPIKeyService *LLMAPIKeyService,
+	webhookService *OrganisationWebhookService,
+	logger *zap.Logger,
+) *StoryDecompositionService {
+	return &StoryDecompositionService{
+		storyRepo:              storyRepo,
+		projectRepo:            projectRepo,
+		storyService:           storyService,
+		storyDependencyService: storyDependencyService,
+		llmAPIKeyService:       llmAPIKeyService,
+		webhookService:         webhookService,
+		logger:                 logger.Named("StoryDecompositionService"),
+	}
+}
+
+// ProposeSubStories asks the LLM to split the story into sub-stories. Nothing is created, the proposals are
+// meant to be reviewed and passed to AcceptSubStories.
+func (s *StoryDecompositionService) ProposeSubStories(storyID uint, guidance string) ([]request.SubStory, error) {
+	story, err := s.getDecomposableStory(storyID)
+	if err != nil {
+		return nil, err
+	}
+	project, err := s.projectRepo.GetProjectById(int(story.ProjectID))
+	if err != nil {
+		return nil, err
+	}
+	testCases, err := s.storyService.GetStoryTestCaseByStoryId(int(story.ID))
+	if err != nil {
+		return nil, err
+	}
+	instructions, err := s.storyService.GetStoryInstructionByStoryId(int(story.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	var storyText strings.Builder
+	fmt.Fprintf(&storyText, "Story: %s\n%s\n", story.Title, story.Description)
+	if len(testCases) > 0 {
+		storyText.WriteString("\nTest cases:\n")
+		for _, testCase := range testCases {
+			fmt.Fprintf(&storyText, "- %s\n", testCase.TestCase)
+		}
+	}
+	for _, instruction := range instructions {
+		if strings.TrimSpace(instruction.Instruction) != "" {
+			fmt.Fprintf(&storyText, "\nInstructions:\n%s\n", instruction.Instruction)
+		}
+	}
+	if strings.TrimSpace(guidance) != "" {
+		fmt.Fprintf(&storyText, "\nHow to split the story:\n%s\n", guidance)
+	}
+
+	llmAPIKey, err := s.llmAPIKeyService.GetLLMAPIKeyByModelName(constants.GPT_4O, project.OrganisationID)
+	if err != nil {
+		return nil, err
+	}
+	if llmAPIKey == nil || llmAPIKey.LLMAPIKey == "" {
+		return nil, fmt.Errorf("LLM API Key for model %s not found in database", constants.GPT_4O)
+	}
+	reply, err := llms.NewOpenAiClient(llmAPIKey.LLMAPIKey).ChatCompletion([]llms.OpenAiChatCompletionMessage{
+		{
+			Role: "system",
+			Content: "You split a user story of a software project into smaller stories, each of which can be implemented and tested on its own " +
+				"in a single change. Together the smaller stories must deliver the whole story. " +
+				fmt.Sprintf("Reply with a JSON object with the key `sub_stories`, an array of at most %d objects with the keys ", maxSubStories) +
+				fmt.Sprintf("`summary` (a title of at most %d characters), `description`, `test_cases` (an array of strings), ", maxStoryTitleLength) +
+				"`instructions` (implementation notes, may be empty) and `depends_on` (an array of the zero-based indexes of the earlier sub-stories it builds on). " +
+				"Order the sub-stories so that each one only depends on earlier ones.",
+		},
+		{
+			Role: "user",
+			Content: fmt.Sprintf("Project: %s\n%s\nBackend framework: %s\nFrontend framework: %s\n\n%s",
+				project.Name, project.Description, project.BackendFramework, project.FrontendFramework, storyText.String()),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var result decompositionResult
+	if err := json.Unmarshal([]byte(strings.TrimSpace(utils.StripCodeFence(reply))), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse sub-stories: %w", err)
+	}
+	return normaliseSubStories(result.SubStories), nil
+}
+
+// AcceptSubStories creates the reviewed sub-stories of the story, along with the dependencies between them.
+func (s *StoryDecompositionService) AcceptSubStories(storyID uint, subStories []request.SubStory) (*response.SubStories, error) {
+	story, err := s.getDecomposableStory(storyID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.storyRepo.GetSubStories(story.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("%w: story %d is already split", types.ErrInvalidSubStories, story.ID)
+	}
+	if story.Status == constants.InProgress || story.Status == constants.ExecutionEnqueued {
+		return nil, fmt.Errorf("%w: story %d is executing", types.ErrInvalidSubStories, story.ID)
+	}
+	if len(subStories) == 0 || len(subStories) > maxSubStories {
+		return nil, fmt.Errorf("%w: a story is split into 1 to %d sub-stories", types.ErrInvalidSubStories, maxSubStories)
+	}
+	for i, subStory := range subStories {
+		summary := strings.TrimSpace(subStory.Summary)
+		if summary == "" || len(summary) > maxStoryTitleLength {
+			return nil, fmt.Errorf("%w: the summary of sub-story %d must have 1 to %d characters", types.ErrInvalidSubStories, i, maxStoryTitleLength)
+		}
+		for _, dependency := range subStory.DependsOn {
+			if dependency < 0 || dependency >= i {
+				return nil, fmt.Errorf("%w: sub-story %d can only depend on earlier sub-stories", types.ErrInvalidSubStories, i)
+			}
+		}
+	}
+
+	// The sub-stories and the dependencies between them are created together, a failure leaves the story
+	// unsplit. The checks above keep the dependencies acyclic and within the project.
+	created := make([]*models.Story, 0, len(subStories))
+	err = s.storyRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		for _, subStory := range subStories {
+			createdStory, err := s.storyService.CreateSubStoryWithTx(tx, story, request.CreateStoryRequest{
+				Summary:      strings.TrimSpace(subStory.Summary),
+				Description:  subStory.Description,
+				TestCases:    subStory.TestCases,
+				Instructions: subStory.Instructions,
+			})
+			if err != nil {
+				return err
+			}
+			created = append(created, createdStory)
+		}
+		for i, subStory := range subStories {
+			if len(subStory.DependsOn) == 0 {
+				continue
+			}
+			dependsOn := make([]uint, 0, len(subStory.DependsOn))
+			for _, dependency := range subStory.DependsOn {
+				if !slices.Contains(dependsOn, created[dependency].ID) {
+					dependsOn = append(dependsOn, created[dependency].ID)
+				}
+			}
+			if err := s.storyDependencyService.CreateDependenciesWithTx(tx, created[i].ID, dependsOn); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Split story into sub-stories", zap.Uint("story_id", story.ID), zap.Int("sub_stories", len(created)))
+
+	if err := rollUpParentStatus(s.storyRepo, s.webhookService, created[0].ID); err != nil {
+		return nil, err
+	}
+	return s.GetSubStories(story.ID)
+}
+
+// GetSubStories returns the sub-stories of the story with the status rolled up from them.
+func (s *StoryDecompositionService) GetSubStories(storyID uint) (*response.SubStories, error) {
+	subStories, err := s.storyRepo.GetSubStories(storyID)
+	if err != nil {
+		return nil, err
+	}
+	result := &response.SubStories{
+		RolledUpStatus: rolledUpStatus(subStories),
+		Total:          len(subStories),
+		SubStories:     make([]response.SubStory, 0, len(subStories)),
+	}
+	for _, subStory := range subStories {
+		if subStory.Status == constants.Done {
+			result.Done++
+		}
+		result.SubStories = append(result.SubStories, response.SubStory{
+			ID:     subStory.ID,
+			Title:  subStory.Title,
+			Status: subStory.Status,
+		})
+	}
+	return result, nil
+}
+
+func (s *StoryDecompositionService) getDecomposableStory(storyID uint) (*models.Story, error) {
+	story, err := s.storyRepo.GetStoryById(int(storyID))
+	if err != nil || story.IsDeleted {
+		return nil, types.ErrInvalidStory
+	}
+	if story.Type != constants.Backend {
+		return nil, fmt.Errorf("%w: only backend stories can be split", types.ErrInvalidSubStories)
+	}
+	return story, nil
+}
+
+// normaliseSubStories keeps the proposals of the LLM within the limits AcceptSubStories enforces.
+func normaliseSubStories(subStories []request.SubStory) []request.SubStory {
+	if len(subStories) > maxSubStories {
+		subStories = subStories[:maxSubStories]
+	}
+	for i := range subStories {
+		subStories[i].Summary = strings.TrimSpace(subStories[i].Summary)
+		if len(subStories[i].Summary) > maxStoryTitleLength {
+			subStories[i].Summary = strings.TrimSpace(subStories[i].Summary[:maxStoryTitleLength])
+		}
+		dependsOn := make([]int, 0, len(subStories[i].DependsOn))
+		for _, dependency := range subStories[i].DependsOn {
+			if dependency >= 0 && dependency < i {
+				dependsOn = append(dependsOn, dependency)
+			}
+		}
+		subStories[i].DependsOn = dependsOn
+		if subStories[i].TestCases == nil {
+			subStories[i].TestCases = []string{}
+		}
+	}
+	return subStories
+}
+
+// rolledUpStatus is the status of a story split into the sub-stories: done once all of them are done, in
+// review once all of them are at least in review, in progress once any of them was picked up, and to do
+// otherwise. Empty when there are no sub-stories.
+func rolledUpStatus(subStories []models.Story) string {
+	if len(subStories) == 0 {
+		return ""
+	}
+	done, reviewable, started := 0, 0, 0
+	for _, subStory := range subStories {
+		switch subStory.Status {
+		case constants.Done:
+			done++
+			reviewable++
+		case constants.InReview:
+			reviewable++
+		}
+		if subStory.Status != constants.Todo {
+			started++
+		}
+	}
+	switch {
+	case done == len(subStories):
+		return constants.Done
+	case reviewable == len(subStories):
+		return constants.InReview
+	case started > 0:
+		return constants.InProgress
+	default:
+		return constants.Todo
+	}
+}
+
+// rollUpParentStatus updates the status of the story the given story was split from, and of its parents in turn,
+// after the status of the given story changed.
+func rollUpParentStatus(storyRepo *repositories.StoryRepository, webhookService *OrganisationWebhookService, storyID uint) error {
+	story, err := storyRepo.GetStoryById(int(storyID))
+	if err != nil {
+		return err
+	}
+	if story.ParentStoryID == nil {
+		return nil
+	}
+	parent, err := storyRepo.GetStoryById(int(*story.ParentStoryID))
+	if err != nil {
+		return err
+	}
+	if parent.IsDeleted {
+		return nil
+	}
+	subStories, err := storyRepo.GetSubStories(parent.ID)
+	if err != nil {
+		return err
+	}
+	status := rolledUpStatus(subStories)
+	if status == "" || status == parent.Status {
+		return nil
+	}
+	previousStatus := parent.Status
+	if err := storyRepo.UpdateStoryStatus(parent, status); err != nil {
+		return err
+	}
+	webhookService.PublishStoryStatusChanged(parent.ID, previousStatus, status)
+	return rollUpParentStatus(storyRepo, webhookService, parent.ID)
+}
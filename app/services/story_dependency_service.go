@@ -0,0 +1,203 @@
+package services
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"ai-developer/app/models/types"
+	"ai-developer/app/repositories"
+	"ai-developer/app/types/response"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// StoryDependencyService keeps track of the stories a story has to wait for. A prerequisite is satisfied once
+// it is done and its pull request was not closed without merging; deleted prerequisites no longer count.
+type StoryDependencyService struct {
+	storyDependencyRepo *repositories.StoryDependencyRepository
+	storyRepo           *repositories.StoryRepository
+	pullRequestRepo     *repositories.PullRequestRepository
+}
+
+func NewStoryDependencyService(
+	storyDependencyRepo *repositories.StoryDependencyRepository,
+	storyRepo *repositories.StoryRepository,
+	pullRequestRepo *repositories.PullRequestRepository,
+) *StoryDependencyService {
+	return &StoryDependencyService{
+		storyDependencyRepo: storyDependencyRepo,
+		storyRepo:           storyRepo,
+		pullRequestRepo:     pullRequestRepo,
+	}
+}
+
+func (s *StoryDependencyService) GetDependencies(storyID uint) ([]response.StoryDependency, error) {
+	prerequisites, err := s.prerequisites(storyID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]response.StoryDependency, 0, len(prerequisites))
+	for _, prerequisite := range prerequisites {
+		satisfied, _, err := s.prerequisiteState(prerequisite)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, response.StoryDependency{
+			StoryID:   prerequisite.ID,
+			Title:     prerequisite.Title,
+			Status:    prerequisite.Status,
+			Satisfied: satisfied,
+		})
+	}
+	return result, nil
+}
+
+// SetDependencies replaces the prerequisites of the story. Prerequisites must be other stories of the same
+// project and must not depend on the story themselves.
+func (s *StoryDependencyService) SetDependencies(storyID uint, dependsOn []uint) ([]response.StoryDependency, error) {
+	story, err := s.storyRepo.GetStoryById(int(storyID))
+	if err != nil {
+		return nil, err
+	}
+	seen := map[uint]bool{}
+	var prerequisiteIDs []uint
+	for _, prerequisiteID := range dependsOn {
+		if seen[prerequisiteID] {
+			continue
+		}
+		seen[prerequisiteID] = true
+		if prerequisiteID == story.ID {
+			return nil, fmt.Errorf("%w: a story cannot depend on itself", types.ErrInvalidStoryDependency)
+		}
+		prerequisite, err := s.storyRepo.GetStoryById(int(prerequisiteID))
+		if err != nil || prerequisite.IsDeleted || prerequisite.ProjectID != story.ProjectID {
+			return nil, fmt.Errorf("%w: story %d is not a story of the project", types.ErrInvalidStoryDependency, prerequisiteID)
+		}
+		prerequisiteIDs = append(prerequisiteIDs, prerequisiteID)
+	}
+
+	dependencies, err := s.storyDependencyRepo.GetDependenciesByProjectID(story.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	graph := map[uint][]uint{story.ID: prerequisiteIDs}
+	for _, dependency := range dependencies {
+		if dependency.StoryID != story.ID {
+			graph[dependency.StoryID] = append(graph[dependency.StoryID], dependency.DependsOnStoryID)
+		}
+	}
+	for _, prerequisiteID := range prerequisiteIDs {
+		if reaches(graph, prerequisiteID, story.ID, map[uint]bool{}) {
+			return nil, fmt.Errorf("%w: story %d already depends on story %d", types.ErrInvalidStoryDependency, prerequisiteID, story.ID)
+		}
+	}
+
+	if err := s.storyDependencyRepo.ReplaceDependencies(story.ID, prerequisiteIDs); err != nil {
+		return nil, err
+	}
+	return s.GetDependencies(story.ID)
+}
+
+// CreateDependenciesWithTx makes a story created in the transaction depend on the given stories. The caller
+// checks the prerequisites, which unlike SetDependencies may also be stories created in the same transaction.
+func (s *StoryDependencyService) CreateDependenciesWithTx(tx *gorm.DB, storyID uint, dependsOn []uint) error {
+	return s.storyDependencyRepo.CreateDependenciesWithTx(tx, storyID, dependsOn)
+}
+
+// UnsatisfiedPrerequisites returns the prerequisites the story is still waiting for.
+func (s *StoryDependencyService) UnsatisfiedPrerequisites(storyID uint) ([]models.Story, error) {
+	prerequisites, err := s.prerequisites(storyID)
+	if err != nil {
+		return nil, err
+	}
+	var unsatisfied []models.Story
+	for _, prerequisite := range prerequisites {
+		satisfied, _, err := s.prerequisiteState(prerequisite)
+		if err != nil {
+			return nil, err
+		}
+		if !satisfied {
+			unsatisfied = append(unsatisfied, prerequisite)
+		}
+	}
+	return unsatisfied, nil
+}
+
+// PrerequisiteBranches returns the source branches of the open pull requests of the prerequisites. Merged
+// prerequisites are part of the base branch already, a new branch of the story merges the others in so it
+// starts from the combined result of all its prerequisites.
+func (s *StoryDependencyService) PrerequisiteBranches(storyID uint) ([]string, error) {
+	prerequisites, err := s.prerequisites(storyID)
+	if err != nil {
+		return nil, err
+	}
+	var branches []string
+	for _, prerequisite := range prerequisites {
+		satisfied, branch, err := s.prerequisiteState(prerequisite)
+		if err != nil {
+			return nil, err
+		}
+		if satisfied && branch != "" {
+			branches = append(branches, branch)
+		}
+	}
+	return branches, nil
+}
+
+func (s *StoryDependencyService) prerequisites(storyID uint) ([]models.Story, error) {
+	dependencies, err := s.storyDependencyRepo.GetDependenciesByStoryID(storyID)
+	if err != nil {
+		return nil, err
+	}
+	prerequisites := make([]models.Story, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		prerequisite, err := s.storyRepo.GetStoryById(int(dependency.DependsOnStoryID))
+		if err != nil {
+			return nil, err
+		}
+		if !prerequisite.IsDeleted {
+			prerequisites = append(prerequisites, *prerequisite)
+		}
+	}
+	return prerequisites, nil
+}
+
+// prerequisiteState reports whether the prerequisite is satisfied, and the source branch of its pull request
+// while that is still open.
+func (s *StoryDependencyService) prerequisiteState(prerequisite models.Story) (bool, string, error) {
+	if prerequisite.Status != constants.Done {
+		return false, "", nil
+	}
+	pullRequests, err := s.pullRequestRepo.GetAllPullRequestsByStoryIDs([]uint{prerequisite.ID}, "ALL")
+	if err != nil {
+		return false, "", err
+	}
+	if len(pullRequests) == 0 {
+		return true, "", nil
+	}
+	switch pullRequests[0].Status {
+	case constants.Open:
+		return true, pullRequests[0].SourceBranch, nil
+	case constants.Close:
+		return false, "", nil
+	default:
+		return true, "", nil
+	}
+}
+
+// reaches reports whether to can be reached from from following the edges of the graph.
+func reaches(graph map[uint][]uint, from, to uint, visited map[uint]bool) bool {
+	if from == to {
+		return true
+	}
+	if visited[from] {
+		return false
+	}
+	visited[from] = true
+	for _, next := range graph[from] {
+		if reaches(graph, next, to, visited) {
+			return true
+		}
+	}
+	return false
+}
@@ -38,6 +38,8 @@ type StoryService struct {
 	hashIdGenerator        *utils.HashIDGenerator
 	workspaceServiceClient *workspace.WorkspaceServiceClient
 	projectService         *ProjectService
+	webhookService         *OrganisationWebhookService
+	storyIssueLinkRepo     *repositories.StoryIssueLinkRepository
 }
 
 func (s *StoryService) GetStoryById(storyId int64) (*models.Story, error) {
@@ -45,21 +47,37 @@ func (s *StoryService) GetStoryById(storyId int64) (*models.Story, error) {
 }
 
 func (s *StoryService) CreateStoryForProject(requestData request.CreateStoryRequest) (int, error) {
+	createdStory, err := s.createStory(s.storyRepo.GetDB(), requestData, nil)
+	if err != nil {
+		return 0, err
+	}
+	return int(createdStory.ID), nil
+}
+
+// CreateSubStoryWithTx creates a story split from the parent story in the project of the parent within a
+// transaction.
+func (s *StoryService) CreateSubStoryWithTx(tx *gorm.DB, parentStory *models.Story, requestData request.CreateStoryRequest) (*models.Story, error) {
+	requestData.ProjectId = int(parentStory.ProjectID)
+	return s.createStory(tx, requestData, &parentStory.ID)
+}
+
+func (s *StoryService) createStory(tx *gorm.DB, requestData request.CreateStoryRequest, parentStoryID *uint) (*models.Story, error) {
 	storyType := constants.Backend
 	hashID := s.hashIdGenerator.Generate() + "-" + uuid.New().String()
 	story := &models.Story{
-		ProjectID:   uint(requestData.ProjectId),
-		Title:       requestData.Summary,
-		Description: requestData.Description,
-		Status:      constants.Todo,
-		HashID:      hashID,
-		Type:        storyType,
+		ProjectID:     uint(requestData.ProjectId),
+		Title:         requestData.Summary,
+		Description:   requestData.Description,
+		Status:        constants.Todo,
+		HashID:        hashID,
+		Type:          storyType,
+		ParentStoryID: parentStoryID,
 	}
 
 	// create a story
-	createdStory, err := s.storyRepo.CreateStory(story)
+	createdStory, err := s.storyRepo.CreateStoryWithTx(tx, story)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	// create story test cases
@@ -69,8 +87,8 @@ func (s *StoryService) CreateStoryForProject(requestData request.CreateStoryRequ
 			TestCase: testCase,
 		}
 		// Insert the storyTestCase record
-		if err := s.storyTestCaseRepo.CreateStoryTestCase(storyTestCase); err != nil {
-			return 0, err
+		if err := s.storyTestCaseRepo.CreateStoryTestCaseWithTx(tx, storyTestCase); err != nil {
+			return nil, err
 		}
 	}
 
@@ -80,10 +98,10 @@ func (s *StoryService) CreateStoryForProject(requestData request.CreateStoryRequ
 		Instruction: requestData.Instructions,
 	}
 	// Insert the storyInstruction record
-	if err := s.storyInstructionRepo.CreateStoryInstructions(storyInstruction); err != nil {
-		return 0, err
+	if err := s.storyInstructionRepo.CreateStoryInstructionsWithTx(tx, storyInstruction); err != nil {
+		return nil, err
 	}
-	return int(createdStory.ID), nil
+	return createdStory, nil
 }
 
 func (s *StoryService) CreateDesignStoryForProject(file multipart.File, fileName, title string, projectID int, storyType string) (uint, error) {
@@ -420,6 +438,15 @@ func (s *StoryService) GetStoryDetails(storyId int) (*response.GetStoryByIdRespo
 	} else {
 		storyDetailsResponse.StoryInputFileUrl = storyFile.FilePath
 	}
+
+	issueLink, err := s.storyIssueLinkRepo.GetLinkByStoryID(story.ID)
+	if err != nil {
+		return &response.GetStoryByIdResponse{}, err
+	}
+	if issueLink != nil {
+		storyDetailsResponse.Issue = &response.StoryIssue{Key: issueLink.ExternalKey, URL: issueLink.URL}
+	}
+	storyDetailsResponse.ParentStoryID = story.ParentStoryID
 	return storyDetailsResponse, nil
 
 }
@@ -565,6 +592,14 @@ func (s *StoryService) UpdateStoryStatus(storyID int, status string) error {
 	s.logger.Info("New Status", zap.String("status", status))
 	if strings.ToUpper(status) == constants.InProgress {
 		s.logger.Info("Story to be updated to InProgress", zap.Int("storyID", storyID))
+		subStories, err := s.storyRepo.GetSubStories(story.ID)
+		if err != nil {
+			return err
+		}
+		if len(subStories) > 0 {
+			s.logger.Info("Story is split into sub-stories", zap.Int("storyID", storyID))
+			return types.ErrStoryDecomposed
+		}
 		if story.Status == constants.Todo || story.Status == constants.InReview {
 			s.logger.Info("Story is in Todo", zap.Int("storyID", storyID))
 			s.logger.Info("Executing story", zap.Int("storyID", storyID))
@@ -590,11 +625,14 @@ func (s *StoryService) UpdateStoryStatus(storyID int, status string) error {
 				s.logger.Error("Error enqueuing task", zap.Error(err))
 				return err
 			}
+			previousStatus := story.Status
 			err = s.storyRepo.UpdateStoryStatus(story, constants.ExecutionEnqueued)
 			if err != nil {
 				s.logger.Error("Error updating story status", zap.Error(err))
 				return err
 			}
+			s.webhookService.PublishStoryStatusChanged(story.ID, previousStatus, constants.ExecutionEnqueued)
+			s.rollUpParentStatus(story.ID)
 
 		} else {
 			s.logger.Info("Story already in progress", zap.Int("storyID", storyID))
@@ -602,11 +640,14 @@ func (s *StoryService) UpdateStoryStatus(storyID int, status string) error {
 		}
 	} else {
 		s.logger.Info("Story to be updating to", zap.String("status", status))
+		previousStatus := story.Status
 		err = s.storyRepo.UpdateStoryStatus(story, status)
 		if err != nil {
 			s.logger.Error("Error updating story status", zap.Error(err))
 			return err
 		}
+		s.webhookService.PublishStoryStatusChanged(story.ID, previousStatus, status)
+		s.rollUpParentStatus(story.ID)
 	}
 
 	return nil
@@ -665,6 +706,10 @@ func (s *StoryService) GetStoryByExecutionID(executionID uint) (*models.Story, e
 	return s.storyRepo.GetStoryByExecutionID(executionID)
 }
 
+func (s *StoryService) CountStoriesByProjectIdAndStatusesWithTx(tx *gorm.DB, projectId int, statuses []string) (int64, error) {
+	return s.storyRepo.CountStoriesByProjectIdAndStatusesWithTx(tx, projectId, statuses)
+}
+
 func (s *StoryService) GetStoryByProjectIdAndStatus(projectId int, status string) (*models.Story, error) {
 	return s.storyRepo.GetStoryByProjectIdAndStatus(projectId, status)
 
@@ -674,6 +719,18 @@ func (s *StoryService) UpdateStoryStatusWithTx(tx *gorm.DB, storyId int, progres
 	return s.storyRepo.UpdateStoryStatusWithTx(tx, storyId, progress)
 }
 
+// PublishStoryStatusChanged publishes a status change made with UpdateStoryStatusWithTx once the transaction is committed.
+func (s *StoryService) PublishStoryStatusChanged(storyID uint, previousStatus, status string) {
+	s.webhookService.PublishStoryStatusChanged(storyID, previousStatus, status)
+	s.rollUpParentStatus(storyID)
+}
+
+func (s *StoryService) rollUpParentStatus(storyID uint) {
+	if err := rollUpParentStatus(s.storyRepo, s.webhookService, storyID); err != nil {
+		s.logger.Error("Error rolling up the status of the parent story", zap.Uint("storyID", storyID), zap.Error(err))
+	}
+}
+
 func NewStoryService(
 	storyRepo *repositories.StoryRepository,
 	storyTestCaseRepo *repositories.StoryTestCaseRepository,
@@ -684,6 +741,8 @@ func NewStoryService(
 	storyFileRepo *repositories.StoryFileRepository,
 	workspaceServiceClient *workspace.WorkspaceServiceClient,
 	projectService *ProjectService,
+	webhookService *OrganisationWebhookService,
+	storyIssueLinkRepo *repositories.StoryIssueLinkRepository,
 ) *StoryService {
 	return &StoryService{
 		storyRepo:              storyRepo,
@@ -696,5 +755,7 @@ func NewStoryService(
 		hashIdGenerator:        utils.NewHashIDGenerator(5),
 		workspaceServiceClient: workspaceServiceClient,
 		projectService:         projectService,
+		webhookService:         webhookService,
+		storyIssueLinkRepo:     storyIssueLinkRepo,
 	}
 }
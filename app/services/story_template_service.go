@@ -0,0 +1,183 @@
+package services
+
+import (
+	"ai-developer/app/models"
+	"ai-developer/app/models/types"
+	"ai-developer/app/repositories"
+	"ai-developer/app/types/request"
+	"ai-developer/app/types/response"
+	"ai-developer/app/utils"
+	"fmt"
+	"strings"
+)
+
+const maxTemplateNameLength = 100
+
+// StoryTemplateService manages the story templates of organisations, e.g. "CRUD endpoint" or "add auth to
+// route". Rendering a template fills in its placeholders and returns the fields of a story to create.
+type StoryTemplateService struct {
+	templateRepo *repositories.StoryTemplateRepository
+}
+
+func NewStoryTemplateService(templateRepo *repositories.StoryTemplateRepository) *StoryTemplateService {
+	return &StoryTemplateService{templateRepo: templateRepo}
+}
+
+func (s *StoryTemplateService) GetTemplates(organisationID uint) ([]response.StoryTemplate, error) {
+	templates, err := s.templateRepo.GetTemplatesByOrganisationID(organisationID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]response.StoryTemplate, 0, len(templates))
+	for _, template := range templates {
+		result = append(result, toStoryTemplateResponse(&template))
+	}
+	return result, nil
+}
+
+func (s *StoryTemplateService) CreateTemplate(organisationID uint, createRequest request.CreateStoryTemplateRequest) (*response.StoryTemplate, error) {
+	template := &models.StoryTemplate{
+		OrganisationID: organisationID,
+		Name:           strings.TrimSpace(createRequest.Name),
+		Summary:        strings.TrimSpace(createRequest.Summary),
+		Description:    createRequest.Description,
+		TestCases:      joinTemplateTestCases(createRequest.TestCases),
+		Instructions:   createRequest.Instructions,
+	}
+	if err := validateStoryTemplate(template); err != nil {
+		return nil, err
+	}
+	if err := s.templateRepo.CreateTemplate(template); err != nil {
+		return nil, err
+	}
+	result := toStoryTemplateResponse(template)
+	return &result, nil
+}
+
+func (s *StoryTemplateService) UpdateTemplate(organisationID, templateID uint, updateRequest request.UpdateStoryTemplateRequest) (*response.StoryTemplate, error) {
+	template, err := s.getTemplate(organisationID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if updateRequest.Name != nil {
+		template.Name = strings.TrimSpace(*updateRequest.Name)
+	}
+	if updateRequest.Summary != nil {
+		template.Summary = strings.TrimSpace(*updateRequest.Summary)
+	}
+	if updateRequest.Description != nil {
+		template.Description = *updateRequest.Description
+	}
+	if updateRequest.TestCases != nil {
+		template.TestCases = joinTemplateTestCases(*updateRequest.TestCases)
+	}
+	if updateRequest.Instructions != nil {
+		template.Instructions = *updateRequest.Instructions
+	}
+	if err := validateStoryTemplate(template); err != nil {
+		return nil, err
+	}
+	if err := s.templateRepo.UpdateTemplate(template); err != nil {
+		return nil, err
+	}
+	result := toStoryTemplateResponse(template)
+	return &result, nil
+}
+
+func (s *StoryTemplateService) DeleteTemplate(organisationID, templateID uint) error {
+	template, err := s.getTemplate(organisationID, templateID)
+	if err != nil {
+		return err
+	}
+	return s.templateRepo.DeleteTemplate(template)
+}
+
+// RenderTemplate fills in the placeholders of the template with the values, every placeholder needs a value.
+func (s *StoryTemplateService) RenderTemplate(organisationID, templateID uint, values map[string]string) (*response.RenderedStoryTemplate, error) {
+	template, err := s.getTemplate(organisationID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	seen := map[string]bool{}
+	fill := func(text string) string {
+		filled, missingValues := utils.FillPlaceholders(text, values)
+		for _, name := range missingValues {
+			if !seen[name] {
+				seen[name] = true
+				missing = append(missing, name)
+			}
+		}
+		return filled
+	}
+	rendered := &response.RenderedStoryTemplate{
+		Summary:      strings.TrimSpace(fill(template.Summary)),
+		Description:  fill(template.Description),
+		TestCases:    []string{},
+		Instructions: fill(template.Instructions),
+	}
+	for _, testCase := range splitTemplateTestCases(template.TestCases) {
+		rendered.TestCases = append(rendered.TestCases, fill(testCase))
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: no value for placeholders %s", types.ErrInvalidStoryTemplate, strings.Join(missing, ", "))
+	}
+	if rendered.Summary == "" || len(rendered.Summary) > maxStoryTitleLength {
+		return nil, fmt.Errorf("%w: the rendered summary must have 1 to %d characters", types.ErrInvalidStoryTemplate, maxStoryTitleLength)
+	}
+	return rendered, nil
+}
+
+func (s *StoryTemplateService) getTemplate(organisationID, templateID uint) (*models.StoryTemplate, error) {
+	template, err := s.templateRepo.GetOrganisationTemplate(organisationID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, types.ErrStoryTemplateNotFound
+	}
+	return template, nil
+}
+
+func validateStoryTemplate(template *models.StoryTemplate) error {
+	if template.Name == "" || len(template.Name) > maxTemplateNameLength {
+		return fmt.Errorf("%w: name must have 1 to %d characters", types.ErrInvalidStoryTemplate, maxTemplateNameLength)
+	}
+	if template.Summary == "" {
+		return fmt.Errorf("%w: summary must not be empty", types.ErrInvalidStoryTemplate)
+	}
+	return nil
+}
+
+// joinTemplateTestCases stores the test cases one per line, blank test cases are dropped.
+func joinTemplateTestCases(testCases []string) string {
+	lines := make([]string, 0, len(testCases))
+	for _, testCase := range testCases {
+		testCase = strings.Join(strings.Fields(testCase), " ")
+		if testCase != "" {
+			lines = append(lines, testCase)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func splitTemplateTestCases(testCases string) []string {
+	if testCases == "" {
+		return []string{}
+	}
+	return strings.Split(testCases, "\n")
+}
+
+func toStoryTemplateResponse(template *models.StoryTemplate) response.StoryTemplate {
+	return response.StoryTemplate{
+		ID:           template.ID,
+		Name:         template.Name,
+		Summary:      template.Summary,
+		Description:  template.Description,
+		TestCases:    splitTemplateTestCases(template.TestCases),
+		Instructions: template.Instructions,
+		Placeholders: utils.TemplatePlaceholders(template.Summary, template.Description, template.TestCases, template.Instructions),
+		CreatedAt:    template.CreatedAt,
+		UpdatedAt:    template.UpdatedAt,
+	}
+}
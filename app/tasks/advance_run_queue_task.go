@@ -0,0 +1,49 @@
+package tasks
+
+import (
+	"ai-developer/app/models/dtos/asynq_task"
+	"ai-developer/app/services"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+type AdvanceRunQueueTaskHandler struct {
+	runQueueService *services.RunQueueService
+	logger          *zap.Logger
+}
+
+func NewAdvanceRunQueueTaskHandler(
+	runQueueService *services.RunQueueService,
+	logger *zap.Logger) *AdvanceRunQueueTaskHandler {
+	return &AdvanceRunQueueTaskHandler{
+		runQueueService: runQueueService,
+		logger:          logger,
+	}
+}
+
+// HandleTask advances the run queue of the project of the payload, the periodic task carries no payload and
+// advances all run queues.
+func (h *AdvanceRunQueueTaskHandler) HandleTask(ctx context.Context, t *asynq.Task) error {
+	var payload asynq_task.AdvanceRunQueuePayload
+	if len(t.Payload()) > 0 {
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			h.logger.Error("Failed to unmarshal payload", zap.Error(err))
+			return fmt.Errorf("unmarshal payload: %w", err)
+		}
+	}
+	var err error
+	if payload.ProjectID == 0 {
+		err = h.runQueueService.AdvanceRunQueues()
+	} else {
+		err = h.runQueueService.AdvanceRunQueue(payload.ProjectID)
+	}
+	if err != nil {
+		h.logger.Error("Failed to advance run queue", zap.Uint("project_id", payload.ProjectID), zap.Error(err))
+		return fmt.Errorf("advance run queue: %w", err)
+	}
+	return nil
+}
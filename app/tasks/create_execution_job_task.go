@@ -2,6 +2,7 @@ package tasks
 
 import (
 	"ai-developer/app/constants"
+	"ai-developer/app/models"
 	"ai-developer/app/models/dtos/asynq_task"
 	"ai-developer/app/services"
 	"ai-developer/app/types/request"
@@ -27,6 +28,7 @@ type CreateExecutionJobTaskHandler struct {
 	executionStepService   *services.ExecutionStepService
 	pullRequestService     *services.PullRequestService
 	executionOutputService *services.ExecutionOutputService
+	asynqClient            *asynq.Client
 	db                     *gorm.DB
 	logger                 *zap.Logger
 }
@@ -40,6 +42,7 @@ func NewCreateExecutionJobTaskHandler(
 	executionStepService *services.ExecutionStepService,
 	pullRequestService *services.PullRequestService,
 	executionOutputService *services.ExecutionOutputService,
+	asynqClient *asynq.Client,
 	db *gorm.DB,
 	logger *zap.Logger,
 ) *CreateExecutionJobTaskHandler {
@@ -52,11 +55,24 @@ func NewCreateExecutionJobTaskHandler(
 		executionStepService:   executionStepService,
 		pullRequestService:     pullRequestService,
 		executionOutputService: executionOutputService,
+		asynqClient:            asynqClient,
 		db:                     db,
 		logger:                 logger,
 	}
 }
 
+// backendExecutorImage returns the executor image with the toolchain needed to build and run the backend framework.
+func backendExecutorImage(framework string) string {
+	switch framework {
+	case constants.Express:
+		return "node"
+	case constants.Go:
+		return "go"
+	default:
+		return "python"
+	}
+}
+
 func (h *CreateExecutionJobTaskHandler) HandleTask(ctx context.Context, t *asynq.Task) error {
 	var payload asynq_task.CreateJobPayload
 	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
@@ -90,33 +106,37 @@ func (h *CreateExecutionJobTaskHandler) HandleTask(ctx context.Context, t *asynq
 		return errors.New("execution already in progress for this story")
 	}
 
-	project, err := h.projectService.GetProjectById(story.ProjectID)
+	// The project row stays locked until the story is marked in progress, so that concurrent tasks of the
+	// project count the stories this one starts. The job is created after the commit.
+	project, err := h.projectService.GetProjectByIdForUpdateWithTx(tx, story.ProjectID)
 	if err != nil {
 		tx.Rollback()
 		h.logger.Error("Error fetching project", zap.Error(err))
 		return err
 	}
 
-	existingStoryInProgress, err := h.storyService.GetStoryByProjectIdAndStatus(int(project.ID), constants.InProgress)
-	if existingStoryInProgress != nil {
-		tx.Rollback()
-		h.logger.Error("Story with status IN_PROGRESS already exists for this project")
-		return errors.New("story with status IN_PROGRESS already exists for this project")
-	}
-
-	branchPrefix, err := utils.RandString(5)
+	storiesInProgress, err := h.storyService.CountStoriesByProjectIdAndStatusesWithTx(tx, int(project.ID), []string{constants.InProgress})
 	if err != nil {
 		tx.Rollback()
-		h.logger.Error("Error generating random string", zap.Error(err))
+		h.logger.Error("Error counting stories in progress", zap.Error(err))
 		return err
 	}
-	branchName := fmt.Sprintf("branch_%s_%d", branchPrefix, story.ID)
+	if storiesInProgress >= int64(project.MaxConcurrentExecutions) {
+		tx.Rollback()
+		h.logger.Info("Project is executing as many stories as it may", zap.Uint("project_id", project.ID), zap.Int64("in_progress", storiesInProgress))
+		return h.enqueueAgain(t)
+	}
 
+	var branchName string
 	if payload.ReExecute && story.Type != constants.Frontend {
-		pullRequest, _ := h.pullRequestService.GetPullRequestByID(payload.PullRequestId)
-		executionOutput, _ := h.executionOutputService.GetExecutionOutputByID(pullRequest.ExecutionOutputID)
-		existingPullRequestExecution, _ := h.executionService.GetExecutionByID(executionOutput.ExecutionID)
-		branchName = existingPullRequestExecution.BranchName
+		branchName, err = h.pullRequestBranchName(payload.PullRequestId)
+	} else {
+		branchName, err = h.newBranchName(project, story)
+	}
+	if err != nil {
+		tx.Rollback()
+		h.logger.Error("Error determining branch name", zap.Error(err))
+		return err
 	}
 	h.logger.Info("Branch name generated", zap.String("branchName", branchName))
 	h.logger.Info("Updating story status to IN_PROGRESS", zap.Any("story", story))
@@ -141,6 +161,18 @@ func (h *CreateExecutionJobTaskHandler) HandleTask(ctx context.Context, t *asynq
 		return err
 	}
 
+	err = h.activityLogService.CreateActivityLogWithTx(tx, execution.ID, executionStep.ID, "INFO", "Initializing Workspace for automated development...")
+	if err != nil {
+		tx.Rollback()
+		h.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		h.logger.Error("Transaction commit failed", zap.Error(err))
+		return err
+	}
+
 	createJobRequest := request.NewCreateJobRequest()
 	createJobRequest.WithBranch(branchName)
 	createJobRequest.WithStoryId(int64(payload.StoryID))
@@ -157,7 +189,7 @@ func (h *CreateExecutionJobTaskHandler) HandleTask(ctx context.Context, t *asynq
 		fmt.Println("Project Framework", project.BackendFramework)
 		createJobRequest.WithPullRequestId(int64(payload.PullRequestId))
 		createJobRequest.WithProjectId(project.HashID)
-		createJobRequest.WithExecutorImage("python")
+		createJobRequest.WithExecutorImage(backendExecutorImage(project.BackendFramework))
 		mountPath := "/workspaces/" + project.HashID
 		createJobRequest.WithWorkspaceMountPath(mountPath)
 		createJobRequest.Env = append(createJobRequest.Env, "EXECUTION_TEMPLATE="+strings.ToUpper(project.BackendFramework))
@@ -169,23 +201,80 @@ func (h *CreateExecutionJobTaskHandler) HandleTask(ctx context.Context, t *asynq
 
 	job, err := h.workspaceServiceClient.CreateJob(createJobRequest)
 	if err != nil {
-		tx.Rollback()
 		h.logger.Error("Error creating job", zap.Error(err))
+		h.releaseSlot(story, execution)
 		return err
 	}
 
-	err = h.activityLogService.CreateActivityLogWithTx(tx, execution.ID, executionStep.ID, "INFO", "Initializing Workspace for automated development...")
+	h.logger.Info("Job created", zap.Any("job", job))
+	h.storyService.PublishStoryStatusChanged(story.ID, story.Status, constants.InProgress)
+	h.executionService.PublishExecutionEvent(execution, constants.EventExecutionStarted, nil)
+	return nil
+}
+
+// enqueueAgain enqueues the task again for when a slot of the project may be free. Returning an error instead
+// would count as a failure and back off further with every attempt.
+func (h *CreateExecutionJobTaskHandler) enqueueAgain(t *asynq.Task) error {
+	_, err := h.asynqClient.Enqueue(asynq.NewTask(t.Type(), t.Payload()), asynq.ProcessIn(constants.ExecutionSlotWaitInterval), asynq.MaxRetry(5))
 	if err != nil {
-		tx.Rollback()
-		h.logger.Error("Error creating activity log", zap.Error(err))
+		h.logger.Error("Error enqueuing task again", zap.Error(err))
 		return err
 	}
+	return nil
+}
 
-	if err := tx.Commit().Error; err != nil {
-		h.logger.Error("Transaction commit failed", zap.Error(err))
-		return err
+// releaseSlot gives up the slot reserved for a job the workspace service did not create. The execution is marked
+// failed and the story gets its previous status back, the retry of the task starts a new execution.
+func (h *CreateExecutionJobTaskHandler) releaseSlot(story *models.Story, execution *models.Execution) {
+	if err := h.executionService.UpdateExecutionStatus(execution.ID, constants.ExecutionFailed); err != nil {
+		h.logger.Error("Error marking execution failed", zap.Uint("execution_id", execution.ID), zap.Error(err))
 	}
+	if err := h.storyService.UpdateStoryStatusWithTx(h.db, int(story.ID), story.Status); err != nil {
+		h.logger.Error("Error resetting story status", zap.Uint("story_id", story.ID), zap.Error(err))
+	}
+}
 
-	h.logger.Info("Job created and transaction committed", zap.Any("job", job))
-	return nil
+// newBranchName names the branch of a new execution after the branch name template of the project. Names
+// already used by an earlier execution, e.g. from templates without {rand}, get a random suffix.
+func (h *CreateExecutionJobTaskHandler) newBranchName(project *models.Project, story *models.Story) (string, error) {
+	branchName, err := utils.GenerateBranchName(project.BranchNameTemplate, story.ID, story.Title)
+	if err != nil {
+		return "", err
+	}
+	existingExecution, err := h.executionService.GetExecutionByBranchName(branchName)
+	if err != nil {
+		return "", err
+	}
+	if existingExecution != nil && existingExecution.ID != 0 {
+		suffix, err := utils.RandString(5)
+		if err != nil {
+			return "", err
+		}
+		branchName = branchName + "-" + strings.ToLower(suffix)
+	}
+	return branchName, nil
+}
+
+// pullRequestBranchName returns the source branch of the pull request a re-execution works on. Pull requests
+// created before source branches were stored fall back to the branch of the execution that created them.
+func (h *CreateExecutionJobTaskHandler) pullRequestBranchName(pullRequestID uint) (string, error) {
+	pullRequest, err := h.pullRequestService.GetPullRequestByID(pullRequestID)
+	if err != nil {
+		return "", err
+	}
+	if pullRequest == nil {
+		return "", fmt.Errorf("pull request %d not found", pullRequestID)
+	}
+	if pullRequest.SourceBranch != "" {
+		return pullRequest.SourceBranch, nil
+	}
+	executionOutput, err := h.executionOutputService.GetExecutionOutputByID(pullRequest.ExecutionOutputID)
+	if err != nil {
+		return "", err
+	}
+	existingPullRequestExecution, err := h.executionService.GetExecutionByID(executionOutput.ExecutionID)
+	if err != nil {
+		return "", err
+	}
+	return existingPullRequestExecution.BranchName, nil
 }
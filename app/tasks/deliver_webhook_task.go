@@ -0,0 +1,45 @@
+package tasks
+
+import (
+	"ai-developer/app/services"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+
+	"ai-developer/app/models/dtos/asynq_task"
+)
+
+type DeliverWebhookTaskHandler struct {
+	organisationWebhookService *services.OrganisationWebhookService
+	logger                     *zap.Logger
+}
+
+func NewDeliverWebhookTaskHandler(
+	organisationWebhookService *services.OrganisationWebhookService,
+	logger *zap.Logger) *DeliverWebhookTaskHandler {
+	return &DeliverWebhookTaskHandler{
+		organisationWebhookService: organisationWebhookService,
+		logger:                     logger,
+	}
+}
+
+func (h *DeliverWebhookTaskHandler) HandleTask(ctx context.Context, t *asynq.Task) error {
+	var p asynq_task.DeliverWebhookPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		h.logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return fmt.Errorf("unmarshal payload: %w", err)
+	}
+	retryCount, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	h.logger.Info("Processing deliver webhook task", zap.Uint("delivery_id", p.DeliveryID), zap.Int("retry", retryCount))
+
+	if err := h.organisationWebhookService.Deliver(p.DeliveryID, retryCount >= maxRetry); err != nil {
+		h.logger.Error("Failed to deliver webhook", zap.Uint("delivery_id", p.DeliveryID), zap.Error(err))
+		return fmt.Errorf("deliver webhook: %w", err)
+	}
+	h.logger.Info("Successfully delivered webhook", zap.Uint("delivery_id", p.DeliveryID))
+	return nil
+}
@@ -0,0 +1,33 @@
+package tasks
+
+import (
+	"ai-developer/app/services"
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+type ReconcilePullRequestsTaskHandler struct {
+	pullRequestService *services.PullRequestService
+	logger             *zap.Logger
+}
+
+func NewReconcilePullRequestsTaskHandler(
+	pullRequestService *services.PullRequestService,
+	logger *zap.Logger) *ReconcilePullRequestsTaskHandler {
+	return &ReconcilePullRequestsTaskHandler{
+		pullRequestService: pullRequestService,
+		logger:             logger,
+	}
+}
+
+func (h *ReconcilePullRequestsTaskHandler) HandleTask(ctx context.Context, t *asynq.Task) error {
+	h.logger.Info("Running ReconcilePullRequestsTaskHandler.........")
+	if err := h.pullRequestService.ReconcilePullRequests(); err != nil {
+		h.logger.Error("Failed to reconcile pull requests", zap.Error(err))
+		return fmt.Errorf("reconcile pull requests: %w", err)
+	}
+	return nil
+}
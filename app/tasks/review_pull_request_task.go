@@ -0,0 +1,45 @@
+package tasks
+
+import (
+	"ai-developer/app/services"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+
+	"ai-developer/app/models/dtos/asynq_task"
+)
+
+type ReviewPullRequestTaskHandler struct {
+	pullRequestReviewService *services.PullRequestReviewService
+	logger                   *zap.Logger
+}
+
+func NewReviewPullRequestTaskHandler(
+	pullRequestReviewService *services.PullRequestReviewService,
+	logger *zap.Logger) *ReviewPullRequestTaskHandler {
+	return &ReviewPullRequestTaskHandler{
+		pullRequestReviewService: pullRequestReviewService,
+		logger:                   logger,
+	}
+}
+
+func (h *ReviewPullRequestTaskHandler) HandleTask(ctx context.Context, t *asynq.Task) error {
+	var p asynq_task.ReviewPullRequestPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		h.logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return fmt.Errorf("unmarshal payload: %w", err)
+	}
+	retryCount, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	h.logger.Info("Processing review pull request task", zap.Uint("review_id", p.ReviewID), zap.Int("retry", retryCount))
+
+	if err := h.pullRequestReviewService.RunReview(p.ReviewID, retryCount >= maxRetry); err != nil {
+		h.logger.Error("Failed to review pull request", zap.Uint("review_id", p.ReviewID), zap.Error(err))
+		return fmt.Errorf("review pull request: %w", err)
+	}
+	h.logger.Info("Successfully reviewed pull request", zap.Uint("review_id", p.ReviewID))
+	return nil
+}
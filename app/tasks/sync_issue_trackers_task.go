@@ -0,0 +1,33 @@
+package tasks
+
+import (
+	"ai-developer/app/services"
+	"context"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"go.uber.org/zap"
+)
+
+type SyncIssueTrackersTaskHandler struct {
+	issueTrackerService *services.IssueTrackerService
+	logger              *zap.Logger
+}
+
+func NewSyncIssueTrackersTaskHandler(
+	issueTrackerService *services.IssueTrackerService,
+	logger *zap.Logger) *SyncIssueTrackersTaskHandler {
+	return &SyncIssueTrackersTaskHandler{
+		issueTrackerService: issueTrackerService,
+		logger:              logger,
+	}
+}
+
+func (h *SyncIssueTrackersTaskHandler) HandleTask(ctx context.Context, t *asynq.Task) error {
+	h.logger.Info("Running SyncIssueTrackersTaskHandler.........")
+	if err := h.issueTrackerService.SyncIssueTrackers(); err != nil {
+		h.logger.Error("Failed to sync issue trackers", zap.Error(err))
+		return fmt.Errorf("sync issue trackers: %w", err)
+	}
+	return nil
+}
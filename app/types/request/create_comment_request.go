@@ -3,4 +3,6 @@ package request
 type CreateCommentRequest struct {
 	PullRequestID uint   `json:"pull_request_id"`
 	Comment       string `json:"comment"`
+	Path          string `json:"path"`
+	Line          int    `json:"line"`
 }
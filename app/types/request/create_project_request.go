@@ -5,4 +5,7 @@ type CreateProjectRequest struct {
 	Framework         string `json:"framework"`
 	FrontendFramework string `json:"frontend_framework"`
 	Description       string `json:"description"`
+	GitProvider       string `json:"git_provider"`
+	RepositoryOwner   string `json:"repository_owner"`
+	DefaultBranch     string `json:"default_branch"`
 }
@@ -1,13 +1,22 @@
 package request
 
 type CreateWorkspaceRequest struct {
-	StoryHashId      string  `json:"storyHashId"`
-	WorkspaceId      string  `json:"workspaceId"`
-	RemoteURL        string  `json:"remoteURL"`
-	BackendTemplate  *string `json:"backendTemplate,omitempty"`
-	FrontendTemplate *string `json:"frontendTemplate,omitempty"`
-	GitnessUserName  string  `json:"gitnessUserName"`
-	GitnessToken     string  `json:"gitnessToken"`
+	StoryHashId      string        `json:"storyHashId"`
+	WorkspaceId      string        `json:"workspaceId"`
+	RemoteURL        string        `json:"remoteURL"`
+	BackendTemplate  *string       `json:"backendTemplate,omitempty"`
+	FrontendTemplate *string       `json:"frontendTemplate,omitempty"`
+	GitnessUserName  string        `json:"gitnessUserName"`
+	GitnessToken     string        `json:"gitnessToken"`
+	DefaultBranch    string        `json:"defaultBranch,omitempty"`
+	Import           *ImportSource `json:"import,omitempty"`
+}
+
+// ImportSource is an existing repository the workspace is cloned from instead of a template.
+type ImportSource struct {
+	CloneURL string `json:"cloneURL"`
+	UserName string `json:"userName,omitempty"`
+	Token    string `json:"token,omitempty"`
 }
 
 func (receiver *CreateWorkspaceRequest) WithBackendTemplate(backendTemplate string) *CreateWorkspaceRequest {
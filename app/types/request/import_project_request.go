@@ -0,0 +1,19 @@
+package request
+
+type ImportProjectRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	CloneURL    string `json:"clone_url" binding:"required"`
+	Username    string `json:"username"`
+	Token       string `json:"token"`
+	// DefaultBranch is the branch to import, the default branch of the repository when empty.
+	DefaultBranch string `json:"default_branch"`
+	// Framework and FrontendFramework override the frameworks detected from the imported code.
+	Framework         string `json:"framework"`
+	FrontendFramework string `json:"frontend_framework"`
+	GitProvider       string `json:"git_provider"`
+	RepositoryOwner   string `json:"repository_owner"`
+	// UseExistingRepository marks the clone URL as the project repository on the git provider, otherwise
+	// a new repository is created and the imported branch is pushed to it.
+	UseExistingRepository bool `json:"use_existing_repository"`
+}
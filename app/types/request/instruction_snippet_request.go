@@ -0,0 +1,15 @@
+package request
+
+type CreateInstructionSnippetRequest struct {
+	Name    string `json:"name" binding:"required"`
+	Content string `json:"content" binding:"required"`
+	// IsActive defaults to true, inactive snippets are not appended to the instructions of stories.
+	IsActive *bool `json:"is_active"`
+}
+
+// UpdateInstructionSnippetRequest leaves the fields which are omitted unchanged.
+type UpdateInstructionSnippetRequest struct {
+	Name     *string `json:"name"`
+	Content  *string `json:"content"`
+	IsActive *bool   `json:"is_active"`
+}
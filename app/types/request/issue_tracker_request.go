@@ -0,0 +1,34 @@
+package request
+
+type CreateIssueTrackerRequest struct {
+	Provider string `json:"provider" binding:"required"`
+	// BaseURL is the Jira site, or overrides the Linear or GitHub API endpoint.
+	BaseURL string `json:"base_url"`
+	// Username is the account email Jira Cloud API tokens belong to.
+	Username string `json:"username"`
+	Token    string `json:"token" binding:"required"`
+	// Scope is the Jira project key, the Linear team key or the GitHub repository as owner/repo.
+	Scope string `json:"scope" binding:"required"`
+	// Label marks the issues to import, supercoder when omitted.
+	Label string `json:"label"`
+	// WebhookSecret verifies the webhook deliveries of the tracker, one is generated when omitted.
+	WebhookSecret   string `json:"webhook_secret"`
+	PollingEnabled  *bool  `json:"polling_enabled"`
+	TodoState       string `json:"todo_state"`
+	InProgressState string `json:"in_progress_state"`
+	DoneState       string `json:"done_state"`
+}
+
+// UpdateIssueTrackerRequest leaves the settings which are omitted unchanged.
+type UpdateIssueTrackerRequest struct {
+	BaseURL         *string `json:"base_url"`
+	Username        *string `json:"username"`
+	Token           *string `json:"token"`
+	Scope           *string `json:"scope"`
+	Label           *string `json:"label"`
+	WebhookSecret   *string `json:"webhook_secret"`
+	PollingEnabled  *bool   `json:"polling_enabled"`
+	TodoState       *string `json:"todo_state"`
+	InProgressState *string `json:"in_progress_state"`
+	DoneState       *string `json:"done_state"`
+}
@@ -2,4 +2,6 @@ package request
 
 type MergePullRequest struct {
 	PullRequestID int `json:"pull_request_id"`
+	// MergeMethod is one of merge, squash or rebase, the merge method of the project when omitted.
+	MergeMethod string `json:"merge_method"`
 }
@@ -0,0 +1,17 @@
+package request
+
+type CreateOrganisationWebhookRequest struct {
+	URL string `json:"url" binding:"required"`
+	// Secret signs the deliveries, one is generated when omitted.
+	Secret string `json:"secret"`
+	// Events the webhook receives, all events when empty.
+	Events []string `json:"events"`
+}
+
+// UpdateOrganisationWebhookRequest leaves the settings which are omitted unchanged.
+type UpdateOrganisationWebhookRequest struct {
+	URL      *string   `json:"url"`
+	Secret   *string   `json:"secret"`
+	Events   *[]string `json:"events"`
+	IsActive *bool     `json:"is_active"`
+}
@@ -0,0 +1,19 @@
+package request
+
+// CreatePromptVersionRequest stores a new version of a prompt. Without content the version copies the prompt
+// executions currently use, e.g. to compare it against a new version.
+type CreatePromptVersionRequest struct {
+	Content     string `json:"content"`
+	Description string `json:"description"`
+	// Activate makes the new version the one executions use.
+	Activate bool `json:"activate"`
+}
+
+// UpdatePromptVersionRequest leaves the settings which are omitted unchanged, the content of a version is fixed.
+type UpdatePromptVersionRequest struct {
+	Description *string `json:"description"`
+	// IsActive false makes executions use the default prompt file again.
+	IsActive *bool `json:"is_active"`
+	// ABWeight assigns executions to the version in proportion to its weight, 0 takes it out of the comparison.
+	ABWeight *int `json:"ab_weight"`
+}
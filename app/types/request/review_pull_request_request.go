@@ -0,0 +1,5 @@
+package request
+
+type ReviewPullRequestRequest struct {
+	PullRequestNumber int `json:"pull_request_number" binding:"required"`
+}
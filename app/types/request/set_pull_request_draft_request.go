@@ -0,0 +1,5 @@
+package request
+
+type SetPullRequestDraftRequest struct {
+	Draft *bool `json:"draft" binding:"required"`
+}
@@ -0,0 +1,21 @@
+package request
+
+type DecomposeStoryRequest struct {
+	// Guidance is passed to the LLM along with the story, e.g. how to cut the story.
+	Guidance string `json:"guidance"`
+}
+
+// SubStory is a sub-story proposed by a decomposition. Proposals are returned for review and come back, possibly
+// edited, when they are accepted.
+type SubStory struct {
+	Summary      string   `json:"summary" binding:"required"`
+	Description  string   `json:"description"`
+	TestCases    []string `json:"test_cases"`
+	Instructions string   `json:"instructions"`
+	// DependsOn holds the indexes of the earlier sub-stories this one builds on.
+	DependsOn []int `json:"depends_on"`
+}
+
+type AcceptSubStoriesRequest struct {
+	SubStories []SubStory `json:"sub_stories" binding:"required,min=1,dive"`
+}
@@ -0,0 +1,10 @@
+package request
+
+type SetStoryDependenciesRequest struct {
+	// DependsOn lists the stories of the same project which have to be done first, empty to drop all dependencies.
+	DependsOn []uint `json:"depends_on"`
+}
+
+type EnqueueStoriesRequest struct {
+	StoryIDs []uint `json:"story_ids" binding:"required"`
+}
@@ -0,0 +1,24 @@
+package request
+
+// CreateStoryTemplateRequest creates a template, its texts may contain {{placeholder}}s.
+type CreateStoryTemplateRequest struct {
+	Name         string   `json:"name" binding:"required"`
+	Summary      string   `json:"summary" binding:"required"`
+	Description  string   `json:"description"`
+	TestCases    []string `json:"test_cases"`
+	Instructions string   `json:"instructions"`
+}
+
+// UpdateStoryTemplateRequest leaves the fields which are omitted unchanged.
+type UpdateStoryTemplateRequest struct {
+	Name         *string   `json:"name"`
+	Summary      *string   `json:"summary"`
+	Description  *string   `json:"description"`
+	TestCases    *[]string `json:"test_cases"`
+	Instructions *string   `json:"instructions"`
+}
+
+// RenderStoryTemplateRequest fills in the placeholders of a template, every placeholder needs a value.
+type RenderStoryTemplateRequest struct {
+	Values map[string]string `json:"values"`
+}
@@ -4,4 +4,27 @@ type UpdateProjectRequest struct {
 	ProjectID   int    `json:"project_id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// Branch settings are left unchanged when omitted, an empty value resets them to the default.
+	BaseBranch         *string `json:"base_branch"`
+	TargetBranch       *string `json:"target_branch"`
+	BranchNameTemplate *string `json:"branch_name_template"`
+	// PullRequestTemplate is the text/template pull request descriptions are rendered from.
+	PullRequestTemplate *string `json:"pull_request_template"`
+	// Auto-merge policy, AutoMergeExcludedPaths holds one glob per line.
+	AutoMergeEnabled           *bool   `json:"auto_merge_enabled"`
+	AutoMergeMethod            *string `json:"auto_merge_method"`
+	AutoMergeRequireServerTest *bool   `json:"auto_merge_require_server_test"`
+	AutoMergeRequireTests      *bool   `json:"auto_merge_require_tests"`
+	AutoMergeRequireLint       *bool   `json:"auto_merge_require_lint"`
+	AutoMergeMaxDiffLines      *int    `json:"auto_merge_max_diff_lines"`
+	AutoMergeExcludedPaths     *string `json:"auto_merge_excluded_paths"`
+	// MaxConcurrentExecutions limits the stories of the project executing at the same time.
+	MaxConcurrentExecutions *int `json:"max_concurrent_executions"`
+	// Conventions are fed to every prompt, the .supercoder/conventions.md of the repository is used when empty.
+	Conventions *string `json:"conventions"`
+	// System prompts replacing the built-in prompts of the code generators, an empty value restores the built-in one.
+	// The backend prompt may use {project_workspace_id}, {project_name} and {framework}, the frontend prompt
+	// {{EXISTING_CODE}}, {{USER_FEEDBACK}} and {{FILE_NAME}}.
+	BackendSystemPrompt  *string `json:"backend_system_prompt"`
+	FrontendSystemPrompt *string `json:"frontend_system_prompt"`
 }
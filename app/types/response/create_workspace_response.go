@@ -7,6 +7,7 @@ type WorkspaceDetails struct {
 	WorkspaceUrl     *string `json:"workspaceUrl,omitempty"`
 	FrontendUrl      *string `json:"frontendUrl,omitempty"`
 	BackendUrl       *string `json:"backendUrl,omitempty"`
+	DefaultBranch    *string `json:"defaultBranch,omitempty"`
 }
 
 type CreateWorkspaceResponse struct {
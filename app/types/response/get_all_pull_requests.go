@@ -8,6 +8,7 @@ type GetAllPullRequests struct {
 	CreatedOn              string `json:"created_on"`
 	TotalComments          int64  `json:"total_comments"`
 	Status                 string `json:"status"`
+	IsDraft                bool   `json:"is_draft"`
 	MergedOn               string `json:"merged_on"`
 	ClosedOn               string `json:"closed_on"`
 }
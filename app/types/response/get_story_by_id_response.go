@@ -7,6 +7,15 @@ type GetStoryByIdResponse struct {
 	Status            string        `json:"status"`
 	Reason            string        `json:"reason"`
 	StoryInputFileUrl string        `json:"story_input_file_url"`
+	// Issue is the issue the story was imported from, nil for stories created in SuperCoder.
+	Issue *StoryIssue `json:"issue"`
+	// ParentStoryID is the story the story was split from, nil for stories which were not.
+	ParentStoryID *uint `json:"parent_story_id"`
+}
+
+type StoryIssue struct {
+	Key string `json:"key"`
+	URL string `json:"url"`
 }
 
 type StoryOverview struct {
@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+type InstructionSnippet struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Content   string    `json:"content"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
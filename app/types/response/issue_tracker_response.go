@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+type IssueTracker struct {
+	ID              uint       `json:"id"`
+	ProjectID       uint       `json:"project_id"`
+	Provider        string     `json:"provider"`
+	BaseURL         string     `json:"base_url"`
+	Username        string     `json:"username"`
+	Scope           string     `json:"scope"`
+	Label           string     `json:"label"`
+	PollingEnabled  bool       `json:"polling_enabled"`
+	TodoState       string     `json:"todo_state"`
+	InProgressState string     `json:"in_progress_state"`
+	DoneState       string     `json:"done_state"`
+	LastPolledAt    *time.Time `json:"last_polled_at"`
+	// WebhookURL is the path the tracker delivers its webhooks to.
+	WebhookURL string `json:"webhook_url"`
+	// WebhookSecret is only returned when the tracker is created.
+	WebhookSecret string    `json:"webhook_secret,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// IssueTrackerSync counts the issues a sync imported and the issues it pushed a state to.
+type IssueTrackerSync struct {
+	Imported int `json:"imported"`
+	Updated  int `json:"updated"`
+	Pushed   int `json:"pushed"`
+	Failed   int `json:"failed"`
+}
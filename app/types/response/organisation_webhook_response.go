@@ -0,0 +1,26 @@
+package response
+
+import "time"
+
+type OrganisationWebhook struct {
+	ID       uint     `json:"id"`
+	URL      string   `json:"url"`
+	Events   []string `json:"events"`
+	IsActive bool     `json:"is_active"`
+	// Secret is only returned when the webhook is created.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID             uint       `json:"id"`
+	Event          string     `json:"event"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status"`
+	Error          string     `json:"error"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,35 @@
+package response
+
+import "time"
+
+type Prompt struct {
+	Key string `json:"key"`
+	// ActiveVersion is the version executions use, 0 for the default prompt file.
+	ActiveVersion int `json:"active_version"`
+	Versions      int `json:"versions"`
+	// ABTest tells that executions are assigned to the versions with an A/B weight.
+	ABTest bool `json:"ab_test"`
+}
+
+type PromptVersion struct {
+	PromptKey   string    `json:"prompt_key"`
+	Version     int       `json:"version"`
+	Content     string    `json:"content"`
+	Description string    `json:"description"`
+	IsActive    bool      `json:"is_active"`
+	ABWeight    int       `json:"ab_weight"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// PromptVariantReport compares the executions assigned to a version of a prompt. The success rate is over the
+// finished executions, costs are estimated from the tokens the executions used.
+type PromptVariantReport struct {
+	Version           int     `json:"version"`
+	Executions        int     `json:"executions"`
+	Finished          int     `json:"finished"`
+	Succeeded         int     `json:"succeeded"`
+	SuccessRate       float64 `json:"success_rate"`
+	AverageIterations float64 `json:"average_iterations"`
+	AverageCost       float64 `json:"average_cost"`
+	TotalCost         float64 `json:"total_cost"`
+}
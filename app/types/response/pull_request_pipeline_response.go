@@ -0,0 +1,9 @@
+package response
+
+type PullRequestPipeline struct {
+	ID     int    `json:"id"`
+	Ref    string `json:"ref"`
+	SHA    string `json:"sha"`
+	Status string `json:"status"`
+	URL    string `json:"url"`
+}
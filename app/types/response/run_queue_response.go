@@ -0,0 +1,26 @@
+package response
+
+import "time"
+
+type StoryDependency struct {
+	StoryID uint   `json:"story_id"`
+	Title   string `json:"title"`
+	Status  string `json:"status"`
+	// Satisfied is set when the dependency no longer holds the story back.
+	Satisfied bool `json:"satisfied"`
+}
+
+type RunQueueEntry struct {
+	ID          uint   `json:"id"`
+	StoryID     uint   `json:"story_id"`
+	StoryTitle  string `json:"story_title"`
+	StoryStatus string `json:"story_status"`
+	Position    int    `json:"position"`
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	// WaitingFor lists the prerequisites of a queued story which are not done yet.
+	WaitingFor []uint     `json:"waiting_for"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
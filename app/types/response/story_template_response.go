@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+type StoryTemplate struct {
+	ID           uint     `json:"id"`
+	Name         string   `json:"name"`
+	Summary      string   `json:"summary"`
+	Description  string   `json:"description"`
+	TestCases    []string `json:"test_cases"`
+	Instructions string   `json:"instructions"`
+	// Placeholders are the names of the {{placeholder}}s rendering the template needs values for.
+	Placeholders []string  `json:"placeholders"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// RenderedStoryTemplate has the fields of a story request, ready to be reviewed and used to create the story.
+type RenderedStoryTemplate struct {
+	Summary      string   `json:"summary"`
+	Description  string   `json:"description"`
+	TestCases    []string `json:"test_cases"`
+	Instructions string   `json:"instructions"`
+}
@@ -0,0 +1,15 @@
+package response
+
+type SubStory struct {
+	ID     uint   `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+// SubStories are the stories a story was split into, with the status of the story rolled up from theirs.
+type SubStories struct {
+	RolledUpStatus string     `json:"rolled_up_status"`
+	Done           int        `json:"done"`
+	Total          int        `json:"total"`
+	SubStories     []SubStory `json:"sub_stories"`
+}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ProjectMergeMethod is the method pull requests of the project are merged with, squash unless configured.
+func ProjectMergeMethod(project *models.Project) string {
+	if project.AutoMergeMethod != "" {
+		return project.AutoMergeMethod
+	}
+	return constants.DefaultMergeMethod
+}
+
+// DiffStats is the size of a unified diff.
+type DiffStats struct {
+	Files        []string
+	AddedLines   int
+	RemovedLines int
+}
+
+func (s DiffStats) ChangedLines() int {
+	return s.AddedLines + s.RemovedLines
+}
+
+// GetDiffStats returns the files touched by a unified diff, the old path of deleted and renamed files included,
+// and the number of added and removed lines.
+func GetDiffStats(diff string) DiffStats {
+	var stats DiffStats
+	seen := map[string]bool{}
+	addFile := func(path string) {
+		if path != "/dev/null" && !seen[path] {
+			seen[path] = true
+			stats.Files = append(stats.Files, path)
+		}
+	}
+	lines := strings.Split(diff, "\n")
+	for i, text := range lines {
+		switch {
+		case strings.HasPrefix(text, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			addFile(strings.TrimPrefix(strings.TrimPrefix(text, "--- "), "a/"))
+		case strings.HasPrefix(text, "+++ ") && i > 0 && strings.HasPrefix(lines[i-1], "--- "):
+			addFile(strings.TrimPrefix(strings.TrimPrefix(text, "+++ "), "b/"))
+		case strings.HasPrefix(text, "+"):
+			stats.AddedLines++
+		case strings.HasPrefix(text, "-"):
+			stats.RemovedLines++
+		}
+	}
+	return stats
+}
+
+// ParsePathPatterns splits path patterns given one per line, blank lines and lines starting with # are skipped.
+func ParsePathPatterns(patterns string) []string {
+	var parsed []string
+	for _, pattern := range strings.Split(patterns, "\n") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		parsed = append(parsed, pattern)
+	}
+	return parsed
+}
+
+// ValidatePathPatterns checks that every pattern of ParsePathPatterns is a valid glob.
+func ValidatePathPatterns(patterns string) error {
+	for _, pattern := range ParsePathPatterns(patterns) {
+		if _, err := filepath.Match(strings.TrimSuffix(pattern, "/**"), ""); err != nil {
+			return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// MatchPathPattern reports whether the path matches the pattern. A pattern ending in "/" or "/**" matches
+// everything below the directory, a pattern without "/" matches the base name in any directory and any other
+// pattern is matched against the full path.
+func MatchPathPattern(pattern, path string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	if strings.HasSuffix(pattern, "/**") || strings.HasSuffix(pattern, "/") {
+		dir := strings.TrimSuffix(strings.TrimSuffix(pattern, "**"), "/")
+		for parent := filepath.Dir(path); parent != "." && parent != "/"; parent = filepath.Dir(parent) {
+			if matched, _ := filepath.Match(dir, parent); matched {
+				return true
+			}
+		}
+		return false
+	}
+	if !strings.Contains(pattern, "/") {
+		matched, _ := filepath.Match(pattern, filepath.Base(path))
+		return matched
+	}
+	matched, _ := filepath.Match(pattern, path)
+	return matched
+}
+
+// MatchingPaths returns the paths matching any of the patterns.
+func MatchingPaths(patterns []string, paths []string) []string {
+	var matching []string
+	for _, path := range paths {
+		for _, pattern := range patterns {
+			if MatchPathPattern(pattern, path) {
+				matching = append(matching, path)
+				break
+			}
+		}
+	}
+	return matching
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ProjectBaseBranch is the branch story branches are created from, the default branch unless configured.
+func ProjectBaseBranch(project *models.Project) string {
+	if project.BaseBranch != "" {
+		return project.BaseBranch
+	}
+	if project.DefaultBranch != "" {
+		return project.DefaultBranch
+	}
+	return constants.DefaultBranch
+}
+
+// ProjectTargetBranch is the branch pull requests are opened against, the base branch unless configured.
+func ProjectTargetBranch(project *models.Project) string {
+	if project.TargetBranch != "" {
+		return project.TargetBranch
+	}
+	return ProjectBaseBranch(project)
+}
+
+var (
+	branchPlaceholderPattern = regexp.MustCompile(`\{[^}]*\}`)
+	slugInvalidPattern       = regexp.MustCompile(`[^a-z0-9]+`)
+	invalidRefPattern        = regexp.MustCompile(`[\s~^:?*\[\\]|\.\.|@\{|//`)
+)
+
+const maxSlugLength = 40
+
+// ValidateBranchNameTemplate checks that the template only uses the supported placeholders {story_id}, {slug}
+// and {rand}, and that it contains {story_id} or {rand} so that stories do not share branches.
+func ValidateBranchNameTemplate(template string) error {
+	for _, placeholder := range branchPlaceholderPattern.FindAllString(template, -1) {
+		switch placeholder {
+		case "{story_id}", "{slug}", "{rand}":
+		default:
+			return fmt.Errorf("unsupported placeholder %s in branch name template", placeholder)
+		}
+	}
+	if !strings.Contains(template, "{story_id}") && !strings.Contains(template, "{rand}") {
+		return fmt.Errorf("branch name template must contain {story_id} or {rand}")
+	}
+	name, err := GenerateBranchName(template, 1, "story")
+	if err != nil {
+		return err
+	}
+	if !isValidBranchName(name) {
+		return fmt.Errorf("branch name template does not produce a valid branch name: %s", name)
+	}
+	return nil
+}
+
+// ValidateBranchName checks that the name is a valid git branch name.
+func ValidateBranchName(name string) error {
+	if !isValidBranchName(name) {
+		return fmt.Errorf("invalid branch name: %s", name)
+	}
+	return nil
+}
+
+func isValidBranchName(name string) bool {
+	return name != "" && !invalidRefPattern.MatchString(name) && !strings.HasPrefix(name, "/") && !strings.HasSuffix(name, "/") &&
+		!strings.HasSuffix(name, ".lock") && !strings.HasPrefix(name, "-")
+}
+
+// GenerateBranchName fills in the branch name template, the default template is used when it is empty.
+func GenerateBranchName(template string, storyID uint, storyTitle string) (string, error) {
+	if template == "" {
+		template = constants.DefaultBranchNameTemplate
+	}
+	name := strings.ReplaceAll(template, "{story_id}", strconv.Itoa(int(storyID)))
+	name = strings.ReplaceAll(name, "{slug}", branchSlug(storyTitle))
+	if strings.Contains(name, "{rand}") {
+		random, err := RandString(5)
+		if err != nil {
+			return "", err
+		}
+		name = strings.ReplaceAll(name, "{rand}", random)
+	}
+	return name, nil
+}
+
+func branchSlug(title string) string {
+	slug := strings.Trim(slugInvalidPattern.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	if slug == "" {
+		return "story"
+	}
+	return slug
+}
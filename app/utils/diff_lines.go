@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// DiffNewLines returns, per file of a unified diff, the lines of the new version that appear in the diff, added
+// or context lines. Only these lines can carry line comments on git hosts.
+func DiffNewLines(diff string) map[string]map[int]bool {
+	files := map[string]map[int]bool{}
+	var current map[int]bool
+	line := 0
+	previous := ""
+	for _, text := range strings.Split(diff, "\n") {
+		// File headers are told apart from changed lines starting with "++ " or "-- " by their pairing.
+		header := strings.HasPrefix(text, "+++ ") && strings.HasPrefix(previous, "--- ")
+		previous = text
+		switch {
+		case header:
+			path := strings.TrimPrefix(strings.TrimPrefix(text, "+++ "), "b/")
+			current = nil
+			if path != "/dev/null" {
+				current = map[int]bool{}
+				files[path] = current
+			}
+		case strings.HasPrefix(text, "diff --git "):
+			current = nil
+		case strings.HasPrefix(text, "@@"):
+			matches := hunkHeaderPattern.FindStringSubmatch(text)
+			if matches != nil {
+				line, _ = strconv.Atoi(matches[1])
+			}
+		case current == nil:
+			continue
+		case strings.HasPrefix(text, "+"), strings.HasPrefix(text, " "):
+			current[line] = true
+			line++
+		}
+	}
+	return files
+}
@@ -1,9 +1,6 @@
 package utils
 
 import (
-	"ai-developer/app/config"
-	"ai-developer/app/constants"
-	"ai-developer/app/models"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -105,9 +102,9 @@ func PullBranch(workingDir string, origin string, branchName string) error {
 	return nil
 }
 
-func PullOriginMain(workingDir string, origin string) error {
-	fmt.Printf("Executing git pull origin main --no-rebase",)
-	cmd := exec.Command("git", "pull", origin, "main", "--no-rebase")
+func PullOriginBaseBranch(workingDir string, origin string, branchName string) error {
+	fmt.Printf("Executing git pull origin %s --no-rebase", branchName)
+	cmd := exec.Command("git", "pull", origin, branchName, "--no-rebase")
 	cmd.Dir = workingDir
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -117,19 +114,10 @@ func PullOriginMain(workingDir string, origin string) error {
 	return nil
 }
 
-func PullOriginBranch(workingDir string, project *models.Project, GitnessSpaceOrProjectName string) error {
-	branchName := "main"
-	httpPrefix := "https"
-
-	if config.AppEnv() == constants.Development {
-		httpPrefix = "http"
-	}
-	origin := fmt.Sprintf("%s://%s:%s@%s/git/%s/%s.git", httpPrefix, config.GitnessUser(), config.GitnessToken(), config.GitnessHost(), GitnessSpaceOrProjectName, project.Name)
-	fmt.Printf("User: %s, Token: %s, Host: %s, Space/Project: %s, Project: %s\n", config.GitnessUser(), config.GitnessToken(), config.GitnessHost(), GitnessSpaceOrProjectName, project.Name)
-	fmt.Printf("Origin: %s\n", origin)
+func PullOriginBranch(workingDir string, origin string, branchName string) error {
 	err := PullBranch(workingDir, origin, branchName)
 	if err != nil {
-		return fmt.Errorf("error pulling latest main: %s", err.Error())
+		return fmt.Errorf("error pulling latest %s: %s", branchName, err.Error())
 	}
 	return nil
 }
@@ -0,0 +1,11 @@
+package utils
+
+// GetStagedDiff returns the diff of the changes staged for the next commit.
+func GetStagedDiff(workingDir string) (string, error) {
+	return runGit(workingDir, "diff", "--cached")
+}
+
+// GetDiffSinceMergeBase returns the diff of HEAD against its merge base with commit, i.e. the changes of the branch.
+func GetDiffSinceMergeBase(workingDir string, commit string) (string, error) {
+	return runGit(workingDir, "diff", commit+"...HEAD")
+}
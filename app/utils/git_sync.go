@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+func runGit(workingDir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workingDir
+	// Never wait for an editor, rebase --continue and merge commits keep their default messages.
+	cmd.Env = append(os.Environ(), "GIT_EDITOR=true")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return strings.TrimSpace(string(output)), fmt.Errorf("git %s error: %s, output: %s", args[0], err.Error(), string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// RemoteDefaultBranch returns the branch HEAD of the remote repository points to. Errors do not carry the output
+// of git, which may contain the credentials of the remote URL.
+func RemoteDefaultBranch(remoteURL string) (string, error) {
+	output, err := runGit("", "ls-remote", "--symref", remoteURL, "HEAD")
+	if err != nil {
+		return "", errors.New("failed to read the default branch of the repository")
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if branch, ok := strings.CutPrefix(line, "ref: refs/heads/"); ok {
+			return strings.TrimSpace(strings.TrimSuffix(branch, "HEAD")), nil
+		}
+	}
+	return "", errors.New("the repository has no default branch")
+}
+
+// FetchBranch fetches the branch from origin and returns the commit it points to.
+func FetchBranch(workingDir string, origin string, branchName string) (string, error) {
+	fmt.Printf("Fetching branch '%s'\n", branchName)
+	if _, err := runGit(workingDir, "fetch", origin, branchName); err != nil {
+		return "", err
+	}
+	return runGit(workingDir, "rev-parse", "FETCH_HEAD")
+}
+
+// GetRemoteBranchCommit returns the commit the branch points to on origin, empty when the branch does not exist there.
+func GetRemoteBranchCommit(workingDir string, origin string, branchName string) (string, error) {
+	output, err := runGit(workingDir, "ls-remote", origin, "refs/heads/"+branchName)
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return fields[0], nil
+}
+
+// IsAncestor reports whether commit is reachable from HEAD.
+func IsAncestor(workingDir string, commit string) (bool, error) {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", commit, "HEAD")
+	cmd.Dir = workingDir
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return true, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("git merge-base error: %s, output: %s", err.Error(), string(output))
+}
+
+// MergeCommit merges the commit into the current branch. A merge that stops on conflicts is left in
+// progress and reported through conflicted, so that the conflicts can be resolved and committed.
+func MergeCommit(workingDir string, commit string, message string) (conflicted bool, err error) {
+	fmt.Printf("Merging '%s' into the current branch\n", commit)
+	_, err = runGit(workingDir, "merge", "--no-ff", "-m", message, commit)
+	return conflictsPending(workingDir, err)
+}
+
+// RebaseOnto rebases the current branch onto the commit, see MergeCommit for how conflicts are reported.
+func RebaseOnto(workingDir string, commit string) (conflicted bool, err error) {
+	fmt.Printf("Rebasing the current branch onto '%s'\n", commit)
+	_, err = runGit(workingDir, "rebase", commit)
+	return conflictsPending(workingDir, err)
+}
+
+// ContinueRebase continues a rebase after the conflicts of the current commit have been staged.
+func ContinueRebase(workingDir string) (conflicted bool, err error) {
+	_, err = runGit(workingDir, "rebase", "--continue")
+	return conflictsPending(workingDir, err)
+}
+
+// CommitMerge concludes a merge after its conflicts have been staged.
+func CommitMerge(workingDir string) error {
+	_, err := runGit(workingDir, "commit", "--no-edit")
+	return err
+}
+
+func AbortMerge(workingDir string) error {
+	_, err := runGit(workingDir, "merge", "--abort")
+	return err
+}
+
+func AbortRebase(workingDir string) error {
+	_, err := runGit(workingDir, "rebase", "--abort")
+	return err
+}
+
+// GetConflictedFiles returns the paths of the files with unresolved conflicts.
+func GetConflictedFiles(workingDir string) ([]string, error) {
+	output, err := runGit(workingDir, "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+func GitAddFiles(workingDir string, paths []string) error {
+	_, err := runGit(workingDir, append([]string{"add", "--"}, paths...)...)
+	return err
+}
+
+// GitForcePushWithLease pushes a rewritten branch, refusing to overwrite the remote branch unless it
+// still points to expectedCommit.
+func GitForcePushWithLease(workingDir, origin, branch, expectedCommit string) error {
+	fmt.Printf("Force pushing branch '%s'\n", branch)
+	lease := fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", branch, expectedCommit)
+	_, err := runGit(workingDir, "push", lease, origin, branch)
+	return err
+}
+
+func conflictsPending(workingDir string, gitErr error) (bool, error) {
+	if gitErr == nil {
+		return false, nil
+	}
+	files, err := GetConflictedFiles(workingDir)
+	if err != nil || len(files) == 0 {
+		return false, gitErr
+	}
+	return true, nil
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// AddWorktree adds a worktree of the repository in repoDir at worktreeDir, detached at the current commit of
+// the repository. Worktrees left behind by executions which did not finish are pruned first.
+func AddWorktree(repoDir string, worktreeDir string) error {
+	fmt.Printf("Adding worktree '%s'\n", worktreeDir)
+	unlock, err := lockWorktrees(repoDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if _, err := runGit(repoDir, "worktree", "prune"); err != nil {
+		return err
+	}
+	if _, err := os.Stat(worktreeDir); err == nil {
+		if err := removeWorktree(repoDir, worktreeDir); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(worktreeDir), os.ModePerm); err != nil {
+		return err
+	}
+	_, err = runGit(repoDir, "worktree", "add", "--detach", worktreeDir)
+	return err
+}
+
+// RemoveWorktree removes the worktree together with the changes it did not commit.
+func RemoveWorktree(repoDir string, worktreeDir string) error {
+	fmt.Printf("Removing worktree '%s'\n", worktreeDir)
+	unlock, err := lockWorktrees(repoDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return removeWorktree(repoDir, worktreeDir)
+}
+
+func removeWorktree(repoDir string, worktreeDir string) error {
+	if _, err := runGit(repoDir, "worktree", "remove", "--force", worktreeDir); err != nil {
+		if removeErr := os.RemoveAll(worktreeDir); removeErr != nil {
+			return err
+		}
+		_, err = runGit(repoDir, "worktree", "prune")
+		return err
+	}
+	return nil
+}
+
+// lockWorktrees takes an exclusive lock on the worktrees of the repository in repoDir and returns the function
+// releasing it. The executors of a project run in separate containers sharing the repository, so git's own
+// administrative files under .git/worktrees are guarded with a file lock rather than a mutex.
+func lockWorktrees(repoDir string) (func(), error) {
+	lockFile, err := os.OpenFile(filepath.Join(repoDir, ".git", "worktrees.lock"), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+		_ = lockFile.Close()
+		return nil, fmt.Errorf("locking worktrees: %w", err)
+	}
+	return func() {
+		_ = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
+		_ = lockFile.Close()
+	}, nil
+}
+
+// ResetBranchTo checks out the branch at the commit, creating the branch when it does not exist.
+func ResetBranchTo(workingDir string, branchName string, commit string) error {
+	fmt.Printf("Checking out branch '%s' at '%s'\n", branchName, commit)
+	_, err := runGit(workingDir, "checkout", "-B", branchName, commit)
+	return err
+}
+
+// CheckoutWorktreeBranch checks out an existing branch in a worktree, also when another worktree, e.g. the
+// project workspace, has the branch checked out.
+func CheckoutWorktreeBranch(workingDir string, branchName string) error {
+	fmt.Printf("Checking out branch '%s'\n", branchName)
+	_, err := runGit(workingDir, "checkout", "--ignore-other-worktrees", branchName)
+	return err
+}
+
+// HasLocalBranch reports whether the repository has a local branch of that name.
+func HasLocalBranch(workingDir string, branchName string) bool {
+	_, err := runGit(workingDir, "rev-parse", "--verify", "--quiet", "refs/heads/"+branchName)
+	return err == nil
+}
+
+// ExcludeFromGit adds the pattern to the local exclude file of the repository, which unlike .gitignore is
+// never committed.
+func ExcludeFromGit(repoDir string, pattern string) error {
+	excludeFile := filepath.Join(repoDir, ".git", "info", "exclude")
+	content, err := os.ReadFile(excludeFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return nil
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(excludeFile), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(excludeFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		pattern = "\n" + pattern
+	}
+	_, err = file.WriteString(pattern + "\n")
+	return err
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// IssueStory is an issue description split into the parts of a story.
+type IssueStory struct {
+	Description  string
+	TestCases    []string
+	Instructions string
+}
+
+var (
+	markdownHeadingPattern = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*$`)
+	jiraHeadingPattern     = regexp.MustCompile(`^h[1-6]\.\s+(.+)$`)
+	boldHeadingPattern     = regexp.MustCompile(`^(?:\*\*|__|\*)(.+?)(?:\*\*|__|\*)$`)
+	listItemPattern        = regexp.MustCompile(`^(?:[-*+#]+|\d+[.)])\s+(.*)$`)
+	checkboxPattern        = regexp.MustCompile(`^\[[ xX]\]\s*(.*)$`)
+)
+
+const (
+	descriptionSection = iota
+	acceptanceCriteriaSection
+	instructionsSection
+)
+
+var issueSections = map[string]int{
+	"acceptance criteria":     acceptanceCriteriaSection,
+	"acceptance criterion":    acceptanceCriteriaSection,
+	"test cases":              acceptanceCriteriaSection,
+	"implementation notes":    instructionsSection,
+	"implementation":          instructionsSection,
+	"instructions":            instructionsSection,
+	"technical notes":         instructionsSection,
+	"notes for the developer": instructionsSection,
+}
+
+// ParseIssueDescription splits an issue description, in markdown or Jira wiki markup, into a story.
+// The items of the "Acceptance Criteria" section and checklist items anywhere else become test cases,
+// the "Implementation notes" or "Instructions" section becomes the instructions and the rest the
+// description.
+func ParseIssueDescription(text string) IssueStory {
+	var description, instructions []string
+	var testCases []string
+	section := descriptionSection
+	lastItem := -1
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if heading, ok := issueSectionHeading(trimmed, section); ok {
+			if next, known := issueSections[heading]; known {
+				section = next
+				lastItem = -1
+				continue
+			}
+			section = descriptionSection
+		}
+
+		switch section {
+		case acceptanceCriteriaSection:
+			if trimmed == "" {
+				lastItem = -1
+				continue
+			}
+			if match := listItemPattern.FindStringSubmatch(trimmed); match != nil {
+				trimmed = match[1]
+			} else if lastItem >= 0 {
+				testCases[lastItem] += " " + trimmed
+				continue
+			}
+			if match := checkboxPattern.FindStringSubmatch(trimmed); match != nil {
+				trimmed = match[1]
+			}
+			if trimmed != "" {
+				testCases = append(testCases, trimmed)
+				lastItem = len(testCases) - 1
+			}
+		case instructionsSection:
+			instructions = append(instructions, line)
+		default:
+			if match := listItemPattern.FindStringSubmatch(trimmed); match != nil {
+				if checkbox := checkboxPattern.FindStringSubmatch(match[1]); checkbox != nil {
+					if checkbox[1] != "" {
+						testCases = append(testCases, checkbox[1])
+					}
+					continue
+				}
+			}
+			description = append(description, line)
+		}
+	}
+
+	return IssueStory{
+		Description:  strings.TrimSpace(strings.Join(description, "\n")),
+		TestCases:    testCases,
+		Instructions: strings.TrimSpace(strings.Join(instructions, "\n")),
+	}
+}
+
+// issueSectionHeading returns the lower-cased name of the heading on the line. Inside the acceptance
+// criteria, where "# item" is a Jira numbered list item, only known section names and markdown headings
+// of a deeper level count as headings.
+func issueSectionHeading(line string, section int) (string, bool) {
+	var heading string
+	if match := jiraHeadingPattern.FindStringSubmatch(line); match != nil {
+		heading = match[1]
+	} else if match := markdownHeadingPattern.FindStringSubmatch(line); match != nil {
+		heading = match[1]
+	} else if match := boldHeadingPattern.FindStringSubmatch(line); match != nil {
+		heading = match[1]
+	} else {
+		heading = line
+	}
+	heading = strings.ToLower(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(heading), ":")))
+	heading = strings.Trim(heading, "*_ ")
+
+	if _, known := issueSections[heading]; known {
+		return heading, true
+	}
+	if section == acceptanceCriteriaSection && strings.HasPrefix(line, "##") && markdownHeadingPattern.MatchString(line) {
+		return heading, true
+	}
+	if heading == strings.ToLower(strings.TrimSpace(line)) || section == acceptanceCriteriaSection {
+		return "", false
+	}
+	return heading, true
+}
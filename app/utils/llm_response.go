@@ -0,0 +1,16 @@
+package utils
+
+import "strings"
+
+// StripCodeFence removes the code fence LLMs tend to wrap their reply in.
+func StripCodeFence(content string) string {
+	trimmed := strings.TrimSpace(content)
+	if !strings.HasPrefix(trimmed, "```") {
+		return content
+	}
+	lines := strings.Split(trimmed, "\n")
+	if len(lines) < 2 || strings.TrimSpace(lines[len(lines)-1]) != "```" {
+		return content
+	}
+	return strings.Join(lines[1:len(lines)-1], "\n") + "\n"
+}
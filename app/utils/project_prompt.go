@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"ai-developer/app/models"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConventionsFile is the coding conventions document of a repository, relative to its root.
+const ConventionsFile = ".supercoder/conventions.md"
+
+// maxConventionsLength caps the conventions added to prompts, longer conventions are cut off.
+const maxConventionsLength = 20000
+
+// ProjectConventions returns the coding conventions of the project: the conventions stored on the project, or
+// else the conventions file of the repository checked out in workspaceDir. Empty when there are none.
+func ProjectConventions(project *models.Project, workspaceDir string) (string, error) {
+	conventions := strings.TrimSpace(project.Conventions)
+	if conventions == "" {
+		content, err := os.ReadFile(filepath.Join(workspaceDir, ConventionsFile))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return "", nil
+			}
+			return "", err
+		}
+		conventions = strings.TrimSpace(string(content))
+	}
+	if len(conventions) > maxConventionsLength {
+		conventions = conventions[:maxConventionsLength]
+	}
+	return conventions, nil
+}
+
+// WithConventions appends the coding conventions of the project to the system prompt.
+func WithConventions(systemPrompt string, conventions string) string {
+	if conventions == "" {
+		return systemPrompt
+	}
+	return systemPrompt + "\n\nThe project follows the coding conventions below, the code you write must follow them as well.\n" + conventions
+}
+
+// SubstitutePromptVariables replaces the variables of the prompt, given by their placeholder, e.g.
+// {project_workspace_id}, with their values in a single pass.
+func SubstitutePromptVariables(prompt string, variables map[string]string) string {
+	pairs := make([]string, 0, 2*len(variables))
+	for placeholder, value := range variables {
+		pairs = append(pairs, placeholder, value)
+	}
+	return strings.NewReplacer(pairs...).Replace(prompt)
+}
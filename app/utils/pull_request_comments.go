@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"ai-developer/app/models"
+	"sort"
+)
+
+// CommentGroup holds the review comments on one file, Path is empty for comments on the pull request as a whole.
+type CommentGroup struct {
+	Path     string
+	Comments []models.PullRequestComments
+}
+
+// GroupCommentsByFile groups the comments by file, general comments first and files in path order. Comments
+// on a file are ordered by line.
+func GroupCommentsByFile(comments []models.PullRequestComments) []CommentGroup {
+	indexes := map[string]int{}
+	var groups []CommentGroup
+	for _, comment := range comments {
+		index, ok := indexes[comment.Path]
+		if !ok {
+			index = len(groups)
+			indexes[comment.Path] = index
+			groups = append(groups, CommentGroup{Path: comment.Path})
+		}
+		groups[index].Comments = append(groups[index].Comments, comment)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Path < groups[j].Path
+	})
+	for _, group := range groups {
+		sort.SliceStable(group.Comments, func(i, j int) bool {
+			return group.Comments[i].Line < group.Comments[j].Line
+		})
+	}
+	return groups
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"bytes"
+	"text/template"
+)
+
+// DefaultPullRequestTemplate renders the description of pull requests of projects which do not configure a template.
+const DefaultPullRequestTemplate = `## Summary
+{{ .Summary }}
+
+## Story
+[#{{ .StoryID }} {{ .StoryTitle }}]({{ .StoryURL }})
+{{ if .StoryDescription }}
+{{ .StoryDescription }}
+{{ end }}
+{{- if .TestCases }}
+## Test cases
+{{ range .TestCases }}- [{{ if .Verified }}x{{ else }} {{ end }}] {{ .TestCase }} ({{ .Status }})
+{{ end }}{{ end }}
+{{- if .Plan }}
+## Plan
+{{ .Plan }}
+{{ end }}
+## Cost
+{{ .TotalTokens }} tokens ({{ .PromptTokens }} prompt, {{ .CompletionTokens }} completion), about ${{ printf "%.4f" .Cost }}
+{{- if .SecurityReport }}
+
+{{ .SecurityReport }}
+{{- end }}
+`
+
+type PullRequestTestCase struct {
+	TestCase string
+	Status   string
+	Verified bool
+}
+
+// PullRequestDescriptionData is the data pull request templates are rendered with.
+type PullRequestDescriptionData struct {
+	Title            string
+	Summary          string
+	StoryID          uint
+	StoryTitle       string
+	StoryDescription string
+	StoryURL         string
+	TestCases        []PullRequestTestCase
+	Plan             string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	Cost             float64
+	SecurityReport   string
+}
+
+// RenderPullRequestDescription renders the pull request template, the default template is used when it is empty.
+func RenderPullRequestDescription(pullRequestTemplate string, data PullRequestDescriptionData) (string, error) {
+	if pullRequestTemplate == "" {
+		pullRequestTemplate = DefaultPullRequestTemplate
+	}
+	parsed, err := template.New("pull_request").Option("missingkey=error").Parse(pullRequestTemplate)
+	if err != nil {
+		return "", err
+	}
+	var description bytes.Buffer
+	if err := parsed.Execute(&description, data); err != nil {
+		return "", err
+	}
+	return description.String(), nil
+}
+
+// ValidatePullRequestTemplate checks that the template parses and renders with sample data.
+func ValidatePullRequestTemplate(pullRequestTemplate string) error {
+	_, err := RenderPullRequestDescription(pullRequestTemplate, PullRequestDescriptionData{
+		Title:      "feat: sample",
+		StoryID:    1,
+		StoryTitle: "Sample story",
+		TestCases:  []PullRequestTestCase{{TestCase: "Sample test case", Status: "passed", Verified: true}},
+	})
+	return err
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// templatePlaceholderPattern matches the {{name}} placeholders of story templates. Double braces keep single
+// braces, which are common in the code snippets of instructions, literal.
+var templatePlaceholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// TemplatePlaceholders returns the names of the placeholders used in the texts, in the order they first appear.
+func TemplatePlaceholders(texts ...string) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, text := range texts {
+		for _, match := range templatePlaceholderPattern.FindAllStringSubmatch(text, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				names = append(names, match[1])
+			}
+		}
+	}
+	return names
+}
+
+// FillPlaceholders replaces the placeholders of the text with their values. Placeholders without a value are
+// left in place and returned as missing.
+func FillPlaceholders(text string, values map[string]string) (string, []string) {
+	var missing []string
+	filled := templatePlaceholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := templatePlaceholderPattern.FindStringSubmatch(placeholder)[1]
+		value, ok := values[name]
+		if !ok {
+			missing = append(missing, name)
+			return placeholder
+		}
+		return strings.TrimSpace(value)
+	})
+	return filled, missing
+}
@@ -44,7 +44,7 @@ var NextJsWorkflowConfig = &WorkflowConfig{
 					File: "layout.tsx",
 				},
 				Transitions: map[graph.ExecutionState]*steps.StepName{
-					graph.ExecutionSuccessState: &steps.SERVER_START_STEP,
+					graph.ExecutionSuccessState: &steps.LINT_STEP,
 					graph.ExecutionErrorState:   nil,
 				},
 			},
@@ -69,6 +69,18 @@ var NextJsWorkflowConfig = &WorkflowConfig{
 			},
 			steps.SERVER_START_STEP: {
 				Step: &steps.ServerStartTestStep{},
+				Transitions: map[graph.ExecutionState]*steps.StepName{
+					graph.ExecutionSuccessState: &steps.SECURITY_SCAN_STEP,
+					graph.ExecutionRetryState:   &steps.RETRY_CODE_GENERATE_STEP,
+					graph.ExecutionErrorState:   nil,
+				},
+			},
+			steps.SECURITY_SCAN_STEP: {
+				Step: &steps.SecurityScanStep{
+					Scanners:            []string{"npm-audit"},
+					RetryOnHighSeverity: true,
+					MaxRetries:          2,
+				},
 				Transitions: map[graph.ExecutionState]*steps.StepName{
 					graph.ExecutionSuccessState: nil,
 					graph.ExecutionRetryState:   &steps.RETRY_CODE_GENERATE_STEP,
@@ -89,8 +101,18 @@ var NextJsWorkflowConfig = &WorkflowConfig{
 				Step: &steps.UpdateCodeFileStep{
 					Retry: true,
 				},
+				Transitions: map[graph.ExecutionState]*steps.StepName{
+					graph.ExecutionSuccessState: &steps.LINT_STEP,
+					graph.ExecutionErrorState:   nil,
+				},
+			},
+			steps.LINT_STEP: {
+				Step: &steps.LintStep{
+					Analyzers: []string{"tsc", "eslint"},
+				},
 				Transitions: map[graph.ExecutionState]*steps.StepName{
 					graph.ExecutionSuccessState: &steps.SERVER_START_STEP,
+					graph.ExecutionRetryState:   &steps.RETRY_CODE_GENERATE_STEP,
 					graph.ExecutionErrorState:   nil,
 				},
 			},
@@ -0,0 +1,51 @@
+package workflow_executors
+
+import (
+	"ai-developer/app/workflow_executors/step_executors/graph"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+)
+
+// remoteSteps are the steps which talk to the git provider of the project.
+var remoteSteps = map[steps.StepName]bool{
+	steps.GIT_CREATE_BRANCH_STEP:       true,
+	steps.SYNC_BASE_STEP:               true,
+	steps.GIT_PUSH_STEP:                true,
+	steps.GIT_CREATE_PULL_REQUEST_STEP: true,
+}
+
+// OfflineWorkflowConfig returns a copy of the workflow without the steps which talk to the git provider, for
+// running stories against a local workspace. Transitions to a dropped step go to the step following it on success.
+func OfflineWorkflowConfig(workflowConfig *WorkflowConfig) *WorkflowConfig {
+	stepGraph := workflowConfig.StepGraph
+	skip := func(name *steps.StepName) *steps.StepName {
+		for seen := 0; name != nil && remoteSteps[*name] && seen < len(stepGraph.Nodes); seen++ {
+			name = stepGraph.GetNextStep(*name, graph.ExecutionSuccessState)
+		}
+		if name != nil && remoteSteps[*name] {
+			return nil
+		}
+		return name
+	}
+
+	nodes := make(map[steps.StepName]*graph.StepNode)
+	for name, node := range stepGraph.Nodes {
+		if remoteSteps[name] {
+			continue
+		}
+		transitions := make(map[graph.ExecutionState]*steps.StepName)
+		for state, next := range node.Transitions {
+			transitions[state] = skip(next)
+		}
+		nodes[name] = &graph.StepNode{Step: node.Step, Transitions: transitions}
+	}
+
+	startingNode := stepGraph.StartingNode
+	if start := skip(&startingNode); start != nil {
+		startingNode = *start
+	}
+	return &WorkflowConfig{
+		WorkflowName: workflowConfig.WorkflowName + " (offline)",
+		StepGraph:    &graph.StepGraph{StartingNode: startingNode, Nodes: nodes},
+		Files:        workflowConfig.Files,
+	}
+}
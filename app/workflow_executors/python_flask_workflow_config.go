@@ -12,6 +12,15 @@ var FlaskWorkflowConfig = &WorkflowConfig{
 		Nodes: map[steps.StepName]*graph.StepNode{
 			steps.GIT_CREATE_BRANCH_STEP: {
 				Step: &steps.GitMakeBranchStep{},
+				Transitions: map[graph.ExecutionState]*steps.StepName{
+					graph.ExecutionSuccessState: &steps.SYNC_BASE_STEP,
+					graph.ExecutionErrorState:   nil,
+				},
+			},
+			steps.SYNC_BASE_STEP: {
+				Step: &steps.SyncBaseStep{
+					Strategy: steps.SyncStrategyMerge,
+				},
 				Transitions: map[graph.ExecutionState]*steps.StepName{
 					graph.ExecutionSuccessState: &steps.PACKAGE_INSTALL_STEP,
 					graph.ExecutionErrorState:   nil,
@@ -54,14 +63,38 @@ var FlaskWorkflowConfig = &WorkflowConfig{
 
 			steps.UPDATE_CODE_FILE_STEP: {
 				Step: &steps.UpdateCodeFileStep{},
+				Transitions: map[graph.ExecutionState]*steps.StepName{
+					graph.ExecutionSuccessState: &steps.LINT_STEP,
+					graph.ExecutionErrorState:   nil,
+				},
+			},
+
+			steps.LINT_STEP: {
+				Step: &steps.LintStep{
+					Analyzers: []string{"ruff", "mypy"},
+				},
 				Transitions: map[graph.ExecutionState]*steps.StepName{
 					graph.ExecutionSuccessState: &steps.SERVER_START_STEP,
+					graph.ExecutionRetryState:   &steps.RETRY_CODE_GENERATE_STEP,
 					graph.ExecutionErrorState:   nil,
 				},
 			},
 
 			steps.SERVER_START_STEP: {
 				Step: &steps.ServerStartTestStep{},
+				Transitions: map[graph.ExecutionState]*steps.StepName{
+					graph.ExecutionSuccessState: &steps.SECURITY_SCAN_STEP,
+					graph.ExecutionRetryState:   &steps.RETRY_CODE_GENERATE_STEP,
+					graph.ExecutionErrorState:   nil,
+				},
+			},
+
+			steps.SECURITY_SCAN_STEP: {
+				Step: &steps.SecurityScanStep{
+					Scanners:            []string{"bandit", "semgrep", "secrets"},
+					RetryOnHighSeverity: true,
+					MaxRetries:          2,
+				},
 				Transitions: map[graph.ExecutionState]*steps.StepName{
 					graph.ExecutionSuccessState: &steps.GIT_COMMIT_STEP,
 					graph.ExecutionRetryState:   &steps.RETRY_CODE_GENERATE_STEP,
@@ -0,0 +1,101 @@
+package impl
+
+import (
+	"ai-developer/app/models"
+	"ai-developer/app/utils"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"fmt"
+	"strings"
+)
+
+// autoMerge merges the pull request when the project enables auto-merge and the execution satisfies its policy:
+// the required checks passed, the diff is small enough and no excluded path is touched. The outcome is kept on
+// the step response under "auto_merge".
+func (e *GitMakePullRequestExecutor) autoMerge(step steps.GitMakePullRequestStep, pullRequest *models.PullRequest) error {
+	project := step.Project
+	if !project.AutoMergeEnabled {
+		return nil
+	}
+	reasons, err := e.autoMergeBlockers(step, pullRequest)
+	if err != nil {
+		return err
+	}
+	method := utils.ProjectMergeMethod(project)
+	merged := false
+	if len(reasons) == 0 {
+		if _, err := e.pullRequestService.MergePullRequestByID(int(pullRequest.ID), project.OrganisationID, method); err != nil {
+			reasons = append(reasons, "merge failed: "+err.Error())
+		} else {
+			merged = true
+		}
+	}
+
+	response := map[string]interface{}{}
+	for key, value := range step.ExecutionStep.Response {
+		response[key] = value
+	}
+	response["auto_merge"] = map[string]interface{}{
+		"merged":  merged,
+		"method":  method,
+		"reasons": reasons,
+	}
+	err = e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, response, "SUCCESS")
+	if err != nil {
+		return err
+	}
+	if merged {
+		return e.activeLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", fmt.Sprintf("Pull request auto-merged using %s.", method))
+	}
+	return e.activeLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "Auto-merge skipped: "+strings.Join(reasons, "; "))
+}
+
+// autoMergeBlockers returns why the pull request must not be merged automatically, none when the policy holds.
+func (e *GitMakePullRequestExecutor) autoMergeBlockers(step steps.GitMakePullRequestStep, pullRequest *models.PullRequest) ([]string, error) {
+	project := step.Project
+	var reasons []string
+	if pullRequest.IsDraft {
+		reasons = append(reasons, "pull request is a draft")
+	}
+	if pullRequest.AutoMergeBlocked {
+		reasons = append(reasons, "security scan blocked auto-merge")
+	}
+	requiredChecks := []struct {
+		required bool
+		name     steps.StepName
+		label    string
+	}{
+		{project.AutoMergeRequireServerTest, steps.SERVER_START_STEP, "server test"},
+		{project.AutoMergeRequireTests, steps.TEST_STEP, "tests"},
+		{project.AutoMergeRequireLint, steps.LINT_STEP, "lint"},
+	}
+	for _, check := range requiredChecks {
+		if !check.required {
+			continue
+		}
+		checkStep, err := e.executionStepService.FetchLatestExecutionStepOfNames(step.Execution.ID, []string{check.name.String()})
+		if err != nil || checkStep == nil {
+			reasons = append(reasons, check.label+" did not run")
+			continue
+		}
+		if checkError, _ := checkStep.Response["error"].(string); checkError != "" {
+			reasons = append(reasons, check.label+" failed")
+		}
+	}
+
+	excludedPaths := utils.ParsePathPatterns(project.AutoMergeExcludedPaths)
+	if project.AutoMergeMaxDiffLines == 0 && len(excludedPaths) == 0 {
+		return reasons, nil
+	}
+	diff, err := e.branchDiff(step.WorkspaceDir(), step)
+	if err != nil {
+		return nil, err
+	}
+	stats := utils.GetDiffStats(diff)
+	if project.AutoMergeMaxDiffLines > 0 && stats.ChangedLines() > project.AutoMergeMaxDiffLines {
+		reasons = append(reasons, fmt.Sprintf("diff changes %d lines, more than the limit of %d", stats.ChangedLines(), project.AutoMergeMaxDiffLines))
+	}
+	if touched := utils.MatchingPaths(excludedPaths, stats.Files); len(touched) > 0 {
+		reasons = append(reasons, "excluded paths changed: "+strings.Join(touched, ", "))
+	}
+	return reasons, nil
+}
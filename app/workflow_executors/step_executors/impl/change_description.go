@@ -0,0 +1,184 @@
+package impl
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/llms"
+	"ai-developer/app/models"
+	"ai-developer/app/services"
+	"ai-developer/app/utils"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxDiffLength caps the diff handed to the LLM when describing changes.
+const maxDiffLength = 30000
+
+// changeDescriptionModel is the model writing the commit messages and pull request summaries.
+const changeDescriptionModel = constants.GPT_4O
+
+var conventionalCommitPattern = regexp.MustCompile(`^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([\w\-./]+\))?!?: \S`)
+
+type pullRequestSummary struct {
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+}
+
+// changeDescriber writes commit messages and pull request summaries for the changes of a story.
+type changeDescriber struct {
+	llmAPIKeyService *services.LLMAPIKeyService
+}
+
+func (d changeDescriber) client(organisationID uint) (*llms.OpenAiClient, error) {
+	llmAPIKey, err := d.llmAPIKeyService.GetLLMAPIKeyByModelName(changeDescriptionModel, organisationID)
+	if err != nil {
+		return nil, err
+	}
+	if llmAPIKey == nil || llmAPIKey.LLMAPIKey == "" {
+		return nil, fmt.Errorf("LLM API Key for model %s not found in database", changeDescriptionModel)
+	}
+	openAIClient := llms.NewOpenAiClient(llmAPIKey.LLMAPIKey)
+	openAIClient.Model = changeDescriptionModel
+	return openAIClient, nil
+}
+
+// CommitMessage returns a conventional commit message for the staged diff.
+func (d changeDescriber) CommitMessage(organisationID uint, story *models.Story, diff string) (string, *llms.OpenAiUsage, error) {
+	openAIClient, err := d.client(organisationID)
+	if err != nil {
+		return "", nil, err
+	}
+	message, usage, err := openAIClient.ChatCompletionWithUsage([]llms.OpenAiChatCompletionMessage{
+		{
+			Role: "system",
+			Content: "You write git commit messages following the Conventional Commits specification. " +
+				"The first line is `<type>(<optional scope>): <description>`, imperative mood, lower case, at most 72 characters. " +
+				"Add a blank line and a short body explaining what changed and why when the change is not trivial. " +
+				"Reply with the commit message only, without code fences.",
+		},
+		{
+			Role:    "user",
+			Content: fmt.Sprintf("Story: %s\n%s\n\nDiff:\n%s", story.Title, story.Description, truncateDiff(diff)),
+		},
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	message = strings.TrimSpace(utils.StripCodeFence(message))
+	if !conventionalCommitPattern.MatchString(message) {
+		return "", usage, fmt.Errorf("commit message is not a conventional commit: %q", firstLine(message))
+	}
+	return message, usage, nil
+}
+
+// PullRequestSummary returns a conventional pull request title and a markdown summary of the diff.
+func (d changeDescriber) PullRequestSummary(organisationID uint, story *models.Story, diff string) (*pullRequestSummary, *llms.OpenAiUsage, error) {
+	openAIClient, err := d.client(organisationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	response, usage, err := openAIClient.ChatCompletionWithUsage([]llms.OpenAiChatCompletionMessage{
+		{
+			Role: "system",
+			Content: "You describe pull requests. Reply with a JSON object with the keys `title` and `summary` only. " +
+				"The title follows the Conventional Commits specification, `<type>(<optional scope>): <description>`, at most 72 characters. " +
+				"The summary is markdown: a short paragraph on what the change does followed by a bullet list of the notable changes per file.",
+		},
+		{
+			Role:    "user",
+			Content: fmt.Sprintf("Story: %s\n%s\n\nDiff:\n%s", story.Title, story.Description, truncateDiff(diff)),
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var summary pullRequestSummary
+	if err := json.Unmarshal([]byte(strings.TrimSpace(utils.StripCodeFence(response))), &summary); err != nil {
+		return nil, usage, fmt.Errorf("failed to parse pull request summary: %w", err)
+	}
+	if !conventionalCommitPattern.MatchString(summary.Title) {
+		return nil, usage, fmt.Errorf("pull request title is not a conventional commit: %q", summary.Title)
+	}
+	return &summary, usage, nil
+}
+
+type commentResolution struct {
+	CommentID uint   `json:"comment_id"`
+	Resolved  bool   `json:"resolved"`
+	Note      string `json:"note"`
+}
+
+// CommentResolutions judges from the diff which review comments the change resolves, with a one line note per comment.
+func (d changeDescriber) CommentResolutions(organisationID uint, comments []models.PullRequestComments, diff string) (map[uint]services.CommentResolution, *llms.OpenAiUsage, error) {
+	openAIClient, err := d.client(organisationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sb strings.Builder
+	for _, comment := range comments {
+		sb.WriteString(fmt.Sprintf("Comment %d", comment.ID))
+		if comment.Path != "" {
+			sb.WriteString(" on " + comment.Path)
+			if comment.Line > 0 {
+				sb.WriteString(fmt.Sprintf(" line %d", comment.Line))
+			}
+		}
+		sb.WriteString(": " + comment.Comment + "\n")
+	}
+	response, usage, err := openAIClient.ChatCompletionWithUsage([]llms.OpenAiChatCompletionMessage{
+		{
+			Role: "system",
+			Content: "You check whether code review comments were addressed by a change. " +
+				"Reply with a JSON array with one object per comment with the keys `comment_id`, `resolved` (boolean) and `note`, " +
+				"a single sentence on how the comment was addressed or why it was not.",
+		},
+		{
+			Role:    "user",
+			Content: fmt.Sprintf("Review comments:\n%s\nDiff:\n%s", sb.String(), truncateDiff(diff)),
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var judged []commentResolution
+	if err := json.Unmarshal([]byte(strings.TrimSpace(utils.StripCodeFence(response))), &judged); err != nil {
+		return nil, usage, fmt.Errorf("failed to parse comment resolutions: %w", err)
+	}
+	resolutions := make(map[uint]services.CommentResolution, len(judged))
+	for _, resolution := range judged {
+		status := constants.CommentStatusUnresolved
+		if resolution.Resolved {
+			status = constants.CommentStatusResolved
+		}
+		resolutions[resolution.CommentID] = services.CommentResolution{Status: status, Note: strings.TrimSpace(resolution.Note)}
+	}
+	return resolutions, usage, nil
+}
+
+// truncateDiff cuts the diff down to maxDiffLength bytes at the last line that fits, or at a rune boundary
+// when the first line alone is too long.
+func truncateDiff(diff string) string {
+	if len(diff) <= maxDiffLength {
+		return diff
+	}
+	cut := strings.LastIndexByte(diff[:maxDiffLength], '\n')
+	if cut <= 0 {
+		cut = maxDiffLength
+		for cut > 0 && !utf8.RuneStart(diff[cut]) {
+			cut--
+		}
+	}
+	return diff[:cut] + "\n... diff truncated ..."
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
+
+// defaultCommitMessage is used when no commit message could be generated.
+func defaultCommitMessage(story *models.Story) string {
+	return "feat: " + story.Title
+}
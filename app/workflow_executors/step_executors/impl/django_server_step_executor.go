@@ -1,7 +1,6 @@
 package impl
 
 import (
-	"ai-developer/app/config"
 	"ai-developer/app/services"
 	"ai-developer/app/workflow_executors/step_executors/steps"
 	"bufio"
@@ -115,7 +114,7 @@ func (e *DjangoServerStartTestExecutor) getDjangoServerAppFileName() string {
 
 // serverRunTest runs the server test.
 func (e *DjangoServerStartTestExecutor) serverRunTest(step steps.ServerStartTestStep) (string, string) {
-	projectDir := config.WorkspaceWorkingDirectory() + "/" + step.Project.HashID
+	projectDir := step.WorkspaceDir()
 	appPath := projectDir + "/" + e.getDjangoServerAppFileName()
 	serverURL := e.getDjangoServerURL()
 	timeout := 60 * time.Second
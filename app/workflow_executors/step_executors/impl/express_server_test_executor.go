@@ -0,0 +1,164 @@
+package impl
+
+import (
+	"ai-developer/app/services"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type ExpressServerStartTestExecutor struct {
+	executionStepService *services.ExecutionStepService
+	activityLogService   *services.ActivityLogService
+	logger               *zap.Logger
+}
+
+func NewExpressServerStartTestExecutor(
+	executionStepService *services.ExecutionStepService,
+	activityLogService *services.ActivityLogService,
+	logger *zap.Logger,
+) *ExpressServerStartTestExecutor {
+	return &ExpressServerStartTestExecutor{
+		executionStepService: executionStepService,
+		activityLogService:   activityLogService,
+		logger:               logger.Named("ExpressServerStartTestExecutor"),
+	}
+}
+
+func (e ExpressServerStartTestExecutor) Execute(step steps.ServerStartTestStep) error {
+	e.logger.Info("Executing Express Server Start Test Step", zap.String("step", step.StepName()))
+
+	err := e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", "Starting and testing Server...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	projectDir := step.WorkspaceDir()
+	testErr := e.serverRunTest(projectDir)
+	if testErr == nil {
+		err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", "Server working well!")
+		if err != nil {
+			e.logger.Error("Error creating activity log", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+
+	e.logger.Info("Server test failed", zap.Error(testErr))
+	err = e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, map[string]interface{}{"error": testErr.Error()}, "SUCCESS")
+	if err != nil {
+		e.logger.Error("Error updating execution step", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "ERROR", fmt.Sprintf("Server test failed: %s", testErr.Error()))
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", "Server test failed fixing the issue...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	return fmt.Errorf("%w: %v", steps.ErrReiterate, testErr)
+}
+
+func (e *ExpressServerStartTestExecutor) getExpressServerAddress() string {
+	return "127.0.0.1:5000"
+}
+
+// serverRunTest installs dependencies, starts the dev server and probes its port and test endpoint.
+func (e *ExpressServerStartTestExecutor) serverRunTest(projectDir string) error {
+	if err := e.executeDependencies(projectDir); err != nil {
+		return err
+	}
+
+	var output bytes.Buffer
+	cmd := exec.Command(nodePackageManager(projectDir), "run", "dev")
+	cmd.Dir = projectDir
+	cmd.Env = os.Environ()
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	// npm starts the watcher in a child process, so the whole process group is killed afterwards.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	e.logger.Info("Starting Express server", zap.String("command", cmd.String()))
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error starting Express server: %w", err)
+	}
+	var waitErr error
+	exited := make(chan struct{})
+	go func() {
+		waitErr = cmd.Wait()
+		close(exited)
+	}()
+
+	testErr := probeHTTPServer(e.getExpressServerAddress(), readServerTestPath(projectDir), 60*time.Second, exited, &waitErr)
+	// Kill fails once the server has exited on its own, which is fine. Waiting for the exit also makes output safe to read.
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	<-exited
+	if testErr != nil {
+		return fmt.Errorf("%s\nServer output:\n%s", testErr.Error(), output.String())
+	}
+	return nil
+}
+
+// executeDependencies executes commands from terminal.txt and installs the node dependencies.
+func (e *ExpressServerStartTestExecutor) executeDependencies(workDir string) error {
+	commands, err := e.readCommandsFromFile(workDir)
+	if err != nil {
+		return fmt.Errorf("error reading terminal.txt: %w", err)
+	}
+	for _, command := range commands {
+		e.logger.Info("Executing command", zap.String("command", command))
+		stdout, err := ExecuteTerminalCommand(workDir, command, os.Environ())
+		if err != nil {
+			return fmt.Errorf("error executing command '%s': %s", command, string(stdout))
+		}
+	}
+
+	if output, err := NodePackageInstall(workDir); err != nil {
+		return fmt.Errorf("error installing dependencies: %s", string(output))
+	}
+	return nil
+}
+
+func (e *ExpressServerStartTestExecutor) getTerminalFileName() string {
+	return "terminal.txt"
+}
+
+// readCommandsFromFile returns the setup commands from terminal.txt, leaving out the ones that start the server.
+func (e *ExpressServerStartTestExecutor) readCommandsFromFile(workingDir string) ([]string, error) {
+	file, err := os.Open(filepath.Join(workingDir, e.getTerminalFileName()))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var commands []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		command := strings.TrimSpace(scanner.Text())
+		if command != "" &&
+			!strings.HasPrefix(command, "npm run") &&
+			!strings.HasPrefix(command, "npm start") &&
+			!strings.HasPrefix(command, "pnpm run") &&
+			!strings.HasPrefix(command, "node ") &&
+			!strings.HasPrefix(command, "npx tsx") {
+			commands = append(commands, command)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
@@ -0,0 +1,240 @@
+package impl
+
+import (
+	"ai-developer/app/services"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type FastAPIServerStartTestExecutor struct {
+	executionStepService *services.ExecutionStepService
+	activityLogService   *services.ActivityLogService
+	logger               *zap.Logger
+}
+
+func NewFastAPIServerStartTestExecutor(
+	executionStepService *services.ExecutionStepService,
+	activityLogService *services.ActivityLogService,
+	logger *zap.Logger,
+) *FastAPIServerStartTestExecutor {
+	return &FastAPIServerStartTestExecutor{
+		executionStepService: executionStepService,
+		activityLogService:   activityLogService,
+		logger:               logger.Named("FastAPIServerStartTestExecutor"),
+	}
+}
+
+func (e FastAPIServerStartTestExecutor) Execute(step steps.ServerStartTestStep) error {
+	e.logger.Info("Executing FastAPI Server Start Test Step", zap.String("step", step.StepName()))
+
+	err := e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", "Starting and testing Server...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	projectDir := step.WorkspaceDir()
+	testErr := e.serverRunTest(projectDir)
+	if testErr == nil {
+		err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", "Server working well!")
+		if err != nil {
+			e.logger.Error("Error creating activity log", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+
+	e.logger.Info("Server test failed", zap.Error(testErr))
+	err = e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, map[string]interface{}{"error": testErr.Error()}, "SUCCESS")
+	if err != nil {
+		e.logger.Error("Error updating execution step", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "ERROR", fmt.Sprintf("Server test failed: %s", testErr.Error()))
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", "Server test failed fixing the issue...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	return fmt.Errorf("%w: %v", steps.ErrReiterate, testErr)
+}
+
+func (e *FastAPIServerStartTestExecutor) getFastAPIServerURL() string {
+	return "http://127.0.0.1:5000"
+}
+
+func (e *FastAPIServerStartTestExecutor) getFastAPIAppModule() string {
+	return "main:app"
+}
+
+// serverRunTest installs dependencies, starts uvicorn and fetches the OpenAPI schema as a smoke test.
+func (e *FastAPIServerStartTestExecutor) serverRunTest(projectDir string) error {
+	if err := e.executeDependencies(projectDir); err != nil {
+		return err
+	}
+
+	venvBin := filepath.Join(projectDir, ".venv", "bin")
+	newPath := fmt.Sprintf("PATH=%s:%s", venvBin, os.Getenv("PATH"))
+
+	var output bytes.Buffer
+	cmd := exec.Command(filepath.Join(venvBin, "python"), "-m", "uvicorn", e.getFastAPIAppModule(), "--host", "0.0.0.0", "--port", "5000")
+	cmd.Dir = projectDir
+	cmd.Env = getUpdateEnvs(projectDir, newPath)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	e.logger.Info("Starting FastAPI server", zap.String("command", cmd.String()))
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error starting FastAPI server: %w", err)
+	}
+	var waitErr error
+	exited := make(chan struct{})
+	go func() {
+		waitErr = cmd.Wait()
+		close(exited)
+	}()
+
+	schema, err := e.fetchOpenAPISchema(e.getFastAPIServerURL()+"/openapi.json", 60*time.Second, exited, &waitErr)
+	// Kill fails once the server has exited on its own, which is fine. Waiting for the exit also makes output safe to read.
+	_ = cmd.Process.Kill()
+	<-exited
+	if err != nil {
+		return fmt.Errorf("%s\nServer output:\n%s", err.Error(), output.String())
+	}
+	e.logger.Info("OpenAPI schema fetched", zap.Int("paths", len(schema.Paths)))
+	return nil
+}
+
+type openAPISchema struct {
+	OpenAPI string                     `json:"openapi"`
+	Paths   map[string]json.RawMessage `json:"paths"`
+}
+
+// fetchOpenAPISchema polls the schema endpoint until it is served, the timeout is reached or the server exits.
+func (e *FastAPIServerStartTestExecutor) fetchOpenAPISchema(url string, timeout time.Duration, exited <-chan struct{}, waitErr *error) (*openAPISchema, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	var lastError error
+	for start := time.Now(); time.Since(start) < timeout; time.Sleep(time.Second) {
+		select {
+		case <-exited:
+			err := *waitErr
+			if err == nil {
+				err = errors.New("exit status 0")
+			}
+			return nil, fmt.Errorf("FastAPI server exited before serving requests: %w", err)
+		default:
+		}
+
+		resp, err := client.Get(url)
+		if err != nil {
+			lastError = err
+			continue
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("received status code %d from endpoint %s: %s", resp.StatusCode, url, string(body))
+		}
+		var schema openAPISchema
+		if err := json.Unmarshal(body, &schema); err != nil {
+			return nil, fmt.Errorf("invalid OpenAPI schema from endpoint %s: %w", url, err)
+		}
+		if schema.OpenAPI == "" || len(schema.Paths) == 0 {
+			return nil, fmt.Errorf("OpenAPI schema from endpoint %s has no paths", url)
+		}
+		return &schema, nil
+	}
+	if lastError != nil {
+		return nil, fmt.Errorf("timeout reached for endpoint %s: %w", url, lastError)
+	}
+	return nil, fmt.Errorf("timeout reached for endpoint %s", url)
+}
+
+// executeDependencies executes commands from terminal.txt and installs the poetry dependencies.
+func (e *FastAPIServerStartTestExecutor) executeDependencies(workDir string) error {
+	venvPath := filepath.Join(workDir, ".venv")
+	if _, err := ensureVenv(workDir, venvPath); err != nil {
+		return fmt.Errorf("error ensuring virtual environment: %w", err)
+	}
+	if err := os.Setenv("VIRTUAL_ENV", venvPath); err != nil {
+		return fmt.Errorf("error setting VIRTUAL_ENV environment variable: %w", err)
+	}
+	newPath := fmt.Sprintf("PATH=%s:%s:%s", filepath.Join(venvPath, "bin"), "/opt/poetry/bin", os.Getenv("PATH"))
+	updatedEnv := getUpdateEnvs(workDir, newPath)
+
+	commands, err := e.readCommandsFromFile(workDir)
+	if err != nil {
+		return fmt.Errorf("error reading terminal.txt: %w", err)
+	}
+	for _, command := range commands {
+		e.logger.Info("Executing command", zap.String("command", command))
+		stdout, err := ExecuteTerminalCommand(workDir, command, updatedEnv)
+		if err != nil {
+			// Alembic logs to stderr and exits non-zero when there is nothing to upgrade.
+			if strings.Contains(string(stdout), "alembic") &&
+				!strings.Contains(string(stdout), "Error") &&
+				!strings.Contains(string(stdout), "ERROR") &&
+				strings.Contains(string(stdout), "INFO") {
+				continue
+			}
+			return fmt.Errorf("error executing command '%s': %s", command, string(stdout))
+		}
+		if strings.Contains(string(stdout), "FAILED") || strings.Contains(string(stdout), "ERROR") {
+			return fmt.Errorf("command '%s' failed with output: %s", command, string(stdout))
+		}
+	}
+
+	if err := PoetryInstall(workDir, nil); err != nil {
+		return fmt.Errorf("error installing dependencies: %w", err)
+	}
+	return nil
+}
+
+func (e *FastAPIServerStartTestExecutor) getTerminalFileName() string {
+	return "terminal.txt"
+}
+
+// readCommandsFromFile returns the setup commands from terminal.txt, leaving out the ones that start the server.
+func (e *FastAPIServerStartTestExecutor) readCommandsFromFile(workingDir string) ([]string, error) {
+	file, err := os.Open(filepath.Join(workingDir, e.getTerminalFileName()))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var commands []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		command := strings.TrimSpace(scanner.Text())
+		if command != "" &&
+			!strings.HasPrefix(command, "python") &&
+			!strings.HasPrefix(command, "source") &&
+			!strings.HasPrefix(command, "uvicorn") &&
+			!strings.HasPrefix(command, "fastapi") {
+			commands = append(commands, command)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
@@ -1,7 +1,6 @@
 package impl
 
 import (
-	"ai-developer/app/config"
 	"ai-developer/app/services"
 	"ai-developer/app/workflow_executors/step_executors/steps"
 	"bufio"
@@ -113,7 +112,7 @@ func (e *FlaskServerStartTestExecutor) getFlaskServerAppFileName() string {
 
 // serverRunTest runs the server test.
 func (e *FlaskServerStartTestExecutor) serverRunTest(step steps.ServerStartTestStep) (string, string) {
-	projectDir := config.WorkspaceWorkingDirectory() + "/" + step.Project.HashID
+	projectDir := step.WorkspaceDir()
 	appPath := projectDir + "/" + e.getFlaskServerAppFileName()
 	serverURL := e.getFlaskServerURL()
 	timeout := 60 * time.Second
@@ -1,7 +1,7 @@
 package impl
 
 import (
-	"ai-developer/app/config"
+	"ai-developer/app/llms"
 	"ai-developer/app/services"
 	"ai-developer/app/utils"
 	"ai-developer/app/workflow_executors/step_executors/steps"
@@ -10,19 +10,24 @@ import (
 )
 
 type GitCommitExecutor struct {
-	executionService *services.ExecutionService
-	activeLogService *services.ActivityLogService
+	executionService     *services.ExecutionService
+	executionStepService *services.ExecutionStepService
+	activeLogService     *services.ActivityLogService
+	changeDescriber      changeDescriber
 }
 
 // TODO: Move util out of stuct
 func NewGitCommitExecutor(
 	executionService *services.ExecutionService,
+	executionStepService *services.ExecutionStepService,
 	activityLogService *services.ActivityLogService,
-
+	llmAPIKeyService *services.LLMAPIKeyService,
 ) *GitCommitExecutor {
 	return &GitCommitExecutor{
-		executionService: executionService,
-		activeLogService: activityLogService,
+		executionService:     executionService,
+		executionStepService: executionStepService,
+		activeLogService:     activityLogService,
+		changeDescriber:      changeDescriber{llmAPIKeyService: llmAPIKeyService},
 	}
 }
 
@@ -32,7 +37,7 @@ func (e GitCommitExecutor) Execute(step steps.GitCommitStep) error {
 		fmt.Println("Error creating activity log" + err.Error())
 		return err
 	}
-	workingDir := config.WorkspaceWorkingDirectory() + "/" + step.Project.HashID
+	workingDir := step.WorkspaceDir()
 	currentBranch, err := utils.GetCurrentBranch(workingDir)
 	if err != nil {
 		fmt.Printf("Error getting current branch: %s\n", err.Error())
@@ -43,19 +48,27 @@ func (e GitCommitExecutor) Execute(step steps.GitCommitStep) error {
 		fmt.Printf("Current branch '%s' does not match execution branch '%s'\n", currentBranch, step.Execution.BranchName)
 		return fmt.Errorf("current branch '%s' does not match execution branch '%s'", currentBranch, step.Execution.BranchName)
 	}
-	commitMessage := "Update Code for " + step.Story.Title
-	commitID, err := e.makeCommit(workingDir, commitMessage)
+	commitID, commitMessage, usage, err := e.makeCommit(workingDir, step)
 	if err != nil {
 		fmt.Printf("Error making commit: %s\n", err.Error())
 		return err
 	}
+	err = e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, map[string]interface{}{
+		"commit_id":      commitID,
+		"commit_message": commitMessage,
+		"llm_usage":      usage,
+	}, "SUCCESS")
+	if err != nil {
+		fmt.Printf("Error updating execution step: %s\n", err.Error())
+		return err
+	}
 	err = e.executionService.UpdateCommitID(step.Execution, commitID)
 	if err != nil {
 		fmt.Printf("Error updating execution with commit ID: %s\n", err.Error())
 		return err
 	}
 
-	err = e.activeLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "Code changes committed successfully.")
+	err = e.activeLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", fmt.Sprintf("Code changes committed successfully: %s", firstLine(commitMessage)))
 	if err != nil {
 		fmt.Println("Error creating activity log" + err.Error())
 		return err
@@ -63,35 +76,51 @@ func (e GitCommitExecutor) Execute(step steps.GitCommitStep) error {
 	return nil
 }
 
-func (e *GitCommitExecutor) makeCommit(workingDir, commitMessage string) (string, error) {
+func (e *GitCommitExecutor) makeCommit(workingDir string, step steps.GitCommitStep) (string, string, *llms.OpenAiUsage, error) {
 
 	// Set global configuration for user email and name
 	err := utils.ConfigGitUserEmail(workingDir)
 	if err != nil {
 		fmt.Printf("Error setting global git user Email")
-		return "", err
+		return "", "", nil, err
 	}
 	err = utils.ConfigureGitUserName(workingDir)
 	if err != nil {
 		fmt.Printf("Error setting global git user name")
-		return "", err
+		return "", "", nil, err
 
 	}
 	_, err = utils.GitAddToTrackFiles(workingDir, err)
 	if err != nil {
 		fmt.Printf("Error adding files to track: %s\n", err)
-		return "", err
+		return "", "", nil, err
 	}
+	commitMessage, usage := e.commitMessage(workingDir, step)
 	output, err := utils.GitCommitWithMessage(workingDir, commitMessage, err)
 	if err != nil {
 		fmt.Printf("Error committing changes: %s\n", output)
-		return "", err
+		return "", "", nil, err
 	}
 	fmt.Printf("Commit output: %s\n", output)
 	commitIDOutput, err := utils.GetLatestCommitID(workingDir, err)
 	if err != nil {
 		fmt.Printf("Error getting latest commit ID: %s\n", err)
-		return "", err
+		return "", "", nil, err
+	}
+	return strings.TrimSpace(commitIDOutput), commitMessage, usage, nil
+}
+
+// commitMessage asks the LLM for a conventional commit message describing the staged changes, falling back
+// to a message built from the story title.
+func (e *GitCommitExecutor) commitMessage(workingDir string, step steps.GitCommitStep) (string, *llms.OpenAiUsage) {
+	diff, err := utils.GetStagedDiff(workingDir)
+	if err != nil || diff == "" {
+		return defaultCommitMessage(step.Story), nil
+	}
+	commitMessage, usage, err := e.changeDescriber.CommitMessage(step.Project.OrganisationID, step.Story, diff)
+	if err != nil {
+		fmt.Printf("Error generating commit message, using the default message: %s\n", err.Error())
+		return defaultCommitMessage(step.Story), usage
 	}
-	return strings.TrimSpace(commitIDOutput), nil
+	return commitMessage, usage
 }
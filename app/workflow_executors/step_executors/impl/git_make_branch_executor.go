@@ -13,23 +13,26 @@ import (
 )
 
 type GitMakeBranchExecutor struct {
-	executionService    *services.ExecutionService
-	activityLogService  *services.ActivityLogService
-	gitnessService      *git_providers.GitnessService
-	organisationService *services.OrganisationService
+	executionService       *services.ExecutionService
+	activityLogService     *services.ActivityLogService
+	gitProviderResolver    *git_providers.GitProviderResolver
+	organisationService    *services.OrganisationService
+	storyDependencyService *services.StoryDependencyService
 }
 
 func NewGitMakeBranchExecutor(
 	executionService *services.ExecutionService,
 	activityLogService *services.ActivityLogService,
 	organisationService *services.OrganisationService,
-	gitnessService *git_providers.GitnessService,
+	gitProviderResolver *git_providers.GitProviderResolver,
+	storyDependencyService *services.StoryDependencyService,
 ) *GitMakeBranchExecutor {
 	return &GitMakeBranchExecutor{
-		executionService:    executionService,
-		activityLogService:  activityLogService,
-		organisationService: organisationService,
-		gitnessService:      gitnessService,
+		executionService:       executionService,
+		activityLogService:     activityLogService,
+		organisationService:    organisationService,
+		gitProviderResolver:    gitProviderResolver,
+		storyDependencyService: storyDependencyService,
 	}
 
 }
@@ -43,38 +46,39 @@ func (e GitMakeBranchExecutor) Execute(step steps.GitMakeBranchStep) error {
 	}
 
 	projectDir := config.WorkspaceWorkingDirectory() + "/" + step.Project.HashID
-	workingDir := projectDir
+	workingDir := step.WorkspaceDir()
 	branchName := step.Execution.BranchName
 
 	//STEP -
-	if !step.Execution.ReExecution {
-		if !e.isGitInitializedInRoot(workingDir) {
-			err := e.initializeGitWithConfig(workingDir)
-			if err != nil {
-				fmt.Printf("Error initializing Git repository: %s\n", err)
-				return err
-			}
-			fmt.Println("Initialized Git repository in the working directory, adding updating logs")
-			err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", "Initialized Git repository in the working directory.")
-			if err != nil {
-				fmt.Printf("Error creating activity log: %s\n", err.Error())
-				return err
-			}
-		} else {
-			err := e.configureGit(workingDir)
-			if err != nil {
-				fmt.Printf("Error configuring Git: %s\n", err)
-				return err
-			}
+	if !e.isGitInitializedInRoot(projectDir) {
+		err := e.initializeGitWithConfig(projectDir)
+		if err != nil {
+			fmt.Printf("Error initializing Git repository: %s\n", err)
+			return err
 		}
-
-		err = e.checkoutAndPullMain(workingDir, step.Project)
+		fmt.Println("Initialized Git repository in the working directory, adding updating logs")
+		err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", "Initialized Git repository in the working directory.")
 		if err != nil {
-			fmt.Printf("Error checking out to main and pulling latest changes: %s\n", err)
+			fmt.Printf("Error creating activity log: %s\n", err.Error())
 			return err
 		}
+	} else {
+		err := e.configureGit(projectDir)
+		if err != nil {
+			fmt.Printf("Error configuring Git: %s\n", err)
+			return err
+		}
+	}
+
+	err = e.addWorktree(projectDir, workingDir)
+	if err != nil {
+		fmt.Printf("Error adding worktree: %s\n", err)
+		return err
+	}
+
+	if !step.Execution.ReExecution {
 		fmt.Println("Creating new branch")
-		err = utils.CreateBranch(workingDir, branchName)
+		err = e.createBranchFromBase(workingDir, step.Project, branchName)
 		if err != nil {
 			fmt.Printf("Error creating branch: %s\n", err)
 			return err
@@ -84,9 +88,14 @@ func (e GitMakeBranchExecutor) Execute(step steps.GitMakeBranchStep) error {
 			fmt.Printf("Error creating activity log: %s\n", err.Error())
 			return err
 		}
+		err = e.mergePrerequisiteBranches(step, workingDir, branchName)
+		if err != nil {
+			fmt.Printf("Error merging prerequisite branches: %s\n", err)
+			return err
+		}
 	} else {
 		fmt.Printf("Re-execution flag is set. Attempting to switch to existing branch '%s'.\n", branchName)
-		err := utils.CheckoutBranch(workingDir, branchName)
+		err := e.checkoutExistingBranch(workingDir, step.Project, branchName)
 		if err != nil {
 			fmt.Printf("Error checking out branch: %s\n", err)
 			return err
@@ -159,20 +168,100 @@ func (e *GitMakeBranchExecutor) configureGit(workingDir string) error {
 	return nil
 }
 
-func (e *GitMakeBranchExecutor) checkoutAndPullMain(workingDir string, project *models.Project) error {
-	fmt.Printf("Checking out to main, pulling latest changes\n")
-	//Checkout to main
-	err := utils.CheckoutBranch(workingDir, "main")
+// addWorktree gives the execution a worktree of its own, so that executions of several stories of the project
+// can run at the same time without touching each other's files or the project workspace.
+func (e *GitMakeBranchExecutor) addWorktree(projectDir string, workingDir string) error {
+	err := utils.ExcludeFromGit(projectDir, "/"+config.ExecutionWorktreesDir+"/")
 	if err != nil {
 		return err
 	}
-	organisation, err := e.organisationService.GetOrganisationByID(uint(int(project.OrganisationID)))
-	GitnessSpaceOrProjectName := e.gitnessService.GetSpaceOrProjectName(organisation)
-	err = utils.PullOriginBranch(workingDir, project, GitnessSpaceOrProjectName)
+	return utils.AddWorktree(projectDir, workingDir)
+}
+
+// createBranchFromBase creates the branch of a new execution from the latest commit of the base branch.
+func (e *GitMakeBranchExecutor) createBranchFromBase(workingDir string, project *models.Project, branchName string) error {
+	baseBranch := utils.ProjectBaseBranch(project)
+	fmt.Printf("Creating branch from the latest changes of %s\n", baseBranch)
+	origin, err := e.origin(project)
 	if err != nil {
-		fmt.Println("Error pulling latest changes: ", err)
 		return err
 	}
+	commit, err := utils.FetchBranch(workingDir, origin, baseBranch)
+	if err != nil {
+		fmt.Println("Error fetching latest changes: ", err)
+		return err
+	}
+	return utils.ResetBranchTo(workingDir, branchName, commit)
+}
 
+// checkoutExistingBranch checks out the branch a re-execution works on. Branches which are only known to the
+// git host, e.g. after the workspace was recreated, are fetched first.
+func (e *GitMakeBranchExecutor) checkoutExistingBranch(workingDir string, project *models.Project, branchName string) error {
+	if utils.HasLocalBranch(workingDir, branchName) {
+		return utils.CheckoutWorktreeBranch(workingDir, branchName)
+	}
+	origin, err := e.origin(project)
+	if err != nil {
+		return err
+	}
+	commit, err := utils.FetchBranch(workingDir, origin, branchName)
+	if err != nil {
+		return err
+	}
+	return utils.ResetBranchTo(workingDir, branchName, commit)
+}
+
+// mergePrerequisiteBranches merges the branches of the prerequisites whose pull requests are still open into
+// the new branch, so the story starts from the combined result of the work it depends on. Prerequisites which
+// conflict with each other fail the step.
+func (e *GitMakeBranchExecutor) mergePrerequisiteBranches(step steps.GitMakeBranchStep, workingDir, branchName string) error {
+	if step.Story == nil {
+		return nil
+	}
+	branches, err := e.storyDependencyService.PrerequisiteBranches(step.Story.ID)
+	if err != nil || len(branches) == 0 {
+		return err
+	}
+	origin, err := e.origin(step.Project)
+	if err != nil {
+		return err
+	}
+	for _, prerequisiteBranch := range branches {
+		commit, err := utils.FetchBranch(workingDir, origin, prerequisiteBranch)
+		if err != nil {
+			return err
+		}
+		conflicted, err := utils.MergeCommit(workingDir, commit, fmt.Sprintf("Merge prerequisite branch '%s' into %s", prerequisiteBranch, branchName))
+		if err != nil {
+			return err
+		}
+		if conflicted {
+			if abortErr := utils.AbortMerge(workingDir); abortErr != nil {
+				fmt.Printf("Error aborting merge: %s\n", abortErr)
+			}
+			logErr := e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "ERROR", fmt.Sprintf("Prerequisite branch '%s' conflicts with the other prerequisites of the story.", prerequisiteBranch))
+			if logErr != nil {
+				fmt.Printf("Error creating activity log: %s\n", logErr.Error())
+			}
+			return fmt.Errorf("prerequisite branch '%s' conflicts with the other prerequisites", prerequisiteBranch)
+		}
+		err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", fmt.Sprintf("Merged prerequisite branch '%s'.", prerequisiteBranch))
+		if err != nil {
+			fmt.Printf("Error creating activity log: %s\n", err.Error())
+			return err
+		}
+	}
 	return nil
 }
+
+func (e *GitMakeBranchExecutor) origin(project *models.Project) (string, error) {
+	organisation, err := e.organisationService.GetOrganisationByID(uint(int(project.OrganisationID)))
+	if err != nil {
+		return "", err
+	}
+	gitProvider, err := e.gitProviderResolver.ForProject(project)
+	if err != nil {
+		return "", err
+	}
+	return git_providers.AuthenticatedRemoteURL(gitProvider, organisation, project)
+}
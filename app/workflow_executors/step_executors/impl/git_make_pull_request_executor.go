@@ -0,0 +1,450 @@
+package impl
+
+import (
+	"ai-developer/app/config"
+	"ai-developer/app/constants"
+	"ai-developer/app/llms"
+	"ai-developer/app/models"
+	"ai-developer/app/services"
+	"ai-developer/app/services/git_providers"
+	"ai-developer/app/utils"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type GitMakePullRequestExecutor struct {
+	storyService              *services.StoryService
+	executionService          *services.ExecutionService
+	executionOutputService    *services.ExecutionOutputService
+	organisationService       *services.OrganisationService
+	gitProviderResolver       *git_providers.GitProviderResolver
+	pullRequestService        *services.PullRequestService
+	activeLogService          *services.ActivityLogService
+	executionStepService      *services.ExecutionStepService
+	pullRequestCommentService *services.PullRequestCommentsService
+	changeDescriber           changeDescriber
+}
+
+func NewGitMakePullRequestExecutor(
+	storyService *services.StoryService,
+	executionService *services.ExecutionService,
+	organisationService *services.OrganisationService,
+	executionOutputService *services.ExecutionOutputService,
+	gitProviderResolver *git_providers.GitProviderResolver,
+	pullRequestService *services.PullRequestService,
+	activeLogService *services.ActivityLogService,
+	executionStepService *services.ExecutionStepService,
+	llmAPIKeyService *services.LLMAPIKeyService,
+	pullRequestCommentService *services.PullRequestCommentsService,
+) *GitMakePullRequestExecutor {
+	return &GitMakePullRequestExecutor{
+		storyService:              storyService,
+		executionService:          executionService,
+		organisationService:       organisationService,
+		executionOutputService:    executionOutputService,
+		gitProviderResolver:       gitProviderResolver,
+		pullRequestService:        pullRequestService,
+		activeLogService:          activeLogService,
+		executionStepService:      executionStepService,
+		pullRequestCommentService: pullRequestCommentService,
+		changeDescriber:           changeDescriber{llmAPIKeyService: llmAPIKeyService},
+	}
+}
+
+func (e GitMakePullRequestExecutor) Execute(step steps.GitMakePullRequestStep) error {
+	fmt.Printf("Executing Step '%s' for Project '%s'...\n", step.StepName(), step.Project.Name)
+	fmt.Println("RE-EXECUTION : ", step.Execution.ReExecution)
+	organisation, err := e.organisationService.GetOrganisationByID(step.Project.OrganisationID)
+	if err != nil {
+		fmt.Printf("Error getting organisation: %s\n", err.Error())
+		return err
+	}
+	gitProvider, err := e.gitProviderResolver.ForProject(step.Project)
+	if err != nil {
+		fmt.Printf("Error resolving git provider: %s\n", err.Error())
+		return err
+	}
+	securityReport, autoMergeBlocked, err := e.fetchSecurityScanResult(step.Execution.ID)
+	if err != nil {
+		fmt.Printf("Error fetching security scan result: %s\n", err.Error())
+		return err
+	}
+	var pullRequest *models.PullRequest
+	if !step.Execution.ReExecution {
+		title, description, err := e.describePullRequest(step, securityReport)
+		if err != nil {
+			fmt.Printf("Error describing pull request: %s\n", err.Error())
+			return err
+		}
+		pr, err := gitProvider.CreatePullRequest(organisation, step.Project, step.Execution.BranchName, utils.ProjectTargetBranch(step.Project),
+			title, description)
+		if err != nil {
+			fmt.Printf("Error creating pull request: %s\n", err.Error())
+			return err
+		}
+
+		err = e.activeLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", fmt.Sprintf("Pull request created successfully. %s", pr.Title))
+		if err != nil {
+			fmt.Printf("Error creating activity log: %s\n", err.Error())
+			return err
+		}
+
+		// Pass PR details to EndExecution via options
+		optionsMap := map[string]interface{}{
+			"pr_name":            pr.Title,
+			"pr_number":          pr.Number,
+			"pr_description":     pr.Description,
+			"source_sha":         pr.SourceSHA,
+			"merge_target_sha":   pr.MergeTargetSHA,
+			"merge_base_sha":     pr.MergeBaseSHA,
+			"story_id":           step.Execution.StoryID,
+			"remote_type":        gitProvider.RemoteType(),
+			"source_branch":      pr.SourceBranch,
+			"target_branch":      pr.TargetBranch,
+			"auto_merge_blocked": autoMergeBlocked,
+		}
+
+		pullRequest, err = e.handleExecutionOutput(step.ExecutionStep.ExecutionID, optionsMap)
+		if err != nil {
+			fmt.Printf("Error handling execution: %s\n", err.Error())
+			return err
+		}
+		err = e.activeLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "Pull request created successfully.")
+		if err != nil {
+			fmt.Printf("Error creating activity log: %s\n", err.Error())
+			return err
+		}
+	} else {
+		// Rerun PR with comments
+		pullRequest, err = e.pullRequestService.GetPullRequestByID(step.PullRequestID)
+		if err != nil {
+			fmt.Printf("Error getting pull request by execution output: %s\n", err.Error())
+			return err
+		}
+		pullRequestProvider, err := e.gitProviderResolver.ForPullRequest(pullRequest, step.Project)
+		if err != nil {
+			fmt.Printf("Error resolving git provider of pull request: %s\n", err.Error())
+			return err
+		}
+		newPullRequestData, err := pullRequestProvider.FetchPullRequest(organisation, step.Project, pullRequest.PullRequestNumber)
+		if err != nil {
+			fmt.Printf("Error fetching pull request data: %s\n", err.Error())
+			return err
+		}
+		previousSourceSHA := pullRequest.SourceSHA
+		err = e.pullRequestService.UpdatePullRequestSourceSHA(pullRequest, newPullRequestData.SourceSHA)
+		if err != nil {
+			fmt.Printf("Error updating pull request source SHA: %s\n", err.Error())
+			return err
+		}
+		err = e.pullRequestService.UpdatePullRequestAutoMergeBlocked(pullRequest, autoMergeBlocked)
+		if err != nil {
+			fmt.Printf("Error updating pull request auto merge blocked: %s\n", err.Error())
+			return err
+		}
+		_, err = e.handleExecutionOutput(step.ExecutionStep.ExecutionID)
+		if err != nil {
+			fmt.Printf("Error handling execution: %s\n", err.Error())
+			return err
+		}
+		// Resolving the comments is best effort, the changes are pushed either way.
+		err = e.resolveComments(step, pullRequest, previousSourceSHA, newPullRequestData.SourceSHA)
+		if err != nil {
+			fmt.Printf("Error resolving review comments: %s\n", err.Error())
+		}
+	}
+
+	//Update Execution Step Status
+	err = e.executionService.UpdateExecutionStatus(step.ExecutionStep.ExecutionID, "DONE")
+	if err != nil {
+		fmt.Printf("Error updating execution status: %s\n", err.Error())
+		return err
+	}
+	fmt.Println("Execution Step Status Updated to DONE")
+	//Update Story Status
+	err = e.storyService.UpdateStoryStatus(int(step.Execution.StoryID), "DONE")
+	if err != nil {
+		fmt.Printf("Error updating story status: %s\n", err.Error())
+		return err
+	}
+	fmt.Println("Story Status Updated to DONE")
+	// Auto-merge is best effort, the pull request stays open for review when it fails.
+	if pullRequest != nil {
+		err = e.autoMerge(step, pullRequest)
+		if err != nil {
+			fmt.Printf("Error auto-merging pull request: %s\n", err.Error())
+		}
+	}
+	return nil
+}
+
+func (e *GitMakePullRequestExecutor) handleExecutionOutput(executionID uint, options ...map[string]interface{}) (*models.PullRequest, error) {
+	fmt.Printf("Ending Git Make Pull Request Step for Execution ID: %d\n", executionID)
+	fmt.Printf("Options: %v\n", options)
+	// Extract PR details from options
+	if len(options) > 0 {
+		fmt.Printf("Options: %v\n", options)
+		prDetails := options[0]
+		fmt.Println("PR Details: ", prDetails)
+		prName := prDetails["pr_name"].(string)
+		prNumber := prDetails["pr_number"].(int)
+		prDescription := prDetails["pr_description"].(string)
+		sourceSHA := prDetails["source_sha"].(string)
+		mergeBaseSHA := prDetails["merge_base_sha"].(string)
+		// Providers only report the commit the pull request merges into once they computed the merge.
+		mergeTargetSHA, _ := prDetails["merge_target_sha"].(string)
+		if mergeTargetSHA == "" {
+			mergeTargetSHA = mergeBaseSHA
+		}
+		storyID := prDetails["story_id"].(uint)
+		remoteType := prDetails["remote_type"].(string)
+		sourceBranch, _ := prDetails["source_branch"].(string)
+		targetBranch, _ := prDetails["target_branch"].(string)
+		fmt.Printf("PR Details: %s, %d, %s, %s, %s, %s\n", prName, prNumber, prDescription, sourceSHA, mergeTargetSHA, mergeBaseSHA)
+		executionOutput, err := e.executionOutputService.CreateExecutionOutput(executionID)
+		if err != nil {
+			return nil, err
+		}
+		prType := constants.Automated
+		pullRequest, err2 := e.pullRequestService.CreatePullRequest(prName, prDescription, strconv.Itoa(prNumber), remoteType,
+			sourceBranch, targetBranch, sourceSHA, mergeTargetSHA, mergeBaseSHA, prNumber, storyID, executionOutput.ID, prType)
+		if err2 != nil {
+			fmt.Printf("Error creating execution output: %s\n", err2.Error())
+			return nil, err2
+		}
+		if autoMergeBlocked, _ := prDetails["auto_merge_blocked"].(bool); autoMergeBlocked {
+			err = e.pullRequestService.UpdatePullRequestAutoMergeBlocked(pullRequest, true)
+			if err != nil {
+				fmt.Printf("Error updating pull request auto merge blocked: %s\n", err.Error())
+				return nil, err
+			}
+		}
+		fmt.Printf("Execution output created successfully: %v\n", pullRequest)
+		return pullRequest, nil
+	}
+	return nil, nil
+}
+
+// resolveComments marks the review comments addressed by the execution resolved, or leaves them unresolved with a
+// note when the pushed change does not address them, and replies with the status of each comment.
+func (e *GitMakePullRequestExecutor) resolveComments(step steps.GitMakePullRequestStep, pullRequest *models.PullRequest, previousSourceSHA, sourceSHA string) error {
+	summary := e.reviewReply(step.Execution.ID, sourceSHA)
+	comments, err := e.pullRequestCommentService.GetExecutionComments(step.Execution.ID)
+	if err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		return e.pullRequestCommentService.CreateAgentReply(pullRequest, summary)
+	}
+
+	var resolutions map[uint]services.CommentResolution
+	var usage *llms.OpenAiUsage
+	workingDir := step.WorkspaceDir()
+	diff, err := utils.GetDiffSinceMergeBase(workingDir, previousSourceSHA)
+	if err != nil || previousSourceSHA == "" {
+		fmt.Printf("Error getting diff of the re-execution, taking all comments as resolved: %v\n", err)
+	} else {
+		resolutions, usage, err = e.changeDescriber.CommentResolutions(step.Project.OrganisationID, comments, diff)
+		if err != nil {
+			fmt.Printf("Error checking comment resolutions, taking all comments as resolved: %s\n", err.Error())
+		}
+	}
+	err = e.pullRequestCommentService.ResolveComments(pullRequest, comments, resolutions, summary)
+	if err != nil {
+		return err
+	}
+
+	statuses := make(map[string]string, len(comments))
+	for _, comment := range comments {
+		statuses[strconv.Itoa(int(comment.ID))] = comment.Status
+	}
+	return e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, map[string]interface{}{
+		"comment_statuses": statuses,
+		"llm_usage":        usage,
+	}, "SUCCESS")
+}
+
+// reviewReply tells the reviewers which commit addressed their comments.
+func (e *GitMakePullRequestExecutor) reviewReply(executionID uint, sourceSHA string) string {
+	commit := sourceSHA
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	commitStep, err := e.executionStepService.FetchLatestExecutionStepOfNames(executionID, []string{steps.GIT_COMMIT_STEP.String()})
+	if err != nil || commitStep == nil {
+		return fmt.Sprintf("Addressed the review comments in %s.", commit)
+	}
+	commitMessage, _ := commitStep.Response["commit_message"].(string)
+	if commitMessage == "" {
+		return fmt.Sprintf("Addressed the review comments in %s.", commit)
+	}
+	return fmt.Sprintf("Addressed the review comments in %s: %s", commit, firstLine(commitMessage))
+}
+
+// fetchSecurityScanResult returns the report to attach to the pull request and whether auto-merge must be blocked,
+// based on the latest security scan of the execution.
+func (e *GitMakePullRequestExecutor) fetchSecurityScanResult(executionID uint) (string, bool, error) {
+	securityScanSteps, err := e.executionStepService.FetchExecutionSteps(executionID, steps.SECURITY_SCAN_STEP.String(), steps.CODE_TEST.String(), 1)
+	if err != nil {
+		return "", false, err
+	}
+	if len(securityScanSteps) == 0 {
+		return "", false, nil
+	}
+	report, _ := securityScanSteps[0].Response["report"].(string)
+	blocked, _ := securityScanSteps[0].Response["block_auto_merge"].(bool)
+	return report, blocked, nil
+}
+
+// describePullRequest returns the title and the description of the pull request, rendered from the template of the
+// project with an LLM written summary of the diff, the story, its test cases, the plan and the token cost of the execution.
+func (e *GitMakePullRequestExecutor) describePullRequest(step steps.GitMakePullRequestStep, securityReport string) (string, string, error) {
+	data := utils.PullRequestDescriptionData{
+		Title:            "Pull Request: " + step.Story.Title,
+		Summary:          "Auto-generated pull request",
+		StoryID:          step.Story.ID,
+		StoryTitle:       step.Story.Title,
+		StoryDescription: step.Story.Description,
+		StoryURL:         storyURL(step.Story),
+		Plan:             e.executionPlan(step.Execution),
+		SecurityReport:   securityReport,
+	}
+
+	var usage *llms.OpenAiUsage
+	workingDir := step.WorkspaceDir()
+	diff, err := e.branchDiff(workingDir, step)
+	if err != nil {
+		fmt.Printf("Error getting branch diff: %s\n", err.Error())
+	} else if diff != "" {
+		var summary *pullRequestSummary
+		summary, usage, err = e.changeDescriber.PullRequestSummary(step.Project.OrganisationID, step.Story, diff)
+		if err != nil {
+			fmt.Printf("Error generating pull request summary, using the default description: %s\n", err.Error())
+		} else {
+			data.Title = summary.Title
+			data.Summary = summary.Summary
+		}
+	}
+
+	testCases, err := e.storyService.GetStoryTestCaseByStoryId(int(step.Story.ID))
+	if err != nil {
+		return "", "", err
+	}
+	status, verified := e.verificationStatus(step.Execution.ID)
+	for _, testCase := range testCases {
+		data.TestCases = append(data.TestCases, utils.PullRequestTestCase{TestCase: testCase.TestCase, Status: status, Verified: verified})
+	}
+
+	// Usage stored before models were recorded with it is priced at the model generating the code.
+	usageByModel, err := e.executionStepService.GetExecutionTokenUsageByModel(step.Execution.ID, generationModel(step.Story, step.Project))
+	if err != nil {
+		return "", "", err
+	}
+	if usage != nil {
+		summaryUsage := usageByModel[usage.Model]
+		summaryUsage.PromptTokens += usage.PromptTokens
+		summaryUsage.CompletionTokens += usage.CompletionTokens
+		usageByModel[usage.Model] = summaryUsage
+	}
+	for model, modelUsage := range usageByModel {
+		data.PromptTokens += modelUsage.PromptTokens
+		data.CompletionTokens += modelUsage.CompletionTokens
+		data.Cost += llms.EstimateCost(model, modelUsage.PromptTokens, modelUsage.CompletionTokens)
+	}
+	data.TotalTokens = data.PromptTokens + data.CompletionTokens
+
+	description, err := utils.RenderPullRequestDescription(step.Project.PullRequestTemplate, data)
+	if err != nil {
+		fmt.Printf("Error rendering pull request template of project, using the default template: %s\n", err.Error())
+		description, err = utils.RenderPullRequestDescription("", data)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	err = e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, map[string]interface{}{
+		"pr_title":       data.Title,
+		"pr_description": description,
+		"llm_usage":      usage,
+	}, "SUCCESS")
+	if err != nil {
+		return "", "", err
+	}
+	return data.Title, description, nil
+}
+
+// branchDiff returns the changes of the story branch against the target branch.
+func (e *GitMakePullRequestExecutor) branchDiff(workingDir string, step steps.GitMakePullRequestStep) (string, error) {
+	organisation, err := e.organisationService.GetOrganisationByID(step.Project.OrganisationID)
+	if err != nil {
+		return "", err
+	}
+	gitProvider, err := e.gitProviderResolver.ForProject(step.Project)
+	if err != nil {
+		return "", err
+	}
+	origin, err := git_providers.AuthenticatedRemoteURL(gitProvider, organisation, step.Project)
+	if err != nil {
+		return "", err
+	}
+	targetCommit, err := utils.FetchBranch(workingDir, origin, utils.ProjectTargetBranch(step.Project))
+	if err != nil {
+		return "", err
+	}
+	return utils.GetDiffSinceMergeBase(workingDir, targetCommit)
+}
+
+// verificationStatus reports how the test cases were verified, by the latest test or server start step of the execution.
+func (e *GitMakePullRequestExecutor) verificationStatus(executionID uint) (string, bool) {
+	testStep, err := e.executionStepService.FetchLatestExecutionStepOfNames(executionID, []string{steps.TEST_STEP.String(), steps.SERVER_START_STEP.String()})
+	if err != nil {
+		return "not verified", false
+	}
+	check := "server test"
+	if testStep.Name == steps.TEST_STEP.String() {
+		check = "tests"
+	}
+	if testError, _ := testStep.Response["error"].(string); testError != "" {
+		return check + " failed", false
+	}
+	return check + " passed", true
+}
+
+// executionPlan returns the plan of the execution, or the plan the LLM thought out in its latest code generation.
+func (e *GitMakePullRequestExecutor) executionPlan(execution *models.Execution) string {
+	if execution.Plan != "" {
+		return execution.Plan
+	}
+	generateCodeSteps, err := e.executionStepService.FetchExecutionSteps(execution.ID, steps.CODE_GENERATE_STEP.String(), steps.LLM.String(), 1)
+	if err != nil || len(generateCodeSteps) == 0 {
+		return ""
+	}
+	llmResponse, _ := generateCodeSteps[0].Response["llm_response"].(string)
+	thought := thoughtPattern.FindStringSubmatch(llmResponse)
+	if thought == nil {
+		return ""
+	}
+	return strings.TrimSpace(thought[1])
+}
+
+var thoughtPattern = regexp.MustCompile(`(?s)THOUGHT\s*:\s*"?(.*?)"?\s*\n\s*\|filename\|`)
+
+func storyURL(story *models.Story) string {
+	appURL, _ := config.Get("app.url").(string)
+	return fmt.Sprintf("%s/board?story_id=%d", strings.TrimSuffix(appURL, "/"), story.ID)
+}
+
+// generationModel is the model the code of the story is generated with. The tokens of the execution are
+// priced at it.
+func generationModel(story *models.Story, project *models.Project) string {
+	promptKey := constants.PromptNextJs
+	if story.Type != constants.Frontend {
+		promptKey = constants.BackendFrameworkPrompts[project.BackendFramework]
+	}
+	if model, ok := constants.PromptModels[promptKey]; ok {
+		return model
+	}
+	return constants.GPT_4O
+}
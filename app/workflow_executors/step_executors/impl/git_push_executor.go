@@ -1,8 +1,6 @@
 package impl
 
 import (
-	"ai-developer/app/config"
-	"ai-developer/app/constants"
 	"ai-developer/app/services"
 	"ai-developer/app/services/git_providers"
 	"ai-developer/app/utils"
@@ -12,18 +10,18 @@ import (
 
 type GitPushExecutor struct {
 	organisationService *services.OrganisationService
-	gitnessService      *git_providers.GitnessService
+	gitProviderResolver *git_providers.GitProviderResolver
 	activeLogService    *services.ActivityLogService
 }
 
 func NewGitPushExecutor(
 	organisationService *services.OrganisationService,
-	gitnessService *git_providers.GitnessService,
+	gitProviderResolver *git_providers.GitProviderResolver,
 	activeLogService *services.ActivityLogService,
 ) *GitPushExecutor {
 	return &GitPushExecutor{
 		organisationService: organisationService,
-		gitnessService:      gitnessService,
+		gitProviderResolver: gitProviderResolver,
 		activeLogService:    activeLogService,
 	}
 
@@ -36,16 +34,22 @@ func (e GitPushExecutor) Execute(step steps.GitPushStep) error {
 		return err
 	}
 	organisation, err := e.organisationService.GetOrganisationByID(step.Project.OrganisationID)
-	spaceOrProjectName := e.gitnessService.GetSpaceOrProjectName(organisation)
-	httpPrefix := "https"
-
-	if config.AppEnv() == constants.Development {
-		httpPrefix = "http"
+	if err != nil {
+		fmt.Printf("Error getting organisation: %s\n", err.Error())
+		return err
+	}
+	gitProvider, err := e.gitProviderResolver.ForProject(step.Project)
+	if err != nil {
+		fmt.Printf("Error resolving git provider: %s\n", err.Error())
+		return err
+	}
+	origin, err := git_providers.AuthenticatedRemoteURL(gitProvider, organisation, step.Project)
+	if err != nil {
+		fmt.Printf("Error building remote url: %s\n", err.Error())
+		return err
 	}
-	origin := fmt.Sprintf("%s://%s:%s@%s/git/%s/%s.git", httpPrefix, config.GitnessUser(), config.GitnessToken(),
-		config.GitnessHost(), spaceOrProjectName, step.Project.Name)
 	branch := step.Execution.BranchName
-	projectDir := config.WorkspaceWorkingDirectory() + "/" + step.Project.HashID
+	projectDir := step.WorkspaceDir()
 	err = utils.GitPush(projectDir, origin, branch)
 	if err != nil {
 		//TODO Handle Failure
@@ -0,0 +1,177 @@
+package impl
+
+import (
+	"ai-developer/app/services"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type GoServerStartTestExecutor struct {
+	executionStepService *services.ExecutionStepService
+	activityLogService   *services.ActivityLogService
+	logger               *zap.Logger
+}
+
+func NewGoServerStartTestExecutor(
+	executionStepService *services.ExecutionStepService,
+	activityLogService *services.ActivityLogService,
+	logger *zap.Logger,
+) *GoServerStartTestExecutor {
+	return &GoServerStartTestExecutor{
+		executionStepService: executionStepService,
+		activityLogService:   activityLogService,
+		logger:               logger.Named("GoServerStartTestExecutor"),
+	}
+}
+
+func (e GoServerStartTestExecutor) Execute(step steps.ServerStartTestStep) error {
+	e.logger.Info("Executing Go Server Start Test Step", zap.String("step", step.StepName()))
+
+	err := e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", "Starting and testing Server...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	projectDir := step.WorkspaceDir()
+	testErr := e.serverRunTest(projectDir)
+	if testErr == nil {
+		err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", "Server working well!")
+		if err != nil {
+			e.logger.Error("Error creating activity log", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+
+	e.logger.Info("Server test failed", zap.Error(testErr))
+	err = e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, map[string]interface{}{"error": testErr.Error()}, "SUCCESS")
+	if err != nil {
+		e.logger.Error("Error updating execution step", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "ERROR", fmt.Sprintf("Server test failed: %s", testErr.Error()))
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	err = e.activityLogService.CreateActivityLog(step.ExecutionStep.ExecutionID, step.ExecutionStep.ID, "INFO", "Server test failed fixing the issue...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	return fmt.Errorf("%w: %v", steps.ErrReiterate, testErr)
+}
+
+func (e *GoServerStartTestExecutor) getGoServerAddress() string {
+	return "127.0.0.1:5000"
+}
+
+// serverRunTest resolves the module dependencies, builds the server outside the workspace and probes its port and test endpoint.
+func (e *GoServerStartTestExecutor) serverRunTest(projectDir string) error {
+	if err := e.executeDependencies(projectDir); err != nil {
+		return err
+	}
+
+	buildDir, err := os.MkdirTemp("", "go-server-")
+	if err != nil {
+		return fmt.Errorf("error creating build directory: %w", err)
+	}
+	defer os.RemoveAll(buildDir)
+	binary := filepath.Join(buildDir, "server")
+
+	build := exec.Command("go", "build", "-o", binary, ".")
+	build.Dir = projectDir
+	build.Env = os.Environ()
+	e.logger.Info("Building Go server", zap.String("command", build.String()))
+	if output, err := build.CombinedOutput(); err != nil {
+		return fmt.Errorf("error building Go server: %s", string(output))
+	}
+
+	var output bytes.Buffer
+	cmd := exec.Command(binary)
+	cmd.Dir = projectDir
+	cmd.Env = os.Environ()
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	e.logger.Info("Starting Go server", zap.String("command", cmd.String()))
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error starting Go server: %w", err)
+	}
+	var waitErr error
+	exited := make(chan struct{})
+	go func() {
+		waitErr = cmd.Wait()
+		close(exited)
+	}()
+
+	testErr := probeHTTPServer(e.getGoServerAddress(), readServerTestPath(projectDir), 60*time.Second, exited, &waitErr)
+	// Kill fails once the server has exited on its own, which is fine. Waiting for the exit also makes output safe to read.
+	_ = cmd.Process.Kill()
+	<-exited
+	if testErr != nil {
+		return fmt.Errorf("%s\nServer output:\n%s", testErr.Error(), output.String())
+	}
+	return nil
+}
+
+// executeDependencies executes commands from terminal.txt and tidies go.mod so every import resolves.
+func (e *GoServerStartTestExecutor) executeDependencies(workDir string) error {
+	commands, err := e.readCommandsFromFile(workDir)
+	if err != nil {
+		return fmt.Errorf("error reading terminal.txt: %w", err)
+	}
+	for _, command := range commands {
+		e.logger.Info("Executing command", zap.String("command", command))
+		stdout, err := ExecuteTerminalCommand(workDir, command, os.Environ())
+		if err != nil {
+			return fmt.Errorf("error executing command '%s': %s", command, string(stdout))
+		}
+	}
+
+	cmd := exec.Command("go", "mod", "tidy")
+	cmd.Dir = workDir
+	cmd.Env = os.Environ()
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error running go mod tidy: %s", string(output))
+	}
+	return nil
+}
+
+func (e *GoServerStartTestExecutor) getTerminalFileName() string {
+	return "terminal.txt"
+}
+
+// readCommandsFromFile returns the setup commands from terminal.txt, leaving out the ones that start the server.
+func (e *GoServerStartTestExecutor) readCommandsFromFile(workingDir string) ([]string, error) {
+	file, err := os.Open(filepath.Join(workingDir, e.getTerminalFileName()))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var commands []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		command := strings.TrimSpace(scanner.Text())
+		if command != "" &&
+			!strings.HasPrefix(command, "go run") &&
+			!strings.HasPrefix(command, "go build") &&
+			!strings.HasPrefix(command, "./") {
+			commands = append(commands, command)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
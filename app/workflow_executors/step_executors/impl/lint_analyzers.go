@@ -0,0 +1,339 @@
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LintDiagnostic is a single finding reported by a static analyzer.
+type LintDiagnostic struct {
+	Analyzer string `json:"analyzer"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Code     string `json:"code"`
+	Severity string `json:"severity"` // error, warning
+	Message  string `json:"message"`
+}
+
+func (d LintDiagnostic) String() string {
+	if d.Code != "" {
+		return fmt.Sprintf("%s:%d:%d: %s %s: %s (%s)", d.File, d.Line, d.Column, d.Severity, d.Code, d.Message, d.Analyzer)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s (%s)", d.File, d.Line, d.Column, d.Severity, d.Message, d.Analyzer)
+}
+
+type lintAnalyzer struct {
+	// command returns the binary and arguments to run inside workDir, or an empty binary if there is nothing to analyze.
+	command func(workDir string) (string, []string)
+	parse   func(analyzer string, workDir string, output string) ([]LintDiagnostic, error)
+	// withStderr feeds stderr to parse as well, for analyzers that report part of their findings there.
+	withStderr bool
+}
+
+var lintAnalyzers = map[string]lintAnalyzer{
+	"ruff": {
+		command: func(workDir string) (string, []string) {
+			return pythonToolPath(workDir, "ruff"), []string{"check", "--output-format=json", "--no-cache", "."}
+		},
+		parse: parseRuffOutput,
+	},
+	"pyflakes": {
+		command: func(workDir string) (string, []string) {
+			files := collectLintFiles(workDir, []string{".py"})
+			if len(files) == 0 {
+				return "", nil
+			}
+			return pythonToolPath(workDir, "pyflakes"), files
+		},
+		parse:      parsePyflakesOutput,
+		withStderr: true,
+	},
+	"mypy": {
+		command: func(workDir string) (string, []string) {
+			files := collectLintFiles(workDir, []string{".py"})
+			if len(files) == 0 {
+				return "", nil
+			}
+			args := []string{"--ignore-missing-imports", "--explicit-package-bases", "--show-column-numbers",
+				"--no-error-summary", "--no-color-output", "--no-incremental"}
+			return pythonToolPath(workDir, "mypy"), append(args, files...)
+		},
+		parse: parseMypyOutput,
+	},
+	"tsc": {
+		command: func(workDir string) (string, []string) {
+			return "npx", []string{"--no-install", "tsc", "--noEmit", "--pretty", "false"}
+		},
+		parse: parseTscOutput,
+	},
+	"eslint": {
+		command: func(workDir string) (string, []string) {
+			return "npx", []string{"--no-install", "eslint", "--format", "json", "app"}
+		},
+		parse: parseEslintOutput,
+	},
+	"go-build": {
+		command: func(workDir string) (string, []string) {
+			if _, err := os.Stat(filepath.Join(workDir, "go.mod")); err != nil {
+				return "", nil
+			}
+			return "go", []string{"build", "-o", os.DevNull, "./..."}
+		},
+		parse:      parseGoToolOutput,
+		withStderr: true,
+	},
+	"go-vet": {
+		command: func(workDir string) (string, []string) {
+			if _, err := os.Stat(filepath.Join(workDir, "go.mod")); err != nil {
+				return "", nil
+			}
+			return "go", []string{"vet", "./..."}
+		},
+		parse:      parseGoToolOutput,
+		withStderr: true,
+	},
+}
+
+// lintSkipDirectories are never handed to analyzers that take an explicit file list.
+var lintSkipDirectories = map[string]bool{
+	".venv": true, "venv": true, ".git": true, ".vscode": true, "node_modules": true,
+	"frontend": true, ".stories": true, "migrations": true, "__pycache__": true, "vendor": true,
+}
+
+func pythonToolPath(workDir string, tool string) string {
+	venvTool := filepath.Join(workDir, ".venv", "bin", tool)
+	if _, err := os.Stat(venvTool); err == nil {
+		return venvTool
+	}
+	return tool
+}
+
+func collectLintFiles(workDir string, extensions []string) []string {
+	var files []string
+	_ = filepath.Walk(workDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path != workDir && lintSkipDirectories[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		for _, ext := range extensions {
+			if strings.HasSuffix(path, ext) {
+				files = append(files, relativeLintPath(workDir, path))
+				break
+			}
+		}
+		return nil
+	})
+	return files
+}
+
+func relativeLintPath(workDir string, path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+	relativePath, err := filepath.Rel(workDir, path)
+	if err != nil {
+		return path
+	}
+	return relativePath
+}
+
+// ruffErrorCodes are the rule prefixes that indicate code which will fail at import or run time.
+var ruffErrorCodes = []string{"E9", "F63", "F7", "F82"}
+
+func parseRuffOutput(analyzer string, workDir string, output string) ([]LintDiagnostic, error) {
+	var results []struct {
+		Code     *string `json:"code"`
+		Message  string  `json:"message"`
+		Filename string  `json:"filename"`
+		Location struct {
+			Row    int `json:"row"`
+			Column int `json:"column"`
+		} `json:"location"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &results); err != nil {
+		return nil, fmt.Errorf("failed to parse ruff output: %w", err)
+	}
+	diagnostics := make([]LintDiagnostic, 0, len(results))
+	for _, result := range results {
+		code := ""
+		if result.Code != nil {
+			code = *result.Code
+		}
+		// Syntax errors are reported without a rule code.
+		severity := "warning"
+		if code == "" {
+			severity = "error"
+		}
+		for _, prefix := range ruffErrorCodes {
+			if strings.HasPrefix(code, prefix) {
+				severity = "error"
+			}
+		}
+		diagnostics = append(diagnostics, LintDiagnostic{
+			Analyzer: analyzer,
+			File:     relativeLintPath(workDir, result.Filename),
+			Line:     result.Location.Row,
+			Column:   result.Location.Column,
+			Code:     code,
+			Severity: severity,
+			Message:  result.Message,
+		})
+	}
+	return diagnostics, nil
+}
+
+var pyflakesLinePattern = regexp.MustCompile(`^(.+?):(\d+):(?:(\d+):?)? (.+)$`)
+
+// pyflakesErrorMessages are the pyflakes messages that indicate code which will fail at import or run time.
+var pyflakesErrorMessages = []string{"undefined name", "syntax", "invalid", "unexpected", "not properly in loop", "outside function"}
+
+func parsePyflakesOutput(analyzer string, workDir string, output string) ([]LintDiagnostic, error) {
+	var diagnostics []LintDiagnostic
+	for _, line := range strings.Split(output, "\n") {
+		matches := pyflakesLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		lineNumber, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+		severity := "warning"
+		for _, message := range pyflakesErrorMessages {
+			if strings.Contains(strings.ToLower(matches[4]), message) {
+				severity = "error"
+			}
+		}
+		diagnostics = append(diagnostics, LintDiagnostic{
+			Analyzer: analyzer,
+			File:     relativeLintPath(workDir, matches[1]),
+			Line:     lineNumber,
+			Column:   column,
+			Severity: severity,
+			Message:  matches[4],
+		})
+	}
+	return diagnostics, nil
+}
+
+var mypyLinePattern = regexp.MustCompile(`^(.+?):(\d+):(?:(\d+):)? (error|warning|note): (.+?)(?:  \[([a-z0-9-]+)\])?$`)
+
+// parseMypyOutput reports every finding as a warning. Generated code is mostly untyped, type errors would keep
+// code which runs fine in the retry loop.
+func parseMypyOutput(analyzer string, workDir string, output string) ([]LintDiagnostic, error) {
+	var diagnostics []LintDiagnostic
+	for _, line := range strings.Split(output, "\n") {
+		matches := mypyLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil || matches[4] == "note" {
+			continue
+		}
+		lineNumber, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+		diagnostics = append(diagnostics, LintDiagnostic{
+			Analyzer: analyzer,
+			File:     relativeLintPath(workDir, matches[1]),
+			Line:     lineNumber,
+			Column:   column,
+			Code:     matches[6],
+			Severity: "warning",
+			Message:  matches[5],
+		})
+	}
+	return diagnostics, nil
+}
+
+var tscLinePattern = regexp.MustCompile(`^(.+?)\((\d+),(\d+)\): (error|warning) (TS\d+): (.+)$`)
+
+func parseTscOutput(analyzer string, workDir string, output string) ([]LintDiagnostic, error) {
+	var diagnostics []LintDiagnostic
+	for _, line := range strings.Split(output, "\n") {
+		matches := tscLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		lineNumber, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+		diagnostics = append(diagnostics, LintDiagnostic{
+			Analyzer: analyzer,
+			File:     relativeLintPath(workDir, matches[1]),
+			Line:     lineNumber,
+			Column:   column,
+			Code:     matches[5],
+			Severity: matches[4],
+			Message:  matches[6],
+		})
+	}
+	return diagnostics, nil
+}
+
+func parseEslintOutput(analyzer string, workDir string, output string) ([]LintDiagnostic, error) {
+	var results []struct {
+		FilePath string `json:"filePath"`
+		Messages []struct {
+			RuleID   *string `json:"ruleId"`
+			Severity int     `json:"severity"` // 1 warning, 2 error
+			Message  string  `json:"message"`
+			Line     int     `json:"line"`
+			Column   int     `json:"column"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &results); err != nil {
+		return nil, fmt.Errorf("failed to parse eslint output: %w", err)
+	}
+	var diagnostics []LintDiagnostic
+	for _, result := range results {
+		for _, message := range result.Messages {
+			code := ""
+			if message.RuleID != nil {
+				code = *message.RuleID
+			}
+			severity := "warning"
+			if message.Severity == 2 {
+				severity = "error"
+			}
+			diagnostics = append(diagnostics, LintDiagnostic{
+				Analyzer: analyzer,
+				File:     relativeLintPath(workDir, result.FilePath),
+				Line:     message.Line,
+				Column:   message.Column,
+				Code:     code,
+				Severity: severity,
+				Message:  message.Message,
+			})
+		}
+	}
+	return diagnostics, nil
+}
+
+var goToolLinePattern = regexp.MustCompile(`^(?:vet: )?(.+?\.go):(\d+):(?:(\d+):)? (.+)$`)
+
+// parseGoToolOutput reads the compiler and vet findings; both block the build, so every finding is an error.
+func parseGoToolOutput(analyzer string, workDir string, output string) ([]LintDiagnostic, error) {
+	var diagnostics []LintDiagnostic
+	for _, line := range strings.Split(output, "\n") {
+		matches := goToolLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		lineNumber, _ := strconv.Atoi(matches[2])
+		column, _ := strconv.Atoi(matches[3])
+		diagnostics = append(diagnostics, LintDiagnostic{
+			Analyzer: analyzer,
+			File:     relativeLintPath(workDir, strings.TrimPrefix(matches[1], "./")),
+			Line:     lineNumber,
+			Column:   column,
+			Severity: "error",
+			Message:  matches[4],
+		})
+	}
+	return diagnostics, nil
+}
@@ -0,0 +1,172 @@
+package impl
+
+import (
+	"ai-developer/app/config"
+	"ai-developer/app/constants"
+	"ai-developer/app/services"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type LintStepExecutor struct {
+	executionStepService *services.ExecutionStepService
+	activityLogService   *services.ActivityLogService
+	logger               *zap.Logger
+}
+
+func NewLintStepExecutor(
+	executionStepService *services.ExecutionStepService,
+	activityLogService *services.ActivityLogService,
+	logger *zap.Logger,
+) *LintStepExecutor {
+	return &LintStepExecutor{
+		executionStepService: executionStepService,
+		activityLogService:   activityLogService,
+		logger:               logger.Named("LintStepExecutor"),
+	}
+}
+
+func (e LintStepExecutor) Execute(step steps.LintStep) error {
+	e.logger.Info("Running static analysis...", zap.Strings("analyzers", step.Analyzers))
+	err := e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "Running static analysis on generated code...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	workDir := step.WorkspaceDir()
+	if step.Story.Type == constants.Frontend {
+		workDir = config.FrontendWorkspacePath(step.Project.HashID, step.Story.HashID)
+	}
+
+	var diagnostics []LintDiagnostic
+	for _, name := range step.Analyzers {
+		analyzer, ok := lintAnalyzers[name]
+		if !ok {
+			e.logger.Error("Unknown analyzer", zap.String("analyzer", name))
+			return fmt.Errorf("unknown analyzer: %s", name)
+		}
+		analyzerDiagnostics, err := e.runAnalyzer(name, analyzer, workDir)
+		if err != nil {
+			e.logger.Warn("Skipping analyzer", zap.String("analyzer", name), zap.Error(err))
+			err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "WARNING", fmt.Sprintf("Skipped %s: %s", name, err.Error()))
+			if err != nil {
+				e.logger.Error("Error creating activity log", zap.Error(err))
+				return err
+			}
+			continue
+		}
+		diagnostics = append(diagnostics, analyzerDiagnostics...)
+	}
+
+	var lintErrors []LintDiagnostic
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == "error" {
+			lintErrors = append(lintErrors, diagnostic)
+		}
+	}
+
+	response := map[string]interface{}{
+		"diagnostics": diagnostics,
+		"error_count": len(lintErrors),
+	}
+	if len(lintErrors) > 0 {
+		response["error"] = formatLintDiagnostics(lintErrors)
+		if step.Story.Type == constants.Frontend {
+			// The Next.js retry generator edits one file per iteration, so hand it the first broken file.
+			fileName := lintErrors[0].File
+			var fileErrors []LintDiagnostic
+			for _, diagnostic := range lintErrors {
+				if diagnostic.File == fileName {
+					fileErrors = append(fileErrors, diagnostic)
+				}
+			}
+			response["actionType"] = "edit"
+			response["fileName"] = fileName
+			response["description"] = formatLintDiagnostics(fileErrors)
+			response["command"] = ""
+			response["cwd"] = ""
+		}
+	}
+	err = e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, response, "SUCCESS")
+	if err != nil {
+		e.logger.Error("Error updating execution step", zap.Error(err))
+		return err
+	}
+
+	if len(lintErrors) == 0 {
+		err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO",
+			fmt.Sprintf("Static analysis passed with %d warning(s).", len(diagnostics)))
+		if err != nil {
+			e.logger.Error("Error creating activity log", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+
+	err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "ERROR",
+		fmt.Sprintf("Static analysis found %d error(s):\n%s", len(lintErrors), response["error"]))
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "Static analysis failed fixing the issues...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	return fmt.Errorf("%w: %d lint error(s)", steps.ErrReiterate, len(lintErrors))
+}
+
+func (e LintStepExecutor) runAnalyzer(name string, analyzer lintAnalyzer, workDir string) ([]LintDiagnostic, error) {
+	binary, args := analyzer.command(workDir)
+	if binary == "" {
+		return nil, nil
+	}
+	if _, err := exec.LookPath(binary); err != nil {
+		return nil, fmt.Errorf("%s is not installed", binary)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = workDir
+	cmd.Env = os.Environ()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+	e.logger.Debug("Analyzer output", zap.String("analyzer", name), zap.String("stdout", stdout.String()), zap.String("stderr", stderr.String()))
+
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return nil, runErr
+	}
+	output := stdout.String()
+	if analyzer.withStderr {
+		output += "\n" + stderr.String()
+	}
+	diagnostics, err := analyzer.parse(name, workDir, output)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	// Analyzers exit non-zero when they report findings; a non-zero exit with nothing parsed means the tool itself failed.
+	if runErr != nil && len(diagnostics) == 0 {
+		return nil, fmt.Errorf("%s exited with %d: %s", name, exitErr.ExitCode(), strings.TrimSpace(stderr.String()+stdout.String()))
+	}
+	return diagnostics, nil
+}
+
+func formatLintDiagnostics(diagnostics []LintDiagnostic) string {
+	var sb strings.Builder
+	for _, diagnostic := range diagnostics {
+		sb.WriteString(diagnostic.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
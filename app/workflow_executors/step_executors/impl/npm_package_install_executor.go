@@ -0,0 +1,69 @@
+package impl
+
+import (
+	"ai-developer/app/services"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"go.uber.org/zap"
+)
+
+type NpmPackageInstallStepExecutor struct {
+	activeLogService *services.ActivityLogService
+	logger           *zap.Logger
+}
+
+func NewNpmPackageInstallStepExecutor(
+	activeLogService *services.ActivityLogService,
+	logger *zap.Logger,
+) *NpmPackageInstallStepExecutor {
+	return &NpmPackageInstallStepExecutor{
+		activeLogService: activeLogService,
+		logger:           logger.Named("NpmPackageInstallStepExecutor"),
+	}
+}
+
+func (e NpmPackageInstallStepExecutor) Execute(step steps.PackageInstallStep) error {
+	projectDir := step.WorkspaceDir()
+	packageManager := nodePackageManager(projectDir)
+	e.logger.Info("Installing Node Packages ...", zap.String("packageManager", packageManager))
+
+	err := e.activeLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", fmt.Sprintf("Installing Node Packages using %s ...", packageManager))
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	output, err := NodePackageInstall(projectDir)
+	if err != nil {
+		e.logger.Error("Error installing node packages", zap.Error(err), zap.String("output", string(output)))
+		return fmt.Errorf("%s install failed: %w: %s", packageManager, err, string(output))
+	}
+
+	err = e.activeLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "Node Packages Installed Successfully!")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	e.logger.Info("Node Packages Installed Successfully!")
+	return nil
+}
+
+// nodePackageManager picks pnpm when the project is locked with it and npm otherwise.
+func nodePackageManager(workDir string) string {
+	if _, err := os.Stat(filepath.Join(workDir, "pnpm-lock.yaml")); err == nil {
+		return "pnpm"
+	}
+	return "npm"
+}
+
+func NodePackageInstall(workDir string) ([]byte, error) {
+	cmd := exec.Command(nodePackageManager(workDir), "install")
+	cmd.Dir = workDir
+	cmd.Env = os.Environ()
+	return cmd.CombinedOutput()
+}
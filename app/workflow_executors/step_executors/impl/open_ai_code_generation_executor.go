@@ -24,6 +24,8 @@ type OpenAICodeGenerator struct {
 	pullRequestCommentService *services.PullRequestCommentsService
 	activityLogService        *services.ActivityLogService
 	llmAPIKeyService          *services.LLMAPIKeyService
+	instructionSnippetService *services.InstructionSnippetService
+	promptRegistryService     *services.PromptRegistryService
 	slackAlert                *monitoring.SlackAlert
 }
 
@@ -35,6 +37,8 @@ func NewOpenAICodeGenerator(
 	pullRequestCommentService *services.PullRequestCommentsService,
 	activityLogService *services.ActivityLogService,
 	llmAPIKeyService *services.LLMAPIKeyService,
+	instructionSnippetService *services.InstructionSnippetService,
+	promptRegistryService *services.PromptRegistryService,
 	slackAlert *monitoring.SlackAlert,
 ) *OpenAICodeGenerator {
 	return &OpenAICodeGenerator{
@@ -45,6 +49,8 @@ func NewOpenAICodeGenerator(
 		pullRequestCommentService: pullRequestCommentService,
 		activityLogService:        activityLogService,
 		llmAPIKeyService:          llmAPIKeyService,
+		instructionSnippetService: instructionSnippetService,
+		promptRegistryService:     promptRegistryService,
 		slackAlert:                slackAlert,
 	}
 
@@ -66,7 +72,7 @@ func (openAICodeGenerator OpenAICodeGenerator) Execute(step steps.GenerateCodeSt
 		fmt.Printf("Error creating activity log: %s\n", err.Error())
 		return err
 	}
-	projectDir := config.WorkspaceWorkingDirectory() + "/" + step.Project.HashID
+	projectDir := step.WorkspaceDir()
 	fmt.Println("____________Project Directory: ", projectDir)
 	fmt.Println("___________Checking for Max Retry______________")
 	count, err := openAICodeGenerator.executionStepService.CountExecutionStepsOfName(step.Execution.ID, steps.CODE_GENERATE_STEP.String())
@@ -207,7 +213,7 @@ func (openAICodeGenerator OpenAICodeGenerator) Execute(step steps.GenerateCodeSt
 	framework := project.BackendFramework
 	fmt.Println("_________FRAMEWORK_________", framework)
 	// Generate code using the final instruction
-	code, err := openAICodeGenerator.GenerateCode(apiKey, framework, finalInstructionForGeneration, step.ExecutionStep, projectDir, step)
+	code, usage, err := openAICodeGenerator.GenerateCode(apiKey, framework, finalInstructionForGeneration, step.ExecutionStep, projectDir, step)
 	if err != nil {
 		fmt.Printf("Error generating code: %s\n", err.Error())
 		return err
@@ -219,6 +225,7 @@ func (openAICodeGenerator OpenAICodeGenerator) Execute(step steps.GenerateCodeSt
 		step.ExecutionStep,
 		map[string]interface{}{
 			"llm_response": code,
+			"llm_usage":    usage,
 		},
 		"SUCCESS"); err != nil {
 		fmt.Printf("Error updating execution step: %s\n", err.Error())
@@ -240,18 +247,23 @@ func (openAICodeGenerator OpenAICodeGenerator) Execute(step steps.GenerateCodeSt
 }
 
 // GenerateCode uses OpenAI API to generate code based on the instruction.
-func (openAICodeGenerator *OpenAICodeGenerator) GenerateCode(apiKey string, framework string, instruction string, executionStep *models.ExecutionStep, projectDir string, step steps.GenerateCodeStep) (string, error) {
-	messages := openAICodeGenerator.generateMessages(framework, instruction, executionStep.ExecutionID, projectDir)
-	err := openAICodeGenerator.executionStepService.UpdateExecutionStepRequest(
+func (openAICodeGenerator *OpenAICodeGenerator) GenerateCode(apiKey string, framework string, instruction string, executionStep *models.ExecutionStep, projectDir string, step steps.GenerateCodeStep) (string, *llms.OpenAiUsage, error) {
+	messages, prompt, err := openAICodeGenerator.generateMessages(step, framework, instruction, executionStep.ExecutionID, projectDir)
+	if err != nil {
+		fmt.Printf("Error generating messages: %s\n", err.Error())
+		return "", nil, err
+	}
+	err = openAICodeGenerator.executionStepService.UpdateExecutionStepRequest(
 		executionStep,
 		map[string]interface{}{
 			"final_instruction": instruction,
 			"llm_request":       messages,
+			"prompt":            prompt,
 		},
 		"IN_PROGRESS",
 	)
 	openAIClient := llms.NewOpenAiClient(apiKey)
-	response, err := openAIClient.ChatCompletion(messages)
+	response, usage, err := openAIClient.ChatCompletionWithUsage(messages)
 	if err != nil {
 		settingsUrl := config.Get("app.url").(string) + "/settings"
 		err := openAICodeGenerator.activityLogService.CreateActivityLog(
@@ -262,23 +274,23 @@ func (openAICodeGenerator *OpenAICodeGenerator) GenerateCode(apiKey string, fram
 		)
 		if err != nil {
 			fmt.Printf("Error creating activity log: %s\n", err.Error())
-			return "", err
+			return "", nil, err
 		}
 		//Update Execution Status and Story Status
 		if err := openAICodeGenerator.storyService.UpdateStoryStatus(int(step.Story.ID), constants.InReviewLLMKeyNotFound); err != nil {
 			fmt.Printf("Error updating story status: %s\n", err.Error())
-			return "", err
+			return "", nil, err
 		}
 		//Update execution status to MAX_LOOP_ITERATION_REACHED
 		if err := openAICodeGenerator.executionService.UpdateExecutionStatus(step.Execution.ID, constants.InReviewLLMKeyNotFound); err != nil {
 			fmt.Printf("Error updating execution step: %s\n", err.Error())
-			return "", err
+			return "", nil, err
 		}
 		//Add all code to stage
 		output, err := utils.GitAddToTrackFiles(projectDir, nil)
 		if err != nil {
 			fmt.Printf("Error adding files to track: %s\n", err.Error())
-			return "", err
+			return "", nil, err
 		}
 		fmt.Printf("Git add output: %s\n", output)
 		//Handle workspace clean up by commiting could be stashing or other ways later
@@ -290,20 +302,24 @@ func (openAICodeGenerator *OpenAICodeGenerator) GenerateCode(apiKey string, fram
 		fmt.Printf("Git commit output: %s\n", output)
 		if err != nil {
 			fmt.Printf("Error commiting code: %s\n", err.Error())
-			return "", err
+			return "", nil, err
 		}
-		return "", fmt.Errorf("failed to generate code from OpenAI API: %w", err)
+		return "", nil, fmt.Errorf("failed to generate code from OpenAI API: %w", err)
 	}
-	return response, nil
+	return response, usage, nil
 }
 
-func (openAICodeGenerator *OpenAICodeGenerator) generateMessages(framework string, instruction string, executionId uint, projectDir string) []llms.OpenAiChatCompletionMessage {
-	inputContext, err := openAICodeGenerator.createInputContext(projectDir)
+func (openAICodeGenerator *OpenAICodeGenerator) generateMessages(step steps.GenerateCodeStep, framework string, instruction string, executionId uint, projectDir string) ([]llms.OpenAiChatCompletionMessage, services.PromptRef, error) {
+	systemPrompt, prompt, err := openAICodeGenerator.getSystemPrompt(step, framework, projectDir)
+	if err != nil {
+		return nil, prompt, err
+	}
+	inputContext, err := openAICodeGenerator.createInputContext(framework, projectDir)
 	if err != nil {
 		fmt.Printf("Failed to create input context: %v\n", err)
 	}
 	messages := []llms.OpenAiChatCompletionMessage{
-		{Role: "system", Content: openAICodeGenerator.getSystemPrompt(framework, projectDir)},
+		{Role: "system", Content: systemPrompt},
 		{Role: "user", Content: "The current codebase is:\n" + inputContext},
 		{Role: "user", Content: instruction},
 	}
@@ -329,12 +345,12 @@ func (openAICodeGenerator *OpenAICodeGenerator) generateMessages(framework strin
 		}
 	}
 
-	return messages
+	return messages, prompt, nil
 }
 
-func (openAICodeGenerator *OpenAICodeGenerator) createInputContext(projectDir string) (string, error) {
+func (openAICodeGenerator *OpenAICodeGenerator) createInputContext(framework string, projectDir string) (string, error) {
 	outputFile := projectDir + "/input_context.txt"
-	allowedExtensions := []string{".py", ".html", ".css", ".txt", ".ini", ".jpg", ".png"}
+	allowedExtensions := openAICodeGenerator.getInputContextExtensions(framework)
 	if err := openAICodeGenerator.ensureDirectoryExists(projectDir); err != nil {
 		return "", err
 	}
@@ -366,10 +382,15 @@ func (openAICodeGenerator *OpenAICodeGenerator) generateFileListForInputContext(
 			return err
 		}
 		// Skip .venv directory and any other directories you want to exclude
-		if info.IsDir() && (info.Name() == ".venv" || info.Name() == ".vscode" || info.Name() == "venv" || info.Name() == "frontend" || info.Name() == ".stories") {
+		if info.IsDir() && (info.Name() == ".venv" || info.Name() == ".vscode" || info.Name() == "venv" || info.Name() == "frontend" || info.Name() == ".stories" ||
+			info.Name() == "node_modules" || info.Name() == "dist" || info.Name() == "vendor") {
 			fmt.Printf("Skipping directory: %s\n", path)
 			return filepath.SkipDir
 		}
+		// Lockfiles are generated and too large to be useful as context
+		if !info.IsDir() && (info.Name() == "package-lock.json" || info.Name() == "pnpm-lock.yaml") {
+			return nil
+		}
 
 		if !info.IsDir() && openAICodeGenerator.fileExtensionAllowed(path, allowedExtensions) {
 			if err := openAICodeGenerator.writeFileContent(path, directory, outFile); err != nil {
@@ -381,6 +402,17 @@ func (openAICodeGenerator *OpenAICodeGenerator) generateFileListForInputContext(
 	})
 }
 
+func (openAICodeGenerator *OpenAICodeGenerator) getInputContextExtensions(framework string) []string {
+	switch framework {
+	case "express":
+		return []string{".ts", ".js", ".json", ".txt", ".html", ".css", ".jpg", ".png"}
+	case "go":
+		return []string{".go", "go.mod", ".sql", ".txt", ".html", ".css", ".jpg", ".png"}
+	default:
+		return []string{".py", ".html", ".css", ".txt", ".ini", ".jpg", ".png"}
+	}
+}
+
 func (openAICodeGenerator *OpenAICodeGenerator) fileExtensionAllowed(file string, allowedExtensions []string) bool {
 	for _, ext := range allowedExtensions {
 		if strings.HasSuffix(file, ext) {
@@ -411,25 +443,36 @@ func (openAICodeGenerator *OpenAICodeGenerator) writeFileContent(filePath string
 	return err
 }
 
-func (openAICodeGenerator *OpenAICodeGenerator) getSystemPrompt(framework string, projectDir string) string {
-	var filePath string
-	switch framework {
-	case "flask":
-		filePath = "/go/prompts/python/ai_developer_flask.txt"
-	case "django":
-		filePath = "/go/prompts/python/ai_developer_django.txt"
-	default:
-		filePath = ""
+// getSystemPrompt returns the system prompt for the execution, the one of the project when it overrides the
+// prompt of the framework and else the version of the prompt registry the execution is assigned to, with the
+// coding conventions of the project appended.
+func (openAICodeGenerator *OpenAICodeGenerator) getSystemPrompt(step steps.GenerateCodeStep, framework string, projectDir string) (string, services.PromptRef, error) {
+	project := step.Project
+	promptKey, ok := constants.BackendFrameworkPrompts[framework]
+	prompt := services.PromptRef{Key: promptKey}
+	conventions, err := utils.ProjectConventions(project, projectDir)
+	if err != nil {
+		fmt.Printf("Error reading coding conventions: %s\n", err.Error())
+	}
+	variables := map[string]string{
+		"{project_workspace_id}": projectDir,
+		"{project_name}":         project.Name,
+		"{framework}":            framework,
+	}
+	if project.BackendSystemPrompt != "" {
+		prompt.ProjectOverride = true
+		return utils.WithConventions(utils.SubstitutePromptVariables(project.BackendSystemPrompt, variables), conventions), prompt, nil
+	}
+	if !ok {
+		return "", prompt, fmt.Errorf("no system prompt for framework %s", framework)
 	}
 
-	fmt.Println("______FILEPATH: _______" + filePath)
-	content, err := os.ReadFile(filePath)
+	content, prompt, err := openAICodeGenerator.promptRegistryService.ResolvePrompt(project.OrganisationID, step.Execution.ID, promptKey)
 	if err != nil {
-		panic(fmt.Sprintf("failed to read system prompt from %s: %v", filePath, err))
+		return "", prompt, fmt.Errorf("failed to resolve system prompt %s: %w", promptKey, err)
 	}
-
-	modifiedContent := strings.Replace(string(content), "{project_workspace_id}", projectDir, -1)
-	return modifiedContent
+	fmt.Printf("Using version %d of system prompt %s\n", prompt.Version, promptKey)
+	return utils.WithConventions(utils.SubstitutePromptVariables(content, variables), conventions), prompt, nil
 }
 
 func (openAICodeGenerator *OpenAICodeGenerator) ensureDirectoryExists(dirPath string) error {
@@ -465,16 +508,97 @@ func (openAICodeGenerator *OpenAICodeGenerator) buildFinalInstructionForGenerati
 func (openAICodeGenerator *OpenAICodeGenerator) buildInstructionOnReExecutionWithComments(step steps.GenerateCodeStep) (string, error) {
 	fmt.Printf("Building instruction on re-execution with comments for step: %s\n", step.StepName())
 	fmt.Printf("Pull Request ID is %d\n", step.PullRequestID)
-	comments, err := openAICodeGenerator.pullRequestCommentService.GetAllCommentsByPullRequestID(step.PullRequestID)
+	comments, err := openAICodeGenerator.pullRequestCommentService.GetUnresolvedComments(step.PullRequestID)
 	if err != nil {
 		fmt.Printf("Error fetching comments: %s\n", err.Error())
 		return "", err
 	}
-	finalInstruction := ""
-	if len(comments) > 0 {
-		finalInstruction = comments[len(comments)-1].Comment
+	if len(comments) == 0 {
+		return "", nil
 	}
-	return finalInstruction, nil
+	// The comments are resolved against this execution once its changes are pushed.
+	err = openAICodeGenerator.pullRequestCommentService.AssignCommentsToExecution(comments, step.Execution.ID)
+	if err != nil {
+		fmt.Printf("Error assigning comments to execution: %s\n", err.Error())
+		return "", err
+	}
+	if len(comments) == 1 && comments[0].Path == "" {
+		return comments[0].Comment, nil
+	}
+
+	projectDir := step.WorkspaceDir()
+	var sb strings.Builder
+	sb.WriteString("Address all of the following review comments:\n")
+	for _, group := range utils.GroupCommentsByFile(comments) {
+		if group.Path == "" {
+			sb.WriteString("\nGeneral comments:\n")
+			for _, comment := range group.Comments {
+				sb.WriteString(fmt.Sprintf("- %s\n", comment.Comment))
+			}
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\nComments on %s:\n", group.Path))
+		for _, comment := range group.Comments {
+			if comment.Line > 0 {
+				sb.WriteString(fmt.Sprintf("- Line %d: %s\n", comment.Line, comment.Comment))
+			} else {
+				sb.WriteString(fmt.Sprintf("- %s\n", comment.Comment))
+			}
+		}
+		hunk, err := readFileHunk(filepath.Join(projectDir, group.Path), commentLines(group.Comments))
+		if err != nil {
+			fmt.Printf("Error reading %s for comments: %s\n", group.Path, err.Error())
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("Current code of %s:\n```\n%s```\n", group.Path, hunk))
+	}
+	return sb.String(), nil
+}
+
+func commentLines(comments []models.PullRequestComments) []int {
+	var lines []int
+	for _, comment := range comments {
+		if comment.Line > 0 {
+			lines = append(lines, comment.Line)
+		}
+	}
+	return lines
+}
+
+// commentHunkContext is the number of lines shown around the lines review comments are anchored to.
+const commentHunkContext = 10
+
+// readFileHunk returns the numbered lines of the file around the given lines, the whole file when none are given.
+// Overlapping hunks are merged and gaps are marked with "...".
+func readFileHunk(path string, lines []int) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	fileLines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	show := make([]bool, len(fileLines)+1)
+	for number := 1; number <= len(fileLines); number++ {
+		show[number] = len(lines) == 0
+	}
+	for _, line := range lines {
+		for number := max(1, line-commentHunkContext); number <= min(len(fileLines), line+commentHunkContext); number++ {
+			show[number] = true
+		}
+	}
+	var sb strings.Builder
+	gap := false
+	for number := 1; number <= len(fileLines); number++ {
+		if !show[number] {
+			gap = true
+			continue
+		}
+		if gap && sb.Len() > 0 {
+			sb.WriteString("...\n")
+		}
+		gap = false
+		sb.WriteString(fmt.Sprintf("%d: %s\n", number, fileLines[number-1]))
+	}
+	return sb.String(), nil
 }
 
 func (openAICodeGenerator *OpenAICodeGenerator) buildInstructionForFirstExecution(step steps.GenerateCodeStep) (string, error) {
@@ -488,6 +612,11 @@ func (openAICodeGenerator *OpenAICodeGenerator) buildInstructionForFirstExecutio
 		fmt.Printf("Error fetching test cases: %s\n", err.Error())
 		return "", err
 	}
+	snippets, err := openAICodeGenerator.instructionSnippetService.GetActiveSnippetContents(step.Story.ProjectID)
+	if err != nil {
+		fmt.Printf("Error fetching instruction snippets: %s\n", err.Error())
+		return "", err
+	}
 
 	fmt.Printf("Building instruction for first execution\n")
 	var sb strings.Builder
@@ -497,6 +626,10 @@ func (openAICodeGenerator *OpenAICodeGenerator) buildInstructionForFirstExecutio
 	for _, instruction := range instructions {
 		sb.WriteString(instruction.Instruction + " ")
 	}
+	// The instruction snippets of the organisation, e.g. its coding standards, apply to every story.
+	for _, snippet := range snippets {
+		sb.WriteString(snippet + " ")
+	}
 	sb.WriteString("\n")
 	sb.WriteString("Test cases: ")
 	for _, testCase := range testCases {
@@ -508,19 +641,14 @@ func (openAICodeGenerator *OpenAICodeGenerator) buildInstructionForFirstExecutio
 
 func (openAICodeGenerator *OpenAICodeGenerator) buildInstructionOnRetry(step steps.GenerateCodeStep) (string, error) {
 	fmt.Printf("Building instruction on retry for step: %s\n", step.StepName())
-	previousServerTestExecutionStep, err := openAICodeGenerator.executionStepService.FetchExecutionSteps(
+	previousTestExecutionStep, err := openAICodeGenerator.executionStepService.FetchLatestExecutionStepOfNames(
 		step.Execution.ID,
-		steps.SERVER_START_STEP.String(),
-		steps.CODE_TEST.String(),
-		1,
+		[]string{steps.SERVER_START_STEP.String(), steps.LINT_STEP.String(), steps.TEST_STEP.String(), steps.SECURITY_SCAN_STEP.String()},
 	)
 	if err != nil {
-		fmt.Printf("Error fetching previous server test execution step: %s\n", err.Error())
+		fmt.Printf("Error fetching previous test execution step: %s\n", err.Error())
 		return "", err
 	}
-	finalInstruction := ""
-	if len(previousServerTestExecutionStep) > 0 {
-		finalInstruction = previousServerTestExecutionStep[0].Response["error"].(string)
-	}
+	finalInstruction, _ := previousTestExecutionStep.Response["error"].(string)
 	return finalInstruction, nil
 }
\ No newline at end of file
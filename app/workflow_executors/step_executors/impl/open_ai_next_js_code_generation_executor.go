@@ -28,6 +28,7 @@ type OpenAiNextJsCodeGenerator struct {
 	designReviewService  *services.DesignStoryReviewService
 	s3Service            *s3_providers.S3Service
 	llmAPIKeyService     *services.LLMAPIKeyService
+	promptRegistryService *services.PromptRegistryService
 	logger         		 *zap.Logger
 }
 
@@ -40,6 +41,7 @@ func NewOpenAINextJsCodeGenerationExecutor(
 	designReviewService *services.DesignStoryReviewService,
 	s3Service *s3_providers.S3Service,
 	llmAPIKeyService *services.LLMAPIKeyService,
+	promptRegistryService *services.PromptRegistryService,
 	logger *zap.Logger,
 ) *OpenAiNextJsCodeGenerator {
 	return &OpenAiNextJsCodeGenerator{
@@ -51,6 +53,7 @@ func NewOpenAINextJsCodeGenerationExecutor(
 		designReviewService:  designReviewService,
 		s3Service:            s3Service,
 		llmAPIKeyService:     llmAPIKeyService,
+		promptRegistryService: promptRegistryService,
 		logger:               logger,
 	}
 }
@@ -304,19 +307,17 @@ func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) getFilesContent(folderPath
 
 func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) buildInstructionOnRetry(step steps.GenerateCodeStep, storyDir string) (map[string]string, error) {
 	fmt.Printf("Building instruction on retry for step: %s\n", step.StepName())
-	previousServerTestExecutionStep, err := openAiCodeGenerator.executionStepService.FetchExecutionSteps(
+	previousTestExecutionStep, err := openAiCodeGenerator.executionStepService.FetchLatestExecutionStepOfNames(
 		step.Execution.ID,
-		steps.SERVER_START_STEP.String(),
-		steps.CODE_TEST.String(),
-		1,
+		[]string{steps.SERVER_START_STEP.String(), steps.LINT_STEP.String()},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	fmt.Println("---Response from GPT in case of NPM Build Failure---", previousServerTestExecutionStep[0].Response)
+	fmt.Println("---Response from GPT in case of NPM Build Failure---", previousTestExecutionStep.Response)
 
-	fileName := previousServerTestExecutionStep[0].Response["fileName"].(string)
+	fileName := previousTestExecutionStep.Response["fileName"].(string)
 
 	err = openAiCodeGenerator.activityLogService.CreateActivityLog(
 		step.Execution.ID,
@@ -340,11 +341,11 @@ func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) buildInstructionOnRetry(st
 	}
 
 	return map[string]string{
-		"actionType":   previousServerTestExecutionStep[0].Response["actionType"].(string),
+		"actionType":   previousTestExecutionStep.Response["actionType"].(string),
 		"fileName":     fileName,
-		"command":      previousServerTestExecutionStep[0].Response["command"].(string),
-		"cwd":          previousServerTestExecutionStep[0].Response["cwd"].(string),
-		"description":  previousServerTestExecutionStep[0].Response["description"].(string),
+		"command":      previousTestExecutionStep.Response["command"].(string),
+		"cwd":          previousTestExecutionStep.Response["cwd"].(string),
+		"description":  previousTestExecutionStep.Response["description"].(string),
 		"existingCode": string(code),
 	}, nil
 }
@@ -386,15 +387,20 @@ func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) buildInstructionOnReExecut
 }
 
 func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GenerateCode(step steps.GenerateCodeStep, instruction map[string]string, storyDir string, apiKey string) (string, error) {
+	conventions, err := utils.ProjectConventions(step.Project, storyDir)
+	if err != nil {
+		fmt.Printf("Error reading coding conventions: %s\n", err.Error())
+	}
+	instruction["conventions"] = conventions
 	if step.Retry {
-		response, err := openAiCodeGenerator.GenerateCodeOnRetry(step.ExecutionStep, instruction, storyDir, apiKey)
+		response, err := openAiCodeGenerator.GenerateCodeOnRetry(step.ExecutionStep, step.Project, instruction, storyDir, apiKey)
 		if err != nil {
 			fmt.Println("Error generating code on retry")
 			return "", err
 		}
 		return response, nil
 	} else {
-		messages, err := openAiCodeGenerator.GenerateMessages(instruction, storyDir, step)
+		messages, prompt, err := openAiCodeGenerator.GenerateMessages(instruction, storyDir, step)
 		if err != nil {
 			return "", err
 		}
@@ -403,6 +409,7 @@ func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GenerateCode(step steps.Ge
 			map[string]interface{}{
 				"final_instruction": instruction,
 				"llm_request":       messages,
+				"prompt":            prompt,
 			},
 			"IN_PROGRESS",
 		)
@@ -430,7 +437,7 @@ func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) ProcessMessageResponse(mes
 
 }
 
-func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GenerateCodeOnRetry(executionStep *models.ExecutionStep, instruction map[string]string, storyDir string, apiKey string) (string, error) {
+func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GenerateCodeOnRetry(executionStep *models.ExecutionStep, project *models.Project, instruction map[string]string, storyDir string, apiKey string) (string, error) {
 	switch instruction["actionType"] {
 	case "create":
 		filePath := storyDir + instruction["fileName"]
@@ -458,7 +465,7 @@ func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GenerateCodeOnRetry(execut
 		}
 		return "", nil
 	case "edit":
-		response, err := openAiCodeGenerator.EditCodeOnRetry(instruction, storyDir, executionStep, apiKey)
+		response, err := openAiCodeGenerator.EditCodeOnRetry(instruction, storyDir, executionStep, project, apiKey)
 		if err != nil {
 			return "", err
 		}
@@ -469,12 +476,12 @@ func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GenerateCodeOnRetry(execut
 	}
 }
 
-func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) EditCodeOnRetry(instruction map[string]string, storyDir string, executionStep *models.ExecutionStep, apiKey string) (string, error) {
+func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) EditCodeOnRetry(instruction map[string]string, storyDir string, executionStep *models.ExecutionStep, project *models.Project, apiKey string) (string, error) {
 	generationPlan, err := openAiCodeGenerator.GetCodeGenerationPlan(storyDir)
 	if err != nil {
 		return "", err
 	}
-	systemPrompt, err := openAiCodeGenerator.GetRetrySystemPrompt(instruction, generationPlan)
+	systemPrompt, prompt, err := openAiCodeGenerator.GetRetrySystemPrompt(instruction, generationPlan, project.OrganisationID, executionStep.ExecutionID)
 	if err != nil {
 		return "", err
 	}
@@ -484,6 +491,7 @@ func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) EditCodeOnRetry(instructio
 		map[string]interface{}{
 			"final_instruction": instruction,
 			"llm_request":       messages,
+			"prompt":            prompt,
 		},
 		"IN_PROGRESS",
 	)
@@ -495,17 +503,17 @@ func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) EditCodeOnRetry(instructio
 	return response, nil
 }
 
-func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GenerateMessages(instruction map[string]string, storyDir string, step steps.GenerateCodeStep) ([]llms.ClaudeChatCompletionMessage, error) {
+func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GenerateMessages(instruction map[string]string, storyDir string, step steps.GenerateCodeStep) ([]llms.ClaudeChatCompletionMessage, services.PromptRef, error) {
 	generationPlan, err := openAiCodeGenerator.GetCodeGenerationPlan(storyDir)
 	if err != nil {
-		return nil, err
+		return nil, services.PromptRef{}, err
 	}
-	systemPrompt, err := openAiCodeGenerator.getSystemPrompt(instruction, step)
+	systemPrompt, prompt, err := openAiCodeGenerator.getSystemPrompt(instruction, step)
 	if err != nil {
-		return nil, err
+		return nil, prompt, err
 	}
 	messages := openAiCodeGenerator.GetMessages(systemPrompt, instruction, generationPlan)
-	return messages, nil
+	return messages, prompt, nil
 }
 
 func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GetMessages(systemPrompt string, instruction map[string]string, generationPlan string) []llms.ClaudeChatCompletionMessage {
@@ -566,28 +574,36 @@ func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GetMessagesOnRetry(systemP
 	return messages
 }
 
-func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) getSystemPrompt(instruction map[string]string, step steps.GenerateCodeStep) (string, error) {
-	content, err := os.ReadFile("/go/prompts/nextjs/ai_frontend_developer.txt")
-	if err != nil {
-		panic(fmt.Sprintf("failed to read system prompt: %v", err))
+// getSystemPrompt returns the system prompt of the project when it overrides the built-in one, and else the
+// version of the prompt registry the execution is assigned to.
+func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) getSystemPrompt(instruction map[string]string, step steps.GenerateCodeStep) (string, services.PromptRef, error) {
+	prompt := services.PromptRef{Key: constants.PromptNextJs, ProjectOverride: step.Project.FrontendSystemPrompt != ""}
+	content := step.Project.FrontendSystemPrompt
+	if content == "" {
+		var err error
+		content, prompt, err = openAiCodeGenerator.promptRegistryService.ResolvePrompt(step.Project.OrganisationID, step.Execution.ID, constants.PromptNextJs)
+		if err != nil {
+			return "", prompt, fmt.Errorf("failed to resolve system prompt %s: %w", constants.PromptNextJs, err)
+		}
 	}
-	systemPrompt := strings.Replace(string(content), "{{EXISTING_CODE}}", instruction["existingCode"], -1)
-	systemPrompt = strings.Replace(string(systemPrompt), "{{USER_FEEDBACK}}", instruction["feedback"], -1)
-	systemPrompt = strings.Replace(string(systemPrompt), "{{FILE_NAME}}", step.File, -1)
-	return systemPrompt, nil
+	systemPrompt := utils.SubstitutePromptVariables(content, map[string]string{
+		"{{EXISTING_CODE}}": instruction["existingCode"],
+		"{{USER_FEEDBACK}}": instruction["feedback"],
+		"{{FILE_NAME}}":     step.File,
+	})
+	return utils.WithConventions(systemPrompt, instruction["conventions"]), prompt, nil
 }
 
-func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GetRetrySystemPrompt(instruction map[string]string, directoryStructure string) (string, error) {
-	content, err := os.ReadFile("/go/prompts/nextjs/ai_frontend_developer_edit_code.txt")
+func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GetRetrySystemPrompt(instruction map[string]string, directoryStructure string, organisationID uint, executionID uint) (string, services.PromptRef, error) {
+	content, prompt, err := openAiCodeGenerator.promptRegistryService.ResolvePrompt(organisationID, executionID, constants.PromptNextJsEditCode)
 	if err != nil {
-		panic(fmt.Sprintf("failed to read system prompt: %v", err))
-		return "", err
+		return "", prompt, fmt.Errorf("failed to resolve system prompt %s: %w", constants.PromptNextJsEditCode, err)
 	}
-	modifiedContent := strings.Replace(string(content), "{{FILE_NAME}}", instruction["fileName"], -1)
+	modifiedContent := strings.Replace(content, "{{FILE_NAME}}", instruction["fileName"], -1)
 	modifiedContent = strings.Replace(string(modifiedContent), "{{ERROR_DESCRIPTION}}", instruction["description"], -1)
 	modifiedContent = strings.Replace(string(modifiedContent), "{{DIRECTORY_STRUCTURE}}", directoryStructure, -1)
 	modifiedContent = strings.Replace(string(modifiedContent), "{{CURRENT_CODE}}", instruction["existingCode"], -1)
-	return modifiedContent, nil
+	return utils.WithConventions(modifiedContent, instruction["conventions"]), prompt, nil
 }
 
 func (openAiCodeGenerator *OpenAiNextJsCodeGenerator) GetCodeGenerationPlan(storyDir string) (string, error) {
@@ -9,6 +9,14 @@ import (
 	"strings"
 )
 
+// codeFenceLines are the markdown fences the LLM wraps generated files in, they are dropped when writing the file.
+var codeFenceLines = map[string]bool{
+	"```": true, "```shell": true, "```plaintext": true, "```bash": true, "```terminal": true, "```python": true,
+	"```css": true, "```html": true, "```javascript": true, "```ini": true,
+	"```typescript": true, "```ts": true, "```js": true, "```json": true,
+	"```go": true, "```sql": true,
+}
+
 type UpdateCodeFileExecutor struct {
 	executionStepService *services.ExecutionStepService
 	activityLogService   *services.ActivityLogService
@@ -82,7 +90,7 @@ func (e UpdateCodeFileExecutor) Execute(step steps.UpdateCodeFileStep) error {
 		} else if strings.HasPrefix(line, "|code|") || strings.HasPrefix(line, "|terminal|") {
 			isCode = true
 		} else if isCode {
-			if strings.TrimSpace(line) == "```" || strings.TrimSpace(line) == "```shell" || strings.TrimSpace(line) == "```plaintext" || strings.TrimSpace(line) == "```bash" || strings.TrimSpace(line) == "```terminal" || strings.TrimSpace(line) == "```python" || strings.TrimSpace(line) == "```css" || strings.TrimSpace(line) == "```html" || strings.TrimSpace(line) == "```javascript" || strings.TrimSpace(line) == "```ini" {
+			if codeFenceLines[strings.TrimSpace(line)] {
 				continue
 			}
 			currentContent = append(currentContent, line)
@@ -1,7 +1,6 @@
 package impl
 
 import (
-	"ai-developer/app/config"
 	"ai-developer/app/services"
 	"ai-developer/app/workflow_executors/step_executors/steps"
 	"go.uber.org/zap"
@@ -32,7 +31,7 @@ func (e PackageInstallStepExecutor) Execute(step steps.PackageInstallStep) error
 		return err
 	}
 
-	projectDir := config.WorkspaceWorkingDirectory() + "/" + step.Project.HashID
+	projectDir := step.WorkspaceDir()
 
 	err = e.PoetryInstall(projectDir, err)
 	if err != nil {
@@ -0,0 +1,88 @@
+package impl
+
+import (
+	"ai-developer/app/services"
+	"ai-developer/app/utils"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+)
+
+// ResetAlembicDBStepExecutor recreates the sqlite database of a plain SQLAlchemy project from its models using alembic.
+type ResetAlembicDBStepExecutor struct {
+	activeLogService *services.ActivityLogService
+	logger           *zap.Logger
+}
+
+func NewResetAlembicDBStepExecutor(
+	activeLogService *services.ActivityLogService,
+	logger *zap.Logger,
+) *ResetAlembicDBStepExecutor {
+	return &ResetAlembicDBStepExecutor{
+		activeLogService: activeLogService,
+		logger:           logger.Named("ResetAlembicDBStepExecutor"),
+	}
+}
+
+func (e ResetAlembicDBStepExecutor) Execute(step steps.ResetDBStep) error {
+	e.logger.Info("Resetting Alembic DB...")
+
+	err := e.activeLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "Resetting DB...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	projectDir := step.WorkspaceDir()
+
+	// Remove the database and the generated revisions, the models are the source of truth
+	if err := e.removeFile(filepath.Join(projectDir, "app.db")); err != nil {
+		e.logger.Error("Error removing database", zap.Error(err))
+		return err
+	}
+	revisions, err := filepath.Glob(filepath.Join(projectDir, "alembic", "versions", "*.py"))
+	if err != nil {
+		e.logger.Error("Error listing alembic revisions", zap.Error(err))
+		return err
+	}
+	for _, revision := range revisions {
+		if err := e.removeFile(revision); err != nil {
+			e.logger.Error("Error removing alembic revision", zap.Error(err))
+			return err
+		}
+	}
+
+	if _, err := ensureVenv(projectDir, filepath.Join(projectDir, ".venv")); err != nil {
+		e.logger.Error("Error ensuring virtual environment", zap.Error(err))
+		return err
+	}
+
+	pythonPath := filepath.Join(projectDir, ".venv", "bin", "python")
+	if err := utils.RunCommand(pythonPath, projectDir, "-m", "alembic", "revision", "--autogenerate", "-m", "latest_migration"); err != nil {
+		e.logger.Error("Error generating alembic revision", zap.Error(err))
+		return err
+	}
+	if err := utils.RunCommand(pythonPath, projectDir, "-m", "alembic", "upgrade", "head"); err != nil {
+		e.logger.Error("Error running alembic upgrade", zap.Error(err))
+		return err
+	}
+
+	err = e.activeLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "DB reset successfully.")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	e.logger.Info("Alembic DB reset successfully!")
+	return nil
+}
+
+func (e ResetAlembicDBStepExecutor) removeFile(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		e.logger.Error("Error removing file", zap.String("path", path), zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -1,7 +1,6 @@
 package impl
 
 import (
-	"ai-developer/app/config"
 	"ai-developer/app/utils"
 	"fmt"
 	"go.uber.org/zap"
@@ -37,7 +36,7 @@ func (e ResetFlaskDBStepExecutor) Execute(step steps.ResetDBStep) error {
 		return err
 	}
 
-	projectDir := config.WorkspaceWorkingDirectory() + "/" + step.Project.HashID
+	projectDir := step.WorkspaceDir()
 
 	// Remove instance and migrations directories if they exist
 	if err := e.removeDir(projectDir + "/instance"); err != nil {
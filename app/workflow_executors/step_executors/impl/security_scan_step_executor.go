@@ -0,0 +1,212 @@
+package impl
+
+import (
+	"ai-developer/app/config"
+	"ai-developer/app/constants"
+	"ai-developer/app/services"
+	"ai-developer/app/services/git_providers"
+	"ai-developer/app/utils"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type SecurityScanStepExecutor struct {
+	executionStepService *services.ExecutionStepService
+	activityLogService   *services.ActivityLogService
+	organisationService  *services.OrganisationService
+	gitProviderResolver  *git_providers.GitProviderResolver
+	logger               *zap.Logger
+}
+
+func NewSecurityScanStepExecutor(
+	executionStepService *services.ExecutionStepService,
+	activityLogService *services.ActivityLogService,
+	organisationService *services.OrganisationService,
+	gitProviderResolver *git_providers.GitProviderResolver,
+	logger *zap.Logger,
+) *SecurityScanStepExecutor {
+	return &SecurityScanStepExecutor{
+		executionStepService: executionStepService,
+		activityLogService:   activityLogService,
+		organisationService:  organisationService,
+		gitProviderResolver:  gitProviderResolver,
+		logger:               logger.Named("SecurityScanStepExecutor"),
+	}
+}
+
+func (e SecurityScanStepExecutor) Execute(step steps.SecurityScanStep) error {
+	e.logger.Info("Running security scan...", zap.Strings("scanners", step.Scanners))
+	err := e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "Running security scan on generated code...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	// Frontend stories are generated outside the git worktree, there is no base branch to compare them with.
+	workDir := step.WorkspaceDir()
+	baseCommit := ""
+	if step.Story.Type == constants.Frontend {
+		workDir = config.FrontendWorkspacePath(step.Project.HashID, step.Story.HashID)
+	} else {
+		baseCommit, err = e.baseCommit(step)
+		if err != nil {
+			e.logger.Error("Error resolving base branch", zap.Error(err))
+			return err
+		}
+	}
+
+	var findings []SecurityFinding
+	for _, name := range step.Scanners {
+		scanner, ok := securityScanners[name]
+		if !ok {
+			e.logger.Error("Unknown security scanner", zap.String("scanner", name))
+			return fmt.Errorf("unknown security scanner: %s", name)
+		}
+		scannerFindings, scanErr := scanner(name, workDir, baseCommit)
+		if errors.Is(scanErr, errScannerUnavailable) {
+			e.logger.Warn("Skipping security scanner", zap.String("scanner", name), zap.Error(scanErr))
+			err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "WARNING", fmt.Sprintf("Skipped %s: %s", name, scanErr.Error()))
+			if err != nil {
+				e.logger.Error("Error creating activity log", zap.Error(err))
+				return err
+			}
+			continue
+		}
+		// A scanner which ran but failed could hide findings, so the scan is not passed without it.
+		if scanErr != nil {
+			e.logger.Error("Security scanner failed", zap.String("scanner", name), zap.Error(scanErr))
+			err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "ERROR", fmt.Sprintf("Security scanner %s failed: %s", name, scanErr.Error()))
+			if err != nil {
+				e.logger.Error("Error creating activity log", zap.Error(err))
+				return err
+			}
+			return fmt.Errorf("security scanner %s failed: %w", name, scanErr)
+		}
+		findings = append(findings, scannerFindings...)
+	}
+
+	var highSeverityFindings []SecurityFinding
+	for _, finding := range findings {
+		if finding.Severity == SecuritySeverityHigh {
+			highSeverityFindings = append(highSeverityFindings, finding)
+		}
+	}
+
+	retry := false
+	if len(highSeverityFindings) > 0 && step.RetryOnHighSeverity {
+		// The current scan is already stored, so it counts towards the attempts.
+		attempts, err := e.executionStepService.CountExecutionStepsOfName(step.Execution.ID, steps.SECURITY_SCAN_STEP.String())
+		if err != nil {
+			e.logger.Error("Error counting security scan steps", zap.Error(err))
+			return err
+		}
+		retry = int(attempts) <= step.MaxRetries
+	}
+
+	response := map[string]interface{}{
+		"findings":            findings,
+		"high_severity_count": len(highSeverityFindings),
+		"block_auto_merge":    len(highSeverityFindings) > 0 && !retry,
+	}
+	if len(highSeverityFindings) > 0 {
+		if retry {
+			response["error"] = "The security scan reported the following high severity issues. Fix them without changing the behaviour of the code:\n" +
+				formatSecurityFindings(highSeverityFindings)
+		} else {
+			response["report"] = buildSecurityReport(highSeverityFindings)
+		}
+	}
+	err = e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, response, "SUCCESS")
+	if err != nil {
+		e.logger.Error("Error updating execution step", zap.Error(err))
+		return err
+	}
+
+	if len(highSeverityFindings) == 0 {
+		err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO",
+			fmt.Sprintf("Security scan passed with %d lower severity finding(s).", len(findings)))
+		if err != nil {
+			e.logger.Error("Error creating activity log", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+
+	err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "ERROR",
+		fmt.Sprintf("Security scan found %d high severity issue(s):\n%s", len(highSeverityFindings), formatSecurityFindings(highSeverityFindings)))
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+	if !retry {
+		err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "WARNING",
+			"Security findings will be attached to the pull request and auto-merge is blocked.")
+		if err != nil {
+			e.logger.Error("Error creating activity log", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+	err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "Security scan failed fixing the issues...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	return fmt.Errorf("%w: %d high severity security finding(s)", steps.ErrReiterate, len(highSeverityFindings))
+}
+
+// baseCommit fetches the base branch of the project and returns the commit it points to. When the git host can not
+// be reached the local base branch is used, which is at most older and so only widens the range that is scanned.
+func (e SecurityScanStepExecutor) baseCommit(step steps.SecurityScanStep) (string, error) {
+	workDir := step.WorkspaceDir()
+	baseBranch := utils.ProjectBaseBranch(step.Project)
+	baseCommit, err := e.fetchBaseBranch(step, baseBranch)
+	if err == nil {
+		return baseCommit, nil
+	}
+	if !utils.HasLocalBranch(workDir, baseBranch) {
+		return "", fmt.Errorf("fetching base branch '%s': %w", baseBranch, err)
+	}
+	e.logger.Warn("Error fetching base branch, using the local branch", zap.String("branch", baseBranch), zap.Error(err))
+	return baseBranch, nil
+}
+
+func (e SecurityScanStepExecutor) fetchBaseBranch(step steps.SecurityScanStep, baseBranch string) (string, error) {
+	organisation, err := e.organisationService.GetOrganisationByID(step.Project.OrganisationID)
+	if err != nil {
+		return "", err
+	}
+	gitProvider, err := e.gitProviderResolver.ForProject(step.Project)
+	if err != nil {
+		return "", err
+	}
+	origin, err := git_providers.AuthenticatedRemoteURL(gitProvider, organisation, step.Project)
+	if err != nil {
+		return "", err
+	}
+	return utils.FetchBranch(step.WorkspaceDir(), origin, baseBranch)
+}
+
+func formatSecurityFindings(findings []SecurityFinding) string {
+	var sb strings.Builder
+	for _, finding := range findings {
+		sb.WriteString(finding.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// buildSecurityReport renders findings as a markdown section for the pull request description.
+func buildSecurityReport(findings []SecurityFinding) string {
+	var sb strings.Builder
+	sb.WriteString("### Security scan\n\n")
+	sb.WriteString(fmt.Sprintf("%d high severity finding(s) could not be fixed automatically. Auto-merge is blocked until they are reviewed.\n\n", len(findings)))
+	for _, finding := range findings {
+		sb.WriteString(fmt.Sprintf("- `%s` %s: %s (%s)\n", finding.location(), finding.RuleID, finding.Message, finding.Scanner))
+	}
+	return sb.String()
+}
@@ -0,0 +1,336 @@
+package impl
+
+import (
+	"ai-developer/app/constants"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	SecuritySeverityHigh   = "HIGH"
+	SecuritySeverityMedium = "MEDIUM"
+	SecuritySeverityLow    = "LOW"
+)
+
+// SecurityFinding is a single issue reported by a security scanner.
+type SecurityFinding struct {
+	Scanner  string `json:"scanner"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	RuleID   string `json:"rule_id"`
+	Severity string `json:"severity"` // HIGH, MEDIUM, LOW
+	Message  string `json:"message"`
+}
+
+func (f SecurityFinding) String() string {
+	return fmt.Sprintf("%s: %s %s: %s (%s)", f.location(), f.Severity, f.RuleID, f.Message, f.Scanner)
+}
+
+func (f SecurityFinding) location() string {
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	return f.File
+}
+
+// securityScanner runs one scanner inside workDir, baseCommit is the commit of the base branch the changes of the
+// branch are made on. It returns no findings and no error when there is nothing to scan.
+type securityScanner func(scanner string, workDir string, baseCommit string) ([]SecurityFinding, error)
+
+// errScannerUnavailable marks scanners which could not run because they are not installed or the registry they
+// query could not be reached. The scan goes on without them.
+var errScannerUnavailable = errors.New("scanner unavailable")
+
+// networkErrorPattern matches the error codes of the network failures scanners report.
+var networkErrorPattern = regexp.MustCompile(`\b(ENOTFOUND|EAI_AGAIN|ECONNREFUSED|ECONNRESET|ETIMEDOUT|ENETUNREACH)\b`)
+
+var securityScanners = map[string]securityScanner{
+	"bandit":    runBanditScanner,
+	"semgrep":   runSemgrepScanner,
+	"npm-audit": runNpmAuditScanner,
+	"secrets":   runSecretsScanner,
+}
+
+// runScannerCommand runs a scanner binary and returns its stdout. Scanners exit non-zero when they report findings,
+// so a non-zero exit is only an error when the scanner wrote nothing to stdout.
+func runScannerCommand(workDir string, binary string, args ...string) (string, error) {
+	if _, err := exec.LookPath(binary); err != nil {
+		return "", fmt.Errorf("%w: %s is not installed", errScannerUnavailable, binary)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = workDir
+	cmd.Env = os.Environ()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return "", err
+	}
+	if err != nil && strings.TrimSpace(stdout.String()) == "" {
+		if networkErrorPattern.MatchString(stderr.String()) {
+			return "", fmt.Errorf("%w: %s could not reach the network: %s", errScannerUnavailable, binary, strings.TrimSpace(stderr.String()))
+		}
+		return "", fmt.Errorf("%s exited with %d: %s", binary, exitErr.ExitCode(), strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+func runBanditScanner(scanner string, workDir string, _ string) ([]SecurityFinding, error) {
+	if len(collectLintFiles(workDir, []string{".py"})) == 0 {
+		return nil, nil
+	}
+	output, err := runScannerCommand(workDir, pythonToolPath(workDir, "bandit"),
+		"-r", ".", "-f", "json", "-q", "-x", "./.venv,./venv,./node_modules,./frontend,./.stories")
+	if err != nil {
+		return nil, err
+	}
+	var report struct {
+		Results []struct {
+			Filename        string `json:"filename"`
+			LineNumber      int    `json:"line_number"`
+			TestID          string `json:"test_id"`
+			IssueSeverity   string `json:"issue_severity"`
+			IssueConfidence string `json:"issue_confidence"`
+			IssueText       string `json:"issue_text"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		return nil, fmt.Errorf("failed to parse bandit output: %w", err)
+	}
+	findings := make([]SecurityFinding, 0, len(report.Results))
+	for _, result := range report.Results {
+		severity := strings.ToUpper(result.IssueSeverity)
+		// Low confidence results are too noisy to block a pull request on.
+		if severity == SecuritySeverityHigh && strings.ToUpper(result.IssueConfidence) == SecuritySeverityLow {
+			severity = SecuritySeverityMedium
+		}
+		findings = append(findings, SecurityFinding{
+			Scanner:  scanner,
+			File:     relativeLintPath(workDir, filepath.Join(workDir, result.Filename)),
+			Line:     result.LineNumber,
+			RuleID:   result.TestID,
+			Severity: severity,
+			Message:  result.IssueText,
+		})
+	}
+	return findings, nil
+}
+
+var semgrepSeverities = map[string]string{
+	"ERROR":   SecuritySeverityHigh,
+	"WARNING": SecuritySeverityMedium,
+	"INFO":    SecuritySeverityLow,
+}
+
+func runSemgrepScanner(scanner string, workDir string, _ string) ([]SecurityFinding, error) {
+	if _, err := os.Stat(constants.SemgrepRulesFile); err != nil {
+		return nil, fmt.Errorf("%w: semgrep rules not found: %s", errScannerUnavailable, err.Error())
+	}
+	output, err := runScannerCommand(workDir, "semgrep", "scan", "--config", constants.SemgrepRulesFile,
+		"--json", "--quiet", "--metrics", "off", "--disable-version-check",
+		"--exclude", ".venv", "--exclude", "venv", "--exclude", "node_modules", "--exclude", "frontend", "--exclude", ".stories")
+	if err != nil {
+		return nil, err
+	}
+	var report struct {
+		Results []struct {
+			CheckID string `json:"check_id"`
+			Path    string `json:"path"`
+			Start   struct {
+				Line int `json:"line"`
+			} `json:"start"`
+			Extra struct {
+				Message  string `json:"message"`
+				Severity string `json:"severity"`
+			} `json:"extra"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		return nil, fmt.Errorf("failed to parse semgrep output: %w", err)
+	}
+	findings := make([]SecurityFinding, 0, len(report.Results))
+	for _, result := range report.Results {
+		severity, ok := semgrepSeverities[strings.ToUpper(result.Extra.Severity)]
+		if !ok {
+			severity = SecuritySeverityLow
+		}
+		findings = append(findings, SecurityFinding{
+			Scanner:  scanner,
+			File:     relativeLintPath(workDir, result.Path),
+			Line:     result.Start.Line,
+			RuleID:   result.CheckID,
+			Severity: severity,
+			Message:  strings.TrimSpace(result.Extra.Message),
+		})
+	}
+	return findings, nil
+}
+
+var npmAuditSeverities = map[string]string{
+	"critical": SecuritySeverityHigh,
+	"high":     SecuritySeverityHigh,
+	"moderate": SecuritySeverityMedium,
+	"low":      SecuritySeverityLow,
+	"info":     SecuritySeverityLow,
+}
+
+func runNpmAuditScanner(scanner string, workDir string, _ string) ([]SecurityFinding, error) {
+	if _, err := os.Stat(filepath.Join(workDir, "package-lock.json")); err != nil {
+		return nil, nil
+	}
+	output, err := runScannerCommand(workDir, "npm", "audit", "--json", "--package-lock-only")
+	if err != nil {
+		return nil, err
+	}
+	var report struct {
+		Message string `json:"message"`
+		Error   *struct {
+			Code    string `json:"code"`
+			Summary string `json:"summary"`
+			Detail  string `json:"detail"`
+		} `json:"error"`
+		Vulnerabilities map[string]struct {
+			Name     string            `json:"name"`
+			Severity string            `json:"severity"`
+			Range    string            `json:"range"`
+			Via      []json.RawMessage `json:"via"`
+		} `json:"vulnerabilities"`
+	}
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		return nil, fmt.Errorf("failed to parse npm audit output: %w", err)
+	}
+	// The audit is run against the registry, which reports its failures in the output instead of findings.
+	if report.Error != nil {
+		message := strings.TrimSpace(strings.Join([]string{report.Message, report.Error.Code, report.Error.Summary, report.Error.Detail}, " "))
+		if networkErrorPattern.MatchString(message) {
+			return nil, fmt.Errorf("%w: npm registry could not be reached: %s", errScannerUnavailable, message)
+		}
+		return nil, fmt.Errorf("npm audit failed: %s", message)
+	}
+	findings := make([]SecurityFinding, 0, len(report.Vulnerabilities))
+	for name, vulnerability := range report.Vulnerabilities {
+		// via holds advisory objects for direct vulnerabilities and package names for transitive ones.
+		message := fmt.Sprintf("%s %s is vulnerable", name, vulnerability.Range)
+		for _, via := range vulnerability.Via {
+			var advisory struct {
+				Title string `json:"title"`
+			}
+			if json.Unmarshal(via, &advisory) == nil && advisory.Title != "" {
+				message = fmt.Sprintf("%s %s: %s", name, vulnerability.Range, advisory.Title)
+				break
+			}
+		}
+		severity, ok := npmAuditSeverities[vulnerability.Severity]
+		if !ok {
+			severity = SecuritySeverityLow
+		}
+		findings = append(findings, SecurityFinding{
+			Scanner:  scanner,
+			File:     "package-lock.json",
+			RuleID:   name,
+			Severity: severity,
+			Message:  message,
+		})
+	}
+	return findings, nil
+}
+
+type secretPattern struct {
+	ruleID   string
+	severity string
+	pattern  *regexp.Regexp
+}
+
+var secretPatterns = []secretPattern{
+	{"aws-access-key", SecuritySeverityHigh, regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"private-key", SecuritySeverityHigh, regexp.MustCompile(`-----BEGIN (RSA |EC |DSA |OPENSSH |PGP )?PRIVATE KEY`)},
+	{"github-token", SecuritySeverityHigh, regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"slack-token", SecuritySeverityHigh, regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{"openai-api-key", SecuritySeverityHigh, regexp.MustCompile(`\bsk-(proj-)?[A-Za-z0-9_-]{32,}`)},
+	{"hardcoded-credential", SecuritySeverityMedium, regexp.MustCompile(`(?i)(password|passwd|secret|api[_-]?key|access[_-]?token|auth[_-]?token)["']?\s*[:=]\s*["'][^"'\s]{8,}["']`)},
+}
+
+var diffHunkPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// runSecretsScanner looks for credentials in the lines the branch adds on top of the base branch. The scan runs
+// before the changes are committed, so the working tree is compared and files git does not track yet are added.
+func runSecretsScanner(scanner string, workDir string, baseCommit string) ([]SecurityFinding, error) {
+	if baseCommit == "" {
+		return nil, fmt.Errorf("%w: no base commit to compare the branch with", errScannerUnavailable)
+	}
+	mergeBase, err := runScannerCommand(workDir, "git", "merge-base", baseCommit, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	output, err := runScannerCommand(workDir, "git", "diff", "--unified=0", "--no-color", strings.TrimSpace(mergeBase))
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := runScannerCommand(workDir, "git", "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range strings.Split(untracked, "\x00") {
+		if file == "" {
+			continue
+		}
+		// git diff exits with 1 when the files differ, which they always do against /dev/null.
+		fileDiff, err := runScannerCommand(workDir, "git", "diff", "--no-index", "--unified=0", "--no-color", "/dev/null", file)
+		if err != nil {
+			return nil, err
+		}
+		output += fileDiff
+	}
+	return scanDiffForSecrets(scanner, output), nil
+}
+
+func scanDiffForSecrets(scanner string, diff string) []SecurityFinding {
+	var findings []SecurityFinding
+	file := ""
+	lineNumber := 0
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+		case strings.HasPrefix(line, "@@"):
+			if matches := diffHunkPattern.FindStringSubmatch(line); matches != nil {
+				lineNumber, _ = strconv.Atoi(matches[1])
+			}
+		case strings.HasPrefix(line, "+"):
+			for _, secret := range secretPatterns {
+				match := secret.pattern.FindString(line[1:])
+				if match == "" {
+					continue
+				}
+				findings = append(findings, SecurityFinding{
+					Scanner:  scanner,
+					File:     file,
+					Line:     lineNumber,
+					RuleID:   secret.ruleID,
+					Severity: secret.severity,
+					Message:  fmt.Sprintf("possible secret committed: %s", redactSecret(match)),
+				})
+				break
+			}
+			lineNumber++
+		}
+	}
+	return findings
+}
+
+func redactSecret(secret string) string {
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + strings.Repeat("*", len(secret)-8) + secret[len(secret)-4:]
+}
@@ -0,0 +1,66 @@
+package impl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// probeHTTPServer waits for the port to accept connections and then expects a 2xx response from the test path.
+func probeHTTPServer(address string, path string, timeout time.Duration, exited <-chan struct{}, waitErr *error) error {
+	var lastError error
+	for start := time.Now(); time.Since(start) < timeout; time.Sleep(time.Second) {
+		select {
+		case <-exited:
+			err := *waitErr
+			if err == nil {
+				err = errors.New("exit status 0")
+			}
+			return fmt.Errorf("server exited before listening on %s: %w", address, err)
+		default:
+		}
+
+		conn, err := net.DialTimeout("tcp", address, time.Second)
+		if err != nil {
+			lastError = err
+			continue
+		}
+		conn.Close()
+
+		url := "http://" + address + path
+		resp, err := (&http.Client{Timeout: 10 * time.Second}).Get(url)
+		if err != nil {
+			return fmt.Errorf("error calling endpoint %s: %w", url, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("received status code %d from endpoint %s: %s", resp.StatusCode, url, string(body))
+		}
+		return nil
+	}
+	if lastError != nil {
+		return fmt.Errorf("timeout reached waiting for %s: %w", address, lastError)
+	}
+	return fmt.Errorf("timeout reached waiting for %s", address)
+}
+
+// readServerTestPath returns the path of the URL in server_test.txt, falling back to the root endpoint.
+func readServerTestPath(projectDir string) string {
+	content, err := os.ReadFile(filepath.Join(projectDir, "server_test.txt"))
+	if err != nil {
+		return "/"
+	}
+	url := strings.TrimSpace(string(content))
+	url = strings.TrimPrefix(strings.TrimPrefix(url, "http://"), "https://")
+	if index := strings.Index(url, "/"); index >= 0 {
+		return url[index:]
+	}
+	return "/"
+}
@@ -0,0 +1,297 @@
+package impl
+
+import (
+	"ai-developer/app/constants"
+	"ai-developer/app/llms"
+	"ai-developer/app/services"
+	"ai-developer/app/services/git_providers"
+	"ai-developer/app/utils"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const defaultMaxConflictResolutions = 10
+
+var conflictMarkers = []string{"<<<<<<< ", "\n=======\n", ">>>>>>> "}
+
+type SyncBaseStepExecutor struct {
+	executionStepService *services.ExecutionStepService
+	activityLogService   *services.ActivityLogService
+	organisationService  *services.OrganisationService
+	llmAPIKeyService     *services.LLMAPIKeyService
+	gitProviderResolver  *git_providers.GitProviderResolver
+	logger               *zap.Logger
+}
+
+func NewSyncBaseStepExecutor(
+	executionStepService *services.ExecutionStepService,
+	activityLogService *services.ActivityLogService,
+	organisationService *services.OrganisationService,
+	llmAPIKeyService *services.LLMAPIKeyService,
+	gitProviderResolver *git_providers.GitProviderResolver,
+	logger *zap.Logger,
+) *SyncBaseStepExecutor {
+	return &SyncBaseStepExecutor{
+		executionStepService: executionStepService,
+		activityLogService:   activityLogService,
+		organisationService:  organisationService,
+		llmAPIKeyService:     llmAPIKeyService,
+		gitProviderResolver:  gitProviderResolver,
+		logger:               logger.Named("SyncBaseStepExecutor"),
+	}
+}
+
+type conflictResolution struct {
+	Files []string
+	Usage llms.OpenAiUsage
+}
+
+// Execute brings the feature branch up to date with the base branch on re-execution, so that the pull
+// request stays mergeable. Conflicts are resolved by the LLM; the server test later in the workflow
+// runs against the resolved code.
+func (e SyncBaseStepExecutor) Execute(step steps.SyncBaseStep) error {
+	if !step.Execution.ReExecution {
+		e.logger.Info("Branch was just created from the base branch, skipping sync")
+		return nil
+	}
+	baseBranch := utils.ProjectBaseBranch(step.Project)
+	branchName := step.Execution.BranchName
+	strategy := step.Strategy
+	if strategy == "" {
+		strategy = steps.SyncStrategyMerge
+	}
+	err := e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", fmt.Sprintf("Syncing branch '%s' with '%s'...", branchName, baseBranch))
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	organisation, err := e.organisationService.GetOrganisationByID(step.Project.OrganisationID)
+	if err != nil {
+		e.logger.Error("Error getting organisation", zap.Error(err))
+		return err
+	}
+	gitProvider, err := e.gitProviderResolver.ForProject(step.Project)
+	if err != nil {
+		e.logger.Error("Error resolving git provider", zap.Error(err))
+		return err
+	}
+	origin, err := git_providers.AuthenticatedRemoteURL(gitProvider, organisation, step.Project)
+	if err != nil {
+		e.logger.Error("Error building remote url", zap.Error(err))
+		return err
+	}
+
+	projectDir := step.WorkspaceDir()
+	baseCommit, err := utils.FetchBranch(projectDir, origin, baseBranch)
+	if err != nil {
+		e.logger.Error("Error fetching base branch", zap.Error(err))
+		return err
+	}
+	upToDate, err := utils.IsAncestor(projectDir, baseCommit)
+	if err != nil {
+		e.logger.Error("Error comparing with base branch", zap.Error(err))
+		return err
+	}
+	if upToDate {
+		err = e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, map[string]interface{}{
+			"base_branch": baseBranch,
+			"base_commit": baseCommit,
+			"up_to_date":  true,
+		}, "SUCCESS")
+		if err != nil {
+			e.logger.Error("Error updating execution step", zap.Error(err))
+			return err
+		}
+		return e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", fmt.Sprintf("Branch '%s' is already up to date with '%s'.", branchName, baseBranch))
+	}
+
+	var resolution *conflictResolution
+	if strategy == steps.SyncStrategyRebase {
+		resolution, err = e.rebase(step, projectDir, origin, baseCommit, branchName)
+	} else {
+		resolution, err = e.merge(step, projectDir, baseCommit, baseBranch, branchName)
+	}
+	if err != nil {
+		logErr := e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "ERROR", fmt.Sprintf("Failed to sync branch '%s' with '%s': %s", branchName, baseBranch, err.Error()))
+		if logErr != nil {
+			e.logger.Error("Error creating activity log", zap.Error(logErr))
+		}
+		return err
+	}
+
+	err = e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, map[string]interface{}{
+		"base_branch":    baseBranch,
+		"base_commit":    baseCommit,
+		"strategy":       strategy,
+		"resolved_files": resolution.Files,
+		"llm_usage":      resolution.Usage,
+	}, "SUCCESS")
+	if err != nil {
+		e.logger.Error("Error updating execution step", zap.Error(err))
+		return err
+	}
+	message := fmt.Sprintf("Synced branch '%s' with '%s' using %s.", branchName, baseBranch, strategy)
+	if len(resolution.Files) > 0 {
+		message = fmt.Sprintf("Synced branch '%s' with '%s' using %s, resolved conflicts in: %s. The server test will run against the resolved code.", branchName, baseBranch, strategy, strings.Join(resolution.Files, ", "))
+	}
+	return e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", message)
+}
+
+func (e SyncBaseStepExecutor) merge(step steps.SyncBaseStep, projectDir, baseCommit, baseBranch, branchName string) (*conflictResolution, error) {
+	resolution := &conflictResolution{}
+	conflicted, err := utils.MergeCommit(projectDir, baseCommit, fmt.Sprintf("Merge branch '%s' into %s", baseBranch, branchName))
+	if err != nil || !conflicted {
+		return resolution, err
+	}
+	resolved, err := e.resolveConflicts(step, projectDir, steps.SyncStrategyMerge, &resolution.Usage)
+	if err != nil {
+		if abortErr := utils.AbortMerge(projectDir); abortErr != nil {
+			e.logger.Error("Error aborting merge", zap.Error(abortErr))
+		}
+		return nil, err
+	}
+	resolution.Files = append(resolution.Files, resolved...)
+	return resolution, utils.CommitMerge(projectDir)
+}
+
+// rebase replays the branch on the base branch, resolving the conflicts commit by commit, and force pushes
+// the rewritten branch right away so that later pushes in the workflow fast-forward again.
+func (e SyncBaseStepExecutor) rebase(step steps.SyncBaseStep, projectDir, origin, baseCommit, branchName string) (*conflictResolution, error) {
+	resolution := &conflictResolution{}
+	remoteCommit, err := utils.GetRemoteBranchCommit(projectDir, origin, branchName)
+	if err != nil {
+		return nil, err
+	}
+	maxResolutions := step.MaxConflictResolutions
+	if maxResolutions <= 0 {
+		maxResolutions = defaultMaxConflictResolutions
+	}
+
+	conflicted, err := utils.RebaseOnto(projectDir, baseCommit)
+	for resolutions := 0; err == nil && conflicted; resolutions++ {
+		if resolutions == maxResolutions {
+			err = fmt.Errorf("rebase still has conflicts after resolving %d commits", maxResolutions)
+			break
+		}
+		var resolved []string
+		resolved, err = e.resolveConflicts(step, projectDir, steps.SyncStrategyRebase, &resolution.Usage)
+		if err != nil {
+			break
+		}
+		resolution.Files = append(resolution.Files, resolved...)
+		conflicted, err = utils.ContinueRebase(projectDir)
+	}
+	if err != nil {
+		if abortErr := utils.AbortRebase(projectDir); abortErr != nil {
+			e.logger.Error("Error aborting rebase", zap.Error(abortErr))
+		}
+		return nil, err
+	}
+
+	if remoteCommit != "" {
+		if err := utils.GitForcePushWithLease(projectDir, origin, branchName, remoteCommit); err != nil {
+			return nil, err
+		}
+	}
+	return resolution, nil
+}
+
+// resolveConflicts hands each conflicted file, conflict markers included, to the LLM and stages the
+// resolved contents. The strategy decides which side of the markers is the feature branch. The tokens
+// used are added to usage.
+func (e SyncBaseStepExecutor) resolveConflicts(step steps.SyncBaseStep, projectDir string, strategy string, usage *llms.OpenAiUsage) ([]string, error) {
+	files, err := utils.GetConflictedFiles(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", fmt.Sprintf("Resolving conflicts in: %s", strings.Join(files, ", ")))
+	if err != nil {
+		return nil, err
+	}
+
+	llmAPIKey, err := e.llmAPIKeyService.GetLLMAPIKeyByModelName(constants.GPT_4O, step.Project.OrganisationID)
+	if err != nil {
+		return nil, err
+	}
+	if llmAPIKey == nil || llmAPIKey.LLMAPIKey == "" {
+		return nil, fmt.Errorf("LLM API Key for model %s not found in database", constants.GPT_4O)
+	}
+	openAIClient := llms.NewOpenAiClient(llmAPIKey.LLMAPIKey)
+	prompt := conflictResolutionPrompt(strategy)
+
+	for _, file := range files {
+		path := filepath.Join(projectDir, file)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		resolved, fileUsage, err := openAIClient.ChatCompletionWithUsage([]llms.OpenAiChatCompletionMessage{
+			{
+				Role:    "system",
+				Content: prompt,
+			},
+			{
+				Role:    "user",
+				Content: fmt.Sprintf("File: %s\n\n%s", file, string(content)),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve conflicts in %s: %w", file, err)
+		}
+		usage.PromptTokens += fileUsage.PromptTokens
+		usage.CompletionTokens += fileUsage.CompletionTokens
+		usage.TotalTokens += fileUsage.TotalTokens
+		resolved = utils.StripCodeFence(resolved)
+		if hasConflictMarkers(resolved) {
+			return nil, errors.New("resolution of " + file + " still contains conflict markers")
+		}
+		if !strings.HasSuffix(resolved, "\n") && strings.HasSuffix(string(content), "\n") {
+			resolved += "\n"
+		}
+		if err := os.WriteFile(path, []byte(resolved), 0644); err != nil {
+			return nil, err
+		}
+		err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", fmt.Sprintf("Resolved conflicts in %s.", file))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := utils.GitAddFiles(projectDir, files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// conflictResolutionPrompt describes the conflict markers for the strategy. A merge puts the checked out
+// feature branch on the ours side, while a rebase checks out the base branch and replays the feature
+// branch commits, so the sides are swapped.
+func conflictResolutionPrompt(strategy string) string {
+	ours, theirs := "feature branch", "base branch"
+	if strategy == steps.SyncStrategyRebase {
+		ours, theirs = "base branch", "feature branch commit being replayed"
+	}
+	return "You resolve git merge conflicts. You are given a file containing conflict markers. " +
+		"The part between <<<<<<< and ======= is the " + ours + ", the part between ======= and >>>>>>> is the " + theirs + ". " +
+		"Keep the intent of both sides. Reply with the complete resolved file only, without conflict markers, explanations or code fences."
+}
+
+func hasConflictMarkers(content string) bool {
+	content = "\n" + content
+	for _, marker := range conflictMarkers {
+		if strings.HasPrefix(marker, "\n") {
+			if strings.Contains(content, marker) {
+				return true
+			}
+		} else if strings.Contains(content, "\n"+marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package impl
+
+import (
+	"ai-developer/app/services"
+	"ai-developer/app/workflow_executors/step_executors/steps"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxTestOutputLength caps the test output handed back to the LLM; the failures are at the end of it.
+const maxTestOutputLength = 8000
+
+type TestStepExecutor struct {
+	executionStepService *services.ExecutionStepService
+	activityLogService   *services.ActivityLogService
+	logger               *zap.Logger
+}
+
+func NewTestStepExecutor(
+	executionStepService *services.ExecutionStepService,
+	activityLogService *services.ActivityLogService,
+	logger *zap.Logger,
+) *TestStepExecutor {
+	return &TestStepExecutor{
+		executionStepService: executionStepService,
+		activityLogService:   activityLogService,
+		logger:               logger.Named("TestStepExecutor"),
+	}
+}
+
+func (e TestStepExecutor) Execute(step steps.TestStep) error {
+	if len(step.Command) == 0 {
+		return errors.New("test step has no command")
+	}
+	command := strings.Join(step.Command, " ")
+	e.logger.Info("Running tests...", zap.String("command", command))
+	err := e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", fmt.Sprintf("Running tests with `%s`...", command))
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return err
+	}
+
+	timeout := time.Duration(step.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, step.Command[0], step.Command[1:]...)
+	cmd.Dir = step.WorkspaceDir()
+	cmd.Env = os.Environ()
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	runErr := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		e.logger.Error("Error running tests", zap.Error(runErr))
+		return runErr
+	}
+
+	testOutput := output.String()
+	if len(testOutput) > maxTestOutputLength {
+		testOutput = "...\n" + testOutput[len(testOutput)-maxTestOutputLength:]
+	}
+	response := map[string]interface{}{"output": testOutput}
+	if runErr != nil {
+		if ctx.Err() != nil {
+			response["error"] = fmt.Sprintf("Tests timed out after %s running `%s`:\n%s", timeout, command, testOutput)
+		} else {
+			response["error"] = fmt.Sprintf("Tests failed running `%s`:\n%s", command, testOutput)
+		}
+	}
+	err = e.executionStepService.UpdateExecutionStepResponse(step.ExecutionStep, response, "SUCCESS")
+	if err != nil {
+		e.logger.Error("Error updating execution step", zap.Error(err))
+		return err
+	}
+
+	if runErr == nil {
+		err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "Tests passed!")
+		if err != nil {
+			e.logger.Error("Error creating activity log", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+
+	err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "ERROR", response["error"].(string))
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	err = e.activityLogService.CreateActivityLog(step.Execution.ID, step.ExecutionStep.ID, "INFO", "Tests failed fixing the issues...")
+	if err != nil {
+		e.logger.Error("Error creating activity log", zap.Error(err))
+		return fmt.Errorf("%w: %v", steps.ErrReiterate, err)
+	}
+	return fmt.Errorf("%w: %v", steps.ErrReiterate, runErr)
+}
@@ -0,0 +1,8 @@
+package step_executors
+
+import "ai-developer/app/workflow_executors/step_executors/steps"
+
+type LintStepExecutor interface {
+	StepExecutor
+	Execute(step steps.LintStep) error
+}
@@ -0,0 +1,8 @@
+package step_executors
+
+import "ai-developer/app/workflow_executors/step_executors/steps"
+
+type SecurityScanStepExecutor interface {
+	StepExecutor
+	Execute(step steps.SecurityScanStep) error
+}
@@ -1,6 +1,7 @@
 package steps
 
 import (
+	"ai-developer/app/config"
 	"ai-developer/app/models"
 	"errors"
 )
@@ -33,3 +34,8 @@ func (s *BaseStep) WithExecution(execution *models.Execution) *BaseStep {
 	s.Execution = execution
 	return s
 }
+
+// WorkspaceDir is the git worktree of the execution, see config.ExecutionWorkspacePath.
+func (s *BaseStep) WorkspaceDir() string {
+	return config.ExecutionWorkspacePath(s.Project.HashID, s.Execution.ID)
+}
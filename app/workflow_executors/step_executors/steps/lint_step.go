@@ -0,0 +1,15 @@
+package steps
+
+type LintStep struct {
+	BaseStep
+	WorkflowStep
+	Analyzers []string
+}
+
+func (s LintStep) StepType() string {
+	return CODE_TEST.String()
+}
+
+func (s LintStep) StepName() string {
+	return LINT_STEP.String()
+}
@@ -0,0 +1,19 @@
+package steps
+
+type SecurityScanStep struct {
+	BaseStep
+	WorkflowStep
+	Scanners []string
+	// RetryOnHighSeverity sends high severity findings back to code generation instead of attaching them to the pull request.
+	RetryOnHighSeverity bool
+	// MaxRetries caps the fix attempts, after which the findings are attached to the pull request and auto-merge is blocked.
+	MaxRetries int
+}
+
+func (s SecurityScanStep) StepType() string {
+	return CODE_TEST.String()
+}
+
+func (s SecurityScanStep) StepName() string {
+	return SECURITY_SCAN_STEP.String()
+}
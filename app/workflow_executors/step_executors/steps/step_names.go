@@ -19,6 +19,11 @@ var (
 	UPDATE_CODE_LAYOUT_FILE_STEP StepName = "UPDATE_CODE_LAYOUT_FILE_STEP"
 	UPDATE_CODE_PAGE_FILE_STEP   StepName = "UPDATE_CODE_PAGE_FILE_STEP"
 	PACKAGE_INSTALL_STEP         StepName = "PACKAGE_INSTALL_STEP"
+	LINT_STEP                    StepName = "LINT_STEP"
+	SECURITY_SCAN_STEP           StepName = "SECURITY_SCAN_STEP"
+	TEST_STEP                    StepName = "TEST_STEP"
+	SYNC_BASE_STEP               StepName = "SYNC_BASE_STEP"
+	PULL_REQUEST_REVIEW_STEP     StepName = "PULL_REQUEST_REVIEW_STEP"
 )
 
 func (s StepName) String() string {
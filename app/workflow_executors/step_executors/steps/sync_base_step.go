@@ -0,0 +1,22 @@
+package steps
+
+const (
+	SyncStrategyMerge  = "merge"
+	SyncStrategyRebase = "rebase"
+)
+
+type SyncBaseStep struct {
+	BaseStep
+	WorkflowStep
+	Strategy string // merge or rebase, merge when empty
+	// MaxConflictResolutions caps the number of conflicted commits the LLM resolves during a rebase.
+	MaxConflictResolutions int
+}
+
+func (s SyncBaseStep) StepType() string {
+	return GIT.String()
+}
+
+func (s SyncBaseStep) StepName() string {
+	return SYNC_BASE_STEP.String()
+}
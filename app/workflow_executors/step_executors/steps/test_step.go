@@ -0,0 +1,16 @@
+package steps
+
+type TestStep struct {
+	BaseStep
+	WorkflowStep
+	Command []string
+	Timeout int // seconds
+}
+
+func (s TestStep) StepType() string {
+	return CODE_TEST.String()
+}
+
+func (s TestStep) StepName() string {
+	return TEST_STEP.String()
+}
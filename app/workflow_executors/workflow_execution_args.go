@@ -6,4 +6,7 @@ type WorkflowExecutionArgs struct {
 	IsReExecution bool
 	Branch        string
 	PullRequestId int64
+	// KeepWorktree leaves the git worktree of the execution in place once the workflow is done, for the caller to
+	// inspect and remove.
+	KeepWorktree bool
 }
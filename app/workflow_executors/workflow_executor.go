@@ -1,11 +1,16 @@
 package workflow_executors
 
 import (
+	"ai-developer/app/config"
+	"ai-developer/app/constants"
+	"ai-developer/app/models"
 	"ai-developer/app/services"
+	"ai-developer/app/utils"
 	executors "ai-developer/app/workflow_executors/step_executors"
 	"ai-developer/app/workflow_executors/step_executors/steps"
 	"errors"
 	"fmt"
+	"os"
 )
 
 type WorkflowExecutor struct {
@@ -15,6 +20,7 @@ type WorkflowExecutor struct {
 	executionStepService *services.ExecutionStepService
 	activityLogService   *services.ActivityLogService
 	storyService         *services.StoryService
+	runQueueService      *services.RunQueueService
 }
 
 func (we *WorkflowExecutor) Execute(workflowConfig *WorkflowConfig, args *WorkflowExecutionArgs) (err error) {
@@ -41,7 +47,14 @@ func (we *WorkflowExecutor) Execute(workflowConfig *WorkflowConfig, args *Workfl
 
 	project, err := we.projectService.GetProjectById(story.ProjectID)
 
-	workflowConfig.StepGraph.Walk(func(name steps.StepName, step steps.WorkflowStep) error {
+	var stepErr error
+	workflowConfig.StepGraph.Walk(func(name steps.StepName, step steps.WorkflowStep) (err error) {
+		defer func() {
+			if err != nil && !errors.Is(err, steps.ErrReiterate) {
+				stepErr = fmt.Errorf("%s: %w", name, err)
+			}
+		}()
+
 		executor, ok := we.executors[name]
 		if !ok {
 			return errors.New("executor not found")
@@ -160,6 +173,34 @@ func (we *WorkflowExecutor) Execute(workflowConfig *WorkflowConfig, args *Workfl
 			serverStartTestStep.WithExecution(execution)
 			serverStartTestStep.WithExecutionStep(executionStep)
 			return executor.(executors.ServerStartTestExecutor).Execute(*serverStartTestStep)
+		case steps.LINT_STEP:
+			lintStep := step.(*steps.LintStep)
+			lintStep.WithStory(story)
+			lintStep.WithProject(project)
+			lintStep.WithExecution(execution)
+			lintStep.WithExecutionStep(executionStep)
+			return executor.(executors.LintStepExecutor).Execute(*lintStep)
+		case steps.SECURITY_SCAN_STEP:
+			securityScanStep := step.(*steps.SecurityScanStep)
+			securityScanStep.WithStory(story)
+			securityScanStep.WithProject(project)
+			securityScanStep.WithExecution(execution)
+			securityScanStep.WithExecutionStep(executionStep)
+			return executor.(executors.SecurityScanStepExecutor).Execute(*securityScanStep)
+		case steps.TEST_STEP:
+			testStep := step.(*steps.TestStep)
+			testStep.WithStory(story)
+			testStep.WithProject(project)
+			testStep.WithExecution(execution)
+			testStep.WithExecutionStep(executionStep)
+			return executor.(executors.TestStepExecutor).Execute(*testStep)
+		case steps.SYNC_BASE_STEP:
+			syncBaseStep := step.(*steps.SyncBaseStep)
+			syncBaseStep.WithStory(story)
+			syncBaseStep.WithProject(project)
+			syncBaseStep.WithExecution(execution)
+			syncBaseStep.WithExecutionStep(executionStep)
+			return executor.(executors.SyncBaseStepExecutor).Execute(*syncBaseStep)
 		case steps.RESET_DB_STEP:
 			resetDBStep := step.(*steps.ResetDBStep)
 			resetDBStep.WithStory(story)
@@ -178,9 +219,48 @@ func (we *WorkflowExecutor) Execute(workflowConfig *WorkflowConfig, args *Workfl
 
 		return errors.New("step not found")
 	})
+	if !args.KeepWorktree {
+		we.removeWorktree(project, execution.ID)
+	}
+	we.publishOutcome(execution.ID, stepErr)
+	we.runQueueService.ScheduleAdvance(story.ProjectID)
 	return nil
 }
 
+// publishOutcome publishes the failure of executions the workflow left in progress. Executions which finish set
+// their status themselves, which publishes their outcome.
+func (we *WorkflowExecutor) publishOutcome(executionID uint, stepErr error) {
+	execution, err := we.executionService.GetExecutionByID(executionID)
+	if err != nil {
+		fmt.Printf("Error fetching execution: %s\n", err.Error())
+		return
+	}
+	if execution.Status != constants.InProgress {
+		return
+	}
+	data := map[string]interface{}{}
+	if stepErr != nil {
+		data["error"] = stepErr.Error()
+	}
+	we.executionService.PublishExecutionEvent(execution, constants.EventExecutionFailed, data)
+}
+
+// removeWorktree removes the git worktree of the execution once the workflow is done. The branch the execution
+// worked on stays in the repository of the project workspace.
+func (we *WorkflowExecutor) removeWorktree(project *models.Project, executionID uint) {
+	if project == nil {
+		return
+	}
+	worktreeDir := config.ExecutionWorkspacePath(project.HashID, executionID)
+	if _, err := os.Stat(worktreeDir); err != nil {
+		return
+	}
+	projectDir := config.WorkspaceWorkingDirectory() + "/" + project.HashID
+	if err := utils.RemoveWorktree(projectDir, worktreeDir); err != nil {
+		fmt.Printf("Error removing worktree: %s\n", err.Error())
+	}
+}
+
 func NewWorkflowExecutor(
 	executors map[steps.StepName]executors.StepExecutor,
 	projectService *services.ProjectService,
@@ -188,6 +268,7 @@ func NewWorkflowExecutor(
 	executionStepService *services.ExecutionStepService,
 	activityLogService *services.ActivityLogService,
 	storyService *services.StoryService,
+	runQueueService *services.RunQueueService,
 ) *WorkflowExecutor {
 	return &WorkflowExecutor{
 		executors:            executors,
@@ -196,5 +277,6 @@ func NewWorkflowExecutor(
 		executionStepService: executionStepService,
 		activityLogService:   activityLogService,
 		storyService:         storyService,
+		runQueueService:      runQueueService,
 	}
 }
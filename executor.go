@@ -3,8 +3,11 @@ package main
 import (
 	"ai-developer/app/client"
 	gitness_git_provider "ai-developer/app/client/git_provider"
+	"ai-developer/app/client/github_git_provider"
+	"ai-developer/app/client/gitlab_git_provider"
 	"ai-developer/app/client/workspace"
 	"ai-developer/app/config"
+	"ai-developer/app/eval"
 	"ai-developer/app/monitoring"
 	"ai-developer/app/repositories"
 	"ai-developer/app/services"
@@ -19,6 +22,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/hibiken/asynq"
 	"github.com/knadh/koanf/v2"
@@ -154,6 +158,46 @@ func main() {
 		log.Println("Error providing llm api key repository:", err)
 		panic(err)
 	}
+	err = c.Provide(repositories.NewOrganisationWebhookRepository)
+	if err != nil {
+		log.Println("Error providing organisation webhook repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewWebhookDeliveryRepository)
+	if err != nil {
+		log.Println("Error providing webhook delivery repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewStoryIssueLinkRepository)
+	if err != nil {
+		log.Println("Error providing story issue link repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewStoryDependencyRepository)
+	if err != nil {
+		log.Println("Error providing story dependency repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewRunQueueRepository)
+	if err != nil {
+		log.Println("Error providing run queue repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewInstructionSnippetRepository)
+	if err != nil {
+		log.Println("Error providing instruction snippet repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewPromptVersionRepository)
+	if err != nil {
+		log.Println("Error providing prompt version repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewEvalRunRepository)
+	if err != nil {
+		log.Println("Error providing eval run repository:", err)
+		panic(err)
+	}
 	// Provide Redis Client
 	err = c.Provide(config.InitRedis)
 	if err != nil {
@@ -181,15 +225,52 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	// Provide GitHubClient
+	err = c.Provide(func(logger *zap.Logger) (*github_git_provider.GitHubClient, error) {
+		httpClient := client.NewHttpClient()
+		tokenSource, err := github_git_provider.NewTokenSource(config.GithubAPIURL(), config.GithubToken(),
+			config.GithubAppID(), config.GithubAppInstallationID(), config.GithubAppPrivateKey(), httpClient)
+		if err != nil {
+			return nil, err
+		}
+		return github_git_provider.NewGitHubClient(config.GithubAPIURL(), tokenSource, httpClient, logger), nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(git_providers.NewGitHubService)
+	if err != nil {
+		panic(err)
+	}
+	// Provide GitLabClient
+	err = c.Provide(func(logger *zap.Logger) *gitlab_git_provider.GitLabClient {
+		return gitlab_git_provider.NewGitLabClient(config.GitlabURL(), config.GitlabToken(), client.NewHttpClient(), logger)
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(git_providers.NewGitLabService)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(git_providers.NewGitProviderResolver)
+	if err != nil {
+		panic(err)
+	}
 
 	//Provide Services
 	_ = c.Provide(services.NewOrganisationService)
 	_ = c.Provide(services.NewProjectService)
+	_ = c.Provide(services.NewOrganisationWebhookService)
 	_ = c.Provide(services.NewExecutionService)
 	_ = c.Provide(services.NewExecutionOutputService)
 	_ = c.Provide(services.NewPullRequestService)
 	_ = c.Provide(services.NewActivityLogService)
 	_ = c.Provide(services.NewStoryService)
+	_ = c.Provide(services.NewStoryDependencyService)
+	_ = c.Provide(services.NewRunQueueService)
+	_ = c.Provide(services.NewInstructionSnippetService)
+	_ = c.Provide(services.NewPromptRegistryService)
 	_ = c.Provide(services.NewPullRequestCommentsService)
 	_ = c.Provide(services.NewExecutionStepService)
 	_ = c.Provide(services.NewPullRequestService)
@@ -241,6 +322,27 @@ func main() {
 		log.Println("Error providing server start test step:", err)
 		panic(err)
 
+	}
+	//FASTAPI serverStartTestStep
+	err = c.Provide(impl.NewFastAPIServerStartTestExecutor)
+	if err != nil {
+		log.Println("Error providing server start test step:", err)
+		panic(err)
+
+	}
+	//EXPRESS serverStartTestStep
+	err = c.Provide(impl.NewExpressServerStartTestExecutor)
+	if err != nil {
+		log.Println("Error providing server start test step:", err)
+		panic(err)
+
+	}
+	//GO serverStartTestStep
+	err = c.Provide(impl.NewGoServerStartTestExecutor)
+	if err != nil {
+		log.Println("Error providing server start test step:", err)
+		panic(err)
+
 	}
 	//NEXT JS serverStartTestStep
 	err = c.Provide(impl.NewNextJsServerStartTestExecutor)
@@ -261,7 +363,7 @@ func main() {
 		panic(err)
 	}
 	//GitMakePullRequestStep
-	err = c.Provide(impl.NewGitnessMakePullRequestExecutor)
+	err = c.Provide(impl.NewGitMakePullRequestExecutor)
 	if err != nil {
 		log.Println("Error providing git make pull request step:", err)
 		panic(err)
@@ -271,11 +373,45 @@ func main() {
 		log.Println("Error providing reset flask db step:", err)
 		panic(err)
 	}
+	err = c.Provide(impl.NewResetAlembicDBStepExecutor)
+	if err != nil {
+		log.Println("Error providing reset alembic db step:", err)
+		panic(err)
+	}
 	err = c.Provide(impl.NewPackageInstallStepExecutor)
 	if err != nil {
 		log.Println("Error providing package install step:", err)
 		panic(err)
 	}
+	err = c.Provide(impl.NewNpmPackageInstallStepExecutor)
+	if err != nil {
+		log.Println("Error providing npm package install step:", err)
+		panic(err)
+	}
+	//LintStep
+	err = c.Provide(impl.NewLintStepExecutor)
+	if err != nil {
+		log.Println("Error providing lint step:", err)
+		panic(err)
+	}
+	//SecurityScanStep
+	err = c.Provide(impl.NewSecurityScanStepExecutor)
+	if err != nil {
+		log.Println("Error providing security scan step:", err)
+		panic(err)
+	}
+	//TestStep
+	err = c.Provide(impl.NewTestStepExecutor)
+	if err != nil {
+		log.Println("Error providing test step:", err)
+		panic(err)
+	}
+	//SyncBaseStep
+	err = c.Provide(impl.NewSyncBaseStepExecutor)
+	if err != nil {
+		log.Println("Error providing sync base step:", err)
+		panic(err)
+	}
 
 	//Provide Slack Alert For monitoring
 	err = c.Provide(monitoring.NewSlackAlert)
@@ -293,9 +429,12 @@ func main() {
 			flaskServerStartTestExecutor *impl.FlaskServerStartTestExecutor,
 			gitCommitExecutor *impl.GitCommitExecutor,
 			gitPushExecutor *impl.GitPushExecutor,
-			gitnessMakePullRequestExecutor *impl.GitnessMakePullRequestExecutor,
+			gitMakePullRequestExecutor *impl.GitMakePullRequestExecutor,
 			resetFlaskDBStepExecutor *impl.ResetFlaskDBStepExecutor,
 			poetryPackageInstallStepExecutor *impl.PackageInstallStepExecutor,
+			lintStepExecutor *impl.LintStepExecutor,
+			securityScanStepExecutor *impl.SecurityScanStepExecutor,
+			syncBaseStepExecutor *impl.SyncBaseStepExecutor,
 		) map[steps.StepName]step_executors.StepExecutor {
 			return map[steps.StepName]step_executors.StepExecutor{
 				steps.CODE_GENERATE_STEP:           *openAICodeGenerator,
@@ -303,11 +442,14 @@ func main() {
 				steps.GIT_COMMIT_STEP:              *gitCommitExecutor,
 				steps.GIT_CREATE_BRANCH_STEP:       *gitMakeBranchExecutor,
 				steps.GIT_PUSH_STEP:                *gitPushExecutor,
-				steps.GIT_CREATE_PULL_REQUEST_STEP: *gitnessMakePullRequestExecutor,
+				steps.GIT_CREATE_PULL_REQUEST_STEP: *gitMakePullRequestExecutor,
 				steps.SERVER_START_STEP:            *flaskServerStartTestExecutor,
 				steps.RETRY_CODE_GENERATE_STEP:     *openAICodeGenerator,
 				steps.RESET_DB_STEP:                *resetFlaskDBStepExecutor,
 				steps.PACKAGE_INSTALL_STEP:         *poetryPackageInstallStepExecutor,
+				steps.LINT_STEP:                    *lintStepExecutor,
+				steps.SECURITY_SCAN_STEP:           *securityScanStepExecutor,
+				steps.SYNC_BASE_STEP:               *syncBaseStepExecutor,
 			}
 		})
 	} else if template == "DJANGO" {
@@ -318,7 +460,10 @@ func main() {
 			djangoServerStartTestExecutor *impl.DjangoServerStartTestExecutor,
 			gitCommitExecutor *impl.GitCommitExecutor,
 			gitPushExecutor *impl.GitPushExecutor,
-			gitnessMakePullRequestExecutor *impl.GitnessMakePullRequestExecutor,
+			gitMakePullRequestExecutor *impl.GitMakePullRequestExecutor,
+			lintStepExecutor *impl.LintStepExecutor,
+			securityScanStepExecutor *impl.SecurityScanStepExecutor,
+			syncBaseStepExecutor *impl.SyncBaseStepExecutor,
 		) map[steps.StepName]step_executors.StepExecutor {
 			return map[steps.StepName]step_executors.StepExecutor{
 				steps.CODE_GENERATE_STEP:           *openAICodeGenerator,
@@ -326,9 +471,101 @@ func main() {
 				steps.GIT_COMMIT_STEP:              *gitCommitExecutor,
 				steps.GIT_CREATE_BRANCH_STEP:       *gitMakeBranchExecutor,
 				steps.GIT_PUSH_STEP:                *gitPushExecutor,
-				steps.GIT_CREATE_PULL_REQUEST_STEP: *gitnessMakePullRequestExecutor,
+				steps.GIT_CREATE_PULL_REQUEST_STEP: *gitMakePullRequestExecutor,
 				steps.SERVER_START_STEP:            *djangoServerStartTestExecutor,
 				steps.RETRY_CODE_GENERATE_STEP:     *openAICodeGenerator,
+				steps.LINT_STEP:                    *lintStepExecutor,
+				steps.SECURITY_SCAN_STEP:           *securityScanStepExecutor,
+				steps.SYNC_BASE_STEP:               *syncBaseStepExecutor,
+			}
+		})
+	} else if template == "FASTAPI" {
+		_ = c.Provide(func(
+			openAICodeGenerator *impl.OpenAICodeGenerator,
+			gitMakeBranchExecutor *impl.GitMakeBranchExecutor,
+			updateCodeFileExecutor *impl.UpdateCodeFileExecutor,
+			fastAPIServerStartTestExecutor *impl.FastAPIServerStartTestExecutor,
+			gitCommitExecutor *impl.GitCommitExecutor,
+			gitPushExecutor *impl.GitPushExecutor,
+			gitMakePullRequestExecutor *impl.GitMakePullRequestExecutor,
+			resetAlembicDBStepExecutor *impl.ResetAlembicDBStepExecutor,
+			poetryPackageInstallStepExecutor *impl.PackageInstallStepExecutor,
+			lintStepExecutor *impl.LintStepExecutor,
+			securityScanStepExecutor *impl.SecurityScanStepExecutor,
+			syncBaseStepExecutor *impl.SyncBaseStepExecutor,
+		) map[steps.StepName]step_executors.StepExecutor {
+			return map[steps.StepName]step_executors.StepExecutor{
+				steps.CODE_GENERATE_STEP:           *openAICodeGenerator,
+				steps.UPDATE_CODE_FILE_STEP:        *updateCodeFileExecutor,
+				steps.GIT_COMMIT_STEP:              *gitCommitExecutor,
+				steps.GIT_CREATE_BRANCH_STEP:       *gitMakeBranchExecutor,
+				steps.GIT_PUSH_STEP:                *gitPushExecutor,
+				steps.GIT_CREATE_PULL_REQUEST_STEP: *gitMakePullRequestExecutor,
+				steps.SERVER_START_STEP:            *fastAPIServerStartTestExecutor,
+				steps.RETRY_CODE_GENERATE_STEP:     *openAICodeGenerator,
+				steps.RESET_DB_STEP:                *resetAlembicDBStepExecutor,
+				steps.PACKAGE_INSTALL_STEP:         *poetryPackageInstallStepExecutor,
+				steps.LINT_STEP:                    *lintStepExecutor,
+				steps.SECURITY_SCAN_STEP:           *securityScanStepExecutor,
+				steps.SYNC_BASE_STEP:               *syncBaseStepExecutor,
+			}
+		})
+	} else if template == "EXPRESS" {
+		_ = c.Provide(func(
+			openAICodeGenerator *impl.OpenAICodeGenerator,
+			gitMakeBranchExecutor *impl.GitMakeBranchExecutor,
+			updateCodeFileExecutor *impl.UpdateCodeFileExecutor,
+			expressServerStartTestExecutor *impl.ExpressServerStartTestExecutor,
+			gitCommitExecutor *impl.GitCommitExecutor,
+			gitPushExecutor *impl.GitPushExecutor,
+			gitMakePullRequestExecutor *impl.GitMakePullRequestExecutor,
+			npmPackageInstallStepExecutor *impl.NpmPackageInstallStepExecutor,
+			lintStepExecutor *impl.LintStepExecutor,
+			securityScanStepExecutor *impl.SecurityScanStepExecutor,
+			syncBaseStepExecutor *impl.SyncBaseStepExecutor,
+		) map[steps.StepName]step_executors.StepExecutor {
+			return map[steps.StepName]step_executors.StepExecutor{
+				steps.CODE_GENERATE_STEP:           *openAICodeGenerator,
+				steps.UPDATE_CODE_FILE_STEP:        *updateCodeFileExecutor,
+				steps.GIT_COMMIT_STEP:              *gitCommitExecutor,
+				steps.GIT_CREATE_BRANCH_STEP:       *gitMakeBranchExecutor,
+				steps.GIT_PUSH_STEP:                *gitPushExecutor,
+				steps.GIT_CREATE_PULL_REQUEST_STEP: *gitMakePullRequestExecutor,
+				steps.SERVER_START_STEP:            *expressServerStartTestExecutor,
+				steps.RETRY_CODE_GENERATE_STEP:     *openAICodeGenerator,
+				steps.PACKAGE_INSTALL_STEP:         *npmPackageInstallStepExecutor,
+				steps.LINT_STEP:                    *lintStepExecutor,
+				steps.SECURITY_SCAN_STEP:           *securityScanStepExecutor,
+				steps.SYNC_BASE_STEP:               *syncBaseStepExecutor,
+			}
+		})
+	} else if template == "GO" {
+		_ = c.Provide(func(
+			openAICodeGenerator *impl.OpenAICodeGenerator,
+			gitMakeBranchExecutor *impl.GitMakeBranchExecutor,
+			updateCodeFileExecutor *impl.UpdateCodeFileExecutor,
+			goServerStartTestExecutor *impl.GoServerStartTestExecutor,
+			gitCommitExecutor *impl.GitCommitExecutor,
+			gitPushExecutor *impl.GitPushExecutor,
+			gitMakePullRequestExecutor *impl.GitMakePullRequestExecutor,
+			lintStepExecutor *impl.LintStepExecutor,
+			testStepExecutor *impl.TestStepExecutor,
+			securityScanStepExecutor *impl.SecurityScanStepExecutor,
+			syncBaseStepExecutor *impl.SyncBaseStepExecutor,
+		) map[steps.StepName]step_executors.StepExecutor {
+			return map[steps.StepName]step_executors.StepExecutor{
+				steps.CODE_GENERATE_STEP:           *openAICodeGenerator,
+				steps.UPDATE_CODE_FILE_STEP:        *updateCodeFileExecutor,
+				steps.GIT_COMMIT_STEP:              *gitCommitExecutor,
+				steps.GIT_CREATE_BRANCH_STEP:       *gitMakeBranchExecutor,
+				steps.GIT_PUSH_STEP:                *gitPushExecutor,
+				steps.GIT_CREATE_PULL_REQUEST_STEP: *gitMakePullRequestExecutor,
+				steps.SERVER_START_STEP:            *goServerStartTestExecutor,
+				steps.RETRY_CODE_GENERATE_STEP:     *openAICodeGenerator,
+				steps.LINT_STEP:                    *lintStepExecutor,
+				steps.TEST_STEP:                    *testStepExecutor,
+				steps.SECURITY_SCAN_STEP:           *securityScanStepExecutor,
+				steps.SYNC_BASE_STEP:               *syncBaseStepExecutor,
 			}
 		})
 	} else if template == "NEXTJS" {
@@ -336,6 +573,7 @@ func main() {
 			openAiNextJsCodeGenerator *impl.OpenAiNextJsCodeGenerator,
 			updateCodeFileExecutor *impl.NextJsUpdateCodeFileExecutor,
 			serverStartExecutor *impl.NextJsServerStartTestExecutor,
+			lintStepExecutor *impl.LintStepExecutor,
 		) map[steps.StepName]step_executors.StepExecutor {
 			return map[steps.StepName]step_executors.StepExecutor{
 				steps.CODE_GENERATE_CSS_STEP:       *openAiNextJsCodeGenerator,
@@ -347,12 +585,18 @@ func main() {
 				steps.SERVER_START_STEP:            *serverStartExecutor,
 				steps.RETRY_CODE_GENERATE_STEP:     *openAiNextJsCodeGenerator,
 				steps.UPDATE_CODE_FILE_STEP:        *updateCodeFileExecutor,
+				steps.LINT_STEP:                    *lintStepExecutor,
 			}
 		})
 	}
 
 	_ = c.Provide(workflow_executors.NewWorkflowExecutor)
 
+	if os.Getenv("EXECUTION_MODE") == "eval" {
+		runEvaluation(c, template)
+		return
+	}
+
 	err = c.Invoke(func(
 		adec *config.AIDeveloperExecutionConfig,
 		db *gorm.DB,
@@ -387,6 +631,42 @@ func main() {
 				},
 			)
 			return err
+		} else if template == "FASTAPI" {
+			err = executor.Execute(
+				workflow_executors.FastAPIWorkflowConfig,
+				&workflow_executors.WorkflowExecutionArgs{
+					StoryId:       adec.GetStoryID(),
+					IsReExecution: adec.IsReExecution(),
+					Branch:        adec.GetBranch(),
+					PullRequestId: adec.GetPullRequestID(),
+					ExecutionId:   adec.GetExecutionID(),
+				},
+			)
+			return err
+		} else if template == "EXPRESS" {
+			err = executor.Execute(
+				workflow_executors.ExpressWorkflowConfig,
+				&workflow_executors.WorkflowExecutionArgs{
+					StoryId:       adec.GetStoryID(),
+					IsReExecution: adec.IsReExecution(),
+					Branch:        adec.GetBranch(),
+					PullRequestId: adec.GetPullRequestID(),
+					ExecutionId:   adec.GetExecutionID(),
+				},
+			)
+			return err
+		} else if template == "GO" {
+			err = executor.Execute(
+				workflow_executors.GoWorkflowConfig,
+				&workflow_executors.WorkflowExecutionArgs{
+					StoryId:       adec.GetStoryID(),
+					IsReExecution: adec.IsReExecution(),
+					Branch:        adec.GetBranch(),
+					PullRequestId: adec.GetPullRequestID(),
+					ExecutionId:   adec.GetExecutionID(),
+				},
+			)
+			return err
 		} else if template == "NEXTJS" {
 			log.Println("Going to execute AI Developer Next JS Workflow Execution")
 			err = executor.Execute(
@@ -407,3 +687,48 @@ func main() {
 		log.Fatalf("could not run server: %v", err)
 	}
 }
+
+// runEvaluation runs the benchmark suite of EVAL_SUITE through the workflow of the template against local
+// workspaces, with the stubbed LLM unless EVAL_LLM is "real", and stores the results of the run.
+func runEvaluation(c *dig.Container, template string) {
+	err := c.Provide(eval.NewHarness)
+	if err != nil {
+		log.Println("Error providing evaluation harness:", err)
+		panic(err)
+	}
+	if template == "" {
+		template = "FLASK"
+	}
+	workflowConfigs := map[string]*workflow_executors.WorkflowConfig{
+		"FLASK":   workflow_executors.FlaskWorkflowConfig,
+		"DJANGO":  workflow_executors.DjangoWorkflowConfig,
+		"FASTAPI": workflow_executors.FastAPIWorkflowConfig,
+		"EXPRESS": workflow_executors.ExpressWorkflowConfig,
+		"GO":      workflow_executors.GoWorkflowConfig,
+	}
+	workflowConfig, ok := workflowConfigs[template]
+	if !ok {
+		log.Fatalf("evaluating the %s template is not supported", template)
+	}
+	compareRunID := 0
+	if value := os.Getenv("EVAL_COMPARE_RUN"); value != "" {
+		compareRunID, err = strconv.Atoi(value)
+		if err != nil {
+			log.Fatalf("invalid EVAL_COMPARE_RUN: %v", err)
+		}
+	}
+
+	err = c.Invoke(func(harness *eval.Harness) error {
+		log.Println(fmt.Sprintf("Going to evaluate suite %s with the %s workflow", os.Getenv("EVAL_SUITE"), template))
+		_, err := harness.Run(workflowConfig, template, eval.RunOptions{
+			SuitePath:    os.Getenv("EVAL_SUITE"),
+			LLMMode:      os.Getenv("EVAL_LLM"),
+			Label:        os.Getenv("EVAL_LABEL"),
+			CompareRunID: uint(compareRunID),
+		})
+		return err
+	})
+	if err != nil {
+		log.Fatalf("could not run evaluation: %v", err)
+	}
+}
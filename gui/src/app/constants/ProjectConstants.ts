@@ -19,10 +19,22 @@ export const backendFrameworkOptions = [
     available: true,
   },
   {
-    id: 'fast_api',
+    id: 'fastapi',
     text: 'Fast API',
     src: imagePath.fastAPIImage,
-    available: false,
+    available: true,
+  },
+  {
+    id: 'express',
+    text: 'Express',
+    src: imagePath.expressImage,
+    available: true,
+  },
+  {
+    id: 'go',
+    text: 'Go',
+    src: imagePath.goImage,
+    available: true,
   },
 ];
 
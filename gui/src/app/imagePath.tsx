@@ -62,6 +62,8 @@ export default {
   rightArrowThinGrey: '/arrows/right_arrow_thin_grey.svg',
   djangoImage: '/images/django_image.png',
   fastAPIImage: '/images/fastapi_image.png',
+  expressImage: '/images/express_image.svg',
+  goImage: '/images/go_image.svg',
   flaskImage: '/images/flask_image.png',
   nextJsImage: '/images/nextjs_image.png',
   editIcon: '/icons/edit_icon.svg',
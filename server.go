@@ -3,6 +3,8 @@ package main
 import (
 	"ai-developer/app/client"
 	gitness_git_provider "ai-developer/app/client/git_provider"
+	"ai-developer/app/client/github_git_provider"
+	"ai-developer/app/client/gitlab_git_provider"
 	"ai-developer/app/client/workspace"
 	"ai-developer/app/config"
 	"ai-developer/app/constants"
@@ -14,6 +16,7 @@ import (
 	"ai-developer/app/repositories"
 	"ai-developer/app/services"
 	"ai-developer/app/services/git_providers"
+	"ai-developer/app/services/issue_trackers"
 	"ai-developer/app/services/s3_providers"
 	"context"
 	"errors"
@@ -118,6 +121,60 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	// Provide GitHubClient
+	err = c.Provide(func(logger *zap.Logger) (*github_git_provider.GitHubClient, error) {
+		httpClient := client.NewHttpClient()
+		tokenSource, err := github_git_provider.NewTokenSource(config.GithubAPIURL(), config.GithubToken(),
+			config.GithubAppID(), config.GithubAppInstallationID(), config.GithubAppPrivateKey(), httpClient)
+		if err != nil {
+			return nil, err
+		}
+		return github_git_provider.NewGitHubClient(config.GithubAPIURL(), tokenSource, httpClient, logger), nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(git_providers.NewGitHubService)
+	if err != nil {
+		panic(err)
+	}
+	// Provide GitLabClient
+	err = c.Provide(func(logger *zap.Logger) *gitlab_git_provider.GitLabClient {
+		return gitlab_git_provider.NewGitLabClient(config.GitlabURL(), config.GitlabToken(), client.NewHttpClient(), logger)
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(git_providers.NewGitLabService)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(git_providers.NewGitProviderResolver)
+	if err != nil {
+		panic(err)
+	}
+
+	// Provide issue trackers, they build their API clients per tracker configuration
+	err = c.Provide(client.NewHttpClient)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(issue_trackers.NewJiraService)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(issue_trackers.NewLinearService)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(issue_trackers.NewGitHubIssuesService)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(issue_trackers.NewIssueTrackerResolver)
+	if err != nil {
+		panic(err)
+	}
 	err = c.Provide(s3_providers.NewS3Service)
 	if err != nil {
 		panic(err)
@@ -140,6 +197,16 @@ func main() {
 		*repositories.PullRequestCommentsRepository,
 		*repositories.LLMAPIKeyRepository,
 		*repositories.DesignStoryReviewRepository,
+		*repositories.PullRequestReviewRepository,
+		*repositories.OrganisationWebhookRepository,
+		*repositories.WebhookDeliveryRepository,
+		*repositories.IssueTrackerRepository,
+		*repositories.StoryIssueLinkRepository,
+		*repositories.StoryDependencyRepository,
+		*repositories.RunQueueRepository,
+		*repositories.StoryTemplateRepository,
+		*repositories.InstructionSnippetRepository,
+		*repositories.PromptVersionRepository,
 	) {
 		return repositories.NewExecutionOutputRepository(db),
 			repositories.NewProjectRepository(db),
@@ -155,7 +222,17 @@ func main() {
 			repositories.NewPullRequestRepository(db),
 			repositories.NewPullRequestCommentsRepository(db),
 			repositories.NewLLMAPIKeyRepository(db),
-			repositories.NewDesignStoryReviewRepository(db)
+			repositories.NewDesignStoryReviewRepository(db),
+			repositories.NewPullRequestReviewRepository(db),
+			repositories.NewOrganisationWebhookRepository(db),
+			repositories.NewWebhookDeliveryRepository(db),
+			repositories.NewIssueTrackerRepository(db),
+			repositories.NewStoryIssueLinkRepository(db),
+			repositories.NewStoryDependencyRepository(db),
+			repositories.NewRunQueueRepository(db),
+			repositories.NewStoryTemplateRepository(db),
+			repositories.NewInstructionSnippetRepository(db),
+			repositories.NewPromptVersionRepository(db)
 	})
 	if err != nil {
 		panic(err)
@@ -175,6 +252,11 @@ func main() {
 		panic(err)
 	}
 
+	err = c.Provide(services.NewOrganisationWebhookService)
+	if err != nil {
+		panic(err)
+	}
+
 	err = c.Provide(services.NewExecutionService)
 	if err != nil {
 		panic(err)
@@ -242,6 +324,41 @@ func main() {
 		fmt.Printf("Error providing StoryService: %v\n", err)
 		panic(err)
 	}
+	err = c.Provide(services.NewIssueTrackerService)
+	if err != nil {
+		fmt.Printf("Error providing IssueTrackerService: %v\n", err)
+		panic(err)
+	}
+	err = c.Provide(services.NewStoryDependencyService)
+	if err != nil {
+		fmt.Printf("Error providing StoryDependencyService: %v\n", err)
+		panic(err)
+	}
+	err = c.Provide(services.NewRunQueueService)
+	if err != nil {
+		fmt.Printf("Error providing RunQueueService: %v\n", err)
+		panic(err)
+	}
+	err = c.Provide(services.NewStoryDecompositionService)
+	if err != nil {
+		fmt.Printf("Error providing StoryDecompositionService: %v\n", err)
+		panic(err)
+	}
+	err = c.Provide(services.NewStoryTemplateService)
+	if err != nil {
+		fmt.Printf("Error providing StoryTemplateService: %v\n", err)
+		panic(err)
+	}
+	err = c.Provide(services.NewInstructionSnippetService)
+	if err != nil {
+		fmt.Printf("Error providing InstructionSnippetService: %v\n", err)
+		panic(err)
+	}
+	err = c.Provide(services.NewPromptRegistryService)
+	if err != nil {
+		fmt.Printf("Error providing PromptRegistryService: %v\n", err)
+		panic(err)
+	}
 	err = c.Provide(services.NewPullRequestService)
 	if err != nil {
 		fmt.Printf("Error providing PullRequestService: %v\n", err)
@@ -253,6 +370,12 @@ func main() {
 		fmt.Printf("Error providing PullRequestCommentsService: %v\n", err)
 		panic(err)
 	}
+
+	err = c.Provide(services.NewPullRequestReviewService)
+	if err != nil {
+		fmt.Printf("Error providing PullRequestReviewService: %v\n", err)
+		panic(err)
+	}
 	err = c.Provide(func() string {
 		return config.JWTSecret()
 	})
@@ -323,6 +446,49 @@ func main() {
 	err = c.Provide(func(pullRequestCommentService *services.PullRequestCommentsService) *controllers.PullRequestCommentsController {
 		return controllers.NewPullRequestCommentController(pullRequestCommentService)
 	})
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(controllers.NewWebhookController)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(controllers.NewPullRequestReviewController)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(controllers.NewOrganisationWebhookController)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(controllers.NewIssueTrackerController)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(controllers.NewStoryDependencyController)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(controllers.NewRunQueueController)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(controllers.NewStoryDecompositionController)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(controllers.NewStoryTemplateController)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(controllers.NewInstructionSnippetController)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(controllers.NewPromptRegistryController)
+	if err != nil {
+		panic(err)
+	}
 	err = c.Provide(func(executionService *services.ExecutionService) *controllers.ExecutionController {
 		return controllers.NewExecutionController(executionService)
 	})
@@ -391,6 +557,16 @@ func main() {
 		executionCtrl *controllers.ExecutionController,
 		pullRequestCtrl *controllers.PullRequestController,
 		pullRequestCommentCtrl *controllers.PullRequestCommentsController,
+		webhookCtrl *controllers.WebhookController,
+		pullRequestReviewCtrl *controllers.PullRequestReviewController,
+		organisationWebhookCtrl *controllers.OrganisationWebhookController,
+		issueTrackerCtrl *controllers.IssueTrackerController,
+		storyDependencyCtrl *controllers.StoryDependencyController,
+		runQueueCtrl *controllers.RunQueueController,
+		storyDecompositionCtrl *controllers.StoryDecompositionController,
+		storyTemplateCtrl *controllers.StoryTemplateController,
+		instructionSnippetCtrl *controllers.InstructionSnippetController,
+		promptRegistryCtrl *controllers.PromptRegistryController,
 		projectAuthMiddleware *middleware.ProjectAuthorizationMiddleware,
 		storyAuthMiddleware *middleware.StoryAuthorizationMiddleware,
 		orgAuthMiddleware *middleware.OrganizationAuthorizationMiddleware,
@@ -441,6 +617,11 @@ func main() {
 		githubAuth.GET("/signin", auth.GithubSignIn)
 		githubAuth.GET("/callback", auth.GithubCallback)
 
+		// Git hosting providers authenticate webhook deliveries with the configured webhook secret.
+		api.POST("/webhooks/:provider", webhookCtrl.HandleGitProviderWebhook)
+		// Issue trackers authenticate webhook deliveries with the webhook secret of the tracker.
+		api.POST("/issue-trackers/:tracker_id/webhook", issueTrackerCtrl.HandleWebhook)
+
 		projects := api.Group("/projects", middleware.AuthenticateJWT())
 
 		projects.GET("", projectsController.GetAllProjects)
@@ -450,6 +631,7 @@ func main() {
 		projects.GET("/", projectsController.GetAllProjects)
 		projects.POST("/", projectsController.CreateProject)
 		projects.PUT("/", projectsController.UpdateProject)
+		projects.POST("/import", projectsController.ImportProject)
 
 		project := projects.Group("/:project_id", projectAuthMiddleware.Authorize())
 
@@ -458,9 +640,20 @@ func main() {
 
 		project.GET("/download", projectsController.DownloadCode)
 		project.GET("/pull-requests", pullRequestCtrl.GetAllPullRequestsByProjectID)
+		project.GET("/reviews", pullRequestReviewCtrl.GetReviewsByProjectID)
+		project.POST("/reviews", pullRequestReviewCtrl.RequestReview)
+		project.GET("/reviews/:review_id/activity-logs", pullRequestReviewCtrl.GetReviewActivityLogs)
 		project.GET("/stories", storiesController.GetAllStoriesOfProject)
 		project.GET("/stories/in-progress", storiesController.GetInProgressStoriesByProjectId)
 		project.GET("/design/stories", storiesController.GetDesignStoriesOfProject)
+		project.GET("/issue-trackers", issueTrackerCtrl.GetIssueTrackers)
+		project.POST("/issue-trackers", issueTrackerCtrl.CreateIssueTracker)
+		project.PUT("/issue-trackers/:tracker_id", issueTrackerCtrl.UpdateIssueTracker)
+		project.DELETE("/issue-trackers/:tracker_id", issueTrackerCtrl.DeleteIssueTracker)
+		project.POST("/issue-trackers/:tracker_id/sync", issueTrackerCtrl.SyncIssueTracker)
+		project.GET("/run-queue", runQueueCtrl.GetRunQueue)
+		project.POST("/run-queue", runQueueCtrl.EnqueueStories)
+		project.DELETE("/run-queue/:story_id", runQueueCtrl.RemoveStory)
 
 		stories := api.Group("/stories", middleware.AuthenticateJWT())
 
@@ -489,6 +682,11 @@ func main() {
 		story.GET("/execution-outputs", executionOutputCtrl.GetExecutionOutputsByStoryID)
 		story.GET("/activity-logs", activityLogCtrl.GetActivityLogsByStoryID)
 		story.PUT("/status", storiesController.UpdateStoryStatus)
+		story.GET("/dependencies", storyDependencyCtrl.GetDependencies)
+		story.PUT("/dependencies", storyDependencyCtrl.SetDependencies)
+		story.POST("/decompose", storyDecompositionCtrl.ProposeSubStories)
+		story.GET("/sub-stories", storyDecompositionCtrl.GetSubStories)
+		story.POST("/sub-stories", storyDecompositionCtrl.AcceptSubStories)
 
 		designReview := api.Group("/design/review", middleware.AuthenticateJWT())
 		designReview.POST("", designStoryReviewCtrl.CreateCommentForDesignStory)
@@ -501,6 +699,11 @@ func main() {
 		pullRequest.GET("/commits", pullRequestCtrl.FetchPullRequestCommits)
 		pullRequest.POST("/comment", pullRequestCommentCtrl.CreateCommentForPrID)
 		pullRequest.POST("/merge", pullRequestCtrl.MergePullRequest)
+		pullRequest.POST("/close", pullRequestCtrl.ClosePullRequest)
+		pullRequest.POST("/reopen", pullRequestCtrl.ReopenPullRequest)
+		pullRequest.PUT("/draft", pullRequestCtrl.SetPullRequestDraft)
+		pullRequest.GET("/pipeline", pullRequestCtrl.GetPullRequestPipeline)
+		pullRequest.POST("/pipeline", pullRequestCtrl.TriggerPullRequestPipeline)
 
 		llmApiKeys := api.Group("/llm_api_key", middleware.AuthenticateJWT())
 		llmApiKeys.POST("", llm_api_key.CreateLLMAPIKey)
@@ -508,6 +711,38 @@ func main() {
 		llmApiKeys.GET("", llm_api_key.FetchAllLLMAPIKeyByOrganisationID)
 		llmApiKeys.GET("/", llm_api_key.FetchAllLLMAPIKeyByOrganisationID)
 
+		// Outbound webhooks of the organisation, signed with their secret and retried by the worker.
+		organisationWebhooks := api.Group("/organisation/webhooks", middleware.AuthenticateJWT())
+		organisationWebhooks.GET("", organisationWebhookCtrl.GetWebhooks)
+		organisationWebhooks.POST("", organisationWebhookCtrl.CreateWebhook)
+		organisationWebhooks.PUT("/:webhook_id", organisationWebhookCtrl.UpdateWebhook)
+		organisationWebhooks.DELETE("/:webhook_id", organisationWebhookCtrl.DeleteWebhook)
+		organisationWebhooks.GET("/:webhook_id/deliveries", organisationWebhookCtrl.GetDeliveries)
+		organisationWebhooks.POST("/:webhook_id/deliveries/:delivery_id/redeliver", organisationWebhookCtrl.Redeliver)
+
+		// Story templates of the organisation, rendering one fills in its placeholders.
+		storyTemplates := api.Group("/organisation/story-templates", middleware.AuthenticateJWT())
+		storyTemplates.GET("", storyTemplateCtrl.GetTemplates)
+		storyTemplates.POST("", storyTemplateCtrl.CreateTemplate)
+		storyTemplates.PUT("/:template_id", storyTemplateCtrl.UpdateTemplate)
+		storyTemplates.DELETE("/:template_id", storyTemplateCtrl.DeleteTemplate)
+		storyTemplates.POST("/:template_id/render", storyTemplateCtrl.RenderTemplate)
+
+		// Instruction snippets appended to the instructions of every story of the organisation while active.
+		instructionSnippets := api.Group("/organisation/instruction-snippets", middleware.AuthenticateJWT())
+		instructionSnippets.GET("", instructionSnippetCtrl.GetSnippets)
+		instructionSnippets.POST("", instructionSnippetCtrl.CreateSnippet)
+		instructionSnippets.PUT("/:snippet_id", instructionSnippetCtrl.UpdateSnippet)
+		instructionSnippets.DELETE("/:snippet_id", instructionSnippetCtrl.DeleteSnippet)
+
+		// Versioned prompts of the code generators, executions are assigned to versions by A/B weight.
+		prompts := api.Group("/organisation/prompts", middleware.AuthenticateJWT())
+		prompts.GET("", promptRegistryCtrl.GetPrompts)
+		prompts.GET("/:prompt_key/versions", promptRegistryCtrl.GetVersions)
+		prompts.POST("/:prompt_key/versions", promptRegistryCtrl.CreateVersion)
+		prompts.PUT("/:prompt_key/versions/:version", promptRegistryCtrl.UpdateVersion)
+		prompts.GET("/:prompt_key/report", promptRegistryCtrl.GetReport)
+
 		authentication := api.Group("/auth")
 		authentication.GET("/check_user", auth.CheckUser)
 		authentication.POST("/sign_in", auth.SignIn)
@@ -3,6 +3,8 @@ package main
 import (
 	"ai-developer/app/client"
 	gitness_git_provider "ai-developer/app/client/git_provider"
+	"ai-developer/app/client/github_git_provider"
+	"ai-developer/app/client/gitlab_git_provider"
 	"ai-developer/app/client/workspace"
 	"ai-developer/app/config"
 	"ai-developer/app/constants"
@@ -10,6 +12,7 @@ import (
 	"ai-developer/app/repositories"
 	"ai-developer/app/services"
 	"ai-developer/app/services/git_providers"
+	"ai-developer/app/services/issue_trackers"
 	"ai-developer/app/services/s3_providers"
 	"ai-developer/app/tasks"
 	"context"
@@ -154,6 +157,41 @@ func main() {
 		log.Println("Error providing LLM API Key repository:", err)
 		panic(err)
 	}
+	err = c.Provide(repositories.NewPullRequestReviewRepository)
+	if err != nil {
+		log.Println("Error providing pull request review repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewOrganisationWebhookRepository)
+	if err != nil {
+		log.Println("Error providing organisation webhook repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewWebhookDeliveryRepository)
+	if err != nil {
+		log.Println("Error providing webhook delivery repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewIssueTrackerRepository)
+	if err != nil {
+		log.Println("Error providing issue tracker repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewStoryIssueLinkRepository)
+	if err != nil {
+		log.Println("Error providing story issue link repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewStoryDependencyRepository)
+	if err != nil {
+		log.Println("Error providing story dependency repository:", err)
+		panic(err)
+	}
+	err = c.Provide(repositories.NewRunQueueRepository)
+	if err != nil {
+		log.Println("Error providing run queue repository:", err)
+		panic(err)
+	}
 
 	fmt.Println("Worker - Providing workspace service client...")
 	err = c.Provide(config.NewWorkspaceServiceConfig)
@@ -172,6 +210,11 @@ func main() {
 		log.Println("Error providing activity log service:", err)
 		panic(err)
 	}
+	err = c.Provide(services.NewOrganisationWebhookService)
+	if err != nil {
+		log.Println("Error providing organisation webhook service:", err)
+		panic(err)
+	}
 	err = c.Provide(services.NewStoryService)
 	if err != nil {
 		log.Println("Error providing story service:", err)
@@ -208,6 +251,11 @@ func main() {
 		fmt.Println("Error providing LLM API Key Service: ", err)
 		panic(err)
 	}
+	err = c.Provide(services.NewPullRequestReviewService)
+	if err != nil {
+		fmt.Println("Error providing PullRequestReviewService: ", err)
+		panic(err)
+	}
 	// Provide GitnessClient
 	err = c.Provide(func(logger *zap.Logger, slackAlert *monitoring.SlackAlert) *gitness_git_provider.GitnessClient {
 		return gitness_git_provider.NewGitnessClient(config.GitnessURL(), config.GitnessToken(), client.NewHttpClient(), logger, slackAlert)
@@ -224,6 +272,72 @@ func main() {
 	err = c.Provide(func(client *gitness_git_provider.GitnessClient) *git_providers.GitnessService {
 		return git_providers.NewGitnessService(client)
 	})
+	if err != nil {
+		panic(err)
+	}
+	// Provide GitHubClient
+	err = c.Provide(func(logger *zap.Logger) (*github_git_provider.GitHubClient, error) {
+		httpClient := client.NewHttpClient()
+		tokenSource, err := github_git_provider.NewTokenSource(config.GithubAPIURL(), config.GithubToken(),
+			config.GithubAppID(), config.GithubAppInstallationID(), config.GithubAppPrivateKey(), httpClient)
+		if err != nil {
+			return nil, err
+		}
+		return github_git_provider.NewGitHubClient(config.GithubAPIURL(), tokenSource, httpClient, logger), nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(git_providers.NewGitHubService)
+	if err != nil {
+		panic(err)
+	}
+	// Provide GitLabClient
+	err = c.Provide(func(logger *zap.Logger) *gitlab_git_provider.GitLabClient {
+		return gitlab_git_provider.NewGitLabClient(config.GitlabURL(), config.GitlabToken(), client.NewHttpClient(), logger)
+	})
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(git_providers.NewGitLabService)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(git_providers.NewGitProviderResolver)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(issue_trackers.NewJiraService)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(issue_trackers.NewLinearService)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(issue_trackers.NewGitHubIssuesService)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(issue_trackers.NewIssueTrackerResolver)
+	if err != nil {
+		panic(err)
+	}
+	err = c.Provide(services.NewIssueTrackerService)
+	if err != nil {
+		log.Println("Error providing issue tracker service:", err)
+		panic(err)
+	}
+	err = c.Provide(services.NewStoryDependencyService)
+	if err != nil {
+		log.Println("Error providing story dependency service:", err)
+		panic(err)
+	}
+	err = c.Provide(services.NewRunQueueService)
+	if err != nil {
+		log.Println("Error providing run queue service:", err)
+		panic(err)
+	}
 
 	// Provide Asynq client
 	err = c.Provide(func() *asynq.Client {
@@ -258,6 +372,31 @@ func main() {
 	if err != nil {
 		log.Fatalf("could not provide CheckExecutionStatusTaskHandler: %v", err)
 	}
+
+	err = c.Provide(tasks.NewReviewPullRequestTaskHandler)
+	if err != nil {
+		log.Fatalf("could not provide ReviewPullRequestTaskHandler: %v", err)
+	}
+
+	err = c.Provide(tasks.NewReconcilePullRequestsTaskHandler)
+	if err != nil {
+		log.Fatalf("could not provide ReconcilePullRequestsTaskHandler: %v", err)
+	}
+
+	err = c.Provide(tasks.NewDeliverWebhookTaskHandler)
+	if err != nil {
+		log.Fatalf("could not provide DeliverWebhookTaskHandler: %v", err)
+	}
+
+	err = c.Provide(tasks.NewSyncIssueTrackersTaskHandler)
+	if err != nil {
+		log.Fatalf("could not provide SyncIssueTrackersTaskHandler: %v", err)
+	}
+
+	err = c.Provide(tasks.NewAdvanceRunQueueTaskHandler)
+	if err != nil {
+		log.Fatalf("could not provide AdvanceRunQueueTaskHandler: %v", err)
+	}
 	//Provide asynq scheduler
 	err = c.Provide(func() *asynq.Scheduler {
 		return asynq.NewScheduler(asynq.RedisClientOpt{
@@ -270,6 +409,11 @@ func main() {
 		deleteWorkspaceTaskHandler *tasks.DeleteWorkspaceTaskHandler,
 		createExecutionJobTaskHandler *tasks.CreateExecutionJobTaskHandler,
 		checkExecutionStatusTaskHandler *tasks.CheckExecutionStatusTaskHandler,
+		reviewPullRequestTaskHandler *tasks.ReviewPullRequestTaskHandler,
+		reconcilePullRequestsTaskHandler *tasks.ReconcilePullRequestsTaskHandler,
+		deliverWebhookTaskHandler *tasks.DeliverWebhookTaskHandler,
+		syncIssueTrackersTaskHandler *tasks.SyncIssueTrackersTaskHandler,
+		advanceRunQueueTaskHandler *tasks.AdvanceRunQueueTaskHandler,
 		workspaceServiceClient *workspace.WorkspaceServiceClient,
 		projectService *services.ProjectService,
 		logger *zap.Logger,
@@ -278,6 +422,11 @@ func main() {
 		mux.HandleFunc(constants.DeleteWorkspaceTaskType, deleteWorkspaceTaskHandler.HandleTask)
 		mux.HandleFunc(constants.CreateExecutionJobTaskType, createExecutionJobTaskHandler.HandleTask)
 		mux.HandleFunc(constants.CheckExecutionStatusTaskType, checkExecutionStatusTaskHandler.HandleTask)
+		mux.HandleFunc(constants.ReviewPullRequestTaskType, reviewPullRequestTaskHandler.HandleTask)
+		mux.HandleFunc(constants.ReconcilePullRequestsTaskType, reconcilePullRequestsTaskHandler.HandleTask)
+		mux.HandleFunc(constants.DeliverWebhookTaskType, deliverWebhookTaskHandler.HandleTask)
+		mux.HandleFunc(constants.SyncIssueTrackersTaskType, syncIssueTrackersTaskHandler.HandleTask)
+		mux.HandleFunc(constants.AdvanceRunQueueTaskType, advanceRunQueueTaskHandler.HandleTask)
 		return mux
 	})
 
@@ -308,6 +457,18 @@ func main() {
 		if _, err := scheduler.Register("*/30 * * * *", task); err != nil {
 			log.Fatalf("could not schedule task: %v", err)
 		}
+		reconcileTask := asynq.NewTask(constants.ReconcilePullRequestsTaskType, nil, asynq.TaskID(constants.ReconcilePullRequestsTaskType))
+		if _, err := scheduler.Register("*/10 * * * *", reconcileTask); err != nil {
+			log.Fatalf("could not schedule task: %v", err)
+		}
+		syncIssueTrackersTask := asynq.NewTask(constants.SyncIssueTrackersTaskType, nil, asynq.TaskID(constants.SyncIssueTrackersTaskType))
+		if _, err := scheduler.Register("*/2 * * * *", syncIssueTrackersTask); err != nil {
+			log.Fatalf("could not schedule task: %v", err)
+		}
+		advanceRunQueuesTask := asynq.NewTask(constants.AdvanceRunQueueTaskType, nil, asynq.TaskID(constants.AdvanceRunQueueTaskType))
+		if _, err := scheduler.Register("* * * * *", advanceRunQueuesTask); err != nil {
+			log.Fatalf("could not schedule task: %v", err)
+		}
 
 		// Start the scheduler in a separate goroutine
 		go func() {
@@ -26,6 +26,7 @@ func LoadConfig() (config *koanf.Koanf, err error) {
 				"images": map[string]interface{}{
 					"python": "python-executor:latest",
 					"node":   "node-executor:latest",
+					"go":     "go-executor:latest",
 				},
 				"autoremove": "false",
 				"volume": map[string]interface{}{
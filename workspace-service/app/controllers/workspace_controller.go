@@ -21,7 +21,16 @@ func (wc *WorkspaceController) CreateWorkspace(c *gin.Context) {
 		})
 		return
 	}
-	wsDetails, err := wc.wsService.CreateWorkspace(body.WorkspaceId, *body.BackendTemplate, body.FrontendTemplate, body.RemoteURL, body.GitnessUserName, body.GitnessToken)
+	backendTemplate := ""
+	if body.BackendTemplate != nil {
+		backendTemplate = *body.BackendTemplate
+	} else if body.Import == nil {
+		c.AbortWithStatusJSON(400, gin.H{
+			"error": "Bad Request",
+		})
+		return
+	}
+	wsDetails, err := wc.wsService.CreateWorkspace(body.WorkspaceId, backendTemplate, body.FrontendTemplate, body.RemoteURL, body.GitnessUserName, body.GitnessToken, body.DefaultBranch, body.Import)
 	if err != nil {
 		c.AbortWithStatusJSON(
 			500,
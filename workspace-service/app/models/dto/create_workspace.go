@@ -1,11 +1,13 @@
 package dto
 
 type CreateWorkspace struct {
-	StoryHashId      string  `json:"storyHashId"`
-	WorkspaceId      string  `json:"workspaceId"`
-	RemoteURL        string  `json:"remoteURL"`
-	FrontendTemplate *string `json:"frontendTemplate,omitempty"`
-	BackendTemplate  *string `json:"backendTemplate,omitempty"`
-	GitnessUserName  string  `json:"gitnessUserName,omitempty"`
-	GitnessToken     string  `json:"gitnessToken,omitempty"`
+	StoryHashId      string        `json:"storyHashId"`
+	WorkspaceId      string        `json:"workspaceId"`
+	RemoteURL        string        `json:"remoteURL"`
+	FrontendTemplate *string       `json:"frontendTemplate,omitempty"`
+	BackendTemplate  *string       `json:"backendTemplate,omitempty"`
+	GitnessUserName  string        `json:"gitnessUserName,omitempty"`
+	GitnessToken     string        `json:"gitnessToken,omitempty"`
+	DefaultBranch    string        `json:"defaultBranch,omitempty"`
+	Import           *ImportSource `json:"import,omitempty"`
 }
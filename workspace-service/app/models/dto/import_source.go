@@ -0,0 +1,8 @@
+package dto
+
+// ImportSource is an existing repository a workspace is cloned from instead of being seeded from a template.
+type ImportSource struct {
+	CloneURL string `json:"cloneURL"`
+	UserName string `json:"userName,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
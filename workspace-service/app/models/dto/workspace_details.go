@@ -7,4 +7,5 @@ type WorkspaceDetails struct {
 	WorkspaceUrl     *string `json:"workspaceUrl,omitempty"`
 	FrontendUrl      *string `json:"frontendUrl,omitempty"`
 	BackendUrl       *string `json:"backendUrl,omitempty"`
+	DefaultBranch    *string `json:"defaultBranch,omitempty"`
 }
@@ -25,11 +25,25 @@ type DockerWorkspaceService struct {
 	logger                 *zap.Logger
 }
 
-func (ws DockerWorkspaceService) CreateWorkspace(workspaceId string, backendTemplate string, frontendTemplate *string, remoteURL string, gitnessUser string, gitnessToken string) (*dto.WorkspaceDetails, error) {
-	err := ws.checkAndCreateWorkspaceFromTemplate(workspaceId, backendTemplate, frontendTemplate, remoteURL, gitnessUser, gitnessToken)
-	if err != nil {
-		ws.logger.Error("Failed to check and create workspace from template", zap.Error(err))
-		return nil, err
+func (ws DockerWorkspaceService) CreateWorkspace(workspaceId string, backendTemplate string, frontendTemplate *string, remoteURL string, gitnessUser string, gitnessToken string, defaultBranch string, importSource *dto.ImportSource) (*dto.WorkspaceDetails, error) {
+	if importSource != nil {
+		imported, err := importWorkspaceRepository(ws.logger, workspaceId, importSource, defaultBranch, remoteURL, gitnessUser, gitnessToken)
+		if err != nil {
+			ws.logger.Error("Failed to import workspace from repository", zap.Error(err))
+			return nil, err
+		}
+		backendTemplate = imported.BackendTemplate
+		frontendTemplate = imported.FrontendTemplate
+		defaultBranch = imported.DefaultBranch
+	} else {
+		if defaultBranch == "" {
+			defaultBranch = "main"
+		}
+		err := ws.checkAndCreateWorkspaceFromTemplate(workspaceId, backendTemplate, frontendTemplate, remoteURL, gitnessUser, gitnessToken, defaultBranch)
+		if err != nil {
+			ws.logger.Error("Failed to check and create workspace from template", zap.Error(err))
+			return nil, err
+		}
 	}
 
 	workspaceUrl := "http://localhost:8081/?folder=/workspaces/" + workspaceId
@@ -43,6 +57,7 @@ func (ws DockerWorkspaceService) CreateWorkspace(workspaceId string, backendTemp
 		WorkspaceUrl:     &workspaceUrl,
 		FrontendUrl:      &frontendUrl,
 		BackendUrl:       &backendUrl,
+		DefaultBranch:    &defaultBranch,
 	}, nil
 }
 
@@ -66,7 +81,7 @@ func (ws DockerWorkspaceService) CreateFrontendWorkspace(storyHashId, workspaceI
 
 }
 
-func (ws DockerWorkspaceService) checkAndCreateWorkspaceFromTemplate(workspaceId string, backendTemplate string, frontendTemplate *string, remoteURL string, gitnessUser string, gitnessToken string) error {
+func (ws DockerWorkspaceService) checkAndCreateWorkspaceFromTemplate(workspaceId string, backendTemplate string, frontendTemplate *string, remoteURL string, gitnessUser string, gitnessToken string, defaultBranch string) error {
 	exists, err := utils.CheckIfWorkspaceExists(workspaceId)
 	if err != nil {
 		ws.logger.Error("Failed to check if workspace exists", zap.Error(err))
@@ -116,7 +131,7 @@ func (ws DockerWorkspaceService) checkAndCreateWorkspaceFromTemplate(workspaceId
 				return err
 			}
 
-			// Checkout main branch (create if not exists)
+			// Checkout default branch (create if not exists)
 			worktree, err := repo.Worktree()
 			if err != nil {
 				ws.logger.Error("Failed to get worktree", zap.Error(err))
@@ -143,30 +158,30 @@ func (ws DockerWorkspaceService) checkAndCreateWorkspaceFromTemplate(workspaceId
 				return err
 			}
 
-			// Create the main branch from the initial commit
+			// Create the default branch from the initial commit
 			headRef, err := repo.Head()
 			if err != nil {
 				ws.logger.Error("Failed to get HEAD reference", zap.Error(err))
 				return err
 			}
 
-			ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), headRef.Hash())
+			ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName(defaultBranch), headRef.Hash())
 			err = repo.Storer.SetReference(ref)
 			if err != nil {
-				ws.logger.Error("Failed to create main branch", zap.Error(err))
+				ws.logger.Error("Failed to create default branch", zap.Error(err))
 				return err
 			}
 
-			// Set HEAD to point to the main branch
+			// Set HEAD to point to the default branch
 			err = repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, ref.Name()))
 			if err != nil {
-				ws.logger.Error("Failed to set HEAD to main branch", zap.Error(err))
+				ws.logger.Error("Failed to set HEAD to default branch", zap.Error(err))
 				return err
 			}
 
 			ws.logger.Info("Git commit files output", zap.String("output", commit.String()))
 
-			// Push to given URL of remote, in main branch
+			// Push to given URL of remote, in default branch
 			ws.logger.Info("Pushing changes to remote repository", zap.String("remoteURL", remoteURL))
 
 			// Add the remote
@@ -188,7 +203,7 @@ func (ws DockerWorkspaceService) checkAndCreateWorkspaceFromTemplate(workspaceId
 			err = repo.Push(&git.PushOptions{
 				RemoteName: "origin",
 				Auth:       auth,
-				RefSpecs:   []config.RefSpec{"refs/heads/main:refs/heads/main"},
+				RefSpecs:   []config.RefSpec{config.RefSpec("refs/heads/" + defaultBranch + ":refs/heads/" + defaultBranch)},
 			})
 
 			// Cleanup: Remove the remote after pushing
@@ -35,14 +35,28 @@ type K8sWorkspaceService struct {
 	logger                      *zap.Logger
 }
 
-func (ws K8sWorkspaceService) CreateWorkspace(workspaceId string, backendTemplate string, frontendTemplate *string, remoteURL string, gitnessUser string, gitnessToken string) (*dto.WorkspaceDetails, error) {
-	err := ws.checkAndCreateWorkspaceFromTemplate(workspaceId, backendTemplate, frontendTemplate, remoteURL, gitnessUser, gitnessToken)
-	if err != nil {
-		ws.logger.Error("Failed to check and create workspace from template", zap.Error(err))
-		return nil, err
+func (ws K8sWorkspaceService) CreateWorkspace(workspaceId string, backendTemplate string, frontendTemplate *string, remoteURL string, gitnessUser string, gitnessToken string, defaultBranch string, importSource *dto.ImportSource) (*dto.WorkspaceDetails, error) {
+	if importSource != nil {
+		imported, err := importWorkspaceRepository(ws.logger, workspaceId, importSource, defaultBranch, remoteURL, gitnessUser, gitnessToken)
+		if err != nil {
+			ws.logger.Error("Failed to import workspace from repository", zap.Error(err))
+			return nil, err
+		}
+		backendTemplate = imported.BackendTemplate
+		frontendTemplate = imported.FrontendTemplate
+		defaultBranch = imported.DefaultBranch
+	} else {
+		if defaultBranch == "" {
+			defaultBranch = "main"
+		}
+		err := ws.checkAndCreateWorkspaceFromTemplate(workspaceId, backendTemplate, frontendTemplate, remoteURL, gitnessUser, gitnessToken, defaultBranch)
+		if err != nil {
+			ws.logger.Error("Failed to check and create workspace from template", zap.Error(err))
+			return nil, err
+		}
 	}
 
-	err = ws.checkAndCreateWorkspacePVC(workspaceId)
+	err := ws.checkAndCreateWorkspacePVC(workspaceId)
 	if err != nil {
 		ws.logger.Error("Failed to check and create workspace PVC", zap.Error(err))
 		return nil, err
@@ -60,6 +74,7 @@ func (ws K8sWorkspaceService) CreateWorkspace(workspaceId string, backendTemplat
 		WorkspaceUrl:     &workspaceUrl,
 		BackendUrl:       &backendUrl,
 		FrontendUrl:      &frontendUrl,
+		DefaultBranch:    &defaultBranch,
 	}
 
 	exists := ws.checkIfWorkspaceExists(workspaceId)
@@ -211,7 +226,7 @@ func (ws K8sWorkspaceService) checkIfWorkspaceExists(workspaceId string) bool {
 	return true
 }
 
-func (ws K8sWorkspaceService) checkAndCreateWorkspaceFromTemplate(workspaceId string, backendTemplate string, frontendTemplate *string, remoteURL string, gitnessUser string, gitnessToken string) error {
+func (ws K8sWorkspaceService) checkAndCreateWorkspaceFromTemplate(workspaceId string, backendTemplate string, frontendTemplate *string, remoteURL string, gitnessUser string, gitnessToken string, defaultBranch string) error {
 	exists, err := utils.CheckIfWorkspaceExists(workspaceId)
 	if err != nil {
 		ws.logger.Error("Failed to check if workspace exists", zap.Error(err))
@@ -256,7 +271,7 @@ func (ws K8sWorkspaceService) checkAndCreateWorkspaceFromTemplate(workspaceId st
 				return err
 			}
 
-			// Checkout main branch (create if not exists)
+			// Checkout default branch (create if not exists)
 			worktree, err := repo.Worktree()
 			if err != nil {
 				ws.logger.Error("Failed to get worktree", zap.Error(err))
@@ -283,30 +298,30 @@ func (ws K8sWorkspaceService) checkAndCreateWorkspaceFromTemplate(workspaceId st
 				return err
 			}
 
-			// Create the main branch from the initial commit
+			// Create the default branch from the initial commit
 			headRef, err := repo.Head()
 			if err != nil {
 				ws.logger.Error("Failed to get HEAD reference", zap.Error(err))
 				return err
 			}
 
-			ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), headRef.Hash())
+			ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName(defaultBranch), headRef.Hash())
 			err = repo.Storer.SetReference(ref)
 			if err != nil {
-				ws.logger.Error("Failed to create main branch", zap.Error(err))
+				ws.logger.Error("Failed to create default branch", zap.Error(err))
 				return err
 			}
 
-			// Set HEAD to point to the main branch
+			// Set HEAD to point to the default branch
 			err = repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, ref.Name()))
 			if err != nil {
-				ws.logger.Error("Failed to set HEAD to main branch", zap.Error(err))
+				ws.logger.Error("Failed to set HEAD to default branch", zap.Error(err))
 				return err
 			}
 
 			ws.logger.Info("Git commit files output", zap.String("output", commit.String()))
 
-			// Push to given URL of remote, in main branch
+			// Push to given URL of remote, in default branch
 			ws.logger.Info("Pushing changes to remote repository", zap.String("remoteURL", remoteURL))
 
 			// Add the remote
@@ -327,7 +342,7 @@ func (ws K8sWorkspaceService) checkAndCreateWorkspaceFromTemplate(workspaceId st
 			err = repo.Push(&git.PushOptions{
 				RemoteName: "origin",
 				Auth:       auth,
-				RefSpecs:   []config.RefSpec{"refs/heads/main:refs/heads/main"},
+				RefSpecs:   []config.RefSpec{config.RefSpec("refs/heads/" + defaultBranch + ":refs/heads/" + defaultBranch)},
 			})
 
 			// Cleanup: Remove the remote after pushing
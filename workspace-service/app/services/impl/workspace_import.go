@@ -0,0 +1,114 @@
+package impl
+
+import (
+	"errors"
+	"os"
+	"workspace-service/app/models/dto"
+	"workspace-service/app/utils"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"go.uber.org/zap"
+)
+
+type importedWorkspace struct {
+	BackendTemplate  string
+	FrontendTemplate *string
+	DefaultBranch    string
+}
+
+// importWorkspaceRepository clones the import source into the workspace and pushes its default branch to the
+// project repository at remoteURL. The push is skipped when remoteURL is empty, i.e. when the source already
+// is the project repository. Workspaces that exist already are left as they are.
+func importWorkspaceRepository(logger *zap.Logger, workspaceId string, importSource *dto.ImportSource, defaultBranch string, remoteURL string, gitnessUser string, gitnessToken string) (*importedWorkspace, error) {
+	workspacePath := "/workspaces/" + workspaceId
+	exists, err := utils.CheckIfWorkspaceExists(workspaceId)
+	if err != nil {
+		logger.Error("Failed to check if workspace exists", zap.Error(err))
+		return nil, err
+	}
+
+	var repo *git.Repository
+	if exists {
+		logger.Info("Workspace already exists", zap.String("workspaceId", workspaceId))
+		repo, err = git.PlainOpen(workspacePath)
+	} else {
+		logger.Info("Cloning workspace from repository", zap.String("workspaceId", workspaceId), zap.String("cloneURL", importSource.CloneURL))
+		cloneOptions := &git.CloneOptions{URL: importSource.CloneURL}
+		if importSource.Token != "" {
+			username := importSource.UserName
+			if username == "" {
+				username = "git"
+			}
+			cloneOptions.Auth = &http.BasicAuth{Username: username, Password: importSource.Token}
+		}
+		if defaultBranch != "" {
+			cloneOptions.ReferenceName = plumbing.NewBranchReferenceName(defaultBranch)
+			cloneOptions.SingleBranch = true
+		}
+		repo, err = git.PlainClone(workspacePath, false, cloneOptions)
+		if err != nil {
+			// Leave no partial clone behind so that the import can be retried.
+			_ = os.RemoveAll(workspacePath)
+		}
+	}
+	if err != nil {
+		logger.Error("Failed to open repository", zap.Error(err))
+		return nil, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		logger.Error("Failed to get HEAD reference", zap.Error(err))
+		return nil, err
+	}
+	branch := head.Name().Short()
+
+	if !exists && remoteURL != "" {
+		logger.Info("Pushing imported repository to remote repository", zap.String("remoteURL", remoteURL), zap.String("branch", branch))
+		_, err = repo.CreateRemote(&config.RemoteConfig{
+			Name: "supercoder",
+			URLs: []string{remoteURL},
+		})
+		if err != nil {
+			logger.Error("Failed to create remote", zap.Error(err))
+			return nil, err
+		}
+		refSpec := config.RefSpec("refs/heads/" + branch + ":refs/heads/" + branch)
+		err = repo.Push(&git.PushOptions{
+			RemoteName: "supercoder",
+			Auth: &http.BasicAuth{
+				Username: gitnessUser,
+				Password: gitnessToken,
+			},
+			RefSpecs: []config.RefSpec{refSpec},
+		})
+		if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+			logger.Error("Failed to push imported repository", zap.Error(err))
+			return nil, err
+		}
+	}
+	if !exists {
+		// Executors push and pull with explicit remote URLs, as for workspaces created from templates.
+		for _, remoteName := range []string{"origin", "supercoder"} {
+			err = repo.DeleteRemote(remoteName)
+			if err != nil && !errors.Is(err, git.ErrRemoteNotFound) {
+				logger.Error("Failed to delete remote", zap.Error(err))
+				return nil, err
+			}
+		}
+	}
+
+	err = utils.ChownRWorkspace("1000", "1000", workspacePath)
+	if err != nil {
+		logger.Error("Failed to chown workspace", zap.Error(err))
+		return nil, err
+	}
+	return &importedWorkspace{
+		BackendTemplate:  utils.DetectBackendTemplate(workspacePath),
+		FrontendTemplate: utils.DetectFrontendTemplate(workspacePath),
+		DefaultBranch:    branch,
+	}, nil
+}
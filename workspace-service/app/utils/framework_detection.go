@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type packageJSON struct {
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+}
+
+func (p packageJSON) has(name string) bool {
+	_, ok := p.Dependencies[name]
+	if !ok {
+		_, ok = p.DevDependencies[name]
+	}
+	return ok
+}
+
+func readPackageJSON(path string) (*packageJSON, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var pkg packageJSON
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, false
+	}
+	return &pkg, true
+}
+
+// pythonDependencies returns the lower cased contents of the python dependency manifests in dir.
+func pythonDependencies(dir string) string {
+	var dependencies strings.Builder
+	for _, name := range []string{"requirements.txt", "pyproject.toml", "Pipfile", "setup.py"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			dependencies.WriteString(strings.ToLower(string(content)))
+			dependencies.WriteString("\n")
+		}
+	}
+	return dependencies.String()
+}
+
+// DetectBackendTemplate returns the backend template matching the code in dir, empty when none matches.
+func DetectBackendTemplate(dir string) string {
+	if exists, _ := CheckIfDirExists(filepath.Join(dir, "manage.py")); exists {
+		return "django"
+	}
+	dependencies := pythonDependencies(dir)
+	switch {
+	case strings.Contains(dependencies, "django"):
+		return "django"
+	case strings.Contains(dependencies, "fastapi"):
+		return "fastapi"
+	case strings.Contains(dependencies, "flask"):
+		return "flask"
+	}
+	if exists, _ := CheckIfDirExists(filepath.Join(dir, "go.mod")); exists {
+		return "go"
+	}
+	if pkg, ok := readPackageJSON(filepath.Join(dir, "package.json")); ok && pkg.has("express") {
+		return "express"
+	}
+	return ""
+}
+
+// DetectFrontendTemplate returns the frontend template of the code in dir, looking at the frontend folder
+// used by SuperCoder workspaces first and the root of the repository second.
+func DetectFrontendTemplate(dir string) *string {
+	for _, path := range []string{filepath.Join(dir, "frontend", "package.json"), filepath.Join(dir, "package.json")} {
+		if pkg, ok := readPackageJSON(path); ok && pkg.has("next") {
+			nextjs := "nextjs"
+			return &nextjs
+		}
+	}
+	return nil
+}
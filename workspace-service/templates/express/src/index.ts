@@ -0,0 +1,24 @@
+// This should be the only entry point of the application
+import express from 'express';
+
+import routes from './routes';
+
+// Initialize Express app
+const app = express();
+app.use(express.json());
+
+/** Basic endpoint used to check that the server is running. */
+app.get('/', (_req, res) => {
+  res.json({ status: 'ok' });
+});
+
+app.use(routes);
+
+// Add Code Here
+
+
+// Run the application
+const port = 5000;
+app.listen(port, '0.0.0.0', () => {
+  console.log(`Server running on http://0.0.0.0:${port}`);
+});
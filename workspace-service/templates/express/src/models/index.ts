@@ -0,0 +1,2 @@
+// Put your data models related code here and make more model files in this directory as needed
+export {};
@@ -0,0 +1,6 @@
+import { Router } from 'express';
+
+// Register the routers of every resource here
+const router = Router();
+
+export default router;
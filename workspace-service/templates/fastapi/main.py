@@ -0,0 +1,30 @@
+# This should be the only entry point of the application
+import logging
+
+import uvicorn
+from fastapi import FastAPI
+
+import models  # noqa: F401 registers every model on Base.metadata
+
+logging.basicConfig(
+    level=logging.INFO,
+    format='[%(asctime)s] %(levelname)s in %(module)s: %(message)s',
+)
+
+# Initialize FastAPI app
+app = FastAPI()
+
+
+@app.get('/')
+def health_check():
+    """Basic endpoint used to check that the server is running."""
+    return {'status': 'ok'}
+
+
+# Add Code Here
+
+
+
+# Run the application
+if __name__ == '__main__':
+    uvicorn.run('main:app', host='0.0.0.0', port=5000)
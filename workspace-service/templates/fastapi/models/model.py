@@ -0,0 +1 @@
+#Put your database models related code here and make more model file in this directory as needed
\ No newline at end of file
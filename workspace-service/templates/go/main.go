@@ -0,0 +1,19 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"app/handlers"
+)
+
+// main registers the routes and serves the application on port 5000.
+func main() {
+	mux := http.NewServeMux()
+	handlers.Register(mux)
+
+	log.Println("Listening on 0.0.0.0:5000")
+	if err := http.ListenAndServe("0.0.0.0:5000", mux); err != nil {
+		log.Fatal(err)
+	}
+}